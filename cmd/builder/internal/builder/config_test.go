@@ -133,6 +133,15 @@ func TestInvalidModule(t *testing.T) {
 			},
 			err: ErrInvalidGoMod,
 		},
+		{
+			cfg: Config{
+				Logger: zap.NewNop(),
+				Connectors: []Module{{
+					Import: "invalid",
+				}},
+			},
+			err: ErrInvalidGoMod,
+		},
 	}
 
 	for _, test := range configurations {
@@ -4,13 +4,24 @@ package main
 
 import (
 	"go.opentelemetry.io/collector/component"
+	forwardconnector "go.opentelemetry.io/collector/connector/forwardconnector"
+	logmetricsconnector "go.opentelemetry.io/collector/connector/logmetricsconnector"
+	fileexporter "go.opentelemetry.io/collector/exporter/fileexporter"
 	loggingexporter "go.opentelemetry.io/collector/exporter/loggingexporter"
 	otlpexporter "go.opentelemetry.io/collector/exporter/otlpexporter"
 	otlphttpexporter "go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	ballastextension "go.opentelemetry.io/collector/extension/ballastextension"
+	basicauthextension "go.opentelemetry.io/collector/extension/basicauthextension"
+	bearertokenauthextension "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+	healthextension "go.opentelemetry.io/collector/extension/healthextension"
+	tapextension "go.opentelemetry.io/collector/extension/tapextension"
 	zpagesextension "go.opentelemetry.io/collector/extension/zpagesextension"
+	attributesprocessor "go.opentelemetry.io/collector/processor/attributesprocessor"
 	batchprocessor "go.opentelemetry.io/collector/processor/batchprocessor"
 	memorylimiterprocessor "go.opentelemetry.io/collector/processor/memorylimiterprocessor"
+	probabilisticsamplerprocessor "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+	resourcedetectionprocessor "go.opentelemetry.io/collector/processor/resourcedetectionprocessor"
+	filereplayreceiver "go.opentelemetry.io/collector/receiver/filereplayreceiver"
 	otlpreceiver "go.opentelemetry.io/collector/receiver/otlpreceiver"
 )
 
@@ -20,6 +31,10 @@ func components() (component.Factories, error) {
 
 	factories.Extensions, err = component.MakeExtensionFactoryMap(
 		ballastextension.NewFactory(),
+		basicauthextension.NewFactory(),
+		bearertokenauthextension.NewFactory(),
+		healthextension.NewFactory(),
+		tapextension.NewFactory(),
 		zpagesextension.NewFactory(),
 	)
 	if err != nil {
@@ -27,6 +42,7 @@ func components() (component.Factories, error) {
 	}
 
 	factories.Receivers, err = component.MakeReceiverFactoryMap(
+		filereplayreceiver.NewFactory(),
 		otlpreceiver.NewFactory(),
 	)
 	if err != nil {
@@ -34,6 +50,7 @@ func components() (component.Factories, error) {
 	}
 
 	factories.Exporters, err = component.MakeExporterFactoryMap(
+		fileexporter.NewFactory(),
 		loggingexporter.NewFactory(),
 		otlpexporter.NewFactory(),
 		otlphttpexporter.NewFactory(),
@@ -43,8 +60,19 @@ func components() (component.Factories, error) {
 	}
 
 	factories.Processors, err = component.MakeProcessorFactoryMap(
+		attributesprocessor.NewFactory(),
 		batchprocessor.NewFactory(),
 		memorylimiterprocessor.NewFactory(),
+		probabilisticsamplerprocessor.NewFactory(),
+		resourcedetectionprocessor.NewFactory(),
+	)
+	if err != nil {
+		return component.Factories{}, err
+	}
+
+	factories.Connectors, err = component.MakeConnectorFactoryMap(
+		forwardconnector.NewFactory(),
+		logmetricsconnector.NewFactory(),
 	)
 	if err != nil {
 		return component.Factories{}, err
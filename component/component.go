@@ -31,7 +31,7 @@ var (
 	ErrDataTypeIsNotSupported = errors.New("telemetry type is not supported")
 )
 
-// Component is either a receiver, exporter, processor, or an extension.
+// Component is either a receiver, exporter, processor, connector, or an extension.
 //
 // A component's lifecycle has the following phases:
 //
@@ -102,8 +102,25 @@ const (
 	KindProcessor
 	KindExporter
 	KindExtension
+	KindConnector
 )
 
+func (k Kind) String() string {
+	switch k {
+	case KindReceiver:
+		return "receiver"
+	case KindProcessor:
+		return "processor"
+	case KindExporter:
+		return "exporter"
+	case KindExtension:
+		return "extension"
+	case KindConnector:
+		return "connector"
+	}
+	return ""
+}
+
 // StabilityLevel represents the stability level of the component created by the factory.
 // The stability level is used to determine if the component should be used in production
 // or not. For more details see:
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componenttest // import "go.opentelemetry.io/collector/component/componenttest"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+// NewNopConnectorCreateSettings returns a new nop settings for Create*Connector functions.
+func NewNopConnectorCreateSettings() component.ConnectorCreateSettings {
+	return component.ConnectorCreateSettings{
+		TelemetrySettings: NewNopTelemetrySettings(),
+		BuildInfo:         component.NewDefaultBuildInfo(),
+	}
+}
+
+type nopConnectorConfig struct {
+	config.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+}
+
+// NewNopConnectorFactory returns a component.ConnectorFactory that constructs nop connectors,
+// connecting every pair of data types and dropping the data.
+func NewNopConnectorFactory() component.ConnectorFactory {
+	return component.NewConnectorFactory(
+		"nop",
+		func() config.Connector {
+			return &nopConnectorConfig{
+				ConnectorSettings: config.NewConnectorSettings(config.NewComponentID("nop")),
+			}
+		},
+		component.WithTracesToTracesConnector(createTracesToTracesConnector, component.StabilityLevelStable),
+		component.WithTracesToMetricsConnector(createTracesToMetricsConnector, component.StabilityLevelStable),
+		component.WithTracesToLogsConnector(createTracesToLogsConnector, component.StabilityLevelStable),
+		component.WithMetricsToTracesConnector(createMetricsToTracesConnector, component.StabilityLevelStable),
+		component.WithMetricsToMetricsConnector(createMetricsToMetricsConnector, component.StabilityLevelStable),
+		component.WithMetricsToLogsConnector(createMetricsToLogsConnector, component.StabilityLevelStable),
+		component.WithLogsToTracesConnector(createLogsToTracesConnector, component.StabilityLevelStable),
+		component.WithLogsToMetricsConnector(createLogsToMetricsConnector, component.StabilityLevelStable),
+		component.WithLogsToLogsConnector(createLogsToLogsConnector, component.StabilityLevelStable),
+	)
+}
+
+func createTracesToTracesConnector(context.Context, component.ConnectorCreateSettings, config.Connector, consumer.Traces) (component.TracesConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createTracesToMetricsConnector(context.Context, component.ConnectorCreateSettings, config.Connector, consumer.Metrics) (component.TracesConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createTracesToLogsConnector(context.Context, component.ConnectorCreateSettings, config.Connector, consumer.Logs) (component.TracesConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createMetricsToTracesConnector(context.Context, component.ConnectorCreateSettings, config.Connector, consumer.Traces) (component.MetricsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createMetricsToMetricsConnector(context.Context, component.ConnectorCreateSettings, config.Connector, consumer.Metrics) (component.MetricsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createMetricsToLogsConnector(context.Context, component.ConnectorCreateSettings, config.Connector, consumer.Logs) (component.MetricsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createLogsToTracesConnector(context.Context, component.ConnectorCreateSettings, config.Connector, consumer.Traces) (component.LogsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createLogsToMetricsConnector(context.Context, component.ConnectorCreateSettings, config.Connector, consumer.Metrics) (component.LogsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+func createLogsToLogsConnector(context.Context, component.ConnectorCreateSettings, config.Connector, consumer.Logs) (component.LogsConnector, error) {
+	return nopConnectorInstance, nil
+}
+
+var nopConnectorInstance = &nopConnector{
+	Consumer: consumertest.NewNop(),
+}
+
+// nopConnector drops all the data it consumes.
+type nopConnector struct {
+	nopComponent
+	consumertest.Consumer
+}
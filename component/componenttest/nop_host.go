@@ -29,6 +29,8 @@ func NewNopHost() component.Host {
 
 func (nh *nopHost) ReportFatalError(_ error) {}
 
+func (nh *nopHost) ReportComponentStatus(_ component.Status, _ error) {}
+
 func (nh *nopHost) GetFactory(_ component.Kind, _ config.Type) component.Factory {
 	return nil
 }
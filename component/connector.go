@@ -0,0 +1,412 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// Connector joins the end of a pipeline to the start of another one: it consumes the data
+// as an exporter of the first pipeline, and emits data, possibly of a different type, as a
+// receiver of the second one.
+type Connector interface {
+	Component
+}
+
+// TracesConnector is a Connector used as an exporter in traces pipelines.
+type TracesConnector interface {
+	Connector
+	consumer.Traces
+}
+
+// MetricsConnector is a Connector used as an exporter in metrics pipelines.
+type MetricsConnector interface {
+	Connector
+	consumer.Metrics
+}
+
+// LogsConnector is a Connector used as an exporter in logs pipelines.
+type LogsConnector interface {
+	Connector
+	consumer.Logs
+}
+
+// ConnectorCreateSettings is passed to Create* functions in ConnectorFactory.
+type ConnectorCreateSettings struct {
+	TelemetrySettings
+
+	// BuildInfo can be used by components for informational purposes
+	BuildInfo BuildInfo
+}
+
+// ConnectorFactory is Factory interface for connectors.
+//
+// A connector is created for each pair of data types it connects, the data type of the
+// pipelines it is an exporter in and the data type of the pipelines it is a receiver in.
+//
+// This interface cannot be directly implemented. Implementations must
+// use the NewConnectorFactory to implement it.
+type ConnectorFactory interface {
+	Factory
+
+	// CreateDefaultConfig creates the default configuration for the Connector.
+	// This method can be called multiple times depending on the pipeline
+	// configuration and should not cause side-effects that prevent the creation
+	// of multiple instances of the Connector.
+	// The object returned by this method needs to pass the checks implemented by
+	// 'configtest.CheckConfigStruct'. It is recommended to have these checks in the
+	// tests of any implementation of the Factory interface.
+	CreateDefaultConfig() config.Connector
+
+	// CreateTracesToTracesConnector creates a TracesConnector consuming traces and emitting traces to nextConsumer.
+	// If the connector type does not support connecting traces to traces or if the config is not valid,
+	// an error will be returned instead.
+	CreateTracesToTracesConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Traces) (TracesConnector, error)
+
+	// TracesToTracesConnectorStability gets the stability level of the connector consuming traces and emitting traces.
+	TracesToTracesConnectorStability() StabilityLevel
+
+	// CreateTracesToMetricsConnector creates a TracesConnector consuming traces and emitting metrics to nextConsumer.
+	// If the connector type does not support connecting traces to metrics or if the config is not valid,
+	// an error will be returned instead.
+	CreateTracesToMetricsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Metrics) (TracesConnector, error)
+
+	// TracesToMetricsConnectorStability gets the stability level of the connector consuming traces and emitting metrics.
+	TracesToMetricsConnectorStability() StabilityLevel
+
+	// CreateTracesToLogsConnector creates a TracesConnector consuming traces and emitting logs to nextConsumer.
+	// If the connector type does not support connecting traces to logs or if the config is not valid,
+	// an error will be returned instead.
+	CreateTracesToLogsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Logs) (TracesConnector, error)
+
+	// TracesToLogsConnectorStability gets the stability level of the connector consuming traces and emitting logs.
+	TracesToLogsConnectorStability() StabilityLevel
+
+	// CreateMetricsToTracesConnector creates a MetricsConnector consuming metrics and emitting traces to nextConsumer.
+	// If the connector type does not support connecting metrics to traces or if the config is not valid,
+	// an error will be returned instead.
+	CreateMetricsToTracesConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Traces) (MetricsConnector, error)
+
+	// MetricsToTracesConnectorStability gets the stability level of the connector consuming metrics and emitting traces.
+	MetricsToTracesConnectorStability() StabilityLevel
+
+	// CreateMetricsToMetricsConnector creates a MetricsConnector consuming metrics and emitting metrics to nextConsumer.
+	// If the connector type does not support connecting metrics to metrics or if the config is not valid,
+	// an error will be returned instead.
+	CreateMetricsToMetricsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Metrics) (MetricsConnector, error)
+
+	// MetricsToMetricsConnectorStability gets the stability level of the connector consuming metrics and emitting metrics.
+	MetricsToMetricsConnectorStability() StabilityLevel
+
+	// CreateMetricsToLogsConnector creates a MetricsConnector consuming metrics and emitting logs to nextConsumer.
+	// If the connector type does not support connecting metrics to logs or if the config is not valid,
+	// an error will be returned instead.
+	CreateMetricsToLogsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Logs) (MetricsConnector, error)
+
+	// MetricsToLogsConnectorStability gets the stability level of the connector consuming metrics and emitting logs.
+	MetricsToLogsConnectorStability() StabilityLevel
+
+	// CreateLogsToTracesConnector creates a LogsConnector consuming logs and emitting traces to nextConsumer.
+	// If the connector type does not support connecting logs to traces or if the config is not valid,
+	// an error will be returned instead.
+	CreateLogsToTracesConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Traces) (LogsConnector, error)
+
+	// LogsToTracesConnectorStability gets the stability level of the connector consuming logs and emitting traces.
+	LogsToTracesConnectorStability() StabilityLevel
+
+	// CreateLogsToMetricsConnector creates a LogsConnector consuming logs and emitting metrics to nextConsumer.
+	// If the connector type does not support connecting logs to metrics or if the config is not valid,
+	// an error will be returned instead.
+	CreateLogsToMetricsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Metrics) (LogsConnector, error)
+
+	// LogsToMetricsConnectorStability gets the stability level of the connector consuming logs and emitting metrics.
+	LogsToMetricsConnectorStability() StabilityLevel
+
+	// CreateLogsToLogsConnector creates a LogsConnector consuming logs and emitting logs to nextConsumer.
+	// If the connector type does not support connecting logs to logs or if the config is not valid,
+	// an error will be returned instead.
+	CreateLogsToLogsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Logs) (LogsConnector, error)
+
+	// LogsToLogsConnectorStability gets the stability level of the connector consuming logs and emitting logs.
+	LogsToLogsConnectorStability() StabilityLevel
+}
+
+// ConnectorFactoryOption apply changes to ConnectorOptions.
+type ConnectorFactoryOption interface {
+	// applyConnectorFactoryOption applies the option.
+	applyConnectorFactoryOption(o *connectorFactory)
+}
+
+var _ ConnectorFactoryOption = (*connectorFactoryOptionFunc)(nil)
+
+// connectorFactoryOptionFunc is a ConnectorFactoryOption created through a function.
+type connectorFactoryOptionFunc func(*connectorFactory)
+
+func (f connectorFactoryOptionFunc) applyConnectorFactoryOption(o *connectorFactory) {
+	f(o)
+}
+
+// ConnectorCreateDefaultConfigFunc is the equivalent of ConnectorFactory.CreateDefaultConfig().
+type ConnectorCreateDefaultConfigFunc func() config.Connector
+
+// CreateDefaultConfig implements ConnectorFactory.CreateDefaultConfig().
+func (f ConnectorCreateDefaultConfigFunc) CreateDefaultConfig() config.Connector {
+	return f()
+}
+
+// CreateTracesToTracesConnectorFunc is the equivalent of ConnectorFactory.CreateTracesToTracesConnector().
+type CreateTracesToTracesConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (TracesConnector, error)
+
+// CreateTracesToTracesConnector implements ConnectorFactory.CreateTracesToTracesConnector().
+func (f CreateTracesToTracesConnectorFunc) CreateTracesToTracesConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Traces) (TracesConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateTracesToMetricsConnectorFunc is the equivalent of ConnectorFactory.CreateTracesToMetricsConnector().
+type CreateTracesToMetricsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (TracesConnector, error)
+
+// CreateTracesToMetricsConnector implements ConnectorFactory.CreateTracesToMetricsConnector().
+func (f CreateTracesToMetricsConnectorFunc) CreateTracesToMetricsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Metrics) (TracesConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateTracesToLogsConnectorFunc is the equivalent of ConnectorFactory.CreateTracesToLogsConnector().
+type CreateTracesToLogsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Logs) (TracesConnector, error)
+
+// CreateTracesToLogsConnector implements ConnectorFactory.CreateTracesToLogsConnector().
+func (f CreateTracesToLogsConnectorFunc) CreateTracesToLogsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Logs) (TracesConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateMetricsToTracesConnectorFunc is the equivalent of ConnectorFactory.CreateMetricsToTracesConnector().
+type CreateMetricsToTracesConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (MetricsConnector, error)
+
+// CreateMetricsToTracesConnector implements ConnectorFactory.CreateMetricsToTracesConnector().
+func (f CreateMetricsToTracesConnectorFunc) CreateMetricsToTracesConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Traces) (MetricsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateMetricsToMetricsConnectorFunc is the equivalent of ConnectorFactory.CreateMetricsToMetricsConnector().
+type CreateMetricsToMetricsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (MetricsConnector, error)
+
+// CreateMetricsToMetricsConnector implements ConnectorFactory.CreateMetricsToMetricsConnector().
+func (f CreateMetricsToMetricsConnectorFunc) CreateMetricsToMetricsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Metrics) (MetricsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateMetricsToLogsConnectorFunc is the equivalent of ConnectorFactory.CreateMetricsToLogsConnector().
+type CreateMetricsToLogsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Logs) (MetricsConnector, error)
+
+// CreateMetricsToLogsConnector implements ConnectorFactory.CreateMetricsToLogsConnector().
+func (f CreateMetricsToLogsConnectorFunc) CreateMetricsToLogsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Logs) (MetricsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateLogsToTracesConnectorFunc is the equivalent of ConnectorFactory.CreateLogsToTracesConnector().
+type CreateLogsToTracesConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (LogsConnector, error)
+
+// CreateLogsToTracesConnector implements ConnectorFactory.CreateLogsToTracesConnector().
+func (f CreateLogsToTracesConnectorFunc) CreateLogsToTracesConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Traces) (LogsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateLogsToMetricsConnectorFunc is the equivalent of ConnectorFactory.CreateLogsToMetricsConnector().
+type CreateLogsToMetricsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (LogsConnector, error)
+
+// CreateLogsToMetricsConnector implements ConnectorFactory.CreateLogsToMetricsConnector().
+func (f CreateLogsToMetricsConnectorFunc) CreateLogsToMetricsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Metrics) (LogsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+// CreateLogsToLogsConnectorFunc is the equivalent of ConnectorFactory.CreateLogsToLogsConnector().
+type CreateLogsToLogsConnectorFunc func(context.Context, ConnectorCreateSettings, config.Connector, consumer.Logs) (LogsConnector, error)
+
+// CreateLogsToLogsConnector implements ConnectorFactory.CreateLogsToLogsConnector().
+func (f CreateLogsToLogsConnectorFunc) CreateLogsToLogsConnector(ctx context.Context, set ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Logs) (LogsConnector, error) {
+	if f == nil {
+		return nil, ErrDataTypeIsNotSupported
+	}
+	return f(ctx, set, cfg, nextConsumer)
+}
+
+type connectorFactory struct {
+	baseFactory
+	ConnectorCreateDefaultConfigFunc
+	CreateTracesToTracesConnectorFunc
+	tracesToTracesStabilityLevel StabilityLevel
+	CreateTracesToMetricsConnectorFunc
+	tracesToMetricsStabilityLevel StabilityLevel
+	CreateTracesToLogsConnectorFunc
+	tracesToLogsStabilityLevel StabilityLevel
+	CreateMetricsToTracesConnectorFunc
+	metricsToTracesStabilityLevel StabilityLevel
+	CreateMetricsToMetricsConnectorFunc
+	metricsToMetricsStabilityLevel StabilityLevel
+	CreateMetricsToLogsConnectorFunc
+	metricsToLogsStabilityLevel StabilityLevel
+	CreateLogsToTracesConnectorFunc
+	logsToTracesStabilityLevel StabilityLevel
+	CreateLogsToMetricsConnectorFunc
+	logsToMetricsStabilityLevel StabilityLevel
+	CreateLogsToLogsConnectorFunc
+	logsToLogsStabilityLevel StabilityLevel
+}
+
+func (c connectorFactory) TracesToTracesConnectorStability() StabilityLevel {
+	return c.tracesToTracesStabilityLevel
+}
+
+func (c connectorFactory) TracesToMetricsConnectorStability() StabilityLevel {
+	return c.tracesToMetricsStabilityLevel
+}
+
+func (c connectorFactory) TracesToLogsConnectorStability() StabilityLevel {
+	return c.tracesToLogsStabilityLevel
+}
+
+func (c connectorFactory) MetricsToTracesConnectorStability() StabilityLevel {
+	return c.metricsToTracesStabilityLevel
+}
+
+func (c connectorFactory) MetricsToMetricsConnectorStability() StabilityLevel {
+	return c.metricsToMetricsStabilityLevel
+}
+
+func (c connectorFactory) MetricsToLogsConnectorStability() StabilityLevel {
+	return c.metricsToLogsStabilityLevel
+}
+
+func (c connectorFactory) LogsToTracesConnectorStability() StabilityLevel {
+	return c.logsToTracesStabilityLevel
+}
+
+func (c connectorFactory) LogsToMetricsConnectorStability() StabilityLevel {
+	return c.logsToMetricsStabilityLevel
+}
+
+func (c connectorFactory) LogsToLogsConnectorStability() StabilityLevel {
+	return c.logsToLogsStabilityLevel
+}
+
+// WithTracesToTracesConnector overrides the default "error not supported" implementation for CreateTracesToTracesConnector and the default "undefined" stability level.
+func WithTracesToTracesConnector(createTracesToTracesConnector CreateTracesToTracesConnectorFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.tracesToTracesStabilityLevel = sl
+		o.CreateTracesToTracesConnectorFunc = createTracesToTracesConnector
+	})
+}
+
+// WithTracesToMetricsConnector overrides the default "error not supported" implementation for CreateTracesToMetricsConnector and the default "undefined" stability level.
+func WithTracesToMetricsConnector(createTracesToMetricsConnector CreateTracesToMetricsConnectorFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.tracesToMetricsStabilityLevel = sl
+		o.CreateTracesToMetricsConnectorFunc = createTracesToMetricsConnector
+	})
+}
+
+// WithTracesToLogsConnector overrides the default "error not supported" implementation for CreateTracesToLogsConnector and the default "undefined" stability level.
+func WithTracesToLogsConnector(createTracesToLogsConnector CreateTracesToLogsConnectorFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.tracesToLogsStabilityLevel = sl
+		o.CreateTracesToLogsConnectorFunc = createTracesToLogsConnector
+	})
+}
+
+// WithMetricsToTracesConnector overrides the default "error not supported" implementation for CreateMetricsToTracesConnector and the default "undefined" stability level.
+func WithMetricsToTracesConnector(createMetricsToTracesConnector CreateMetricsToTracesConnectorFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.metricsToTracesStabilityLevel = sl
+		o.CreateMetricsToTracesConnectorFunc = createMetricsToTracesConnector
+	})
+}
+
+// WithMetricsToMetricsConnector overrides the default "error not supported" implementation for CreateMetricsToMetricsConnector and the default "undefined" stability level.
+func WithMetricsToMetricsConnector(createMetricsToMetricsConnector CreateMetricsToMetricsConnectorFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.metricsToMetricsStabilityLevel = sl
+		o.CreateMetricsToMetricsConnectorFunc = createMetricsToMetricsConnector
+	})
+}
+
+// WithMetricsToLogsConnector overrides the default "error not supported" implementation for CreateMetricsToLogsConnector and the default "undefined" stability level.
+func WithMetricsToLogsConnector(createMetricsToLogsConnector CreateMetricsToLogsConnectorFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.metricsToLogsStabilityLevel = sl
+		o.CreateMetricsToLogsConnectorFunc = createMetricsToLogsConnector
+	})
+}
+
+// WithLogsToTracesConnector overrides the default "error not supported" implementation for CreateLogsToTracesConnector and the default "undefined" stability level.
+func WithLogsToTracesConnector(createLogsToTracesConnector CreateLogsToTracesConnectorFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.logsToTracesStabilityLevel = sl
+		o.CreateLogsToTracesConnectorFunc = createLogsToTracesConnector
+	})
+}
+
+// WithLogsToMetricsConnector overrides the default "error not supported" implementation for CreateLogsToMetricsConnector and the default "undefined" stability level.
+func WithLogsToMetricsConnector(createLogsToMetricsConnector CreateLogsToMetricsConnectorFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.logsToMetricsStabilityLevel = sl
+		o.CreateLogsToMetricsConnectorFunc = createLogsToMetricsConnector
+	})
+}
+
+// WithLogsToLogsConnector overrides the default "error not supported" implementation for CreateLogsToLogsConnector and the default "undefined" stability level.
+func WithLogsToLogsConnector(createLogsToLogsConnector CreateLogsToLogsConnectorFunc, sl StabilityLevel) ConnectorFactoryOption {
+	return connectorFactoryOptionFunc(func(o *connectorFactory) {
+		o.logsToLogsStabilityLevel = sl
+		o.CreateLogsToLogsConnectorFunc = createLogsToLogsConnector
+	})
+}
+
+// NewConnectorFactory returns a ConnectorFactory.
+func NewConnectorFactory(cfgType config.Type, createDefaultConfig ConnectorCreateDefaultConfigFunc, options ...ConnectorFactoryOption) ConnectorFactory {
+	f := &connectorFactory{
+		baseFactory:                      baseFactory{cfgType: cfgType},
+		ConnectorCreateDefaultConfigFunc: createDefaultConfig,
+	}
+	for _, opt := range options {
+		opt.applyConnectorFactoryOption(f)
+	}
+	return f
+}
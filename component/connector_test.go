@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestNewConnectorFactory(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewConnectorSettings(config.NewComponentID(typeStr))
+	factory := NewConnectorFactory(
+		typeStr,
+		func() config.Connector { return &defaultCfg })
+	assert.EqualValues(t, typeStr, factory.Type())
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+	_, err := factory.CreateTracesToTracesConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, ErrDataTypeIsNotSupported)
+	_, err = factory.CreateTracesToMetricsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, ErrDataTypeIsNotSupported)
+	_, err = factory.CreateMetricsToLogsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, ErrDataTypeIsNotSupported)
+	_, err = factory.CreateLogsToLogsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, ErrDataTypeIsNotSupported)
+	assert.Equal(t, StabilityLevelUndefined, factory.LogsToTracesConnectorStability())
+}
+
+func TestNewConnectorFactory_WithOptions(t *testing.T) {
+	const typeStr = "test"
+	defaultCfg := config.NewConnectorSettings(config.NewComponentID(typeStr))
+	factory := NewConnectorFactory(
+		typeStr,
+		func() config.Connector { return &defaultCfg },
+		WithTracesToTracesConnector(createTracesToTracesConnector, StabilityLevelInDevelopment),
+		WithTracesToMetricsConnector(createTracesToMetricsConnector, StabilityLevelAlpha),
+		WithLogsToMetricsConnector(createLogsToMetricsConnector, StabilityLevelBeta))
+	assert.EqualValues(t, typeStr, factory.Type())
+	assert.EqualValues(t, &defaultCfg, factory.CreateDefaultConfig())
+
+	assert.Equal(t, StabilityLevelInDevelopment, factory.TracesToTracesConnectorStability())
+	_, err := factory.CreateTracesToTracesConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.NoError(t, err)
+
+	assert.Equal(t, StabilityLevelAlpha, factory.TracesToMetricsConnectorStability())
+	_, err = factory.CreateTracesToMetricsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.NoError(t, err)
+
+	assert.Equal(t, StabilityLevelBeta, factory.LogsToMetricsConnectorStability())
+	_, err = factory.CreateLogsToMetricsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.NoError(t, err)
+
+	assert.Equal(t, StabilityLevelUndefined, factory.TracesToLogsConnectorStability())
+	_, err = factory.CreateTracesToLogsConnector(context.Background(), ConnectorCreateSettings{}, &defaultCfg, consumertest.NewNop())
+	assert.ErrorIs(t, err, ErrDataTypeIsNotSupported)
+}
+
+func createTracesToTracesConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Traces) (TracesConnector, error) {
+	return nil, nil
+}
+
+func createTracesToMetricsConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (TracesConnector, error) {
+	return nil, nil
+}
+
+func createLogsToMetricsConnector(context.Context, ConnectorCreateSettings, config.Connector, consumer.Metrics) (LogsConnector, error) {
+	return nil, nil
+}
@@ -16,6 +16,7 @@ package component // import "go.opentelemetry.io/collector/component"
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -44,6 +45,33 @@ type LogsExporter interface {
 	consumer.Logs
 }
 
+// ExporterQueueState is the state of the sending queue and of the retries of an exporter.
+type ExporterQueueState struct {
+	// QueueEnabled is true if the exporter enqueues the data before sending it.
+	QueueEnabled bool
+	// QueueSize is the number of batches currently in the queue.
+	QueueSize int
+	// QueueCapacity is the maximum number of batches allowed in the queue.
+	QueueCapacity int
+	// RetryEnabled is true if the exporter retries sending the data on failures.
+	RetryEnabled bool
+	// RetryingRequests is the number of requests currently waiting to be sent again after a failure.
+	RetryingRequests int
+	// LastError is the error of the last send attempt, nil if it succeeded.
+	LastError error
+	// LastErrorTime is the time of the last failed send attempt, zero if none failed.
+	LastErrorTime time.Time
+}
+
+// QueueStateExporter is an optional interface for the exporters exposing the state of their
+// sending queue and retries, for instance to the zPages.
+type QueueStateExporter interface {
+	Exporter
+
+	// QueueState returns the current state of the sending queue and retries of the exporter.
+	QueueState() ExporterQueueState
+}
+
 // ExporterCreateSettings configures Exporter creators.
 type ExporterCreateSettings struct {
 	TelemetrySettings
@@ -44,6 +44,16 @@ type PipelineWatcher interface {
 	NotReady() error
 }
 
+// StatusWatcher is an extra interface for Extension hosted by the OpenTelemetry
+// Collector that is to be implemented by extensions interested in the statuses of the
+// components, e.g.: a health check endpoint.
+type StatusWatcher interface {
+	// ComponentStatusChanged notifies the Extension that a status was reported for a
+	// component instance, including the extensions. It can be called concurrently, from
+	// any goroutine, and must not block.
+	ComponentStatusChanged(source *StatusSource, event *StatusEvent)
+}
+
 // ExtensionCreateSettings is passed to ExtensionFactory.Create* functions.
 type ExtensionCreateSettings struct {
 	TelemetrySettings
@@ -29,6 +29,16 @@ type Host interface {
 	// before Component.Shutdown() begins.
 	ReportFatalError(err error)
 
+	// ReportComponentStatus is used to report to the host a change of the status of the
+	// component, e.g. an exporter which fails to send data can report a
+	// StatusRecoverableError, and a StatusOK once it recovers. err is the cause of an error
+	// status, and should be nil otherwise. The host reports the statuses of the start and
+	// shutdown of the component.
+	//
+	// ReportComponentStatus can be called by the component anytime after Component.Start()
+	// begins and until Component.Shutdown() ends.
+	ReportComponentStatus(status Status, err error)
+
 	// GetFactory of the specified kind. Returns the factory for a component type.
 	// This allows components to create other components. For example:
 	//   func (r MyReceiver) Start(host component.Host) error {
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component // import "go.opentelemetry.io/collector/component"
+
+import (
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Status represents the status of a component instance.
+type Status int
+
+const (
+	StatusNone Status = iota
+	// StatusStarting is reported by the host before starting the component.
+	StatusStarting
+	// StatusOK is reported by the host once the component is started, and by the component
+	// when it recovers from an error.
+	StatusOK
+	// StatusRecoverableError is reported by the component when it is degraded, e.g. an exporter
+	// failing to send data which is retried, and is expected to recover by itself.
+	StatusRecoverableError
+	// StatusPermanentError is reported by the component when it cannot recover without
+	// intervention, e.g. a configuration rejected by the backend. The host also reports it
+	// when the component fails to start.
+	StatusPermanentError
+	// StatusFatalError is reported by the host when the component calls Host.ReportFatalError.
+	StatusFatalError
+	// StatusStopping is reported by the host before shutting down the component.
+	StatusStopping
+	// StatusStopped is reported by the host once the component is shut down.
+	StatusStopped
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusStarting:
+		return "Starting"
+	case StatusOK:
+		return "OK"
+	case StatusRecoverableError:
+		return "RecoverableError"
+	case StatusPermanentError:
+		return "PermanentError"
+	case StatusFatalError:
+		return "FatalError"
+	case StatusStopping:
+		return "Stopping"
+	case StatusStopped:
+		return "Stopped"
+	}
+	return "None"
+}
+
+// StatusEvent is a change of the status of a component instance.
+type StatusEvent struct {
+	Status Status
+	// Err is the error which caused an error status, nil otherwise.
+	Err       error
+	Timestamp time.Time
+}
+
+// StatusSource identifies the component instance a StatusEvent is reported for.
+type StatusSource struct {
+	Kind Kind
+	ID   config.ComponentID
+	// DataType is the type of data the component instance handles, empty for extensions.
+	// For connectors, it is the type of data the instance consumes.
+	DataType config.DataType
+	// EmittedDataType is the type of data a connector instance emits, empty for the other kinds.
+	EmittedDataType config.DataType
+	// Pipelines are the IDs of the pipelines the component instance is part of, sorted,
+	// empty for extensions.
+	Pipelines []config.ComponentID
+}
+
+// InstanceKey returns a key identifying the component instance. Receivers and exporters have an
+// instance per data type, processors have an instance per pipeline, and connectors have an instance
+// per pair of consumed and emitted data types.
+func (s *StatusSource) InstanceKey() string {
+	key := []string{s.Kind.String(), s.ID.String(), string(s.DataType), string(s.EmittedDataType)}
+	for _, pipelineID := range s.Pipelines {
+		key = append(key, pipelineID.String())
+	}
+	return strings.Join(key, "|")
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+func TestStatusSourceInstanceKey(t *testing.T) {
+	processor := &StatusSource{
+		Kind:      KindProcessor,
+		ID:        config.NewComponentID("batch"),
+		DataType:  config.TracesDataType,
+		Pipelines: []config.ComponentID{config.NewComponentIDWithName(config.TracesDataType, "1")},
+	}
+	assert.Equal(t, "processor|batch|traces||traces/1", processor.InstanceKey())
+
+	connector := &StatusSource{
+		Kind:            KindConnector,
+		ID:              config.NewComponentID("count"),
+		DataType:        config.TracesDataType,
+		EmittedDataType: config.MetricsDataType,
+	}
+	assert.Equal(t, "connector|count|traces|metrics", connector.InstanceKey())
+}
@@ -0,0 +1,285 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauth // import "go.opentelemetry.io/collector/config/configauth"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const (
+	// ActionAllow is the action of rules allowing the requests they match.
+	ActionAllow = "allow"
+	// ActionDeny is the action of rules denying the requests they match.
+	ActionDeny = "deny"
+)
+
+// ErrPermissionDenied is returned by Authorization.Authorize when a request is not allowed.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// defaultPrincipalAttributes are the client.AuthData attributes identifying the principal, in order
+// of preference, when Authorization.PrincipalAttribute isn't set: the subject and username set by
+// authenticators, then the SPIFFE ID and subject of verified client certificates.
+var defaultPrincipalAttributes = []string{"subject", "username", configtls.AttributeSPIFFEID, configtls.AttributeSubject}
+
+// Authorization defines the rules deciding whether authenticated requests are allowed. Rules are
+// evaluated in order, and the first rule matching a request decides whether it is allowed. Requests
+// matching no rule are denied.
+type Authorization struct {
+	// PrincipalAttribute is the name of the client.AuthData attribute identifying the principal.
+	// If not set, the first set among the "subject", "username", "tls.spiffe_id" and "tls.subject"
+	// attributes is used.
+	PrincipalAttribute string `mapstructure:"principal_attribute"`
+
+	// Rules is the ordered list of rules.
+	Rules []AuthorizationRule `mapstructure:"rules"`
+}
+
+// AuthorizationRule matches requests on their principal, signal, auth data attributes and pipeline.
+// A request matches the rule when it matches all the conditions set in the rule.
+type AuthorizationRule struct {
+	// Principals is the list of principals matched by the rule.
+	Principals []string `mapstructure:"principals"`
+
+	// Signals is the list of signals matched by the rule, among traces, metrics and logs.
+	Signals []config.DataType `mapstructure:"signals"`
+
+	// Attributes maps client.AuthData attribute names to their values matched by the rule. For
+	// attributes holding a list, such as group memberships, any of the elements can match.
+	Attributes map[string][]string `mapstructure:"attributes"`
+
+	// Pipelines is the list of pipelines matched by the rule. The pipeline is only known once the
+	// request is passed to the pipelines of the receiver: the rules are then evaluated again for each
+	// pipeline. The request is denied when it can reach none of the pipelines of the receiver, and the
+	// data is not passed to the pipelines the request is not allowed to reach.
+	Pipelines []config.ComponentID `mapstructure:"pipelines"`
+
+	// Action is either "allow" or "deny". Default is "allow".
+	Action string `mapstructure:"action"`
+}
+
+// Validate checks if the authorization configuration is valid.
+func (a *Authorization) Validate() error {
+	if len(a.Rules) == 0 {
+		return errors.New("authorization requires at least one rule")
+	}
+	for i, rule := range a.Rules {
+		switch rule.Action {
+		case "", ActionAllow, ActionDeny:
+		default:
+			return fmt.Errorf("authorization rule %d: invalid action %q, must be %q or %q", i, rule.Action, ActionAllow, ActionDeny)
+		}
+		for _, signal := range rule.Signals {
+			switch signal {
+			case config.TracesDataType, config.MetricsDataType, config.LogsDataType:
+			default:
+				return fmt.Errorf("authorization rule %d: invalid signal %q", i, signal)
+			}
+		}
+		for _, pipelineID := range rule.Pipelines {
+			switch pipelineID.Type() {
+			case config.TracesDataType, config.MetricsDataType, config.LogsDataType:
+			default:
+				return fmt.Errorf("authorization rule %d: invalid pipeline %q", i, pipelineID)
+			}
+		}
+	}
+	return nil
+}
+
+// Authorize checks whether the request for the given signal is allowed, based on the client.Info of
+// the context as populated by the ServerAuthenticator or the TLS client certificate. The signal is empty
+// when it is unknown, in which case rules on signals don't match. Requests without auth data are never
+// allowed. An error wrapping ErrPermissionDenied is returned for requests that are not allowed.
+//
+// When the decision depends on the pipeline, the request is allowed and the returned context carries the
+// decision for each pipeline, see AuthorizeAnyPipeline and AuthorizedPipeline.
+func (a *Authorization) Authorize(ctx context.Context, signal config.DataType) (context.Context, error) {
+	auth := client.FromContext(ctx).Auth
+	principal := a.principal(auth)
+	if auth != nil {
+		allowed, deferred := a.decide(auth, principal, signal, nil)
+		if deferred {
+			return context.WithValue(ctx, pipelineAuthorizationKey{}, pipelineAuthorization{
+				principal: principal,
+				allowed: func(pipelineID config.ComponentID) bool {
+					allowed, _ = a.decide(auth, principal, signal, &pipelineID)
+					return allowed
+				},
+			}), nil
+		}
+		if allowed {
+			return ctx, nil
+		}
+	}
+	return ctx, permissionDenied(principal)
+}
+
+func permissionDenied(principal string) error {
+	if principal == "" {
+		return ErrPermissionDenied
+	}
+	return fmt.Errorf("%w for %q", ErrPermissionDenied, principal)
+}
+
+// decide returns whether the first rule matching the request allows it. When pipelineID is nil and a
+// rule on pipelines is reached, the decision is deferred until the pipeline is known.
+func (a *Authorization) decide(auth client.AuthData, principal string, signal config.DataType, pipelineID *config.ComponentID) (allowed bool, deferred bool) {
+	for _, rule := range a.Rules {
+		if !rule.matches(auth, principal, signal) {
+			continue
+		}
+		if len(rule.Pipelines) > 0 {
+			if pipelineID == nil {
+				return false, true
+			}
+			if !containsID(rule.Pipelines, *pipelineID) {
+				continue
+			}
+		}
+		return rule.Action != ActionDeny, false
+	}
+	return false, false
+}
+
+type pipelineAuthorizationKey struct{}
+
+// pipelineAuthorization is the decision deferred by Authorization.Authorize until the pipeline is known.
+type pipelineAuthorization struct {
+	principal string
+	allowed   func(pipelineID config.ComponentID) bool
+}
+
+// AuthorizedPipeline returns whether the request of the context, allowed by Authorization.Authorize, can be
+// passed to the given pipeline. It is true unless the authorization rules deny that pipeline to the request.
+func AuthorizedPipeline(ctx context.Context, pipelineID config.ComponentID) bool {
+	authorization, ok := ctx.Value(pipelineAuthorizationKey{}).(pipelineAuthorization)
+	return !ok || authorization.allowed(pipelineID)
+}
+
+// AuthorizeAnyPipeline returns an error wrapping ErrPermissionDenied when the request of the context, allowed
+// by Authorization.Authorize, cannot be passed to any of the given pipelines of the receiver. It is checked
+// before the data is consumed, so that the request fails rather than being silently dropped.
+func AuthorizeAnyPipeline(ctx context.Context, pipelineIDs []config.ComponentID) error {
+	authorization, ok := ctx.Value(pipelineAuthorizationKey{}).(pipelineAuthorization)
+	if !ok {
+		return nil
+	}
+	for _, pipelineID := range pipelineIDs {
+		if authorization.allowed(pipelineID) {
+			return nil
+		}
+	}
+	return permissionDenied(authorization.principal)
+}
+
+func (a *Authorization) principal(auth client.AuthData) string {
+	if auth == nil {
+		return ""
+	}
+	if a.PrincipalAttribute != "" {
+		return attributeString(auth.GetAttribute(a.PrincipalAttribute))
+	}
+	for _, name := range defaultPrincipalAttributes {
+		if principal := attributeString(auth.GetAttribute(name)); principal != "" {
+			return principal
+		}
+	}
+	return ""
+}
+
+func (r *AuthorizationRule) matches(auth client.AuthData, principal string, signal config.DataType) bool {
+	if len(r.Principals) > 0 && !contains(r.Principals, principal) {
+		return false
+	}
+	if len(r.Signals) > 0 && !containsSignal(r.Signals, signal) {
+		return false
+	}
+	for name, values := range r.Attributes {
+		if auth == nil || !attributeMatches(auth.GetAttribute(name), values) {
+			return false
+		}
+	}
+	return true
+}
+
+// attributeMatches returns whether the attribute value, or any of its elements, is one of values.
+func attributeMatches(attr interface{}, values []string) bool {
+	switch v := attr.(type) {
+	case nil:
+		return false
+	case []string:
+		for _, elem := range v {
+			if contains(values, elem) {
+				return true
+			}
+		}
+		return false
+	case []interface{}:
+		for _, elem := range v {
+			if contains(values, attributeString(elem)) {
+				return true
+			}
+		}
+		return false
+	default:
+		return contains(values, attributeString(v))
+	}
+}
+
+func attributeString(attr interface{}) string {
+	switch v := attr.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(ids []config.ComponentID, id config.ComponentID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSignal(signals []config.DataType, signal config.DataType) bool {
+	for _, s := range signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config"
+)
+
+type testAuthData map[string]interface{}
+
+func (d testAuthData) GetAttribute(name string) interface{} {
+	return d[name]
+}
+
+func (d testAuthData) GetAttributeNames() []string {
+	var names []string
+	for name := range d {
+		names = append(names, name)
+	}
+	return names
+}
+
+func contextWithAuth(auth client.AuthData) context.Context {
+	return client.NewContext(context.Background(), client.Info{Auth: auth})
+}
+
+func TestAuthorize(t *testing.T) {
+	authorization := &Authorization{
+		Rules: []AuthorizationRule{
+			{
+				Principals: []string{"blocked"},
+				Action:     ActionDeny,
+			},
+			{
+				Principals: []string{"team-logs"},
+				Signals:    []config.DataType{config.LogsDataType},
+			},
+			{
+				Attributes: map[string][]string{"membership": {"admins"}},
+			},
+			{
+				Principals: []string{"team-metrics"},
+				Signals:    []config.DataType{config.MetricsDataType},
+				Attributes: map[string][]string{"tenant": {"a", "b"}},
+				Action:     ActionAllow,
+			},
+		},
+	}
+	assert.NoError(t, authorization.Validate())
+
+	tests := []struct {
+		name   string
+		auth   client.AuthData
+		signal config.DataType
+		err    string
+	}{
+		{
+			name:   "allowed signal",
+			auth:   testAuthData{"subject": "team-logs"},
+			signal: config.LogsDataType,
+		},
+		{
+			name:   "other signal",
+			auth:   testAuthData{"subject": "team-logs"},
+			signal: config.TracesDataType,
+			err:    `permission denied for "team-logs"`,
+		},
+		{
+			name:   "unknown signal",
+			auth:   testAuthData{"subject": "team-logs"},
+			signal: "",
+			err:    `permission denied for "team-logs"`,
+		},
+		{
+			name:   "username principal",
+			auth:   testAuthData{"username": "team-logs"},
+			signal: config.LogsDataType,
+		},
+		{
+			name:   "denied principal",
+			auth:   testAuthData{"subject": "blocked", "membership": []string{"admins"}},
+			signal: config.LogsDataType,
+			err:    `permission denied for "blocked"`,
+		},
+		{
+			name:   "attribute list",
+			auth:   testAuthData{"subject": "someone", "membership": []string{"devs", "admins"}},
+			signal: config.TracesDataType,
+		},
+		{
+			name:   "attribute interface list",
+			auth:   testAuthData{"membership": []interface{}{"admins"}},
+			signal: config.TracesDataType,
+		},
+		{
+			name:   "all conditions",
+			auth:   testAuthData{"subject": "team-metrics", "tenant": "b"},
+			signal: config.MetricsDataType,
+		},
+		{
+			name:   "missing attribute",
+			auth:   testAuthData{"subject": "team-metrics"},
+			signal: config.MetricsDataType,
+			err:    `permission denied for "team-metrics"`,
+		},
+		{
+			name:   "certificate SPIFFE ID principal",
+			auth:   testAuthData{"tls.spiffe_id": "spiffe://example.org/team-logs", "tls.subject": "CN=team-logs"},
+			signal: config.LogsDataType,
+			err:    `permission denied for "spiffe://example.org/team-logs"`,
+		},
+		{
+			name:   "certificate subject principal",
+			auth:   testAuthData{"tls.subject": "CN=team-logs"},
+			signal: config.LogsDataType,
+			err:    `permission denied for "CN=team-logs"`,
+		},
+		{
+			name:   "unauthenticated",
+			signal: config.LogsDataType,
+			err:    "permission denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := authorization.Authorize(contextWithAuth(tt.auth), tt.signal)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+			assert.ErrorIs(t, err, ErrPermissionDenied)
+		})
+	}
+}
+
+func TestAuthorizePrincipalAttribute(t *testing.T) {
+	authorization := &Authorization{
+		PrincipalAttribute: "tls.common_name",
+		Rules: []AuthorizationRule{
+			{Principals: []string{"gateway"}},
+		},
+	}
+
+	_, err := authorization.Authorize(contextWithAuth(testAuthData{"tls.common_name": "gateway"}), config.TracesDataType)
+	assert.NoError(t, err)
+	_, err = authorization.Authorize(contextWithAuth(testAuthData{"subject": "gateway"}), config.TracesDataType)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+}
+
+func TestAuthorizeDefaultPrincipalAttributes(t *testing.T) {
+	authorization := &Authorization{
+		Rules: []AuthorizationRule{
+			{Principals: []string{"spiffe://example.org/gateway", "CN=agent"}},
+		},
+	}
+
+	_, err := authorization.Authorize(contextWithAuth(testAuthData{"tls.spiffe_id": "spiffe://example.org/gateway", "tls.subject": "CN=gateway"}), config.TracesDataType)
+	assert.NoError(t, err)
+	_, err = authorization.Authorize(contextWithAuth(testAuthData{"tls.subject": "CN=agent"}), config.TracesDataType)
+	assert.NoError(t, err)
+	_, err = authorization.Authorize(contextWithAuth(testAuthData{"subject": "someone", "tls.subject": "CN=agent"}), config.TracesDataType)
+	assert.EqualError(t, err, `permission denied for "someone"`)
+}
+
+func TestAuthorizePipelines(t *testing.T) {
+	tenantA := config.NewComponentIDWithName(config.TracesDataType, "tenant-a")
+	tenantB := config.NewComponentIDWithName(config.TracesDataType, "tenant-b")
+	authorization := &Authorization{
+		Rules: []AuthorizationRule{
+			{Principals: []string{"blocked"}, Action: ActionDeny},
+			{Principals: []string{"tenant-a"}, Pipelines: []config.ComponentID{tenantA}},
+			{Principals: []string{"tenant-a"}, Action: ActionDeny},
+			{Principals: []string{"admin"}},
+		},
+	}
+	assert.NoError(t, authorization.Validate())
+
+	ctx, err := authorization.Authorize(contextWithAuth(testAuthData{"subject": "tenant-a"}), config.TracesDataType)
+	assert.NoError(t, err)
+	assert.True(t, AuthorizedPipeline(ctx, tenantA))
+	assert.False(t, AuthorizedPipeline(ctx, tenantB))
+	assert.NoError(t, AuthorizeAnyPipeline(ctx, []config.ComponentID{tenantA, tenantB}))
+	err = AuthorizeAnyPipeline(ctx, []config.ComponentID{tenantB})
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	assert.EqualError(t, err, `permission denied for "tenant-a"`)
+
+	ctx, err = authorization.Authorize(contextWithAuth(testAuthData{"subject": "admin"}), config.TracesDataType)
+	assert.NoError(t, err)
+	assert.True(t, AuthorizedPipeline(ctx, tenantA))
+	assert.True(t, AuthorizedPipeline(ctx, tenantB))
+
+	_, err = authorization.Authorize(contextWithAuth(testAuthData{"subject": "blocked"}), config.TracesDataType)
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+
+	// Without authorization, every pipeline can be reached.
+	assert.True(t, AuthorizedPipeline(context.Background(), tenantB))
+	assert.NoError(t, AuthorizeAnyPipeline(context.Background(), []config.ComponentID{tenantB}))
+}
+
+func TestAuthorizationValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization Authorization
+		err           string
+	}{
+		{
+			name: "no rules",
+			err:  "authorization requires at least one rule",
+		},
+		{
+			name: "invalid action",
+			authorization: Authorization{
+				Rules: []AuthorizationRule{{Action: ActionAllow}, {Action: "reject"}},
+			},
+			err: `authorization rule 1: invalid action "reject", must be "allow" or "deny"`,
+		},
+		{
+			name: "invalid signal",
+			authorization: Authorization{
+				Rules: []AuthorizationRule{{Signals: []config.DataType{"profiles"}}},
+			},
+			err: `authorization rule 0: invalid signal "profiles"`,
+		},
+		{
+			name: "invalid pipeline",
+			authorization: Authorization{
+				Rules: []AuthorizationRule{{Pipelines: []config.ComponentID{config.NewComponentID("profiles")}}},
+			},
+			err: `authorization rule 0: invalid pipeline "profiles"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.authorization.Validate(), tt.err)
+		})
+	}
+}
@@ -32,6 +32,10 @@ var (
 type Authentication struct {
 	// AuthenticatorID specifies the name of the extension to use in order to authenticate the incoming data point.
 	AuthenticatorID config.ComponentID `mapstructure:"authenticator"`
+
+	// Authorization defines which authenticated requests are allowed. If not set, all of them are.
+	// Only used by servers.
+	Authorization *Authorization `mapstructure:"authorization"`
 }
 
 // GetServerAuthenticator attempts to select the appropriate ServerAuthenticator from the list of extensions,
@@ -16,6 +16,9 @@ package configgrpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"net"
 	"os"
@@ -26,9 +29,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
@@ -38,6 +45,7 @@ import (
 	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
 )
@@ -54,7 +62,7 @@ func TestDefaultGrpcClientSettings(t *testing.T) {
 	}
 	opts, err := gcs.ToDialOptions(componenttest.NewNopHost(), tt.TelemetrySettings)
 	assert.NoError(t, err)
-	assert.Len(t, opts, 3)
+	assert.Len(t, opts, 4)
 }
 
 func TestAllGrpcClientSettings(t *testing.T) {
@@ -156,7 +164,7 @@ func TestAllGrpcClientSettings(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			opts, err := test.settings.ToDialOptions(test.host, tt.TelemetrySettings)
 			assert.NoError(t, err)
-			assert.Len(t, opts, 9)
+			assert.Len(t, opts, 10)
 		})
 	}
 }
@@ -167,7 +175,7 @@ func TestDefaultGrpcServerSettings(t *testing.T) {
 	_ = grpc.NewServer(opts...)
 
 	assert.NoError(t, err)
-	assert.Len(t, opts, 2)
+	assert.Len(t, opts, 3)
 }
 
 func TestAllGrpcServerSettingsExceptAuth(t *testing.T) {
@@ -202,7 +210,7 @@ func TestAllGrpcServerSettingsExceptAuth(t *testing.T) {
 	_ = grpc.NewServer(opts...)
 
 	assert.NoError(t, err)
-	assert.Len(t, opts, 9)
+	assert.Len(t, opts, 10)
 }
 
 func TestGrpcServerAuthSettings(t *testing.T) {
@@ -342,6 +350,71 @@ func TestGRPCClientSettingsError(t *testing.T) {
 			},
 			host: &mockHost{},
 		},
+		{
+			err: "unsupported scheme in endpoint \"https://localhost:1235\", use the tls settings to configure the transport security of endpoints",
+			settings: GRPCClientSettings{
+				Endpoints: []string{"localhost:1234", "https://localhost:1235"},
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+			},
+			host: &mockHost{},
+		},
+		{
+			err: "endpoint and endpoints cannot be set at the same time",
+			settings: GRPCClientSettings{
+				Endpoint:  "localhost:1234",
+				Endpoints: []string{"localhost:1235"},
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+			},
+			host: &mockHost{},
+		},
+		{
+			err: "only dns targets can be load balanced",
+			settings: GRPCClientSettings{
+				Endpoint: "unix:///tmp/otel.sock",
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				ResolutionInterval: time.Minute,
+			},
+			host: &mockHost{},
+		},
+		{
+			err: "invalid max_connection_age: -1s",
+			settings: GRPCClientSettings{
+				Endpoints: []string{"localhost:1234"},
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				MaxConnectionAge: -time.Second,
+			},
+			host: &mockHost{},
+		},
+		{
+			err: `invalid proxy_url: unsupported scheme "socks5", must be http or https`,
+			settings: GRPCClientSettings{
+				Endpoint: "localhost:1234",
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				ProxyURL: "socks5://proxy:1080",
+			},
+			host: &mockHost{},
+		},
+		{
+			err: "invalid balancer_name: test",
+			settings: GRPCClientSettings{
+				Endpoints: []string{"localhost:1234"},
+				TLSSetting: configtls.TLSClientSetting{
+					Insecure: true,
+				},
+				BalancerName: "test",
+			},
+			host: &mockHost{},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -367,7 +440,7 @@ func TestUseSecure(t *testing.T) {
 	}
 	dialOpts, err := gcs.ToDialOptions(componenttest.NewNopHost(), tt.TelemetrySettings)
 	assert.NoError(t, err)
-	assert.Len(t, dialOpts, 3)
+	assert.Len(t, dialOpts, 4)
 }
 
 func TestGRPCServerSettingsError(t *testing.T) {
@@ -511,6 +584,26 @@ func TestHttpReception(t *testing.T) {
 				ServerName: "localhost",
 			},
 		},
+		{
+			name: "mTLS with client CA reloading",
+			tlsServerCreds: &configtls.TLSServerSetting{
+				TLSSetting: configtls.TLSSetting{
+					CAFile:         filepath.Join("testdata", "ca.crt"),
+					CertFile:       filepath.Join("testdata", "server.crt"),
+					KeyFile:        filepath.Join("testdata", "server.key"),
+					ReloadInterval: time.Minute,
+				},
+				ClientCAFile: filepath.Join("testdata", "ca.crt"),
+			},
+			tlsClientCreds: &configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{
+					CAFile:   filepath.Join("testdata", "ca.crt"),
+					CertFile: filepath.Join("testdata", "client.crt"),
+					KeyFile:  filepath.Join("testdata", "client.key"),
+				},
+				ServerName: "localhost",
+			},
+		},
 		{
 			name: "NoClientCertificate",
 			tlsServerCreds: &configtls.TLSServerSetting{
@@ -595,6 +688,334 @@ func TestHttpReception(t *testing.T) {
 	}
 }
 
+func TestSanitizedEndpointWithResolver(t *testing.T) {
+	gcs := &GRPCClientSettings{
+		Endpoints: []string{"dns:///collector-1:4317", "collector-2"},
+	}
+	assert.Equal(t, "otelcol:///collector-1:4317", gcs.SanitizedEndpoint())
+
+	gcs = &GRPCClientSettings{
+		Endpoint:         "https://collector:4317",
+		MaxConnectionAge: time.Minute,
+	}
+	assert.Equal(t, "otelcol:///collector:4317", gcs.SanitizedEndpoint())
+}
+
+func TestLoadBalancingAcrossEndpoints(t *testing.T) {
+	var servers []*grpc.Server
+	var counters []*countingTraceServer
+	var endpoints []string
+	for i := 0; i < 2; i++ {
+		gss := &GRPCServerSettings{
+			NetAddr: confignet.NetAddr{
+				Endpoint:  "127.0.0.1:0",
+				Transport: "tcp",
+			},
+		}
+		ln, err := gss.ToListener()
+		require.NoError(t, err)
+		opts, err := gss.ToServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+		require.NoError(t, err)
+		s := grpc.NewServer(opts...)
+		counter := &countingTraceServer{}
+		ptraceotlp.RegisterGRPCServer(s, counter)
+		go func() {
+			_ = s.Serve(ln)
+		}()
+		servers = append(servers, s)
+		counters = append(counters, counter)
+		endpoints = append(endpoints, ln.Addr().String())
+	}
+	defer func() {
+		for _, s := range servers {
+			s.Stop()
+		}
+	}()
+
+	gcs := &GRPCClientSettings{
+		Endpoints: endpoints,
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		MaxConnectionAge: 50 * time.Millisecond,
+	}
+	clientOpts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	grpcClientConn, err := grpc.Dial(gcs.SanitizedEndpoint(), clientOpts...)
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+	client := ptraceotlp.NewGRPCClient(grpcClientConn)
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelFunc()
+	for i := 0; i < 20; i++ {
+		_, err = client.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+		require.NoError(t, err)
+		// Give connections time to be rotated while requests are sent.
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Greater(t, counters[0].count.Load(), int64(0))
+	assert.Greater(t, counters[1].count.Load(), int64(0))
+}
+
+type countingTraceServer struct {
+	count atomic.Int64
+}
+
+func (cts *countingTraceServer) Export(context.Context, ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	cts.count.Inc()
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+func TestGRPCServerAuthorization(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		Auth: &configauth.Authentication{
+			AuthenticatorID: config.NewComponentID("mock"),
+			Authorization: &configauth.Authorization{
+				Rules: []configauth.AuthorizationRule{
+					{
+						Principals: []string{"team-traces"},
+						Signals:    []config.DataType{config.TracesDataType},
+					},
+				},
+			},
+		},
+	}
+	host := &mockHost{
+		ext: map[config.ComponentID]component.Extension{
+			config.NewComponentID("mock"): configauth.NewServerAuthenticator(
+				configauth.WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					cl := client.FromContext(ctx)
+					cl.Auth = &principalAuthData{principal: headers["x-principal"][0]}
+					return client.NewContext(ctx, cl), nil
+				}),
+			),
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	opts, err := gss.ToServerOption(host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	s := grpc.NewServer(opts...)
+	ptraceotlp.RegisterGRPCServer(s, &grpcTraceServer{})
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer s.Stop()
+
+	gcs := &GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	clientOpts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	grpcClientConn, err := grpc.Dial(gcs.SanitizedEndpoint(), clientOpts...)
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+	traceClient := ptraceotlp.NewGRPCClient(grpcClientConn)
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	_, err = traceClient.Export(metadata.AppendToOutgoingContext(ctx, "x-principal", "team-traces"), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.NoError(t, err)
+
+	_, err = traceClient.Export(metadata.AppendToOutgoingContext(ctx, "x-principal", "team-logs"), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.ErrorContains(t, err, `permission denied for "team-logs"`)
+}
+
+// pipelinesTraceServer consumes the requests in pipelines, as done by receivers when their authorization rules
+// are on pipelines.
+type pipelinesTraceServer struct {
+	pipelineIDs []config.ComponentID
+}
+
+func (pts *pipelinesTraceServer) Export(ctx context.Context, _ ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	if err := configauth.AuthorizeAnyPipeline(ctx, pts.pipelineIDs); err != nil {
+		return ptraceotlp.NewExportResponse(), consumererror.NewPermanent(err)
+	}
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+func TestGRPCServerAuthorizationPipelines(t *testing.T) {
+	tenantA := config.NewComponentIDWithName(config.TracesDataType, "tenant-a")
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+		Auth: &configauth.Authentication{
+			AuthenticatorID: config.NewComponentID("mock"),
+			Authorization: &configauth.Authorization{
+				Rules: []configauth.AuthorizationRule{
+					{
+						Principals: []string{"tenant-a"},
+						Pipelines:  []config.ComponentID{tenantA},
+					},
+					{
+						Principals: []string{"tenant-b"},
+						Pipelines:  []config.ComponentID{config.NewComponentIDWithName(config.TracesDataType, "tenant-b")},
+					},
+				},
+			},
+		},
+	}
+	host := &mockHost{
+		ext: map[config.ComponentID]component.Extension{
+			config.NewComponentID("mock"): configauth.NewServerAuthenticator(
+				configauth.WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					cl := client.FromContext(ctx)
+					cl.Auth = &principalAuthData{principal: headers["x-principal"][0]}
+					return client.NewContext(ctx, cl), nil
+				}),
+			),
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	opts, err := gss.ToServerOption(host, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	s := grpc.NewServer(opts...)
+	ptraceotlp.RegisterGRPCServer(s, &pipelinesTraceServer{pipelineIDs: []config.ComponentID{tenantA}})
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer s.Stop()
+
+	gcs := &GRPCClientSettings{
+		Endpoint: ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	clientOpts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	grpcClientConn, err := grpc.Dial(gcs.SanitizedEndpoint(), clientOpts...)
+	require.NoError(t, err)
+	defer grpcClientConn.Close()
+	traceClient := ptraceotlp.NewGRPCClient(grpcClientConn)
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancelFunc()
+	_, err = traceClient.Export(metadata.AppendToOutgoingContext(ctx, "x-principal", "tenant-a"), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.NoError(t, err)
+
+	// The rules on pipelines are deferred, the request fails once none of the pipelines allows it.
+	_, err = traceClient.Export(metadata.AppendToOutgoingContext(ctx, "x-principal", "tenant-b"), ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.ErrorContains(t, err, `permission denied for "tenant-b"`)
+}
+
+func TestGRPCServerAuthorizationClientCertificate(t *testing.T) {
+	// Without an authenticator, the principal is the identity of the verified client certificate.
+	tests := []struct {
+		principal string
+		code      codes.Code
+	}{
+		{principal: "CN=MyCommonName,O=MyOrgName,L=Sydney,ST=Australia,C=AU", code: codes.OK},
+		{principal: "CN=other", code: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.principal, func(t *testing.T) {
+			gss := &GRPCServerSettings{
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "localhost:0",
+					Transport: "tcp",
+				},
+				TLSSetting: &configtls.TLSServerSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile:   filepath.Join("testdata", "ca.crt"),
+						CertFile: filepath.Join("testdata", "server.crt"),
+						KeyFile:  filepath.Join("testdata", "server.key"),
+					},
+					ClientCAFile: filepath.Join("testdata", "ca.crt"),
+				},
+				Auth: &configauth.Authentication{
+					Authorization: &configauth.Authorization{
+						Rules: []configauth.AuthorizationRule{{Principals: []string{tt.principal}}},
+					},
+				},
+			}
+			ln, err := gss.ToListener()
+			require.NoError(t, err)
+			opts, err := gss.ToServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			s := grpc.NewServer(opts...)
+			ptraceotlp.RegisterGRPCServer(s, &grpcTraceServer{})
+			go func() {
+				_ = s.Serve(ln)
+			}()
+			defer s.Stop()
+
+			gcs := &GRPCClientSettings{
+				Endpoint: ln.Addr().String(),
+				TLSSetting: configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile:   filepath.Join("testdata", "ca.crt"),
+						CertFile: filepath.Join("testdata", "client.crt"),
+						KeyFile:  filepath.Join("testdata", "client.key"),
+					},
+					ServerName: "localhost",
+				},
+			}
+			clientOpts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			grpcClientConn, err := grpc.Dial(gcs.SanitizedEndpoint(), clientOpts...)
+			require.NoError(t, err)
+			defer grpcClientConn.Close()
+
+			ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancelFunc()
+			_, err = ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCServerInvalidAuthorization(t *testing.T) {
+	gss := &GRPCServerSettings{
+		Auth: &configauth.Authentication{
+			AuthenticatorID: config.NewComponentID("mock"),
+			Authorization:   &configauth.Authorization{},
+		},
+	}
+	host := &mockHost{
+		ext: map[config.ComponentID]component.Extension{
+			config.NewComponentID("mock"): configauth.NewServerAuthenticator(),
+		},
+	}
+	_, err := gss.ToServerOption(host, componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, "authorization requires at least one rule")
+}
+
+func TestSignalFromMethod(t *testing.T) {
+	assert.Equal(t, config.TracesDataType, signalFromMethod("/opentelemetry.proto.collector.trace.v1.TraceService/Export"))
+	assert.Equal(t, config.MetricsDataType, signalFromMethod("/opentelemetry.proto.collector.metrics.v1.MetricsService/Export"))
+	assert.Equal(t, config.LogsDataType, signalFromMethod("/opentelemetry.proto.collector.logs.v1.LogsService/Export"))
+	assert.Equal(t, config.DataType(""), signalFromMethod("/grpc.health.v1.Health/Check"))
+}
+
+type principalAuthData struct {
+	principal string
+}
+
+func (d *principalAuthData) GetAttribute(name string) interface{} {
+	if name == "subject" {
+		return d.principal
+	}
+	return nil
+}
+
+func (d *principalAuthData) GetAttributeNames() []string {
+	return []string{"subject"}
+}
+
 func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	if runtime.GOOS == "windows" {
 		t.Skip("skipping test on windows")
@@ -640,6 +1061,54 @@ func TestReceiveOnUnixDomainSocket(t *testing.T) {
 	s.Stop()
 }
 
+func TestReceiveOnUnixDomainSocketScheme(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	socketName := tempSocketName(t)
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "unix://" + socketName,
+			Transport: "tcp",
+		},
+		UnixSocket: confignet.UnixSocketSettings{
+			Permissions: "0600",
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	fi, err := os.Stat(socketName)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	opts, err := gss.ToServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	s := grpc.NewServer(opts...)
+	ptraceotlp.RegisterGRPCServer(s, &grpcTraceServer{})
+
+	go func() {
+		_ = s.Serve(ln)
+	}()
+
+	gcs := &GRPCClientSettings{
+		Endpoint: gss.NetAddr.Endpoint,
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+	}
+	clientOpts, errClient := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.NoError(t, errClient)
+	grpcClientConn, errDial := grpc.Dial(gcs.SanitizedEndpoint(), clientOpts...)
+	assert.NoError(t, errDial)
+	client := ptraceotlp.NewGRPCClient(grpcClientConn)
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 2*time.Second)
+	resp, errResp := client.Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	assert.NoError(t, errResp)
+	assert.NotNil(t, resp)
+	cancelFunc()
+	s.Stop()
+}
+
 func TestContextWithClient(t *testing.T) {
 	testCases := []struct {
 		desc       string
@@ -744,6 +1213,97 @@ func TestContextWithClient(t *testing.T) {
 	}
 }
 
+func TestContextWithClientTLSIdentity(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "agent"},
+		DNSNames: []string{"agent.example.org"},
+	}
+	tlsPeer := &peer.Peer{
+		Addr: &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+				VerifiedChains:   [][]*x509.Certificate{{cert}},
+			},
+		},
+	}
+
+	ctx := metadata.NewIncomingContext(peer.NewContext(context.Background(), tlsPeer), metadata.Pairs("test-metadata-key", "test-value"))
+	cl := client.FromContext(contextWithClient(ctx, true))
+	require.NotNil(t, cl.Auth)
+	assert.Equal(t, "agent", cl.Auth.GetAttribute(configtls.AttributeCommonName))
+	assert.Equal(t, []string{"agent.example.org"}, cl.Auth.GetAttribute(configtls.AttributeSANDNSNames))
+	assert.Equal(t, []string{"test-value"}, cl.Metadata.Get("test-metadata-key"))
+	assert.Equal(t, []string{"CN=agent"}, cl.Metadata.Get(configtls.AttributeSubject))
+	assert.Equal(t, []string{"agent.example.org"}, cl.Metadata.Get(configtls.AttributeSANDNSNames))
+
+	// Without include_metadata, the identity is only available as AuthData.
+	cl = client.FromContext(contextWithClient(ctx, false))
+	require.NotNil(t, cl.Auth)
+	assert.Nil(t, cl.Metadata.Get(configtls.AttributeSubject))
+
+	// AuthData populated by an authenticator takes precedence.
+	authData := &mockAuthData{}
+	ctx = peer.NewContext(client.NewContext(context.Background(), client.Info{Auth: authData}), tlsPeer)
+	cl = client.FromContext(contextWithClient(ctx, false))
+	assert.Same(t, authData, cl.Auth)
+
+	// Unverified certificates are ignored.
+	unverifiedPeer := &peer.Peer{
+		Addr: &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+			},
+		},
+	}
+	cl = client.FromContext(contextWithClient(peer.NewContext(context.Background(), unverifiedPeer), true))
+	assert.Nil(t, cl.Auth)
+}
+
+func TestContextWithClientForgedTLSIdentity(t *testing.T) {
+	forged := metadata.Pairs(
+		"tls.spiffe_id", "spiffe://example.org/victim",
+		"TLS.Subject", "CN=victim",
+		"test-metadata-key", "test-value",
+	)
+
+	// A client without a certificate cannot forge an identity.
+	plainPeer := &peer.Peer{Addr: &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)}}
+	ctx := metadata.NewIncomingContext(peer.NewContext(context.Background(), plainPeer), forged)
+	cl := client.FromContext(contextWithClient(ctx, true))
+	assert.Nil(t, cl.Auth)
+	assert.Nil(t, cl.Metadata.Get(configtls.AttributeSPIFFEID))
+	assert.Nil(t, cl.Metadata.Get(configtls.AttributeSubject))
+	assert.Equal(t, []string{"test-value"}, cl.Metadata.Get("test-metadata-key"))
+
+	// A client with a certificate cannot add attributes to its identity.
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "agent"}}
+	tlsPeer := &peer.Peer{
+		Addr: &net.IPAddr{IP: net.IPv4(1, 2, 3, 4)},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{cert},
+				VerifiedChains:   [][]*x509.Certificate{{cert}},
+			},
+		},
+	}
+	ctx = metadata.NewIncomingContext(peer.NewContext(context.Background(), tlsPeer), forged)
+	cl = client.FromContext(contextWithClient(ctx, true))
+	assert.Nil(t, cl.Metadata.Get(configtls.AttributeSPIFFEID))
+	assert.Equal(t, []string{"CN=agent"}, cl.Metadata.Get(configtls.AttributeSubject))
+}
+
+type mockAuthData struct{}
+
+func (*mockAuthData) GetAttribute(string) interface{} {
+	return nil
+}
+
+func (*mockAuthData) GetAttributeNames() []string {
+	return nil
+}
+
 func TestStreamInterceptorEnhancesClient(t *testing.T) {
 	// prepare
 	inCtx := peer.NewContext(context.Background(), &peer.Peer{
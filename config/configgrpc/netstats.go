@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+
+	"google.golang.org/grpc/stats"
+
+	"go.opentelemetry.io/collector/internal/netstats"
+)
+
+// statsHandler counts the bytes of the messages exchanged, for the obsreport helpers
+// recording the size of the requests handled by receivers and exporters.
+type statsHandler struct {
+	// server is true for servers, which count the received messages in a counter
+	// they create for each RPC. Clients count the sent messages in the counter
+	// carried by the context of the RPC, if any.
+	server bool
+}
+
+var _ stats.Handler = (*statsHandler)(nil)
+
+func (h *statsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	if h.server {
+		return netstats.NewContext(ctx, &netstats.Counter{})
+	}
+	return ctx
+}
+
+func (h *statsHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	var length, wireLength int
+	switch s := rs.(type) {
+	case *stats.InPayload:
+		if !h.server {
+			return
+		}
+		length, wireLength = s.Length, s.WireLength
+	case *stats.OutPayload:
+		if h.server {
+			return
+		}
+		length, wireLength = s.Length, s.WireLength
+	default:
+		return
+	}
+	if counter := netstats.FromContext(ctx); counter != nil {
+		counter.AddBytes(int64(length))
+		counter.AddWireBytes(int64(wireLength))
+	}
+}
+
+func (h *statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *statsHandler) HandleConn(context.Context, stats.ConnStats) {}
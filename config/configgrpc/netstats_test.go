@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/internal/netstats"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+type bytesTraceServer struct {
+	bytes, wireBytes int64
+}
+
+func (s *bytesTraceServer) Export(ctx context.Context, _ ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	s.bytes, s.wireBytes = netstats.FromContext(ctx).Swap()
+	return ptraceotlp.NewExportResponse(), nil
+}
+
+func TestBytesCounting(t *testing.T) {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:0",
+			Transport: "tcp",
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	opts, err := gss.ToServerOption(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	s := grpc.NewServer(opts...)
+	srv := &bytesTraceServer{}
+	ptraceotlp.RegisterGRPCServer(s, srv)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer s.Stop()
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName(strings.Repeat("span", 100))
+	req := ptraceotlp.NewExportRequestFromTraces(td)
+
+	tests := []struct {
+		name        string
+		compression configcompression.CompressionType
+	}{
+		{name: "uncompressed"},
+		{name: "gzip", compression: configcompression.Gzip},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcs := &GRPCClientSettings{
+				Endpoint:    ln.Addr().String(),
+				Compression: tt.compression,
+				TLSSetting:  configtls.TLSClientSetting{Insecure: true},
+			}
+			dialOpts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+			conn, err := grpc.Dial(gcs.Endpoint, dialOpts...)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			counter := &netstats.Counter{}
+			ctx, cancel := context.WithTimeout(netstats.NewContext(context.Background(), counter), 2*time.Second)
+			defer cancel()
+			_, err = ptraceotlp.NewGRPCClient(conn).Export(ctx, req)
+			require.NoError(t, err)
+
+			sent, sentWire := counter.Swap()
+			assert.Greater(t, sent, int64(400))
+			assert.Equal(t, sent, srv.bytes)
+			assert.Equal(t, sentWire, srv.wireBytes)
+			if tt.compression == "" {
+				// The wire length includes the gRPC message header.
+				assert.Greater(t, sentWire, sent)
+			} else {
+				assert.Less(t, sentWire, sent)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/internal/proxy"
+)
+
+// proxyDialer establishes connections to the gRPC servers through an HTTP CONNECT tunnel.
+type proxyDialer struct {
+	selector *proxy.Selector
+	// hostFor returns the host name an address was resolved from, or "" if it is not known.
+	// The no_proxy list is matched against that host name rather than the resolved IP.
+	hostFor func(addr string) string
+	dialer  net.Dialer
+}
+
+func newProxyDialer(proxyURL string, noProxy []string) (*proxyDialer, error) {
+	selector, err := proxy.NewSelector(proxyURL, noProxy)
+	if err != nil {
+		return nil, err
+	}
+	return &proxyDialer{
+		selector: selector,
+		hostFor:  func(string) string { return "" },
+	}, nil
+}
+
+// dial connects to addr, in the "host:port" form, through the proxy unless addr matches the no_proxy list.
+func (d *proxyDialer) dial(ctx context.Context, addr string) (net.Conn, error) {
+	target := addr
+	if host := d.hostFor(addr); host != "" {
+		if _, port, err := net.SplitHostPort(addr); err == nil {
+			target = net.JoinHostPort(host, port)
+		}
+	}
+	proxyURL, err := d.selector.ProxyForAddr(target)
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL == nil {
+		return d.dialer.DialContext(ctx, "tcp", addr)
+	}
+
+	conn, err := d.dialer.DialContext(ctx, "tcp", proxyAddr(proxyURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	if proxyURL.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname(), MinVersion: tls.VersionTLS12})
+	}
+	tunnel, err := connect(ctx, conn, proxyURL, addr)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tunnel, nil
+}
+
+// connect sends a CONNECT request for addr to the proxy over conn, and returns the established tunnel.
+// The request is aborted when ctx is done.
+func connect(ctx context.Context, conn net.Conn, proxyURL *url.URL, addr string) (net.Conn, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+	done := make(chan struct{})
+	watcherDone := make(chan struct{})
+	go func() {
+		defer close(watcherDone)
+		select {
+		case <-ctx.Done():
+			// Unblock the pending reads and writes.
+			_ = conn.SetDeadline(time.Unix(1, 0))
+		case <-done:
+		}
+	}()
+	defer func() {
+		close(done)
+		<-watcherDone
+		_ = conn.SetDeadline(time.Time{})
+	}()
+
+	tunnel, err := doConnect(conn, proxyURL, addr)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return tunnel, err
+}
+
+func doConnect(conn net.Conn, proxyURL *url.URL, addr string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return nil, fmt.Errorf("failed to write CONNECT request to proxy: %w", err)
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CONNECT response from proxy: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy refused to connect to %q: %s", addr, resp.Status)
+	}
+	if r.Buffered() > 0 {
+		// The server already sent data through the tunnel, which was read along with the response.
+		return &bufferedConn{Conn: conn, r: r}, nil
+	}
+	return conn, nil
+}
+
+// proxyAddr returns the "host:port" address of the proxy, using the default port of its scheme if needed.
+func proxyAddr(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	if u.Scheme == "https" {
+		return net.JoinHostPort(u.Hostname(), "443")
+	}
+	return net.JoinHostPort(u.Hostname(), "80")
+}
+
+// bufferedConn is a net.Conn whose first reads are served from a bufio.Reader.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+// connectProxy is a minimal HTTP proxy handling CONNECT requests. Every host name is resolved to
+// 127.0.0.1, since loopback addresses are never reached through a proxy.
+type connectProxy struct {
+	ln            net.Listener
+	authorization string
+	tunnels       atomic.Int64
+}
+
+func startConnectProxy(t *testing.T, authorization string) *connectProxy {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	p := &connectProxy{ln: ln, authorization: authorization}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go p.handle(conn)
+		}
+	}()
+	return p
+}
+
+func (p *connectProxy) handle(conn net.Conn) {
+	defer conn.Close()
+	req, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil {
+		return
+	}
+	if req.Method != http.MethodConnect {
+		_, _ = io.WriteString(conn, "HTTP/1.1 405 Method Not Allowed\r\n\r\n")
+		return
+	}
+	if req.Header.Get("Proxy-Authorization") != p.authorization {
+		_, _ = io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+		return
+	}
+	_, port, err := net.SplitHostPort(req.Host)
+	if err != nil {
+		_, _ = io.WriteString(conn, "HTTP/1.1 400 Bad Request\r\n\r\n")
+		return
+	}
+	backend, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", port))
+	if err != nil {
+		_, _ = io.WriteString(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+		return
+	}
+	defer backend.Close()
+	p.tunnels.Inc()
+	_, _ = io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+	go func() {
+		_, _ = io.Copy(backend, conn)
+	}()
+	_, _ = io.Copy(conn, backend)
+}
+
+func (p *connectProxy) url(userinfo string) string {
+	return "http://" + userinfo + p.ln.Addr().String()
+}
+
+// startTraceServer starts a gRPC server and returns its port.
+func startTraceServer(t *testing.T) string {
+	gss := &GRPCServerSettings{
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "127.0.0.1:0",
+			Transport: "tcp",
+		},
+	}
+	ln, err := gss.ToListener()
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	ptraceotlp.RegisterGRPCServer(s, &grpcTraceServer{})
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	t.Cleanup(s.Stop)
+	_, port, err := net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, err)
+	return port
+}
+
+func exportThrough(t *testing.T, gcs *GRPCClientSettings) error {
+	opts, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	conn, err := grpc.Dial(gcs.SanitizedEndpoint(), opts...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = ptraceotlp.NewGRPCClient(conn).Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	return err
+}
+
+func TestProxyTunnelling(t *testing.T) {
+	// "user:password" in base64.
+	proxy := startConnectProxy(t, "Basic dXNlcjpwYXNzd29yZA==")
+	port := startTraceServer(t)
+
+	err := exportThrough(t, &GRPCClientSettings{
+		Endpoint:   "backend.test:" + port,
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+		ProxyURL:   proxy.url("user:password@"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), proxy.tunnels.Load())
+}
+
+func TestProxyNoProxyResolvedHost(t *testing.T) {
+	d, err := newProxyDialer("http://127.0.0.1:1", []string{"backend.internal"})
+	require.NoError(t, err)
+	d.hostFor = func(addr string) string {
+		if addr == "192.0.2.1:4317" {
+			return "backend.internal"
+		}
+		return ""
+	}
+
+	// Fail every dial immediately, the error tells whether the proxy was used.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = d.dial(ctx, "192.0.2.1:4317")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "proxy")
+
+	_, err = d.dial(ctx, "192.0.2.2:4317")
+	assert.ErrorContains(t, err, "failed to connect to proxy")
+}
+
+func TestProxyNoProxyWithoutProxyURL(t *testing.T) {
+	gcs := &GRPCClientSettings{
+		Endpoint:   "localhost:4317",
+		TLSSetting: configtls.TLSClientSetting{Insecure: true},
+		NoProxy:    []string{"internal.test"},
+	}
+	_, err := gcs.ToDialOptions(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, "no_proxy requires proxy_url, set NO_PROXY to exclude hosts from the environment proxy")
+}
+
+func TestProxyConnectCancelled(t *testing.T) {
+	// A proxy accepting connections and never answering.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, aerr := ln.Accept()
+			if aerr != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	d, err := newProxyDialer("http://"+ln.Addr().String(), nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	_, err = d.dial(ctx, "backend.test:4317")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestProxyRefusesTunnel(t *testing.T) {
+	proxy := startConnectProxy(t, "Basic dXNlcjpwYXNzd29yZA==")
+	d, err := newProxyDialer(proxy.url("user:wrong@"), nil)
+	require.NoError(t, err)
+
+	_, err = d.dial(context.Background(), "backend.test:4317")
+	assert.EqualError(t, err, `proxy refused to connect to "backend.test:4317": 407 Proxy Authentication Required`)
+	assert.Equal(t, int64(0), proxy.tunnels.Load())
+}
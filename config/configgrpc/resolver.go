@@ -0,0 +1,301 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc // import "go.opentelemetry.io/collector/config/configgrpc"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// resolverScheme is the scheme of the targets handled by resolverBuilder. The builder is
+	// only registered for the ClientConn it was created for, see grpc.WithResolvers.
+	resolverScheme = "otelcol"
+
+	// dnsScheme is the prefix of targets explicitly using the gRPC DNS resolver.
+	dnsScheme = "dns:///"
+
+	// defaultPort is the port used when an endpoint doesn't specify one, as for the gRPC DNS resolver.
+	defaultPort = "443"
+
+	// minResolveNowInterval limits how often a re-resolution can be triggered by the ClientConn,
+	// which requests one every time a connection fails.
+	minResolveNowInterval = 5 * time.Second
+
+	// resolveTimeout bounds the time spent resolving the host names of all endpoints.
+	resolveTimeout = 10 * time.Second
+)
+
+// generationKey is the resolver.Address attribute holding the connection generation of an address.
+// Changing it makes the balancer replace the connection to that address with a new one.
+type generationKey struct{}
+
+// resolverBuilder builds resolvers periodically resolving the host names of a fixed list of
+// endpoints, and rotating the connections to the resolved addresses after a maximum age.
+type resolverBuilder struct {
+	// endpoints in the "host:port" form.
+	endpoints          []string
+	resolutionInterval time.Duration
+	maxConnectionAge   time.Duration
+	lookupHost         func(ctx context.Context, host string) ([]string, error)
+
+	// hosts maps the last resolved addresses to the host names they were resolved from.
+	hostsMu sync.Mutex
+	hosts   map[string]string
+}
+
+var _ resolver.Builder = (*resolverBuilder)(nil)
+
+func newResolverBuilder(endpoints []string, resolutionInterval, maxConnectionAge time.Duration) *resolverBuilder {
+	return &resolverBuilder{
+		endpoints:          endpoints,
+		resolutionInterval: resolutionInterval,
+		maxConnectionAge:   maxConnectionAge,
+		lookupHost:         net.DefaultResolver.LookupHost,
+	}
+}
+
+// Build starts a resolver for the endpoints of the builder. The target is ignored.
+func (b *resolverBuilder) Build(_ resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &periodicResolver{
+		builder:     b,
+		cc:          cc,
+		ctx:         ctx,
+		cancel:      cancel,
+		resolveNow:  make(chan struct{}, 1),
+		generations: map[string]uint64{},
+	}
+	r.wg.Add(1)
+	go r.run()
+	return r, nil
+}
+
+// hostFor returns the host name addr was last resolved from, or "" if addr was not resolved from a host name.
+func (b *resolverBuilder) hostFor(addr string) string {
+	b.hostsMu.Lock()
+	defer b.hostsMu.Unlock()
+	return b.hosts[addr]
+}
+
+func (b *resolverBuilder) setHosts(addrs []resolver.Address) {
+	hosts := make(map[string]string, len(addrs))
+	for _, addr := range addrs {
+		if addr.ServerName != "" {
+			hosts[addr.Addr] = addr.ServerName
+		}
+	}
+	b.hostsMu.Lock()
+	defer b.hostsMu.Unlock()
+	b.hosts = hosts
+}
+
+// Scheme returns the scheme handled by this builder.
+func (b *resolverBuilder) Scheme() string {
+	return resolverScheme
+}
+
+type periodicResolver struct {
+	builder *resolverBuilder
+	cc      resolver.ClientConn
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	resolveNow chan struct{}
+
+	// generations and nextRotation are only accessed by the run goroutine.
+	generations  map[string]uint64
+	nextRotation int
+}
+
+var _ resolver.Resolver = (*periodicResolver)(nil)
+
+// ResolveNow triggers a re-resolution, unless one happened recently.
+func (r *periodicResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+// Close stops the resolver.
+func (r *periodicResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *periodicResolver) run() {
+	defer r.wg.Done()
+
+	var resolutionC <-chan time.Time
+	if r.builder.resolutionInterval > 0 {
+		ticker := time.NewTicker(r.builder.resolutionInterval)
+		defer ticker.Stop()
+		resolutionC = ticker.C
+	}
+
+	var addrs []resolver.Address
+	var lastResolution time.Time
+	var rotationTicker *time.Ticker
+	var rotationC <-chan time.Time
+	defer func() {
+		if rotationTicker != nil {
+			rotationTicker.Stop()
+		}
+	}()
+
+	resolve := true
+	for {
+		if resolve {
+			resolved, err := r.resolve()
+			lastResolution = time.Now()
+			if err != nil {
+				r.cc.ReportError(err)
+			}
+			if len(resolved) > 0 {
+				// Rotate one connection per tick, so that each connection is rotated every maxConnectionAge.
+				if r.builder.maxConnectionAge > 0 && len(resolved) != len(addrs) {
+					if rotationTicker != nil {
+						rotationTicker.Stop()
+					}
+					rotationTicker = time.NewTicker(r.builder.maxConnectionAge / time.Duration(len(resolved)))
+					rotationC = rotationTicker.C
+				}
+				addrs = resolved
+				r.pruneGenerations(addrs)
+				r.builder.setHosts(addrs)
+			}
+		}
+		if len(addrs) > 0 {
+			// Errors are reported back to the resolver through ResolveNow.
+			_ = r.cc.UpdateState(resolver.State{Addresses: r.withGenerations(addrs)})
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-resolutionC:
+			resolve = true
+		case <-r.resolveNow:
+			resolve = time.Since(lastResolution) >= minResolveNowInterval
+		case <-rotationC:
+			r.rotate(addrs)
+			resolve = false
+		}
+	}
+}
+
+// resolve looks up the addresses of all the endpoints. Endpoints that fail to resolve are skipped.
+func (r *periodicResolver) resolve() ([]resolver.Address, error) {
+	ctx, cancel := context.WithTimeout(r.ctx, resolveTimeout)
+	defer cancel()
+
+	var addrs []resolver.Address
+	var errs error
+	for _, endpoint := range r.builder.endpoints {
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		if net.ParseIP(host) != nil {
+			addrs = append(addrs, resolver.Address{Addr: endpoint})
+			continue
+		}
+		ips, err := r.builder.lookupHost(ctx, host)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to resolve %q: %w", host, err))
+			continue
+		}
+		for _, ip := range ips {
+			addrs = append(addrs, resolver.Address{
+				Addr: net.JoinHostPort(ip, port),
+				// Verify the server certificate against the configured host name rather than the IP.
+				ServerName: host,
+			})
+		}
+	}
+	if len(addrs) == 0 && errs == nil {
+		errs = errors.New("no addresses resolved")
+	}
+	return addrs, errs
+}
+
+// rotate increments the generation of the next address to rotate, replacing its connection.
+func (r *periodicResolver) rotate(addrs []resolver.Address) {
+	if len(addrs) == 0 {
+		return
+	}
+	addr := addrs[r.nextRotation%len(addrs)].Addr
+	r.generations[addr]++
+	r.nextRotation++
+}
+
+// pruneGenerations forgets the generations of addresses that are not resolved anymore.
+func (r *periodicResolver) pruneGenerations(addrs []resolver.Address) {
+	current := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		current[addr.Addr] = struct{}{}
+	}
+	for addr := range r.generations {
+		if _, ok := current[addr]; !ok {
+			delete(r.generations, addr)
+		}
+	}
+}
+
+func (r *periodicResolver) withGenerations(addrs []resolver.Address) []resolver.Address {
+	if r.builder.maxConnectionAge <= 0 {
+		return addrs
+	}
+	ret := make([]resolver.Address, len(addrs))
+	for i, addr := range addrs {
+		addr.Attributes = attributes.New(generationKey{}, r.generations[addr.Addr])
+		ret[i] = addr
+	}
+	return ret
+}
+
+// parseResolverEndpoint converts an endpoint in the "host:port", "dns:///host:port", "http://host:port" or
+// "https://host:port" form into "host:port", using the default port when none is specified.
+func parseResolverEndpoint(endpoint string) (string, error) {
+	for _, prefix := range []string{dnsScheme, "http://", "https://"} {
+		endpoint = strings.TrimPrefix(endpoint, prefix)
+	}
+	if endpoint == "" {
+		return "", errors.New("empty endpoint")
+	}
+	if strings.Contains(endpoint, "://") || strings.Contains(endpoint, "/") {
+		return "", fmt.Errorf("unsupported endpoint %q, only dns targets can be load balanced", endpoint)
+	}
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		// Assume the port is missing, as the gRPC DNS resolver does.
+		endpoint = net.JoinHostPort(strings.Trim(endpoint, "[]"), defaultPort)
+		if _, _, err = net.SplitHostPort(endpoint); err != nil {
+			return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+		}
+	}
+	return endpoint, nil
+}
@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configgrpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+)
+
+type fakeClientConn struct {
+	mu     sync.Mutex
+	states []resolver.State
+	errs   []error
+}
+
+func (cc *fakeClientConn) UpdateState(s resolver.State) error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.states = append(cc.states, s)
+	return nil
+}
+
+func (cc *fakeClientConn) ReportError(err error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.errs = append(cc.errs, err)
+}
+
+func (cc *fakeClientConn) NewAddress([]resolver.Address) {}
+
+func (cc *fakeClientConn) NewServiceConfig(string) {}
+
+func (cc *fakeClientConn) ParseServiceConfig(string) *serviceconfig.ParseResult {
+	return nil
+}
+
+func (cc *fakeClientConn) lastState() (resolver.State, int) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if len(cc.states) == 0 {
+		return resolver.State{}, 0
+	}
+	return cc.states[len(cc.states)-1], len(cc.states)
+}
+
+func (cc *fakeClientConn) errors() []error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return append([]error(nil), cc.errs...)
+}
+
+type fakeLookup struct {
+	mu    sync.Mutex
+	hosts map[string][]string
+	calls int
+}
+
+func (l *fakeLookup) set(host string, ips ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hosts[host] = ips
+}
+
+func (l *fakeLookup) lookupHost(_ context.Context, host string) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls++
+	ips, ok := l.hosts[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return ips, nil
+}
+
+func addrsOf(s resolver.State) []string {
+	var ret []string
+	for _, a := range s.Addresses {
+		ret = append(ret, a.Addr)
+	}
+	return ret
+}
+
+func TestResolverResolvesEndpoints(t *testing.T) {
+	lookup := &fakeLookup{hosts: map[string][]string{"collector": {"10.0.0.1", "10.0.0.2"}}}
+	b := newResolverBuilder([]string{"collector:4317", "10.0.0.3:4317"}, 0, 0)
+	b.lookupHost = lookup.lookupHost
+	cc := &fakeClientConn{}
+	r, err := b.Build(resolver.Target{}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.Eventually(t, func() bool {
+		_, n := cc.lastState()
+		return n == 1
+	}, time.Second, time.Millisecond)
+	state, _ := cc.lastState()
+	assert.Equal(t, []string{"10.0.0.1:4317", "10.0.0.2:4317", "10.0.0.3:4317"}, addrsOf(state))
+	assert.Equal(t, "collector", state.Addresses[0].ServerName)
+	assert.Equal(t, "", state.Addresses[2].ServerName)
+	assert.Nil(t, state.Addresses[0].Attributes)
+	assert.Equal(t, "collector", b.hostFor("10.0.0.2:4317"))
+	assert.Equal(t, "", b.hostFor("10.0.0.3:4317"))
+}
+
+func TestResolverReResolves(t *testing.T) {
+	lookup := &fakeLookup{hosts: map[string][]string{"collector": {"10.0.0.1"}}}
+	b := newResolverBuilder([]string{"collector:4317"}, time.Millisecond, 0)
+	b.lookupHost = lookup.lookupHost
+	cc := &fakeClientConn{}
+	r, err := b.Build(resolver.Target{}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.Eventually(t, func() bool {
+		state, _ := cc.lastState()
+		return len(state.Addresses) == 1
+	}, time.Second, time.Millisecond)
+
+	lookup.set("collector", "10.0.0.1", "10.0.0.2")
+	require.Eventually(t, func() bool {
+		state, _ := cc.lastState()
+		return len(state.Addresses) == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestResolverReportsErrors(t *testing.T) {
+	lookup := &fakeLookup{hosts: map[string][]string{"collector": {"10.0.0.1"}}}
+	b := newResolverBuilder([]string{"collector:4317", "unknown:4317"}, 0, 0)
+	b.lookupHost = lookup.lookupHost
+	cc := &fakeClientConn{}
+	r, err := b.Build(resolver.Target{}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.Eventually(t, func() bool {
+		return len(cc.errors()) == 1
+	}, time.Second, time.Millisecond)
+	assert.ErrorContains(t, cc.errors()[0], `failed to resolve "unknown"`)
+	// Endpoints that resolved successfully are still used.
+	require.Eventually(t, func() bool {
+		_, n := cc.lastState()
+		return n == 1
+	}, time.Second, time.Millisecond)
+	state, _ := cc.lastState()
+	assert.Equal(t, []string{"10.0.0.1:4317"}, addrsOf(state))
+}
+
+func TestResolverRateLimitsResolveNow(t *testing.T) {
+	lookup := &fakeLookup{hosts: map[string][]string{"collector": {"10.0.0.1"}}}
+	b := newResolverBuilder([]string{"collector:4317"}, 0, 0)
+	b.lookupHost = lookup.lookupHost
+	cc := &fakeClientConn{}
+	r, err := b.Build(resolver.Target{}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, n := cc.lastState()
+		return n == 1
+	}, time.Second, time.Millisecond)
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	require.Eventually(t, func() bool {
+		_, n := cc.lastState()
+		return n == 2
+	}, time.Second, time.Millisecond)
+	r.Close()
+
+	lookup.mu.Lock()
+	defer lookup.mu.Unlock()
+	assert.Equal(t, 1, lookup.calls)
+}
+
+func TestResolverRotatesConnections(t *testing.T) {
+	b := newResolverBuilder([]string{"10.0.0.1:4317", "10.0.0.2:4317"}, 0, 20*time.Millisecond)
+	cc := &fakeClientConn{}
+	r, err := b.Build(resolver.Target{}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer r.Close()
+
+	generation := func(a resolver.Address) uint64 {
+		return a.Attributes.Value(generationKey{}).(uint64)
+	}
+	require.Eventually(t, func() bool {
+		_, n := cc.lastState()
+		return n == 1
+	}, time.Second, time.Millisecond)
+	state, _ := cc.lastState()
+	assert.Equal(t, uint64(0), generation(state.Addresses[0]))
+	assert.Equal(t, uint64(0), generation(state.Addresses[1]))
+
+	// Connections are rotated one at a time.
+	require.Eventually(t, func() bool {
+		_, n := cc.lastState()
+		return n >= 2
+	}, time.Second, time.Millisecond)
+	cc.mu.Lock()
+	state = cc.states[1]
+	cc.mu.Unlock()
+	assert.Equal(t, uint64(1), generation(state.Addresses[0]))
+	assert.Equal(t, uint64(0), generation(state.Addresses[1]))
+
+	require.Eventually(t, func() bool {
+		_, n := cc.lastState()
+		return n >= 3
+	}, time.Second, time.Millisecond)
+	cc.mu.Lock()
+	state = cc.states[2]
+	cc.mu.Unlock()
+	assert.Equal(t, uint64(1), generation(state.Addresses[0]))
+	assert.Equal(t, uint64(1), generation(state.Addresses[1]))
+}
+
+func TestParseResolverEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		expected string
+		err      string
+	}{
+		{endpoint: "collector:4317", expected: "collector:4317"},
+		{endpoint: "dns:///collector:4317", expected: "collector:4317"},
+		{endpoint: "https://collector:4317", expected: "collector:4317"},
+		{endpoint: "collector", expected: "collector:443"},
+		{endpoint: "[::1]:4317", expected: "[::1]:4317"},
+		{endpoint: "[::1]", expected: "[::1]:443"},
+		{endpoint: "", err: "empty endpoint"},
+		{endpoint: "unix:///tmp/otel.sock", err: `unsupported endpoint "unix:///tmp/otel.sock", only dns targets can be load balanced`},
+		{endpoint: "dns://8.8.8.8/collector:4317", err: `unsupported endpoint "dns://8.8.8.8/collector:4317", only dns targets can be load balanced`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			got, err := parseResolverEndpoint(tt.endpoint)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"net/http"
 
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config/configtls"
 )
 
 var _ http.Handler = (*clientInfoHandler)(nil)
@@ -40,7 +41,9 @@ func (h *clientInfoHandler) ServeHTTP(w http.ResponseWriter, req *http.Request)
 }
 
 // contextWithClient attempts to add the client IP address to the client.Info from the context. When no
-// client.Info exists in the context, one is created.
+// client.Info exists in the context, one is created. When the client presented a verified TLS
+// certificate, its identity is added as the client.Info's AuthData. Authenticators configured for the
+// server take precedence and replace it.
 func contextWithClient(req *http.Request, includeMetadata bool) context.Context {
 	cl := client.FromContext(req.Context())
 
@@ -49,11 +52,23 @@ func contextWithClient(req *http.Request, includeMetadata bool) context.Context
 		cl.Addr = ip
 	}
 
+	identity := configtls.ClientIdentityFromConnectionState(req.TLS)
+	if identity != nil && cl.Auth == nil {
+		cl.Auth = identity
+	}
+
 	if includeMetadata {
 		md := req.Header.Clone()
+		// Only the identity of a verified certificate may use the reserved keys.
+		configtls.RemoveIdentityMetadata(md)
 		if len(md.Get(client.MetadataHostName)) == 0 && req.Host != "" {
 			md.Add(client.MetadataHostName, req.Host)
 		}
+		if identity != nil {
+			for k, v := range identity.Metadata() {
+				md[k] = v
+			}
+		}
 
 		cl.Metadata = client.NewMetadata(md)
 	}
@@ -16,6 +16,9 @@ package confighttp
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"fmt"
 	"io"
@@ -23,7 +26,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -35,6 +40,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtls"
 )
 
@@ -249,6 +255,13 @@ func TestHTTPClientSettingsError(t *testing.T) {
 				Auth:     &configauth.Authentication{AuthenticatorID: config.NewComponentID("dummy")},
 			},
 		},
+		{
+			err: `^invalid proxy_url: unsupported scheme "socks5", must be http or https`,
+			settings: HTTPClientSettings{
+				Endpoint: "https://localhost:1234/v1/traces",
+				ProxyURL: "socks5://proxy:1080",
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.err, func(t *testing.T) {
@@ -474,6 +487,26 @@ func TestHttpReception(t *testing.T) {
 				ServerName: "localhost",
 			},
 		},
+		{
+			name: "mTLS with client CA reloading",
+			tlsServerCreds: &configtls.TLSServerSetting{
+				TLSSetting: configtls.TLSSetting{
+					CAFile:         filepath.Join("testdata", "ca.crt"),
+					CertFile:       filepath.Join("testdata", "server.crt"),
+					KeyFile:        filepath.Join("testdata", "server.key"),
+					ReloadInterval: time.Minute,
+				},
+				ClientCAFile: filepath.Join("testdata", "ca.crt"),
+			},
+			tlsClientCreds: &configtls.TLSClientSetting{
+				TLSSetting: configtls.TLSSetting{
+					CAFile:   filepath.Join("testdata", "ca.crt"),
+					CertFile: filepath.Join("testdata", "client.crt"),
+					KeyFile:  filepath.Join("testdata", "client.key"),
+				},
+				ServerName: "localhost",
+			},
+		},
 		{
 			name: "NoClientCertificate",
 			tlsServerCreds: &configtls.TLSServerSetting{
@@ -576,6 +609,99 @@ func TestHttpReception(t *testing.T) {
 	}
 }
 
+func TestHttpReceptionOnUnixDomainSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	socket := filepath.Join(t.TempDir(), "otelcol.sock")
+	hss := &HTTPServerSettings{
+		Endpoint: "unix://" + socket,
+		UnixSocket: confignet.UnixSocketSettings{
+			Permissions: "0600",
+		},
+	}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+	fi, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	s, err := hss.ToServer(
+		componenttest.NewNopHost(),
+		componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, errWrite := fmt.Fprint(w, "test")
+			assert.NoError(t, errWrite)
+		}))
+	require.NoError(t, err)
+
+	go func() {
+		_ = s.Serve(ln)
+	}()
+
+	hcs := &HTTPClientSettings{
+		Endpoint:   "http://localhost",
+		UnixSocket: "unix://" + socket,
+	}
+	client, err := hcs.ToClient(componenttest.NewNopHost(), component.TelemetrySettings{})
+	require.NoError(t, err)
+
+	resp, err := client.Get(hcs.Endpoint)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "test", string(body))
+	assert.NoError(t, resp.Body.Close())
+	require.NoError(t, s.Close())
+}
+
+func TestHTTPClientProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// "user:password" in base64.
+		if r.Header.Get("Proxy-Authorization") != "Basic dXNlcjpwYXNzd29yZA==" {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		proxied = append(proxied, r.URL.String())
+		_, _ = fmt.Fprint(w, "proxied")
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	proxyURL.User = url.UserPassword("user", "password")
+
+	hcs := &HTTPClientSettings{
+		Endpoint: "http://backend.test/v1/traces",
+		ProxyURL: proxyURL.String(),
+		NoProxy:  []string{"internal.test"},
+	}
+	client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	resp, err := client.Get(hcs.Endpoint)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, "proxied", string(body))
+	assert.Equal(t, []string{"http://backend.test/v1/traces"}, proxied)
+
+	// Hosts in the no_proxy list are reached directly, and can't be resolved here.
+	_, err = client.Get("http://internal.test/v1/traces")
+	assert.Error(t, err)
+	assert.Len(t, proxied, 1)
+}
+
+func TestHTTPClientNoProxyWithoutProxyURL(t *testing.T) {
+	hcs := &HTTPClientSettings{
+		Endpoint: "http://backend.test/v1/traces",
+		NoProxy:  []string{"internal.test"},
+	}
+	_, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, "no_proxy requires proxy_url, set NO_PROXY to exclude hosts from the environment proxy")
+}
+
 func TestHttpCors(t *testing.T) {
 	tests := []struct {
 		name string
@@ -880,6 +1006,87 @@ func TestContextWithClient(t *testing.T) {
 	}
 }
 
+func TestContextWithClientForgedTLSIdentity(t *testing.T) {
+	header := http.Header{}
+	header.Set("tls.spiffe_id", "spiffe://example.org/victim")
+	header.Set("TLS.SUBJECT", "CN=victim")
+	header.Set("x-test-header", "test-value")
+
+	// A client without a certificate cannot forge an identity.
+	cl := client.FromContext(contextWithClient(&http.Request{Header: header}, true))
+	assert.Nil(t, cl.Auth)
+	assert.Nil(t, cl.Metadata.Get(configtls.AttributeSPIFFEID))
+	assert.Nil(t, cl.Metadata.Get(configtls.AttributeSubject))
+	assert.Equal(t, []string{"test-value"}, cl.Metadata.Get("x-test-header"))
+
+	// A client with a certificate cannot add attributes to its identity.
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "agent"}}
+	req := &http.Request{
+		Header: header,
+		TLS: &tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{cert},
+			VerifiedChains:   [][]*x509.Certificate{{cert}},
+		},
+	}
+	cl = client.FromContext(contextWithClient(req, true))
+	assert.Nil(t, cl.Metadata.Get(configtls.AttributeSPIFFEID))
+	assert.Equal(t, []string{"CN=agent"}, cl.Metadata.Get(configtls.AttributeSubject))
+}
+
+func TestHttpReceptionClientIdentity(t *testing.T) {
+	hss := &HTTPServerSettings{
+		Endpoint: "localhost:0",
+		TLSSetting: &configtls.TLSServerSetting{
+			TLSSetting: configtls.TLSSetting{
+				CAFile:   filepath.Join("testdata", "ca.crt"),
+				CertFile: filepath.Join("testdata", "server.crt"),
+				KeyFile:  filepath.Join("testdata", "server.key"),
+			},
+			ClientCAFile: filepath.Join("testdata", "ca.crt"),
+		},
+		IncludeMetadata: true,
+	}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+
+	var cl client.Info
+	s, err := hss.ToServer(
+		componenttest.NewNopHost(),
+		componenttest.NewNopTelemetrySettings(),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cl = client.FromContext(r.Context())
+		}))
+	require.NoError(t, err)
+
+	go func() {
+		_ = s.Serve(ln)
+	}()
+
+	hcs := &HTTPClientSettings{
+		Endpoint: "https://" + ln.Addr().String(),
+		TLSSetting: configtls.TLSClientSetting{
+			TLSSetting: configtls.TLSSetting{
+				CAFile:   filepath.Join("testdata", "ca.crt"),
+				CertFile: filepath.Join("testdata", "client.crt"),
+				KeyFile:  filepath.Join("testdata", "client.key"),
+			},
+			ServerName: "localhost",
+		},
+	}
+	c, err := hcs.ToClient(componenttest.NewNopHost(), component.TelemetrySettings{})
+	require.NoError(t, err)
+
+	resp, err := c.Get(hcs.Endpoint)
+	require.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+
+	require.NotNil(t, cl.Auth)
+	assert.Equal(t, "MyCommonName", cl.Auth.GetAttribute(configtls.AttributeCommonName))
+	assert.Equal(t, []string{"localhost"}, cl.Auth.GetAttribute(configtls.AttributeSANDNSNames))
+	assert.Equal(t, []string{"MyCommonName"}, cl.Metadata.Get(configtls.AttributeCommonName))
+	require.NoError(t, s.Close())
+}
+
 func TestServerAuth(t *testing.T) {
 	// prepare
 	authCalled := false
@@ -916,6 +1123,129 @@ func TestServerAuth(t *testing.T) {
 	assert.True(t, authCalled)
 }
 
+func TestServerAuthorization(t *testing.T) {
+	hss := HTTPServerSettings{
+		Auth: &configauth.Authentication{
+			AuthenticatorID: config.NewComponentID("mock"),
+			Authorization: &configauth.Authorization{
+				Rules: []configauth.AuthorizationRule{
+					{
+						Principals: []string{"team-logs"},
+						Signals:    []config.DataType{config.LogsDataType},
+					},
+				},
+			},
+		},
+	}
+	host := &mockHost{
+		ext: map[config.ComponentID]component.Extension{
+			config.NewComponentID("mock"): configauth.NewServerAuthenticator(
+				configauth.WithAuthenticate(func(ctx context.Context, headers map[string][]string) (context.Context, error) {
+					cl := client.FromContext(ctx)
+					cl.Auth = &principalAuthData{principal: http.Header(headers).Get("X-Principal")}
+					return client.NewContext(ctx, cl), nil
+				}),
+			),
+		},
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	srv, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), handler)
+	require.NoError(t, err)
+
+	tests := []struct {
+		principal string
+		path      string
+		status    int
+	}{
+		{principal: "team-logs", path: "/v1/logs", status: http.StatusOK},
+		{principal: "team-logs", path: "/v1/traces", status: http.StatusForbidden},
+		{principal: "team-logs", path: "/", status: http.StatusForbidden},
+		{principal: "team-traces", path: "/v1/logs", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.principal+tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Header.Set("X-Principal", tt.principal)
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestServerAuthorizationClientCertificate(t *testing.T) {
+	// Without an authenticator, the principal is the identity of the verified client certificate.
+	hss := HTTPServerSettings{
+		Auth: &configauth.Authentication{
+			Authorization: &configauth.Authorization{
+				Rules: []configauth.AuthorizationRule{{Principals: []string{"CN=agent"}}},
+			},
+		},
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	srv, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), handler)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		cert   *x509.Certificate
+		status int
+	}{
+		{name: "allowed", cert: &x509.Certificate{Subject: pkix.Name{CommonName: "agent"}}, status: http.StatusOK},
+		{name: "other", cert: &x509.Certificate{Subject: pkix.Name{CommonName: "other"}}, status: http.StatusForbidden},
+		{name: "no certificate", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+			// A forged identity is ignored.
+			req.Header.Set("tls.subject", "CN=agent")
+			if tt.cert != nil {
+				req.TLS = &tls.ConnectionState{
+					PeerCertificates: []*x509.Certificate{tt.cert},
+					VerifiedChains:   [][]*x509.Certificate{{tt.cert}},
+				}
+			}
+			rec := httptest.NewRecorder()
+			srv.Handler.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestInvalidServerAuthorization(t *testing.T) {
+	hss := HTTPServerSettings{
+		Auth: &configauth.Authentication{
+			AuthenticatorID: config.NewComponentID("mock"),
+			Authorization: &configauth.Authorization{
+				Rules: []configauth.AuthorizationRule{{Action: "reject"}},
+			},
+		},
+	}
+	host := &mockHost{
+		ext: map[config.ComponentID]component.Extension{
+			config.NewComponentID("mock"): configauth.NewServerAuthenticator(),
+		},
+	}
+	_, err := hss.ToServer(host, componenttest.NewNopTelemetrySettings(), http.NewServeMux())
+	assert.EqualError(t, err, `authorization rule 0: invalid action "reject", must be "allow" or "deny"`)
+}
+
+type principalAuthData struct {
+	principal string
+}
+
+func (d *principalAuthData) GetAttribute(name string) interface{} {
+	if name == "subject" {
+		return d.principal
+	}
+	return nil
+}
+
+func (d *principalAuthData) GetAttributeNames() []string {
+	return []string{"subject"}
+}
+
 func TestInvalidServerAuth(t *testing.T) {
 	hss := HTTPServerSettings{
 		Auth: &configauth.Authentication{
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp // import "go.opentelemetry.io/collector/config/confighttp"
+
+import (
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/collector/internal/netstats"
+)
+
+// countingReadCloser reports the number of bytes read from the wrapped io.ReadCloser.
+type countingReadCloser struct {
+	io.ReadCloser
+	add func(int64)
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.add(int64(n))
+	return n, err
+}
+
+func hasBody(body io.ReadCloser) bool {
+	return body != nil && body != http.NoBody
+}
+
+// wireBytesInterceptor adds a counter to the request context, for the obsreport helpers recording
+// the size of the requests handled by receivers, and counts the bytes of the request body as received.
+func wireBytesInterceptor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counter := &netstats.Counter{}
+		r = r.WithContext(netstats.NewContext(r.Context(), counter))
+		if hasBody(r.Body) {
+			r.Body = &countingReadCloser{ReadCloser: r.Body, add: counter.AddWireBytes}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bytesInterceptor counts the bytes of the request body after decompression, in the counter added
+// by wireBytesInterceptor.
+func bytesInterceptor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if counter := netstats.FromContext(r.Context()); counter != nil && hasBody(r.Body) {
+			r.Body = &countingReadCloser{ReadCloser: r.Body, add: counter.AddBytes}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bytesCounterRoundTripper counts the bytes of the request bodies in the counter carried by the
+// request context, which is added by the obsreport helpers for the requests sent by exporters.
+type bytesCounterRoundTripper struct {
+	transport http.RoundTripper
+	// bytes and wireBytes are whether the bytes are counted as uncompressed, or as sent over the network.
+	bytes     bool
+	wireBytes bool
+}
+
+func (rt *bytesCounterRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	counter := netstats.FromContext(req.Context())
+	if counter == nil || !hasBody(req.Body) {
+		return rt.transport.RoundTrip(req)
+	}
+	add := func(n int64) {
+		if rt.bytes {
+			counter.AddBytes(n)
+		}
+		if rt.wireBytes {
+			counter.AddWireBytes(n)
+		}
+	}
+	// The request must not be modified, see https://golang.org/pkg/net/http/#RoundTripper.
+	req = req.Clone(req.Context())
+	req.Body = &countingReadCloser{ReadCloser: req.Body, add: add}
+	return rt.transport.RoundTrip(req)
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package confighttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/internal/netstats"
+)
+
+func TestBytesCounting(t *testing.T) {
+	payload := strings.Repeat("telemetry", 100)
+
+	type counted struct{ bytes, wireBytes int64 }
+	received := make(chan counted, 1)
+	hss := &HTTPServerSettings{Endpoint: "localhost:0"}
+	ln, err := hss.ToListener()
+	require.NoError(t, err)
+	s, err := hss.ToServer(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings(), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, rerr := io.ReadAll(r.Body)
+		assert.NoError(t, rerr)
+		assert.Equal(t, payload, string(body))
+		var c counted
+		c.bytes, c.wireBytes = netstats.FromContext(r.Context()).Swap()
+		received <- c
+	}))
+	require.NoError(t, err)
+	go func() {
+		_ = s.Serve(ln)
+	}()
+	defer s.Close()
+
+	tests := []struct {
+		name        string
+		compression configcompression.CompressionType
+		compressed  bool
+	}{
+		{name: "uncompressed"},
+		{name: "gzip", compression: configcompression.Gzip, compressed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hcs := &HTTPClientSettings{
+				Endpoint:    "http://" + ln.Addr().String(),
+				Compression: tt.compression,
+			}
+			client, err := hcs.ToClient(componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			counter := &netstats.Counter{}
+			ctx := netstats.NewContext(context.Background(), counter)
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, hcs.Endpoint, bytes.NewReader([]byte(payload)))
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			sent, sentWire := counter.Swap()
+			got := <-received
+			assert.Equal(t, int64(len(payload)), sent)
+			assert.Equal(t, int64(len(payload)), got.bytes)
+			assert.Equal(t, sentWire, got.wireBytes)
+			if tt.compressed {
+				assert.Less(t, sentWire, sent)
+			} else {
+				assert.Equal(t, sent, sentWire)
+			}
+		})
+	}
+}
@@ -15,9 +15,25 @@
 package confignet // import "go.opentelemetry.io/collector/config/confignet"
 
 import (
+	"errors"
+	"fmt"
 	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
 )
 
+const (
+	unixTransport = "unix"
+	unixScheme    = "unix://"
+	npipeScheme   = "npipe://"
+)
+
+// errNamedPipes is returned for the Windows named pipe endpoints, which are not supported. Windows 10 and
+// later support unix domain sockets, which can be used instead.
+var errNamedPipes = errors.New("windows named pipes are not supported, use a unix:// endpoint instead")
+
 // NetAddr represents a network endpoint address.
 type NetAddr struct {
 	// Endpoint configures the address for this network connection.
@@ -33,12 +49,33 @@ type NetAddr struct {
 }
 
 // Dial equivalent with net.Dial for this address.
+// An Endpoint using the "unix://" scheme dials the unix domain socket at the given path regardless of Transport.
 func (na *NetAddr) Dial() (net.Conn, error) {
+	if path, ok := UnixSocketPath(na.Endpoint); ok {
+		return net.Dial(unixTransport, path)
+	}
 	return net.Dial(na.Transport, na.Endpoint)
 }
 
 // Listen equivalent with net.Listen for this address.
+// An Endpoint using the "unix://" scheme listens on the unix domain socket at the given path regardless of Transport.
+// Stale socket files are removed before listening on a unix domain socket, see UnixSocketSettings.Listen.
 func (na *NetAddr) Listen() (net.Listener, error) {
+	return na.ListenWithSocketSettings(UnixSocketSettings{})
+}
+
+// ListenWithSocketSettings is equivalent with Listen, applying the given settings when
+// the address refers to a unix domain socket.
+func (na *NetAddr) ListenWithSocketSettings(us UnixSocketSettings) (net.Listener, error) {
+	if strings.HasPrefix(na.Endpoint, npipeScheme) {
+		return nil, errNamedPipes
+	}
+	if path, ok := UnixSocketPath(na.Endpoint); ok {
+		return us.Listen(path)
+	}
+	if na.Transport == unixTransport {
+		return us.Listen(na.Endpoint)
+	}
 	return net.Listen(na.Transport, na.Endpoint)
 }
 
@@ -61,3 +98,82 @@ func (na *TCPAddr) Dial() (net.Conn, error) {
 func (na *TCPAddr) Listen() (net.Listener, error) {
 	return net.Listen("tcp", na.Endpoint)
 }
+
+// UnixSocketPath reports whether the endpoint uses the "unix://" scheme (e.g. "unix:///var/run/otelcol.sock"),
+// and if so returns the filesystem path of the socket.
+func UnixSocketPath(endpoint string) (string, bool) {
+	if !strings.HasPrefix(endpoint, unixScheme) {
+		return "", false
+	}
+	return strings.TrimPrefix(endpoint, unixScheme), true
+}
+
+// UnixSocketSettings defines settings for servers listening on a unix domain socket.
+type UnixSocketSettings struct {
+	// Permissions sets the file mode of the socket file in octal notation (e.g. "0660"),
+	// allowing filesystem permissions to be used as access control. "0000" denies all
+	// permissions. If empty, the file mode is determined by the process umask.
+	Permissions string `mapstructure:"permissions"`
+}
+
+// Validate checks that the settings are valid.
+func (us *UnixSocketSettings) Validate() error {
+	_, _, err := us.fileMode()
+	return err
+}
+
+// fileMode returns the configured file mode of the socket, and whether one is configured.
+func (us *UnixSocketSettings) fileMode() (os.FileMode, bool, error) {
+	if us.Permissions == "" {
+		return 0, false, nil
+	}
+	mode, err := strconv.ParseUint(us.Permissions, 8, 32)
+	if err != nil || mode > uint64(os.ModePerm) {
+		return 0, false, fmt.Errorf("invalid unix socket permissions %q, must be an octal file mode such as \"0660\"", us.Permissions)
+	}
+	return os.FileMode(mode), true, nil
+}
+
+// Listen creates a listener on the unix domain socket at path.
+// A socket file left behind by a previous process is removed before listening, unless a server
+// is still accepting connections on it. Files that are not sockets are never removed.
+func (us *UnixSocketSettings) Listen(path string) (net.Listener, error) {
+	mode, set, err := us.fileMode()
+	if err != nil {
+		return nil, err
+	}
+	if err = removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	if !set {
+		return net.Listen(unixTransport, path)
+	}
+	return listenUnixWithMode(path, mode)
+}
+
+// removeStaleSocket removes the socket file at path if nothing is listening on it anymore.
+func removeStaleSocket(path string) error {
+	fi, err := os.Lstat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if fi.Mode()&os.ModeSocket == 0 {
+		// Let net.Listen report the conflict, never remove regular files.
+		return nil
+	}
+	conn, err := net.Dial(unixTransport, path)
+	if err == nil {
+		// Another server is still listening on the socket, let net.Listen report the conflict.
+		return conn.Close()
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return nil
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale unix socket %q: %w", path, err)
+	}
+	return nil
+}
@@ -16,9 +16,13 @@ package confignet
 
 import (
 	"net"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNetAddr(t *testing.T) {
@@ -88,3 +92,113 @@ func TestTcpAddr(t *testing.T) {
 	<-done
 	assert.NoError(t, ln.Close())
 }
+
+func TestUnixSocketPath(t *testing.T) {
+	path, ok := UnixSocketPath("unix:///var/run/otelcol.sock")
+	assert.True(t, ok)
+	assert.Equal(t, "/var/run/otelcol.sock", path)
+
+	_, ok = UnixSocketPath("localhost:4317")
+	assert.False(t, ok)
+}
+
+func TestNetAddrUnixScheme(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	socket := filepath.Join(t.TempDir(), "otelcol.sock")
+	nas := &NetAddr{
+		Endpoint:  "unix://" + socket,
+		Transport: "tcp",
+	}
+	ln, err := nas.Listen()
+	require.NoError(t, err)
+	assert.Equal(t, "unix", ln.Addr().Network())
+
+	conn, err := nas.Dial()
+	require.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	assert.NoError(t, ln.Close())
+}
+
+func TestUnixSocketSettingsPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	socket := filepath.Join(t.TempDir(), "otelcol.sock")
+	us := &UnixSocketSettings{Permissions: "0600"}
+	require.NoError(t, us.Validate())
+	ln, err := us.Listen(socket)
+	require.NoError(t, err)
+	fi, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+	assert.NoError(t, ln.Close())
+}
+
+func TestUnixSocketSettingsNoPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	socket := filepath.Join(t.TempDir(), "otelcol.sock")
+	us := &UnixSocketSettings{Permissions: "0000"}
+	require.NoError(t, us.Validate())
+	ln, err := us.Listen(socket)
+	require.NoError(t, err)
+	fi, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0), fi.Mode().Perm())
+	assert.NoError(t, ln.Close())
+}
+
+func TestNetAddrNamedPipe(t *testing.T) {
+	nas := &NetAddr{Endpoint: "npipe:////./pipe/otelcol"}
+	_, err := nas.Listen()
+	assert.EqualError(t, err, "windows named pipes are not supported, use a unix:// endpoint instead")
+}
+
+func TestUnixSocketSettingsInvalidPermissions(t *testing.T) {
+	for _, perm := range []string{"rw-rw----", "0999", "17777"} {
+		us := &UnixSocketSettings{Permissions: perm}
+		assert.Error(t, us.Validate())
+		_, err := us.Listen(filepath.Join(t.TempDir(), "otelcol.sock"))
+		assert.Error(t, err)
+	}
+}
+
+func TestUnixSocketRemovesStaleSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	socket := filepath.Join(t.TempDir(), "otelcol.sock")
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	// Simulate a process that exited without removing its socket file.
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, ln.Close())
+	_, err = os.Stat(socket)
+	require.NoError(t, err)
+
+	us := &UnixSocketSettings{}
+	ln, err = us.Listen(socket)
+	require.NoError(t, err)
+
+	// A socket with an active listener must not be removed.
+	_, err = us.Listen(socket)
+	assert.Error(t, err)
+	assert.NoError(t, ln.Close())
+}
+
+func TestUnixSocketKeepsRegularFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on windows")
+	}
+	file := filepath.Join(t.TempDir(), "otelcol.sock")
+	require.NoError(t, os.WriteFile(file, []byte("data"), 0600))
+
+	us := &UnixSocketSettings{}
+	_, err := us.Listen(file)
+	assert.Error(t, err)
+	_, err = os.Stat(file)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+)
+
+// listenUnixWithMode listens on the unix domain socket at path, with the given file mode. The socket
+// is bound in a private directory, only accessible by the process user, then given the file mode and
+// moved to path, so that it is never reachable by the users the file mode excludes.
+func listenUnixWithMode(path string, mode os.FileMode) (net.Listener, error) {
+	// The directory is created next to path, so that the socket file can be renamed to it.
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".otelcol-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the directory of unix socket %q: %w", path, err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmpPath := filepath.Join(dir, "socket")
+	ln, err := net.ListenUnix(unixTransport, &net.UnixAddr{Name: tmpPath, Net: unixTransport})
+	if err != nil {
+		return nil, err
+	}
+	// The listener would remove its temporary path on close, restrictedListener removes path instead.
+	ln.SetUnlinkOnClose(false)
+	if err = os.Chmod(tmpPath, mode); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to set permissions of unix socket %q: %w", path, err)
+	}
+	if err = os.Rename(tmpPath, path); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to move unix socket to %q: %w", path, err)
+	}
+	return &restrictedListener{UnixListener: ln, addr: &net.UnixAddr{Name: path, Net: unixTransport}}, nil
+}
+
+// restrictedListener is a listener on a unix domain socket moved to addr after being bound.
+type restrictedListener struct {
+	*net.UnixListener
+	addr *net.UnixAddr
+}
+
+func (l *restrictedListener) Addr() net.Addr {
+	return l.addr
+}
+
+// Close closes the listener and removes its socket file.
+func (l *restrictedListener) Close() error {
+	err := l.UnixListener.Close()
+	if rerr := os.Remove(l.addr.Name); rerr != nil && !os.IsNotExist(rerr) && err == nil {
+		err = rerr
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+// +build !windows
+
+package confignet
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenUnixWithMode(t *testing.T) {
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "otelcol.sock")
+	ln, err := listenUnixWithMode(socket, 0o640)
+	require.NoError(t, err)
+	assert.Equal(t, socket, ln.Addr().String())
+
+	fi, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), fi.Mode().Perm())
+	// The private directory the socket was bound in is removed.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	conn, err := net.Dial("unix", socket)
+	require.NoError(t, err)
+	assert.NoError(t, conn.Close())
+
+	// The socket file is removed on close.
+	assert.NoError(t, ln.Close())
+	_, err = os.Stat(socket)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+// +build windows
+
+package confignet // import "go.opentelemetry.io/collector/config/confignet"
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// listenUnixWithMode listens on the unix domain socket at path, and sets its file mode. Windows
+// only honors the read-only attribute of the file mode.
+func listenUnixWithMode(path string, mode os.FileMode) (net.Listener, error) {
+	ln, err := net.Listen(unixTransport, path)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(path, mode); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to set permissions of unix socket %q: %w", path, err)
+	}
+	return ln, nil
+}
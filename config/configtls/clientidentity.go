@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// Attribute names exposed by ClientIdentity, all starting with AttributePrefix.
+const (
+	// AttributePrefix is the prefix of all the attribute names, reserved in client.Metadata for
+	// the identities of verified certificates.
+	AttributePrefix = "tls."
+
+	// AttributeSubject is the distinguished name of the certificate subject, as a string.
+	AttributeSubject = "tls.subject"
+	// AttributeCommonName is the common name of the certificate subject, as a string.
+	AttributeCommonName = "tls.common_name"
+	// AttributeSANDNSNames are the DNS names in the subject alternative names, as a []string.
+	AttributeSANDNSNames = "tls.san.dns"
+	// AttributeSANURIs are the URIs in the subject alternative names, as a []string.
+	AttributeSANURIs = "tls.san.uri"
+	// AttributeSANEmailAddresses are the email addresses in the subject alternative names, as a []string.
+	AttributeSANEmailAddresses = "tls.san.email"
+	// AttributeSANIPAddresses are the IP addresses in the subject alternative names, as a []string.
+	AttributeSANIPAddresses = "tls.san.ip"
+	// AttributeSPIFFEID is the SPIFFE ID found in the URI subject alternative names, as a string.
+	AttributeSPIFFEID = "tls.spiffe_id"
+)
+
+const spiffeScheme = "spiffe"
+
+var _ client.AuthData = (*ClientIdentity)(nil)
+
+// ClientIdentity is a client.AuthData describing a client that presented a
+// verified certificate during the TLS handshake. Only attributes with a
+// non-empty value are present.
+type ClientIdentity struct {
+	attrs map[string]interface{}
+}
+
+// NewClientIdentity creates a ClientIdentity from the given leaf certificate.
+func NewClientIdentity(cert *x509.Certificate) *ClientIdentity {
+	ci := &ClientIdentity{attrs: map[string]interface{}{}}
+	ci.putString(AttributeSubject, cert.Subject.String())
+	ci.putString(AttributeCommonName, cert.Subject.CommonName)
+	ci.putStrings(AttributeSANDNSNames, cert.DNSNames)
+	ci.putStrings(AttributeSANEmailAddresses, cert.EmailAddresses)
+
+	uris := make([]string, 0, len(cert.URIs))
+	for _, uri := range cert.URIs {
+		uris = append(uris, uri.String())
+		// The SPIFFE specification mandates exactly one SPIFFE ID per SVID.
+		if uri.Scheme == spiffeScheme && ci.attrs[AttributeSPIFFEID] == nil {
+			ci.putString(AttributeSPIFFEID, uri.String())
+		}
+	}
+	ci.putStrings(AttributeSANURIs, uris)
+
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	ci.putStrings(AttributeSANIPAddresses, ips)
+	return ci
+}
+
+// ClientIdentityFromConnectionState returns the ClientIdentity of the verified
+// client certificate of the connection, or nil when the client did not present
+// a certificate that was verified by the server.
+func ClientIdentityFromConnectionState(state *tls.ConnectionState) *ClientIdentity {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return NewClientIdentity(state.VerifiedChains[0][0])
+}
+
+// GetAttribute returns the value for the given attribute, either a string or a []string.
+func (ci *ClientIdentity) GetAttribute(name string) interface{} {
+	return ci.attrs[name]
+}
+
+// GetAttributeNames returns the names of all attributes of this identity.
+func (ci *ClientIdentity) GetAttributeNames() []string {
+	names := make([]string, 0, len(ci.attrs))
+	for name := range ci.attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Metadata returns the attributes of this identity in the form used by client.Metadata,
+// keyed by attribute name.
+func (ci *ClientIdentity) Metadata() map[string][]string {
+	md := make(map[string][]string, len(ci.attrs))
+	for name, val := range ci.attrs {
+		switch v := val.(type) {
+		case string:
+			md[name] = []string{v}
+		case []string:
+			md[name] = append([]string(nil), v...)
+		}
+	}
+	return md
+}
+
+// RemoveIdentityMetadata removes the keys starting with AttributePrefix, in any case, from the
+// metadata supplied by a client, so that a client cannot forge the identity of a certificate.
+func RemoveIdentityMetadata(md map[string][]string) {
+	for k := range md {
+		if len(k) >= len(AttributePrefix) && strings.EqualFold(k[:len(AttributePrefix)], AttributePrefix) {
+			delete(md, k)
+		}
+	}
+}
+
+func (ci *ClientIdentity) putString(name, val string) {
+	if val != "" {
+		ci.attrs[name] = val
+	}
+}
+
+func (ci *ClientIdentity) putStrings(name string, vals []string) {
+	if len(vals) != 0 {
+		ci.attrs[name] = append([]string(nil), vals...)
+	}
+}
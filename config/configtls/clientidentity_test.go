@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientIdentity(t *testing.T) {
+	spiffeID, _ := url.Parse("spiffe://example.org/ns/team-a/sa/agent")
+	other, _ := url.Parse("https://example.org/agent")
+	cert := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   "agent",
+			Organization: []string{"team-a"},
+		},
+		DNSNames:       []string{"agent.example.org"},
+		EmailAddresses: []string{"agent@example.org"},
+		IPAddresses:    []net.IP{net.IPv4(10, 0, 0, 1)},
+		URIs:           []*url.URL{other, spiffeID},
+	}
+
+	ci := NewClientIdentity(cert)
+	assert.Equal(t, []string{
+		AttributeCommonName,
+		AttributeSANDNSNames,
+		AttributeSANEmailAddresses,
+		AttributeSANIPAddresses,
+		AttributeSANURIs,
+		AttributeSPIFFEID,
+		AttributeSubject,
+	}, ci.GetAttributeNames())
+	assert.Equal(t, "CN=agent,O=team-a", ci.GetAttribute(AttributeSubject))
+	assert.Equal(t, "agent", ci.GetAttribute(AttributeCommonName))
+	assert.Equal(t, []string{"agent.example.org"}, ci.GetAttribute(AttributeSANDNSNames))
+	assert.Equal(t, []string{"agent@example.org"}, ci.GetAttribute(AttributeSANEmailAddresses))
+	assert.Equal(t, []string{"10.0.0.1"}, ci.GetAttribute(AttributeSANIPAddresses))
+	assert.Equal(t, []string{"https://example.org/agent", "spiffe://example.org/ns/team-a/sa/agent"}, ci.GetAttribute(AttributeSANURIs))
+	assert.Equal(t, "spiffe://example.org/ns/team-a/sa/agent", ci.GetAttribute(AttributeSPIFFEID))
+	assert.Nil(t, ci.GetAttribute("unknown"))
+
+	md := ci.Metadata()
+	assert.Equal(t, []string{"agent"}, md[AttributeCommonName])
+	assert.Equal(t, []string{"agent.example.org"}, md[AttributeSANDNSNames])
+	assert.Len(t, md, 7)
+}
+
+func TestNewClientIdentityOmitsEmptyAttributes(t *testing.T) {
+	ci := NewClientIdentity(&x509.Certificate{Subject: pkix.Name{CommonName: "agent"}})
+	assert.Equal(t, []string{AttributeCommonName, AttributeSubject}, ci.GetAttributeNames())
+	assert.Nil(t, ci.GetAttribute(AttributeSPIFFEID))
+}
+
+func TestRemoveIdentityMetadata(t *testing.T) {
+	md := map[string][]string{
+		"tls.spiffe_id":   {"spiffe://example.org/victim"},
+		"Tls.subject":     {"CN=victim"},
+		"TLS.SAN.DNS":     {"victim.example.org"},
+		"tls":             {"kept"},
+		"x-tls.subject":   {"kept"},
+		"x-forwarded-for": {"1.2.3.4"},
+	}
+	RemoveIdentityMetadata(md)
+	assert.Equal(t, map[string][]string{
+		"tls":             {"kept"},
+		"x-tls.subject":   {"kept"},
+		"x-forwarded-for": {"1.2.3.4"},
+	}, md)
+}
+
+func TestClientIdentityFromConnectionState(t *testing.T) {
+	assert.Nil(t, ClientIdentityFromConnectionState(nil))
+	assert.Nil(t, ClientIdentityFromConnectionState(&tls.ConnectionState{}))
+
+	// Certificates that were presented but not verified are ignored.
+	unverified := &x509.Certificate{Subject: pkix.Name{CommonName: "unverified"}}
+	assert.Nil(t, ClientIdentityFromConnectionState(&tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{unverified},
+	}))
+
+	verified := &x509.Certificate{Subject: pkix.Name{CommonName: "verified"}}
+	ca := &x509.Certificate{Subject: pkix.Name{CommonName: "ca"}}
+	ci := ClientIdentityFromConnectionState(&tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{verified},
+		VerifiedChains:   [][]*x509.Certificate{{verified, ca}},
+	})
+	assert.NotNil(t, ci)
+	assert.Equal(t, "verified", ci.GetAttribute(AttributeCommonName))
+}
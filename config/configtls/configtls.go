@@ -41,12 +41,21 @@ type TLSSetting struct {
 	// (optional)
 	CAFile string `mapstructure:"ca_file"`
 
+	// In memory PEM encoded CA cert, as an alternative to CAFile. (optional)
+	CAPem string `mapstructure:"ca_pem"`
+
 	// Path to the TLS cert to use for TLS required connections. (optional)
 	CertFile string `mapstructure:"cert_file"`
 
+	// In memory PEM encoded TLS cert, as an alternative to CertFile. (optional)
+	CertPem string `mapstructure:"cert_pem"`
+
 	// Path to the TLS key to use for TLS required connections. (optional)
 	KeyFile string `mapstructure:"key_file"`
 
+	// In memory PEM encoded TLS key, as an alternative to KeyFile. (optional)
+	KeyPem string `mapstructure:"key_pem"`
+
 	// MinVersion sets the minimum TLS version that is acceptable.
 	// If not set, TLS 1.2 will be used. (optional)
 	MinVersion string `mapstructure:"min_version"`
@@ -55,7 +64,19 @@ type TLSSetting struct {
 	// If not set, refer to crypto/tls for defaults. (optional)
 	MaxVersion string `mapstructure:"max_version"`
 
-	// ReloadInterval specifies the duration after which the certificate will be reloaded
+	// CipherSuites is a list of TLS 1.0-1.2 cipher suites, by their IANA name
+	// (e.g. "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"). Only the secure cipher
+	// suites implemented by crypto/tls are accepted. TLS 1.3 cipher suites are
+	// not configurable. If not set, refer to crypto/tls for defaults. (optional)
+	CipherSuites []string `mapstructure:"cipher_suites"`
+
+	// CurvePreferences is a list of elliptic curves used in an ECDHE handshake,
+	// in preference order. Valid values are "X25519", "P256", "P384" and "P521".
+	// If not set, refer to crypto/tls for defaults. (optional)
+	CurvePreferences []string `mapstructure:"curve_preferences"`
+
+	// ReloadInterval specifies the duration after which the certificate will be reloaded.
+	// For servers, the client CA file is reloaded as well.
 	// If not set, it will never be reloaded (optional)
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
 }
@@ -100,13 +121,11 @@ type TLSServerSetting struct {
 }
 
 // certReloader is a wrapper object for certificate reloading
-// Its GetCertificate method will either return the current certificate or reload from disk
+// Its GetCertificate method will either return the current certificate or reload it
 // if the last reload happened more than ReloadInterval ago
 type certReloader struct {
-	// Path to the TLS cert
-	CertFile string
-	// Path to the TLS key
-	KeyFile string
+	// load reads the TLS cert and key
+	load func() (tls.Certificate, error)
 	// ReloadInterval specifies the duration after which the certificate will be reloaded
 	// If not set, it will never be reloaded (optional)
 	ReloadInterval time.Duration
@@ -115,14 +134,13 @@ type certReloader struct {
 	lock           sync.RWMutex
 }
 
-func newCertReloader(certFile, keyFile string, reloadInterval time.Duration) (*certReloader, error) {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+func newCertReloader(load func() (tls.Certificate, error), reloadInterval time.Duration) (*certReloader, error) {
+	cert, err := load()
 	if err != nil {
 		return nil, err
 	}
 	return &certReloader{
-		CertFile:       certFile,
-		KeyFile:        keyFile,
+		load:           load,
 		ReloadInterval: reloadInterval,
 		nextReload:     time.Now().Add(reloadInterval),
 		cert:           &cert,
@@ -140,7 +158,7 @@ func (r *certReloader) GetCertificate() (*tls.Certificate, error) {
 		r.lock.RUnlock()
 		r.lock.Lock()
 		defer r.lock.Unlock()
-		cert, err := tls.LoadX509KeyPair(r.CertFile, r.KeyFile)
+		cert, err := r.load()
 		if err != nil {
 			return nil, fmt.Errorf("failed to load TLS cert and key: %w", err)
 		}
@@ -152,30 +170,80 @@ func (r *certReloader) GetCertificate() (*tls.Certificate, error) {
 	return r.cert, nil
 }
 
+// clientCAsReloader is a wrapper object for client CA reloading
+// Its GetClientCAs method will either return the current pool or reload it from disk
+// if the last reload happened more than ReloadInterval ago
+type clientCAsReloader struct {
+	// Path to the client CA cert
+	ClientCAFile string
+	// ReloadInterval specifies the duration after which the client CA pool will be reloaded
+	ReloadInterval time.Duration
+	nextReload     time.Time
+	certPool       *x509.CertPool
+	lock           sync.RWMutex
+}
+
+func (r *clientCAsReloader) GetClientCAs() (*x509.CertPool, error) {
+	now := time.Now()
+	r.lock.RLock()
+	if r.nextReload.Before(now) {
+		r.lock.RUnlock()
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		certPool, err := loadCertFile(r.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client CA CertPool: %w", err)
+		}
+		r.certPool = certPool
+		r.nextReload = now.Add(r.ReloadInterval)
+		return r.certPool, nil
+	}
+	defer r.lock.RUnlock()
+	return r.certPool, nil
+}
+
 // LoadTLSConfig loads TLS certificates and returns a tls.Config.
 // This will set the RootCAs and Certificates of a tls.Config.
 func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
+	if c.CAFile != "" && c.CAPem != "" {
+		return nil, errors.New("provide either a CA file or the PEM-encoded string, but not both")
+	}
+	if c.CertFile != "" && c.CertPem != "" {
+		return nil, errors.New("provide either a certificate file or the PEM-encoded string, but not both")
+	}
+	if c.KeyFile != "" && c.KeyPem != "" {
+		return nil, errors.New("provide either a key file or the PEM-encoded string, but not both")
+	}
+
 	// There is no need to load the System Certs for RootCAs because
 	// if the value is nil, it will default to checking against th System Certs.
 	var err error
 	var certPool *x509.CertPool
-	if len(c.CAFile) != 0 {
+	switch {
+	case len(c.CAFile) != 0:
 		// Set up user specified truststore.
-		certPool, err = c.loadCert(c.CAFile)
+		certPool, err = loadCertFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load CA CertPool: %w", err)
+		}
+	case len(c.CAPem) != 0:
+		certPool, err = loadCertPem([]byte(c.CAPem))
 		if err != nil {
 			return nil, fmt.Errorf("failed to load CA CertPool: %w", err)
 		}
 	}
 
-	if (c.CertFile == "" && c.KeyFile != "") || (c.CertFile != "" && c.KeyFile == "") {
+	hasCert := c.CertFile != "" || c.CertPem != ""
+	hasKey := c.KeyFile != "" || c.KeyPem != ""
+	if hasCert != hasKey {
 		return nil, errors.New("for auth via TLS, either both certificate and key must be supplied, or neither")
 	}
 
 	var getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
 	var getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
-	if c.CertFile != "" && c.KeyFile != "" {
+	if hasCert && hasKey {
 		var certReloader *certReloader
-		certReloader, err = newCertReloader(c.CertFile, c.KeyFile, c.ReloadInterval)
+		certReloader, err = newCertReloader(c.loadCertificate, c.ReloadInterval)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load TLS cert and key: %w", err)
 		}
@@ -191,6 +259,14 @@ func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid TLS max_version: %w", err)
 	}
+	cipherSuites, err := convertCipherSuites(c.CipherSuites)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS cipher_suites: %w", err)
+	}
+	curvePreferences, err := convertCurvePreferences(c.CurvePreferences)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS curve_preferences: %w", err)
+	}
 
 	return &tls.Config{
 		RootCAs:              certPool,
@@ -198,18 +274,47 @@ func (c TLSSetting) loadTLSConfig() (*tls.Config, error) {
 		GetClientCertificate: getClientCertificate,
 		MinVersion:           minTLS,
 		MaxVersion:           maxTLS,
+		CipherSuites:         cipherSuites,
+		CurvePreferences:     curvePreferences,
 	}, nil
 }
 
-func (c TLSSetting) loadCert(caPath string) (*x509.CertPool, error) {
+// loadCertificate reads the TLS cert and key, either from files or from the in memory PEM values.
+func (c TLSSetting) loadCertificate() (tls.Certificate, error) {
+	certPEM := []byte(c.CertPem)
+	if c.CertFile != "" {
+		var err error
+		if certPEM, err = os.ReadFile(filepath.Clean(c.CertFile)); err != nil {
+			return tls.Certificate{}, err
+		}
+	}
+	keyPEM := []byte(c.KeyPem)
+	if c.KeyFile != "" {
+		var err error
+		if keyPEM, err = os.ReadFile(filepath.Clean(c.KeyFile)); err != nil {
+			return tls.Certificate{}, err
+		}
+	}
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+func loadCertFile(caPath string) (*x509.CertPool, error) {
 	caPEM, err := os.ReadFile(filepath.Clean(caPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to load CA %s: %w", caPath, err)
 	}
 
+	certPool, err := loadCertPem(caPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA %s", caPath)
+	}
+	return certPool, nil
+}
+
+func loadCertPem(caPEM []byte) (*x509.CertPool, error) {
 	certPool := x509.NewCertPool()
 	if !certPool.AppendCertsFromPEM(caPEM) {
-		return nil, fmt.Errorf("failed to parse CA %s", caPath)
+		return nil, errors.New("failed to parse CA")
 	}
 	return certPool, nil
 }
@@ -236,12 +341,33 @@ func (c TLSServerSetting) LoadTLSConfig() (*tls.Config, error) {
 		return nil, fmt.Errorf("failed to load TLS config: %w", err)
 	}
 	if c.ClientCAFile != "" {
-		certPool, err := c.loadCert(c.ClientCAFile)
+		certPool, err := loadCertFile(c.ClientCAFile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load TLS config: failed to load client CA CertPool: %w", err)
 		}
 		tlsCfg.ClientCAs = certPool
 		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+
+		if c.ReloadInterval != 0 {
+			reloader := &clientCAsReloader{
+				ClientCAFile:   c.ClientCAFile,
+				ReloadInterval: c.ReloadInterval,
+				nextReload:     time.Now().Add(c.ReloadInterval),
+				certPool:       certPool,
+			}
+			// The returned config is cloned on every handshake, so that changes applied by the
+			// caller after loading (e.g. NextProtos) are taken into account.
+			tlsCfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+				clientCAs, err := reloader.GetClientCAs()
+				if err != nil {
+					return nil, err
+				}
+				cfg := tlsCfg.Clone()
+				cfg.ClientCAs = clientCAs
+				cfg.GetConfigForClient = nil
+				return cfg, nil
+			}
+		}
 	}
 	return tlsCfg, nil
 }
@@ -264,3 +390,56 @@ var tlsVersions = map[string]uint16{
 	"1.2": tls.VersionTLS12,
 	"1.3": tls.VersionTLS13,
 }
+
+func convertCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	supported := make(map[string]*tls.CipherSuite)
+	for _, cs := range tls.CipherSuites() {
+		supported[cs.Name] = cs
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		cs, ok := supported[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported or insecure cipher suite: %q", name)
+		}
+		if isTLS13Only(cs) {
+			return nil, fmt.Errorf("TLS 1.3 cipher suites are not configurable: %q", name)
+		}
+		ids = append(ids, cs.ID)
+	}
+	return ids, nil
+}
+
+func isTLS13Only(cs *tls.CipherSuite) bool {
+	for _, v := range cs.SupportedVersions {
+		if v != tls.VersionTLS13 {
+			return false
+		}
+	}
+	return true
+}
+
+func convertCurvePreferences(names []string) ([]tls.CurveID, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	curves := make([]tls.CurveID, 0, len(names))
+	for _, name := range names {
+		curve, ok := tlsCurves[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve: %q", name)
+		}
+		curves = append(curves, curve)
+	}
+	return curves, nil
+}
+
+var tlsCurves = map[string]tls.CurveID{
+	"X25519": tls.X25519,
+	"P256":   tls.CurveP256,
+	"P384":   tls.CurveP384,
+	"P521":   tls.CurveP521,
+}
@@ -17,6 +17,7 @@ package configtls
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -134,6 +135,96 @@ func TestOptionsToConfig(t *testing.T) {
 			},
 			expectError: "invalid TLS max_",
 		},
+		{
+			name: "should load valid TLS settings from PEM values",
+			options: TLSSetting{
+				CAPem:   readFilePanics(filepath.Join("testdata", "ca-1.crt")),
+				CertPem: readFilePanics(filepath.Join("testdata", "server-1.crt")),
+				KeyPem:  readFilePanics(filepath.Join("testdata", "server-1.key")),
+			},
+		},
+		{
+			name: "should load TLS cert from file and key from PEM value",
+			options: TLSSetting{
+				CertFile: filepath.Join("testdata", "server-1.crt"),
+				KeyPem:   readFilePanics(filepath.Join("testdata", "server-1.key")),
+			},
+		},
+		{
+			name: "should fail with invalid CA PEM value",
+			options: TLSSetting{
+				CAPem: readFilePanics(filepath.Join("testdata", "testCA-bad.txt")),
+			},
+			expectError: "failed to parse CA",
+		},
+		{
+			name: "should fail with both CA file and PEM value",
+			options: TLSSetting{
+				CAFile: filepath.Join("testdata", "ca-1.crt"),
+				CAPem:  readFilePanics(filepath.Join("testdata", "ca-1.crt")),
+			},
+			expectError: "provide either a CA file or the PEM-encoded string, but not both",
+		},
+		{
+			name: "should fail with both cert file and PEM value",
+			options: TLSSetting{
+				CertFile: filepath.Join("testdata", "server-1.crt"),
+				CertPem:  readFilePanics(filepath.Join("testdata", "server-1.crt")),
+				KeyFile:  filepath.Join("testdata", "server-1.key"),
+			},
+			expectError: "provide either a certificate file or the PEM-encoded string, but not both",
+		},
+		{
+			name: "should fail with both key file and PEM value",
+			options: TLSSetting{
+				CertFile: filepath.Join("testdata", "server-1.crt"),
+				KeyFile:  filepath.Join("testdata", "server-1.key"),
+				KeyPem:   readFilePanics(filepath.Join("testdata", "server-1.key")),
+			},
+			expectError: "provide either a key file or the PEM-encoded string, but not both",
+		},
+		{
+			name: "should fail with missing TLS key PEM value",
+			options: TLSSetting{
+				CertPem: readFilePanics(filepath.Join("testdata", "server-1.crt")),
+			},
+			expectError: "both certificate and key must be supplied",
+		},
+		{
+			name: "should pass with valid cipher suites and curves",
+			options: TLSSetting{
+				CipherSuites:     []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+				CurvePreferences: []string{"X25519", "P256"},
+			},
+		},
+		{
+			name: "should fail with unknown cipher suite",
+			options: TLSSetting{
+				CipherSuites: []string{"TLS_UNKNOWN"},
+			},
+			expectError: `invalid TLS cipher_suites: unsupported or insecure cipher suite: "TLS_UNKNOWN"`,
+		},
+		{
+			name: "should fail with insecure cipher suite",
+			options: TLSSetting{
+				CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"},
+			},
+			expectError: `invalid TLS cipher_suites: unsupported or insecure cipher suite: "TLS_RSA_WITH_RC4_128_SHA"`,
+		},
+		{
+			name: "should fail with TLS 1.3 cipher suite",
+			options: TLSSetting{
+				CipherSuites: []string{"TLS_AES_128_GCM_SHA256"},
+			},
+			expectError: `invalid TLS cipher_suites: TLS 1.3 cipher suites are not configurable: "TLS_AES_128_GCM_SHA256"`,
+		},
+		{
+			name: "should fail with unknown curve",
+			options: TLSSetting{
+				CurvePreferences: []string{"P224"},
+			},
+			expectError: `invalid TLS curve_preferences: unsupported curve: "P224"`,
+		},
 	}
 
 	for _, test := range tests {
@@ -392,3 +483,85 @@ func TestMinMaxTLSVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestCipherSuitesAndCurvePreferences(t *testing.T) {
+	options := TLSSetting{
+		CipherSuites:     []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"},
+		CurvePreferences: []string{"P384", "X25519"},
+	}
+	cfg, err := options.loadTLSConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}, cfg.CipherSuites)
+	assert.Equal(t, []tls.CurveID{tls.CurveP384, tls.X25519}, cfg.CurvePreferences)
+}
+
+func TestCertificateFromPem(t *testing.T) {
+	options := TLSSetting{
+		CertPem: readFilePanics(filepath.Join("testdata", "client-1.crt")),
+		KeyPem:  readFilePanics(filepath.Join("testdata", "client-1.key")),
+	}
+	cfg, err := options.loadTLSConfig()
+	require.NoError(t, err)
+	cert, err := cfg.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	pCert, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"example1"}, pCert.DNSNames)
+}
+
+func TestClientCAReload(t *testing.T) {
+	clientCAFile := filepath.Join(t.TempDir(), "client-ca.crt")
+	require.NoError(t, os.WriteFile(clientCAFile, []byte(readFilePanics(filepath.Join("testdata", "ca-1.crt"))), 0600))
+
+	tlsSetting := TLSServerSetting{
+		TLSSetting: TLSSetting{
+			ReloadInterval: 100 * time.Microsecond,
+		},
+		ClientCAFile: clientCAFile,
+	}
+	tlsCfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	require.NotNil(t, tlsCfg.GetConfigForClient)
+	tlsCfg.NextProtos = []string{"h2"}
+
+	ca1, err := loadCertFile(filepath.Join("testdata", "ca-1.crt"))
+	require.NoError(t, err)
+	ca2, err := loadCertFile(filepath.Join("testdata", "ca-2.crt"))
+	require.NoError(t, err)
+
+	cfg, err := tlsCfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.True(t, ca1.Equal(cfg.ClientCAs))
+	assert.Equal(t, []string{"h2"}, cfg.NextProtos)
+	assert.Nil(t, cfg.GetConfigForClient)
+
+	require.NoError(t, os.WriteFile(clientCAFile, []byte(readFilePanics(filepath.Join("testdata", "ca-2.crt"))), 0600))
+	time.Sleep(100 * time.Microsecond)
+
+	cfg, err = tlsCfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.True(t, ca2.Equal(cfg.ClientCAs))
+
+	require.NoError(t, os.WriteFile(clientCAFile, []byte(readFilePanics(filepath.Join("testdata", "testCA-bad.txt"))), 0600))
+	time.Sleep(100 * time.Microsecond)
+
+	_, err = tlsCfg.GetConfigForClient(&tls.ClientHelloInfo{})
+	assert.ErrorContains(t, err, "failed to load client CA CertPool")
+}
+
+func TestClientCANotReloadedWithoutInterval(t *testing.T) {
+	tlsSetting := TLSServerSetting{
+		ClientCAFile: filepath.Join("testdata", "ca-1.crt"),
+	}
+	tlsCfg, err := tlsSetting.LoadTLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, tlsCfg.GetConfigForClient)
+}
+
+func readFilePanics(filePath string) string {
+	fileContents, err := os.ReadFile(filepath.Clean(filePath))
+	if err != nil {
+		panic(fmt.Sprintf("failed to read file %s: %v", filePath, err))
+	}
+	return string(fileContents)
+}
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config // import "go.opentelemetry.io/collector/config"
+
+// PipelineFanout defines how the data of a pipeline is passed to its exporters.
+type PipelineFanout struct {
+	// Async passes the data to the exporters concurrently, instead of one after another, and
+	// acknowledges it to the upstream once the Quorum and the Primary exporters succeeded. The
+	// other exporters keep consuming the data in the background.
+	Async bool `mapstructure:"async"`
+	// Quorum is the number of exporters which must succeed, counted among the exporters the
+	// data is sent to. If zero, all of them must succeed, unless Primary is set.
+	Quorum int `mapstructure:"quorum"`
+	// Primary are the exporters which must succeed when the data is sent to them.
+	Primary []ComponentID `mapstructure:"primary"`
+}
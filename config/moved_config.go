@@ -20,6 +20,10 @@ type Pipeline struct {
 	Receivers  []ComponentID `mapstructure:"receivers"`
 	Processors []ComponentID `mapstructure:"processors"`
 	Exporters  []ComponentID `mapstructure:"exporters"`
+	// Routing, when set, sends the data to a subset of the exporters instead of all of them.
+	Routing *PipelineRouting `mapstructure:"routing"`
+	// Fanout, when set, changes how the data is passed to the exporters.
+	Fanout *PipelineFanout `mapstructure:"fanout"`
 }
 
 // Deprecated: [v0.52.0] will be removed soon.
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config // import "go.opentelemetry.io/collector/config"
+
+// PipelineRouting defines how the data of a pipeline is routed to its exporters.
+//
+// The data of each resource is sent to the exporters of the first route it matches,
+// or to the default exporters if it matches none.
+type PipelineRouting struct {
+	// Routes are evaluated in order, the first route matching a resource wins.
+	Routes []PipelineRoute `mapstructure:"routes"`
+	// DefaultExporters receive the data matching none of the routes.
+	DefaultExporters []ComponentID `mapstructure:"default_exporters"`
+}
+
+// PipelineRoute defines a subset of the exporters of a pipeline, and the data sent to them.
+//
+// A resource matches the route if it has all the ResourceAttributes, and the request it
+// was received with has all the Metadata, see client.Info.
+type PipelineRoute struct {
+	// ResourceAttributes the resources must have, with their string values.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+	// Metadata the requests must have, compared to any of the values of the key.
+	Metadata map[string]string `mapstructure:"metadata"`
+	// Exporters the matching data is sent to.
+	Exporters []ComponentID `mapstructure:"exporters"`
+}
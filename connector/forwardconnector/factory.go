@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardconnector // import "go.opentelemetry.io/collector/connector/forwardconnector"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of connector "type" in configuration.
+	typeStr = "forward"
+	// The stability level of the connector.
+	stability = component.StabilityLevelInDevelopment
+)
+
+// NewFactory returns a factory for the forward connector.
+func NewFactory() component.ConnectorFactory {
+	return component.NewConnectorFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesToTracesConnector(createTracesToTraces, stability),
+		component.WithMetricsToMetricsConnector(createMetricsToMetrics, stability),
+		component.WithLogsToLogsConnector(createLogsToLogs, stability),
+	)
+}
+
+func createDefaultConfig() config.Connector {
+	return &Config{
+		ConnectorSettings: config.NewConnectorSettings(config.NewComponentID(typeStr)),
+	}
+}
+
+func createTracesToTraces(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, nextConsumer consumer.Traces) (component.TracesConnector, error) {
+	return &forward{Traces: nextConsumer}, nil
+}
+
+func createMetricsToMetrics(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, nextConsumer consumer.Metrics) (component.MetricsConnector, error) {
+	return &forward{Metrics: nextConsumer}, nil
+}
+
+func createLogsToLogs(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, nextConsumer consumer.Logs) (component.LogsConnector, error) {
+	return &forward{Logs: nextConsumer}, nil
+}
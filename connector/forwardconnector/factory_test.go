@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+func TestFactory_CreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.Equal(t, &Config{
+		ConnectorSettings: config.NewConnectorSettings(config.NewComponentID(typeStr)),
+	}, cfg)
+	assert.NoError(t, configtest.CheckConfigStruct(cfg))
+}
+
+func TestFactory_Stability(t *testing.T) {
+	factory := NewFactory()
+	assert.Equal(t, component.StabilityLevelInDevelopment, factory.TracesToTracesConnectorStability())
+	assert.Equal(t, component.StabilityLevelInDevelopment, factory.MetricsToMetricsConnectorStability())
+	assert.Equal(t, component.StabilityLevelInDevelopment, factory.LogsToLogsConnectorStability())
+
+	// The data is forwarded to pipelines of the same data type only.
+	assert.Equal(t, component.StabilityLevelUndefined, factory.TracesToMetricsConnectorStability())
+	_, err := factory.CreateTracesToMetricsConnector(context.Background(), componenttest.NewNopConnectorCreateSettings(), factory.CreateDefaultConfig(), consumertest.NewNop())
+	require.ErrorIs(t, err, component.ErrDataTypeIsNotSupported)
+	assert.Equal(t, component.StabilityLevelUndefined, factory.LogsToTracesConnectorStability())
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardconnector // import "go.opentelemetry.io/collector/connector/forwardconnector"
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+// forward passes the data it consumes to the next consumer of the same data type, the
+// first consumer of the pipelines the connector is a receiver in.
+type forward struct {
+	consumer.Traces
+	consumer.Metrics
+	consumer.Logs
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+// Capabilities implements the consumer interfaces. The data is passed unchanged, the
+// pipelines it is passed to are responsible for cloning it if they mutate it.
+func (f *forward) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forwardconnector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestForwardTraces(t *testing.T) {
+	factory := NewFactory()
+	next := new(consumertest.TracesSink)
+	conn, err := factory.CreateTracesToTracesConnector(context.Background(), componenttest.NewNopConnectorCreateSettings(), factory.CreateDefaultConfig(), next)
+	require.NoError(t, err)
+	assert.False(t, conn.Capabilities().MutatesData)
+
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, conn.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.NoError(t, conn.Shutdown(context.Background()))
+	require.Len(t, next.AllTraces(), 1)
+	assert.Equal(t, testdata.GenerateTraces(2), next.AllTraces()[0])
+}
+
+func TestForwardMetrics(t *testing.T) {
+	factory := NewFactory()
+	next := new(consumertest.MetricsSink)
+	conn, err := factory.CreateMetricsToMetricsConnector(context.Background(), componenttest.NewNopConnectorCreateSettings(), factory.CreateDefaultConfig(), next)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, conn.ConsumeMetrics(context.Background(), testdata.GenerateMetrics(2)))
+	assert.NoError(t, conn.Shutdown(context.Background()))
+	require.Len(t, next.AllMetrics(), 1)
+	assert.Equal(t, testdata.GenerateMetrics(2), next.AllMetrics()[0])
+}
+
+func TestForwardLogs(t *testing.T) {
+	factory := NewFactory()
+	next := new(consumertest.LogsSink)
+	conn, err := factory.CreateLogsToLogsConnector(context.Background(), componenttest.NewNopConnectorCreateSettings(), factory.CreateDefaultConfig(), next)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, conn.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	assert.NoError(t, conn.Shutdown(context.Background()))
+	require.Len(t, next.AllLogs(), 1)
+	assert.Equal(t, testdata.GenerateLogs(2), next.AllLogs()[0])
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logmetricsconnector // import "go.opentelemetry.io/collector/connector/logmetricsconnector"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// MetricType is the type of a metric derived from the log records.
+type MetricType string
+
+const (
+	// MetricTypeCount counts the log records, as a monotonic integer sum.
+	MetricTypeCount MetricType = "count"
+	// MetricTypeSum sums the values of a numeric attribute of the log records.
+	MetricTypeSum MetricType = "sum"
+	// MetricTypeHistogram records the values of a numeric attribute of the log
+	// records in an explicit bucket histogram.
+	MetricTypeHistogram MetricType = "histogram"
+)
+
+const (
+	temporalityCumulative = "cumulative"
+	temporalityDelta      = "delta"
+)
+
+// defaultBuckets are the boundaries of the histograms not configuring any.
+var defaultBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+// Config has the configuration for the logmetrics connector.
+type Config struct {
+	config.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Interval between two emissions of the metrics.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// AggregationTemporality of the metrics, "cumulative" or "delta".
+	AggregationTemporality string `mapstructure:"aggregation_temporality"`
+
+	// Metrics are the metrics derived from the log records.
+	Metrics []MetricConfig `mapstructure:"metrics"`
+
+	// ResourceAttributes are the attributes of the resources of the log records
+	// the metrics are emitted with, the metrics being emitted for each distinct
+	// set of values. If empty, all the attributes of the resources are kept.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// MaxSeries is the maximum number of series of each metric, across all the
+	// resources. The log records which would add a series beyond it are ignored
+	// by the metric.
+	MaxSeries int `mapstructure:"max_series"`
+}
+
+// MetricConfig configures a metric derived from the log records.
+type MetricConfig struct {
+	// Name of the metric.
+	Name string `mapstructure:"name"`
+
+	// Description of the metric.
+	Description string `mapstructure:"description"`
+
+	// Unit of the metric.
+	Unit string `mapstructure:"unit"`
+
+	// Type of the metric, "count", "sum" or "histogram".
+	Type MetricType `mapstructure:"type"`
+
+	// Attribute is the numeric attribute of the log records summed or recorded
+	// in the histogram. The log records without it are ignored by the metric.
+	Attribute string `mapstructure:"attribute"`
+
+	// Buckets are the explicit boundaries of the histogram buckets.
+	Buckets []float64 `mapstructure:"buckets"`
+
+	// GroupBy are the attributes the data points of the metric are grouped by,
+	// looked up in the attributes of the log records, then of their resource.
+	GroupBy []string `mapstructure:"group_by"`
+}
+
+var _ config.Connector = (*Config)(nil)
+
+// Validate checks if the connector configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Interval <= 0 {
+		return fmt.Errorf("invalid interval %v, must be positive", cfg.Interval)
+	}
+	if cfg.AggregationTemporality != temporalityCumulative && cfg.AggregationTemporality != temporalityDelta {
+		return fmt.Errorf("invalid aggregation_temporality %q, must be %q or %q", cfg.AggregationTemporality, temporalityCumulative, temporalityDelta)
+	}
+	if len(cfg.Metrics) == 0 {
+		return errors.New("at least one metric must be configured")
+	}
+	if cfg.MaxSeries <= 0 {
+		return fmt.Errorf("invalid max_series %d, must be positive", cfg.MaxSeries)
+	}
+	names := make(map[string]struct{}, len(cfg.Metrics))
+	for _, m := range cfg.Metrics {
+		if m.Name == "" {
+			return errors.New("metric name must not be empty")
+		}
+		if _, ok := names[m.Name]; ok {
+			return fmt.Errorf("duplicate metric %q", m.Name)
+		}
+		names[m.Name] = struct{}{}
+		if err := m.validate(); err != nil {
+			return fmt.Errorf("metric %q: %w", m.Name, err)
+		}
+	}
+	return nil
+}
+
+func (m *MetricConfig) validate() error {
+	switch m.Type {
+	case MetricTypeCount:
+		if m.Attribute != "" {
+			return errors.New("attribute is only used by sum and histogram metrics")
+		}
+	case MetricTypeSum, MetricTypeHistogram:
+		if m.Attribute == "" {
+			return fmt.Errorf("attribute is required by %s metrics", m.Type)
+		}
+	default:
+		return fmt.Errorf("invalid type %q, must be %q, %q or %q", m.Type, MetricTypeCount, MetricTypeSum, MetricTypeHistogram)
+	}
+	if m.Type != MetricTypeHistogram && len(m.Buckets) != 0 {
+		return errors.New("buckets are only used by histogram metrics")
+	}
+	for i := 1; i < len(m.Buckets); i++ {
+		if m.Buckets[i] <= m.Buckets[i-1] {
+			return fmt.Errorf("invalid buckets %v, must be strictly increasing", m.Buckets)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logmetricsconnector
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalConnector(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalConnector(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ConnectorSettings:      config.NewConnectorSettings(config.NewComponentID(typeStr)),
+			Interval:               10 * time.Second,
+			AggregationTemporality: "delta",
+			Metrics: []MetricConfig{
+				{
+					Name:        "http.server.requests",
+					Description: "Number of requests served.",
+					Unit:        "{requests}",
+					Type:        MetricTypeCount,
+					GroupBy:     []string{"http.method", "http.status_code", "service.name"},
+				},
+				{
+					Name:        "http.server.duration",
+					Description: "Duration of the requests served.",
+					Unit:        "ms",
+					Type:        MetricTypeHistogram,
+					Attribute:   "http.duration_ms",
+					Buckets:     []float64{10, 100, 1000},
+					GroupBy:     []string{"http.method"},
+				},
+				{
+					Name:      "http.server.response_size",
+					Unit:      "By",
+					Type:      MetricTypeSum,
+					Attribute: "http.response_content_length",
+				},
+			},
+			ResourceAttributes: []string{"service.name", "deployment.environment"},
+			MaxSeries:          500,
+		}, cfg)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:     "invalid interval",
+			modify:   func(cfg *Config) { cfg.Interval = 0 },
+			expected: "invalid interval 0s, must be positive",
+		},
+		{
+			name:     "invalid max series",
+			modify:   func(cfg *Config) { cfg.MaxSeries = 0 },
+			expected: "invalid max_series 0, must be positive",
+		},
+		{
+			name:     "invalid temporality",
+			modify:   func(cfg *Config) { cfg.AggregationTemporality = "gauge" },
+			expected: `invalid aggregation_temporality "gauge", must be "cumulative" or "delta"`,
+		},
+		{
+			name:     "no metrics",
+			modify:   func(cfg *Config) { cfg.Metrics = nil },
+			expected: "at least one metric must be configured",
+		},
+		{
+			name:     "missing name",
+			modify:   func(cfg *Config) { cfg.Metrics[0].Name = "" },
+			expected: "metric name must not be empty",
+		},
+		{
+			name:     "duplicate name",
+			modify:   func(cfg *Config) { cfg.Metrics = append(cfg.Metrics, cfg.Metrics[0]) },
+			expected: `duplicate metric "log.record.count"`,
+		},
+		{
+			name:     "invalid type",
+			modify:   func(cfg *Config) { cfg.Metrics[0].Type = "gauge" },
+			expected: `metric "log.record.count": invalid type "gauge", must be "count", "sum" or "histogram"`,
+		},
+		{
+			name:     "count with attribute",
+			modify:   func(cfg *Config) { cfg.Metrics[0].Attribute = "size" },
+			expected: `metric "log.record.count": attribute is only used by sum and histogram metrics`,
+		},
+		{
+			name:     "sum without attribute",
+			modify:   func(cfg *Config) { cfg.Metrics[0].Type = MetricTypeSum },
+			expected: `metric "log.record.count": attribute is required by sum metrics`,
+		},
+		{
+			name: "sum with buckets",
+			modify: func(cfg *Config) {
+				cfg.Metrics[0].Type = MetricTypeSum
+				cfg.Metrics[0].Attribute = "size"
+				cfg.Metrics[0].Buckets = []float64{1, 2}
+			},
+			expected: `metric "log.record.count": buckets are only used by histogram metrics`,
+		},
+		{
+			name: "unsorted buckets",
+			modify: func(cfg *Config) {
+				cfg.Metrics[0].Type = MetricTypeHistogram
+				cfg.Metrics[0].Attribute = "size"
+				cfg.Metrics[0].Buckets = []float64{1, 1}
+			},
+			expected: `metric "log.record.count": invalid buckets [1 1], must be strictly increasing`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
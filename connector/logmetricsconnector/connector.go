@@ -0,0 +1,402 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logmetricsconnector // import "go.opentelemetry.io/collector/connector/logmetricsconnector"
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const scopeName = "go.opentelemetry.io/collector/connector/logmetricsconnector"
+
+// logMetrics aggregates the log records it consumes in the series of the
+// configured metrics, and emits them to the next consumer on an interval.
+type logMetrics struct {
+	logger             *zap.Logger
+	interval           time.Duration
+	temporality        pmetric.AggregationTemporality
+	metrics            []MetricConfig
+	resourceAttributes []string
+	maxSeries          int
+	next               consumer.Metrics
+	now                func() time.Time
+
+	mu sync.Mutex
+	// resources are the series of the metrics of each resource, keyed by the resource attribute values.
+	resources map[string]*resourceMetrics
+	// seriesCount is the number of series of each metric, across the resources.
+	seriesCount []int
+	// droppedSeries is the number of series not created since the last emission, as they exceeded maxSeries.
+	droppedSeries int
+	// lastEmit is the start time of the delta data points.
+	lastEmit pcommon.Timestamp
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// resourceMetrics are the series of the metrics derived from the log records of the same resource.
+type resourceMetrics struct {
+	attrs pcommon.Map
+	// series of each metric, keyed by their grouping attribute values.
+	series []map[string]*series
+}
+
+// series is the aggregated state of the data points with the same grouping attributes.
+type series struct {
+	attrs     pcommon.Map
+	startTime pcommon.Timestamp
+	count     uint64
+	sum       float64
+	min       float64
+	max       float64
+	buckets   []uint64
+}
+
+func newLogMetrics(logger *zap.Logger, cfg *Config, next consumer.Metrics) *logMetrics {
+	temporality := pmetric.AggregationTemporalityCumulative
+	if cfg.AggregationTemporality == temporalityDelta {
+		temporality = pmetric.AggregationTemporalityDelta
+	}
+	metrics := make([]MetricConfig, len(cfg.Metrics))
+	for i, m := range cfg.Metrics {
+		if m.Type == MetricTypeHistogram && len(m.Buckets) == 0 {
+			m.Buckets = defaultBuckets
+		}
+		metrics[i] = m
+	}
+	return &logMetrics{
+		logger:             logger,
+		interval:           cfg.Interval,
+		temporality:        temporality,
+		metrics:            metrics,
+		resourceAttributes: cfg.ResourceAttributes,
+		maxSeries:          cfg.MaxSeries,
+		next:               next,
+		now:                time.Now,
+		resources:          map[string]*resourceMetrics{},
+		seriesCount:        make([]int, len(metrics)),
+		done:               make(chan struct{}),
+	}
+}
+
+// Start starts emitting the metrics on the configured interval.
+func (lm *logMetrics) Start(context.Context, component.Host) error {
+	lm.lastEmit = pcommon.NewTimestampFromTime(lm.now())
+	lm.wg.Add(1)
+	go func() {
+		defer lm.wg.Done()
+		ticker := time.NewTicker(lm.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				lm.flush(context.Background())
+			case <-lm.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops emitting the metrics on an interval, and emits them one last time.
+func (lm *logMetrics) Shutdown(ctx context.Context) error {
+	select {
+	case <-lm.done:
+		return nil
+	default:
+	}
+	close(lm.done)
+	lm.wg.Wait()
+	lm.flush(ctx)
+	return nil
+}
+
+// Capabilities implements the consumer.Logs interface.
+func (lm *logMetrics) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeLogs aggregates the log records in the series of the metrics.
+func (lm *logMetrics) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	now := pcommon.NewTimestampFromTime(lm.now())
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resAttrs := rl.Resource().Attributes()
+		key, rm := lm.resourceMetrics(resAttrs)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				attrs := lrs.At(k).Attributes()
+				for m := range lm.metrics {
+					lm.record(m, rm, attrs, resAttrs, now)
+				}
+			}
+		}
+		if rm.empty() {
+			delete(lm.resources, key)
+		}
+	}
+	return nil
+}
+
+// resourceMetrics returns the series of the resource with the given attributes, and
+// their key, keeping the configured resource attributes, or all of them.
+func (lm *logMetrics) resourceMetrics(resAttrs pcommon.Map) (string, *resourceMetrics) {
+	attrs := pcommon.NewMap()
+	if len(lm.resourceAttributes) == 0 {
+		resAttrs.CopyTo(attrs)
+	} else {
+		for _, name := range lm.resourceAttributes {
+			if v, ok := resAttrs.Get(name); ok {
+				v.CopyTo(attrs.PutEmpty(name))
+			}
+		}
+	}
+	attrs.Sort()
+
+	var key strings.Builder
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		key.WriteString(k)
+		key.WriteByte(0)
+		key.WriteByte(byte(v.Type()))
+		key.WriteString(v.AsString())
+		key.WriteByte(0)
+		return true
+	})
+	rm, ok := lm.resources[key.String()]
+	if !ok {
+		rm = &resourceMetrics{attrs: attrs, series: make([]map[string]*series, len(lm.metrics))}
+		for i := range rm.series {
+			rm.series[i] = map[string]*series{}
+		}
+		lm.resources[key.String()] = rm
+	}
+	return key.String(), rm
+}
+
+func (rm *resourceMetrics) empty() bool {
+	for _, s := range rm.series {
+		if len(s) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// record aggregates a log record in the series of the metric matching its
+// grouping attributes. The log records without a numeric value for the
+// attribute of a sum or histogram are ignored, and so are the ones which would
+// add a series beyond the maximum number of series of the metric.
+func (lm *logMetrics) record(i int, rm *resourceMetrics, attrs, resAttrs pcommon.Map, now pcommon.Timestamp) {
+	m := &lm.metrics[i]
+	var value float64
+	if m.Type != MetricTypeCount {
+		v, ok := lookup(m.Attribute, attrs, resAttrs)
+		if !ok {
+			return
+		}
+		if value, ok = numericValue(v); !ok {
+			return
+		}
+	}
+
+	var key strings.Builder
+	values := make([]pcommon.Value, len(m.GroupBy))
+	for i, name := range m.GroupBy {
+		if v, ok := lookup(name, attrs, resAttrs); ok {
+			values[i] = v
+			key.WriteByte(byte(v.Type()) + 1)
+			key.WriteString(v.AsString())
+		}
+		key.WriteByte(0)
+	}
+	s, ok := rm.series[i][key.String()]
+	if !ok {
+		if lm.seriesCount[i] >= lm.maxSeries {
+			lm.droppedSeries++
+			return
+		}
+		lm.seriesCount[i]++
+		s = &series{attrs: pcommon.NewMap(), startTime: now, min: math.Inf(1), max: math.Inf(-1)}
+		for i, name := range m.GroupBy {
+			if values[i] != (pcommon.Value{}) {
+				values[i].CopyTo(s.attrs.PutEmpty(name))
+			}
+		}
+		if m.Type == MetricTypeHistogram {
+			s.buckets = make([]uint64, len(m.Buckets)+1)
+		}
+		rm.series[i][key.String()] = s
+	}
+
+	s.count++
+	s.sum += value
+	if m.Type == MetricTypeHistogram {
+		s.min = math.Min(s.min, value)
+		s.max = math.Max(s.max, value)
+		s.buckets[sort.SearchFloat64s(m.Buckets, value)]++
+	}
+}
+
+// lookup returns the value of the attribute of a log record, or else of its resource.
+func lookup(name string, attrs, resAttrs pcommon.Map) (pcommon.Value, bool) {
+	if v, ok := attrs.Get(name); ok {
+		return v, true
+	}
+	return resAttrs.Get(name)
+}
+
+func numericValue(v pcommon.Value) (float64, bool) {
+	switch v.Type() {
+	case pcommon.ValueTypeInt:
+		return float64(v.Int()), true
+	case pcommon.ValueTypeDouble:
+		return v.Double(), !math.IsNaN(v.Double())
+	case pcommon.ValueTypeStr:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v.Str()), 64)
+		return f, err == nil && !math.IsNaN(f)
+	default:
+		return 0, false
+	}
+}
+
+// flush emits the metrics to the next consumer. The series of delta metrics are reset.
+func (lm *logMetrics) flush(ctx context.Context) {
+	md, dropped := lm.collect()
+	if dropped > 0 {
+		lm.logger.Warn("Ignored log records which would exceed the maximum number of series of the metrics",
+			zap.Int("max_series", lm.maxSeries), zap.Int("dropped_series", dropped))
+	}
+	if md.MetricCount() == 0 {
+		return
+	}
+	if err := lm.next.ConsumeMetrics(ctx, md); err != nil {
+		lm.logger.Warn("Failed to emit the metrics derived from the logs", zap.Error(err))
+	}
+}
+
+// collect returns the metrics of all the resources, and the number of series dropped since the last call.
+func (lm *logMetrics) collect() (pmetric.Metrics, int) {
+	now := pcommon.NewTimestampFromTime(lm.now())
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	resKeys := make([]string, 0, len(lm.resources))
+	for key := range lm.resources {
+		resKeys = append(resKeys, key)
+	}
+	sort.Strings(resKeys)
+
+	md := pmetric.NewMetrics()
+	for _, resKey := range resKeys {
+		rm := lm.resources[resKey]
+		dest := md.ResourceMetrics().AppendEmpty()
+		rm.attrs.CopyTo(dest.Resource().Attributes())
+		sm := dest.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(scopeName)
+		for i := range lm.metrics {
+			if len(rm.series[i]) > 0 {
+				lm.collectMetric(&lm.metrics[i], rm.series[i], sm.Metrics().AppendEmpty(), now)
+			}
+		}
+	}
+	if lm.temporality == pmetric.AggregationTemporalityDelta {
+		lm.resources = map[string]*resourceMetrics{}
+		lm.seriesCount = make([]int, len(lm.metrics))
+	}
+	dropped := lm.droppedSeries
+	lm.droppedSeries = 0
+	lm.lastEmit = now
+	return md, dropped
+}
+
+// collectMetric sets the data points of the series of a metric in dest.
+func (lm *logMetrics) collectMetric(m *MetricConfig, seriesByKey map[string]*series, dest pmetric.Metric, now pcommon.Timestamp) {
+	keys := make([]string, 0, len(seriesByKey))
+	for key := range seriesByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	dest.SetName(m.Name)
+	dest.SetDescription(m.Description)
+	dest.SetUnit(m.Unit)
+	switch m.Type {
+	case MetricTypeCount, MetricTypeSum:
+		sum := dest.SetEmptySum()
+		sum.SetAggregationTemporality(lm.temporality)
+		sum.SetIsMonotonic(m.Type == MetricTypeCount)
+		for _, key := range keys {
+			s := seriesByKey[key]
+			dp := sum.DataPoints().AppendEmpty()
+			lm.setTimestamps(dp, s, now)
+			s.attrs.CopyTo(dp.Attributes())
+			if m.Type == MetricTypeCount {
+				dp.SetIntValue(int64(s.count))
+			} else {
+				dp.SetDoubleValue(s.sum)
+			}
+		}
+	case MetricTypeHistogram:
+		hist := dest.SetEmptyHistogram()
+		hist.SetAggregationTemporality(lm.temporality)
+		for _, key := range keys {
+			s := seriesByKey[key]
+			dp := hist.DataPoints().AppendEmpty()
+			lm.setTimestamps(dp, s, now)
+			s.attrs.CopyTo(dp.Attributes())
+			dp.SetCount(s.count)
+			dp.SetSum(s.sum)
+			dp.SetMin(s.min)
+			dp.SetMax(s.max)
+			dp.ExplicitBounds().FromRaw(m.Buckets)
+			dp.BucketCounts().FromRaw(s.buckets)
+		}
+	}
+}
+
+// dataPoint is implemented by the data points of sums and histograms.
+type dataPoint interface {
+	SetStartTimestamp(pcommon.Timestamp)
+	SetTimestamp(pcommon.Timestamp)
+}
+
+func (lm *logMetrics) setTimestamps(dp dataPoint, s *series, now pcommon.Timestamp) {
+	if lm.temporality == pmetric.AggregationTemporalityDelta {
+		dp.SetStartTimestamp(lm.lastEmit)
+	} else {
+		dp.SetStartTimestamp(s.startTime)
+	}
+	dp.SetTimestamp(now)
+}
@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logmetricsconnector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var (
+	startTime = time.Unix(1000, 0)
+	emitTime  = time.Unix(1060, 0)
+)
+
+func testConfig(temporality string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AggregationTemporality = temporality
+	cfg.Metrics = []MetricConfig{
+		{Name: "requests", Type: MetricTypeCount, GroupBy: []string{"method", "service.name"}},
+		{Name: "bytes", Type: MetricTypeSum, Attribute: "size"},
+		{Name: "duration", Type: MetricTypeHistogram, Attribute: "duration", Buckets: []float64{10, 100}},
+	}
+	cfg.ResourceAttributes = []string{"cloud.region"}
+	return cfg
+}
+
+func newTestLogMetrics(cfg *Config, sink *consumertest.MetricsSink) *logMetrics {
+	lm := newLogMetrics(zap.NewNop(), cfg, sink)
+	lm.now = func() time.Time { return startTime }
+	return lm
+}
+
+// testLogs returns logs of two resources of the same region, with records of the given attributes.
+func testLogs(records ...map[string]interface{}) plog.Logs {
+	ld := plog.NewLogs()
+	for i, service := range []string{"api", "web"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.Resource().Attributes().PutStr("cloud.region", "eu")
+		lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+		for j := i; j < len(records); j += 2 {
+			lrs.AppendEmpty().Attributes().FromRaw(records[j])
+		}
+	}
+	return ld
+}
+
+func findMetric(t *testing.T, md pmetric.Metrics, name string) pmetric.Metric {
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		if ms.At(i).Name() == name {
+			return ms.At(i)
+		}
+	}
+	t.Fatalf("metric %q not found", name)
+	return pmetric.Metric{}
+}
+
+func TestLogMetricsCumulative(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	lm := newTestLogMetrics(testConfig(temporalityCumulative), sink)
+
+	require.NoError(t, lm.ConsumeLogs(context.Background(), testLogs(
+		map[string]interface{}{"method": "GET", "size": int64(100), "duration": 5.0},
+		map[string]interface{}{"method": "GET", "size": "50", "duration": int64(50)},
+		map[string]interface{}{"method": "POST", "size": "none", "duration": 500.0},
+		map[string]interface{}{"duration": int64(100)},
+	)))
+	lm.now = func() time.Time { return emitTime }
+	lm.flush(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	// The resources of the same region are merged, with only the configured resource attributes.
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, map[string]interface{}{"cloud.region": "eu"}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	assert.Equal(t, scopeName, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Scope().Name())
+
+	requests := findMetric(t, md, "requests").Sum()
+	assert.True(t, requests.IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, requests.AggregationTemporality())
+	counts := map[string]int64{}
+	for i := 0; i < requests.DataPoints().Len(); i++ {
+		dp := requests.DataPoints().At(i)
+		assert.Equal(t, pcommon.NewTimestampFromTime(startTime), dp.StartTimestamp())
+		assert.Equal(t, pcommon.NewTimestampFromTime(emitTime), dp.Timestamp())
+		method := "-"
+		if v, ok := dp.Attributes().Get("method"); ok {
+			method = v.Str()
+		}
+		service, _ := dp.Attributes().Get("service.name")
+		counts[method+" "+service.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"GET api": 1, "GET web": 1, "POST api": 1, "- web": 1}, counts)
+
+	// Records without a numeric value are ignored by the sum.
+	bytes := findMetric(t, md, "bytes").Sum()
+	assert.False(t, bytes.IsMonotonic())
+	require.Equal(t, 1, bytes.DataPoints().Len())
+	assert.Equal(t, 150.0, bytes.DataPoints().At(0).DoubleValue())
+	assert.Equal(t, 0, bytes.DataPoints().At(0).Attributes().Len())
+
+	duration := findMetric(t, md, "duration").Histogram()
+	require.Equal(t, 1, duration.DataPoints().Len())
+	hdp := duration.DataPoints().At(0)
+	assert.Equal(t, uint64(4), hdp.Count())
+	assert.Equal(t, 655.0, hdp.Sum())
+	assert.Equal(t, 5.0, hdp.Min())
+	assert.Equal(t, 500.0, hdp.Max())
+	assert.Equal(t, []float64{10, 100}, hdp.ExplicitBounds().AsRaw())
+	assert.Equal(t, []uint64{1, 2, 1}, hdp.BucketCounts().AsRaw())
+
+	// Cumulative series keep their state across emissions.
+	require.NoError(t, lm.ConsumeLogs(context.Background(), testLogs(
+		map[string]interface{}{"method": "GET", "size": 10.5},
+	)))
+	lm.flush(context.Background())
+	require.Len(t, sink.AllMetrics(), 2)
+	md = sink.AllMetrics()[1]
+	assert.Equal(t, 4, findMetric(t, md, "requests").Sum().DataPoints().Len())
+	assert.Equal(t, 160.5, findMetric(t, md, "bytes").Sum().DataPoints().At(0).DoubleValue())
+	assert.Equal(t, uint64(4), findMetric(t, md, "duration").Histogram().DataPoints().At(0).Count())
+}
+
+func TestLogMetricsDelta(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	lm := newTestLogMetrics(testConfig(temporalityDelta), sink)
+	require.NoError(t, lm.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, lm.ConsumeLogs(context.Background(), testLogs(
+		map[string]interface{}{"method": "GET", "size": int64(100)},
+	)))
+	lm.now = func() time.Time { return emitTime }
+	lm.flush(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	md := sink.AllMetrics()[0]
+	assert.Equal(t, 2, md.MetricCount())
+	requests := findMetric(t, md, "requests").Sum()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, requests.AggregationTemporality())
+	require.Equal(t, 1, requests.DataPoints().Len())
+	assert.Equal(t, pcommon.NewTimestampFromTime(startTime), requests.DataPoints().At(0).StartTimestamp())
+	assert.Equal(t, pcommon.NewTimestampFromTime(emitTime), requests.DataPoints().At(0).Timestamp())
+
+	// Delta series are reset after each emission, and nothing is emitted without data.
+	lm.flush(context.Background())
+	assert.Len(t, sink.AllMetrics(), 1)
+
+	require.NoError(t, lm.ConsumeLogs(context.Background(), testLogs(
+		map[string]interface{}{"method": "GET", "size": int64(10)},
+	)))
+	lm.now = func() time.Time { return emitTime.Add(time.Minute) }
+	require.NoError(t, lm.Shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 2)
+	bytes := findMetric(t, sink.AllMetrics()[1], "bytes").Sum().DataPoints().At(0)
+	assert.Equal(t, 10.0, bytes.DoubleValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(emitTime), bytes.StartTimestamp())
+}
+
+func TestLogMetricsResources(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := testConfig(temporalityCumulative)
+	cfg.ResourceAttributes = nil
+	lm := newTestLogMetrics(cfg, sink)
+
+	require.NoError(t, lm.ConsumeLogs(context.Background(), testLogs(
+		map[string]interface{}{"size": int64(100)},
+		map[string]interface{}{"size": int64(50)},
+		map[string]interface{}{"size": int64(10)},
+	)))
+	lm.flush(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	rms := sink.AllMetrics()[0].ResourceMetrics()
+	require.Equal(t, 2, rms.Len())
+	sums := map[string]float64{}
+	for i := 0; i < rms.Len(); i++ {
+		service, _ := rms.At(i).Resource().Attributes().Get("service.name")
+		ms := rms.At(i).ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			if ms.At(j).Name() == "bytes" {
+				sums[service.Str()] = ms.At(j).Sum().DataPoints().At(0).DoubleValue()
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{"api": 110, "web": 50}, sums)
+}
+
+func TestLogMetricsMaxSeries(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	sink := new(consumertest.MetricsSink)
+	cfg := testConfig(temporalityDelta)
+	cfg.MaxSeries = 2
+	lm := newLogMetrics(zap.New(core), cfg, sink)
+
+	require.NoError(t, lm.ConsumeLogs(context.Background(), testLogs(
+		map[string]interface{}{"method": "GET"},
+		map[string]interface{}{"method": "GET"},
+		map[string]interface{}{"method": "POST"},
+	)))
+	lm.flush(context.Background())
+	require.Len(t, sink.AllMetrics(), 1)
+	requests := findMetric(t, sink.AllMetrics()[0], "requests").Sum()
+	assert.Equal(t, 2, requests.DataPoints().Len())
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, int64(1), logs.All()[0].ContextMap()["dropped_series"])
+
+	// The delta series are reset on emission, making room for new ones.
+	require.NoError(t, lm.ConsumeLogs(context.Background(), testLogs(
+		map[string]interface{}{"method": "POST"},
+	)))
+	lm.flush(context.Background())
+	require.Len(t, sink.AllMetrics(), 2)
+	assert.Equal(t, 1, findMetric(t, sink.AllMetrics()[1], "requests").Sum().DataPoints().Len())
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestLogMetricsInterval(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := testConfig(temporalityCumulative)
+	cfg.Interval = 10 * time.Millisecond
+	lm := newLogMetrics(zap.NewNop(), cfg, sink)
+	require.NoError(t, lm.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, lm.ConsumeLogs(context.Background(), testLogs(map[string]interface{}{})))
+	assert.Eventually(t, func() bool { return len(sink.AllMetrics()) >= 2 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, lm.Shutdown(context.Background()))
+	require.NoError(t, lm.Shutdown(context.Background()))
+}
+
+func TestLogMetricsConsumerError(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	lm := newLogMetrics(zap.New(core), testConfig(temporalityCumulative), consumertest.NewErr(errors.New("unavailable")))
+	require.NoError(t, lm.ConsumeLogs(context.Background(), testLogs(map[string]interface{}{})))
+	lm.flush(context.Background())
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "unavailable", logs.All()[0].ContextMap()["error"])
+}
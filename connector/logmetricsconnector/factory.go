@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logmetricsconnector // import "go.opentelemetry.io/collector/connector/logmetricsconnector"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of connector "type" in configuration.
+	typeStr = "logmetrics"
+	// The stability level of the connector.
+	stability = component.StabilityLevelInDevelopment
+
+	defaultInterval  = 60 * time.Second
+	defaultMaxSeries = 1000
+)
+
+// NewFactory returns a factory for the logmetrics connector.
+func NewFactory() component.ConnectorFactory {
+	return component.NewConnectorFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithLogsToMetricsConnector(createLogsToMetrics, stability),
+	)
+}
+
+func createDefaultConfig() config.Connector {
+	return &Config{
+		ConnectorSettings:      config.NewConnectorSettings(config.NewComponentID(typeStr)),
+		Interval:               defaultInterval,
+		AggregationTemporality: temporalityCumulative,
+		MaxSeries:              defaultMaxSeries,
+		Metrics: []MetricConfig{
+			{
+				Name:        "log.record.count",
+				Description: "Number of log records.",
+				Unit:        "{records}",
+				Type:        MetricTypeCount,
+			},
+		},
+	}
+}
+
+func createLogsToMetrics(_ context.Context, set component.ConnectorCreateSettings, cfg config.Connector, nextConsumer consumer.Metrics) (component.LogsConnector, error) {
+	return newLogMetrics(set.Logger, cfg.(*Config), nextConsumer), nil
+}
@@ -160,7 +160,7 @@ func newBaseExporter(cfg config.Exporter, set component.ExporterCreateSettings,
 	be := &baseExporter{}
 
 	be.obsrep = newObsExporter(obsreport.ExporterSettings{ExporterID: cfg.ID(), ExporterCreateSettings: set}, globalInstruments)
-	be.qrSender = newQueuedRetrySender(cfg.ID(), signal, bs.QueueSettings, bs.RetrySettings, reqUnmarshaler, &timeoutSender{cfg: bs.TimeoutSettings}, set.Logger)
+	be.qrSender = newQueuedRetrySender(cfg.ID(), signal, bs.QueueSettings, bs.RetrySettings, reqUnmarshaler, &timeoutSender{cfg: bs.TimeoutSettings}, set.TracerProvider.Tracer(cfg.ID().String()), set.Logger)
 	be.sender = be.qrSender
 	be.StartFunc = func(ctx context.Context, host component.Host) error {
 		// First start the wrapped exporter.
@@ -180,6 +180,11 @@ func newBaseExporter(cfg config.Exporter, set component.ExporterCreateSettings,
 	return be
 }
 
+// QueueState implements component.QueueStateExporter.
+func (be *baseExporter) QueueState() component.ExporterQueueState {
+	return be.qrSender.queueState()
+}
+
 // wrapConsumerSender wraps the consumer sender (the sender that uses retries and timeout) with the given wrapper.
 // This can be used to wrap with observability (create spans, record metrics) the consumer sender.
 func (be *baseExporter) wrapConsumerSender(f func(consumer requestSender) requestSender) {
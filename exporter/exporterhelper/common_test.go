@@ -16,12 +16,14 @@ package exporterhelper
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/codes"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -70,6 +72,17 @@ func checkStatus(t *testing.T, sd sdktrace.ReadOnlySpan, err error) {
 	}
 }
 
+// endedExportSpans returns the ended spans recorded by sr, except the spans of the send attempts.
+func endedExportSpans(sr *tracetest.SpanRecorder) []sdktrace.ReadOnlySpan {
+	var spans []sdktrace.ReadOnlySpan
+	for _, sd := range sr.Ended() {
+		if !strings.HasSuffix(sd.Name(), "/send") {
+			spans = append(spans, sd)
+		}
+	}
+	return spans
+}
+
 func nopTracePusher() consumer.ConsumeTracesFunc {
 	return func(ctx context.Context, ld ptrace.Traces) error {
 		return nil
@@ -241,7 +241,7 @@ func checkWrapSpanForLogsExporter(t *testing.T, sr *tracetest.SpanRecorder, trac
 	generateLogsTraffic(t, tracer, le, numRequests, wantError)
 
 	// Inspection time!
-	gotSpanData := sr.Ended()
+	gotSpanData := endedExportSpans(sr)
 	require.Equal(t, numRequests+1, len(gotSpanData))
 
 	parentSpan := gotSpanData[numRequests]
@@ -243,7 +243,7 @@ func checkWrapSpanForMetricsExporter(t *testing.T, sr *tracetest.SpanRecorder, t
 	generateMetricsTraffic(t, tracer, me, numRequests, wantError)
 
 	// Inspection time!
-	gotSpanData := sr.Ended()
+	gotSpanData := endedExportSpans(sr)
 	require.Equal(t, numRequests+1, len(gotSpanData))
 
 	parentSpan := gotSpanData[numRequests]
@@ -18,11 +18,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"go.opencensus.io/metric/metricdata"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -81,6 +83,8 @@ func (qCfg *QueueSettings) Validate() error {
 }
 
 type queuedRetrySender struct {
+	status             *exporterStatus
+	retryEnabled       bool
 	fullName           string
 	id                 config.ComponentID
 	signal             config.DataType
@@ -94,12 +98,15 @@ type queuedRetrySender struct {
 	requestUnmarshaler internal.RequestUnmarshaler
 }
 
-func newQueuedRetrySender(id config.ComponentID, signal config.DataType, qCfg QueueSettings, rCfg RetrySettings, reqUnmarshaler internal.RequestUnmarshaler, nextSender requestSender, logger *zap.Logger) *queuedRetrySender {
+func newQueuedRetrySender(id config.ComponentID, signal config.DataType, qCfg QueueSettings, rCfg RetrySettings, reqUnmarshaler internal.RequestUnmarshaler, nextSender requestSender, tracer trace.Tracer, logger *zap.Logger) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
 	sampledLogger := createSampledLogger(logger)
 	traceAttr := attribute.String(obsmetrics.ExporterKey, id.String())
 
+	status := &exporterStatus{}
 	qrs := &queuedRetrySender{
+		status:             status,
+		retryEnabled:       rCfg.Enabled,
 		fullName:           id.String(),
 		id:                 id,
 		signal:             signal,
@@ -112,6 +119,9 @@ func newQueuedRetrySender(id config.ComponentID, signal config.DataType, qCfg Qu
 
 	qrs.consumerSender = &retrySender{
 		traceAttribute: traceAttr,
+		tracer:         tracer,
+		spanName:       obsmetrics.ExporterPrefix + id.String() + obsmetrics.NameSep + "send",
+		status:         status,
 		cfg:            rCfg,
 		nextSender:     nextSender,
 		stopCh:         retryStopCh,
@@ -201,6 +211,7 @@ func (qrs *queuedRetrySender) start(ctx context.Context, host component.Host) er
 	if err := qrs.initializePersistentQueue(ctx, host); err != nil {
 		return err
 	}
+	qrs.status.start(host)
 
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item internal.Request) {
 		_ = qrs.consumerSender.send(item)
@@ -243,6 +254,83 @@ func (qrs *queuedRetrySender) shutdown() {
 	if qrs.queue != nil {
 		qrs.queue.Stop()
 	}
+	qrs.status.stop()
+}
+
+// queueState returns the current state of the sending queue and retries.
+func (qrs *queuedRetrySender) queueState() component.ExporterQueueState {
+	state := component.ExporterQueueState{
+		QueueEnabled: qrs.cfg.Enabled,
+		RetryEnabled: qrs.retryEnabled,
+	}
+	if qrs.cfg.Enabled {
+		state.QueueCapacity = qrs.cfg.QueueSize
+		if qrs.queue != nil {
+			state.QueueSize = qrs.queue.Size()
+		}
+	}
+	qrs.status.fillQueueState(&state)
+	return state
+}
+
+// exporterStatus reports the status of the exporter to the host when the result of the send
+// attempts changes: StatusRecoverableError when they fail, and StatusOK once they succeed again.
+// It also keeps the state of the retries exposed by the exporter.
+type exporterStatus struct {
+	mu          sync.Mutex
+	host        component.Host
+	status      component.Status
+	retrying    int
+	lastErr     error
+	lastErrTime time.Time
+}
+
+func (es *exporterStatus) start(host component.Host) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.host = host
+	// The host reports the exporter is OK once started.
+	es.status = component.StatusOK
+}
+
+func (es *exporterStatus) stop() {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.host = nil
+}
+
+func (es *exporterStatus) report(err error) {
+	status := component.StatusOK
+	if err != nil {
+		status = component.StatusRecoverableError
+	}
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.lastErr = err
+	if err != nil {
+		es.lastErrTime = time.Now()
+	}
+	if es.host == nil || es.status == status {
+		return
+	}
+	es.status = status
+	es.host.ReportComponentStatus(status, err)
+}
+
+// addRetrying adds delta to the number of requests waiting to be sent again.
+func (es *exporterStatus) addRetrying(delta int) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.retrying += delta
+}
+
+// fillQueueState fills the retries state of the exporter in state.
+func (es *exporterStatus) fillQueueState(state *component.ExporterQueueState) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	state.RetryingRequests = es.retrying
+	state.LastError = es.lastErr
+	state.LastErrorTime = es.lastErrTime
 }
 
 // RetrySettings defines configuration for retrying batches in case of export failure.
@@ -346,6 +434,9 @@ type onRequestHandlingFinishedFunc func(*zap.Logger, internal.Request, error) er
 
 type retrySender struct {
 	traceAttribute     attribute.KeyValue
+	tracer             trace.Tracer
+	spanName           string
+	status             *exporterStatus
 	cfg                RetrySettings
 	nextSender         requestSender
 	stopCh             chan struct{}
@@ -356,7 +447,7 @@ type retrySender struct {
 // send implements the requestSender interface
 func (rs *retrySender) send(req internal.Request) error {
 	if !rs.cfg.Enabled {
-		err := rs.nextSender.send(req)
+		err := rs.sendAttempt(req, 0)
 		if err != nil {
 			rs.logger.Error(
 				"Exporting failed. Try enabling retry_on_failure config option to retry on retryable errors",
@@ -385,7 +476,7 @@ func (rs *retrySender) send(req internal.Request) error {
 			"Sending request.",
 			trace.WithAttributes(rs.traceAttribute, attribute.Int64("retry_num", retryNum)))
 
-		err := rs.nextSender.send(req)
+		err := rs.sendAttempt(req, retryNum)
 		if err == nil {
 			return nil
 		}
@@ -432,16 +523,39 @@ func (rs *retrySender) send(req internal.Request) error {
 		retryNum++
 
 		// back-off, but get interrupted when shutting down or request is cancelled or timed out.
+		var waitErr error
+		rs.status.addRetrying(1)
 		select {
 		case <-req.Context().Done():
-			return fmt.Errorf("Request is cancelled or timed out %w", err)
+			waitErr = fmt.Errorf("Request is cancelled or timed out %w", err)
 		case <-rs.stopCh:
-			return fmt.Errorf("interrupted due to shutdown %w", err)
+			waitErr = fmt.Errorf("interrupted due to shutdown %w", err)
 		case <-time.After(backoffDelay):
 		}
+		rs.status.addRetrying(-1)
+		if waitErr != nil {
+			return waitErr
+		}
 	}
 }
 
+// sendAttempt sends the request to the next sender within a span, so that each attempt is traced.
+func (rs *retrySender) sendAttempt(req internal.Request, retryNum int64) error {
+	parentCtx := req.Context()
+	ctx, span := rs.tracer.Start(parentCtx, rs.spanName,
+		trace.WithAttributes(rs.traceAttribute, attribute.Int64("retry_num", retryNum)))
+	defer span.End()
+
+	req.SetContext(ctx)
+	err := rs.nextSender.send(req)
+	req.SetContext(parentCtx)
+	rs.status.report(err)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
 // max returns the larger of x or y.
 func max(x, y time.Duration) time.Duration {
 	if x < y {
@@ -28,6 +28,10 @@ import (
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/metric/metricproducer"
 	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/atomic"
 
 	"go.opentelemetry.io/collector/component"
@@ -287,6 +291,105 @@ func TestQueuedRetry_RetryOnError(t *testing.T) {
 	require.Zero(t, be.qrSender.queue.Size())
 }
 
+func TestQueuedRetry_RetrySpans(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.Enabled = false
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 0
+	sr := new(tracetest.SpanRecorder)
+	set := componenttest.NewNopExporterCreateSettings()
+	set.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	be := newBaseExporter(&defaultExporterCfg, set, fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, be.Shutdown(context.Background()))
+	})
+
+	ctx, parent := set.TracerProvider.Tracer("test").Start(context.Background(), "export")
+	mockR := newMockRequest(ctx, 2, errors.New("transient error"))
+	require.NoError(t, be.sender.send(mockR))
+	parent.End()
+	mockR.checkNumRequests(t, 2)
+
+	var attempts []sdktrace.ReadOnlySpan
+	for _, span := range sr.Ended() {
+		if span.Name() == "exporter/test/send" {
+			attempts = append(attempts, span)
+		}
+	}
+	require.Len(t, attempts, 2)
+	for i, span := range attempts {
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+		assert.Contains(t, span.Attributes(), attribute.Int64("retry_num", int64(i)))
+	}
+	assert.Equal(t, codes.Error, attempts[0].Status().Code)
+	assert.Equal(t, codes.Unset, attempts[1].Status().Code)
+}
+
+type statusHost struct {
+	component.Host
+	mu       sync.Mutex
+	statuses []component.Status
+}
+
+func (h *statusHost) ReportComponentStatus(status component.Status, _ error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.statuses = append(h.statuses, status)
+}
+
+func TestQueuedRetry_ReportStatus(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.Enabled = false
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = 0
+	be := newBaseExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, be.Start(context.Background(), host))
+
+	// Only the changes of status are reported.
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, errors.New("transient error"))))
+	require.Error(t, be.sender.send(newMockRequest(context.Background(), 2, consumererror.NewPermanent(errors.New("bad data")))))
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+	require.NoError(t, be.Shutdown(context.Background()))
+
+	assert.Equal(t, []component.Status{
+		component.StatusRecoverableError,
+		component.StatusOK,
+		component.StatusRecoverableError,
+		component.StatusOK,
+	}, host.statuses)
+}
+
+func TestQueuedRetry_QueueState(t *testing.T) {
+	qCfg := NewDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.QueueSize = 10
+	rCfg := NewDefaultRetrySettings()
+	rCfg.InitialInterval = time.Minute
+	be := newBaseExporter(&defaultExporterCfg, componenttest.NewNopExporterCreateSettings(), fromOptions(WithRetry(rCfg), WithQueue(qCfg)), "", nopRequestUnmarshaler())
+	assert.Equal(t, component.ExporterQueueState{QueueEnabled: true, QueueCapacity: 10, RetryEnabled: true}, be.QueueState())
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+
+	// The first request waits to be retried, and blocks the only consumer of the queue.
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, errors.New("transient error"))))
+	assert.Eventually(t, func() bool {
+		return be.QueueState().RetryingRequests == 1
+	}, time.Second, time.Millisecond)
+	require.NoError(t, be.sender.send(newMockRequest(context.Background(), 2, nil)))
+
+	state := be.QueueState()
+	assert.Equal(t, 1, state.QueueSize)
+	assert.EqualError(t, state.LastError, "transient error")
+	assert.False(t, state.LastErrorTime.IsZero())
+
+	require.NoError(t, be.Shutdown(context.Background()))
+	state = be.QueueState()
+	assert.Equal(t, 0, state.QueueSize)
+	assert.Equal(t, 0, state.RetryingRequests)
+}
+
 func TestQueuedRetry_DropOnFull(t *testing.T) {
 	qCfg := NewDefaultQueueSettings()
 	qCfg.QueueSize = 0
@@ -243,7 +243,7 @@ func checkWrapSpanForTracesExporter(t *testing.T, sr *tracetest.SpanRecorder, tr
 	generateTraceTraffic(t, tracer, te, numRequests, wantError)
 
 	// Inspection time!
-	gotSpanData := sr.Ended()
+	gotSpanData := endedExportSpans(sr)
 	require.Equal(t, numRequests+1, len(gotSpanData))
 
 	parentSpan := gotSpanData[numRequests]
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+)
+
+// Config defines configuration for the file exporter.
+type Config struct {
+	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path of the file the data is written to.
+	Path string `mapstructure:"path"`
+
+	// Format of the records written to the file; options are json, proto.
+	Format string `mapstructure:"format"`
+
+	// Compression of the file; options are none, gzip, zstd.
+	Compression configcompression.CompressionType `mapstructure:"compression"`
+
+	// Rotation configures when the file is rotated.
+	Rotation RotationSettings `mapstructure:"rotation"`
+}
+
+// RotationSettings defines when the file is rotated, and how many rotated files are kept.
+type RotationSettings struct {
+	// MaxSizeMiB is the size in MiB the file is rotated at, 0 disables rotation by size.
+	MaxSizeMiB int `mapstructure:"max_size_mib"`
+
+	// Interval is the age the file is rotated at, 0 disables rotation by time.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MaxBackups is the number of rotated files kept, 0 keeps all of them.
+	MaxBackups int `mapstructure:"max_backups"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("\"path\" is required when using the \"file\" exporter")
+	}
+	if err := otlpfile.Format(cfg.Format).Validate(); err != nil {
+		return err
+	}
+	if err := otlpfile.ValidateCompression(cfg.Compression); err != nil {
+		return err
+	}
+	if cfg.Rotation.MaxSizeMiB < 0 {
+		return fmt.Errorf("invalid rotation max_size_mib %d, must not be negative", cfg.Rotation.MaxSizeMiB)
+	}
+	if cfg.Rotation.Interval < 0 {
+		return fmt.Errorf("invalid rotation interval %v, must not be negative", cfg.Rotation.Interval)
+	}
+	if cfg.Rotation.MaxBackups < 0 {
+		return fmt.Errorf("invalid rotation max_backups %d, must not be negative", cfg.Rotation.MaxBackups)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalExporter(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalExporter(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+			Path:             "/var/lib/otelcol/capture.jsonl.gz",
+			Format:           "proto",
+			Compression:      configcompression.Gzip,
+			Rotation: RotationSettings{
+				MaxSizeMiB: 10,
+				Interval:   time.Hour,
+				MaxBackups: 24,
+			},
+		}, cfg)
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected string
+	}{
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:     "missing path",
+			modify:   func(cfg *Config) { cfg.Path = "" },
+			expected: `"path" is required when using the "file" exporter`,
+		},
+		{
+			name:     "unsupported format",
+			modify:   func(cfg *Config) { cfg.Format = "yaml" },
+			expected: `format "yaml" is not supported`,
+		},
+		{
+			name:     "unsupported compression",
+			modify:   func(cfg *Config) { cfg.Compression = configcompression.Snappy },
+			expected: `compression "snappy" is not supported`,
+		},
+		{
+			name:     "invalid max size",
+			modify:   func(cfg *Config) { cfg.Rotation.MaxSizeMiB = -1 },
+			expected: "invalid rotation max_size_mib -1, must not be negative",
+		},
+		{
+			name:     "invalid interval",
+			modify:   func(cfg *Config) { cfg.Rotation.Interval = -time.Second },
+			expected: "invalid rotation interval -1s, must not be negative",
+		},
+		{
+			name:     "invalid max backups",
+			modify:   func(cfg *Config) { cfg.Rotation.MaxBackups = -1 },
+			expected: "invalid rotation max_backups -1, must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Path = "capture.jsonl"
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/internal/rotatingfile"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// fileExporter writes the data of all the pipelines it is in to the same file.
+type fileExporter struct {
+	cfg  *Config
+	file *rotatingFile
+}
+
+func newFileExporter(cfg *Config) *fileExporter {
+	return &fileExporter{cfg: cfg}
+}
+
+// Start opens the file.
+func (e *fileExporter) Start(context.Context, component.Host) error {
+	file, err := openRotatingFile(rotatingFileSettings{
+		Settings: rotatingfile.Settings{
+			Path:       e.cfg.Path,
+			MaxSize:    int64(e.cfg.Rotation.MaxSizeMiB) << 20,
+			Interval:   e.cfg.Rotation.Interval,
+			MaxBackups: e.cfg.Rotation.MaxBackups,
+		},
+		format:      otlpfile.Format(e.cfg.Format),
+		compression: e.cfg.Compression,
+	}, time.Now)
+	if err != nil {
+		return err
+	}
+	e.file = file
+	return nil
+}
+
+// Shutdown closes the file.
+func (e *fileExporter) Shutdown(context.Context) error {
+	if e.file == nil {
+		return nil
+	}
+	return e.file.Close()
+}
+
+func (e *fileExporter) consumeTraces(_ context.Context, td ptrace.Traces) error {
+	return e.file.write(func(w *otlpfile.Writer) error { return w.WriteTraces(td) })
+}
+
+func (e *fileExporter) consumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	return e.file.write(func(w *otlpfile.Writer) error { return w.WriteMetrics(md) })
+}
+
+func (e *fileExporter) consumeLogs(_ context.Context, ld plog.Logs) error {
+	return e.file.write(func(w *otlpfile.Writer) error { return w.WriteLogs(ld) })
+}
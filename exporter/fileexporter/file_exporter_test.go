@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestFileExporter(t *testing.T) {
+	for _, format := range []otlpfile.Format{otlpfile.FormatJSON, otlpfile.FormatProto} {
+		for _, compression := range []configcompression.CompressionType{"", configcompression.Gzip, configcompression.Zstd} {
+			t.Run(string(format)+"/"+string(compression), func(t *testing.T) {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Path = filepath.Join(t.TempDir(), "capture")
+				cfg.Format = string(format)
+				cfg.Compression = compression
+
+				fe := newFileExporter(cfg)
+				require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+				require.NoError(t, fe.consumeTraces(context.Background(), testdata.GenerateTraces(2)))
+				require.NoError(t, fe.consumeMetrics(context.Background(), testdata.GenerateMetrics(3)))
+				require.NoError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogs(4)))
+				require.NoError(t, fe.Shutdown(context.Background()))
+				assert.EqualError(t, fe.consumeLogs(context.Background(), testdata.GenerateLogs(1)), "file is closed")
+
+				records := readFile(t, cfg.Path, format)
+				require.Len(t, records, 3)
+				assert.Equal(t, testdata.GenerateTraces(2), *records[0].Traces)
+				assert.Equal(t, testdata.GenerateMetrics(3), *records[1].Metrics)
+				assert.Equal(t, testdata.GenerateLogs(4), *records[2].Logs)
+			})
+		}
+	}
+}
+
+func TestFileExporterStartError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = filepath.Join(t.TempDir(), "missing", "capture.jsonl")
+	fe := newFileExporter(cfg)
+	assert.Error(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+}
+
+func readFile(t *testing.T, path string, format otlpfile.Format) []otlpfile.Record {
+	f, err := os.Open(filepath.Clean(path))
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := otlpfile.NewDecompressReader(f)
+	require.NoError(t, err)
+	reader := otlpfile.NewReader(r, format)
+	var records []otlpfile.Record
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			return records
+		}
+		require.NoError(t, err)
+		records = append(records, rec)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "go.opentelemetry.io/collector/exporter/fileexporter"
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/internal/rotatingfile"
+)
+
+type rotatingFileSettings struct {
+	rotatingfile.Settings
+	format      otlpfile.Format
+	compression configcompression.CompressionType
+}
+
+// rotatingFile writes records to a rotating file. The file is compressed as a
+// stream closed on rotation, and flushed after each record.
+type rotatingFile struct {
+	rotatingFileSettings
+
+	mu         sync.Mutex
+	file       *rotatingfile.File
+	compressor otlpfile.CompressWriter
+	writer     *otlpfile.Writer
+}
+
+func openRotatingFile(set rotatingFileSettings, now func() time.Time) (*rotatingFile, error) {
+	file, err := rotatingfile.Open(set.Settings, now)
+	if err != nil {
+		return nil, err
+	}
+	f := &rotatingFile{rotatingFileSettings: set, file: file}
+	if err = f.newWriter(); err != nil {
+		return nil, multierr.Append(err, file.Close())
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) newWriter() error {
+	compressor, err := otlpfile.NewCompressWriter(f.file, f.compression)
+	if err != nil {
+		return err
+	}
+	f.compressor = compressor
+	f.writer = otlpfile.NewWriter(compressor, f.format)
+	return nil
+}
+
+// write writes a record with fn, after rotating the file if needed.
+func (f *rotatingFile) write(fn func(w *otlpfile.Writer) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return errors.New("file is closed")
+	}
+	if f.file.ShouldRotate() {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+	if err := fn(f.writer); err != nil {
+		return err
+	}
+	return f.compressor.Flush()
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.compressor.Close(); err != nil {
+		return err
+	}
+	if err := f.file.Rotate(); err != nil {
+		return err
+	}
+	return f.newWriter()
+}
+
+// Close closes the file.
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := multierr.Append(f.compressor.Close(), f.file.Close())
+	f.file = nil
+	return err
+}
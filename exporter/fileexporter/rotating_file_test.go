@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/internal/rotatingfile"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func writeLogs(t *testing.T, f *rotatingFile, count int) {
+	require.NoError(t, f.write(func(w *otlpfile.Writer) error { return w.WriteLogs(testdata.GenerateLogs(count)) }))
+}
+
+func listBackups(t *testing.T, dir string) []string {
+	matches, err := filepath.Glob(filepath.Join(dir, "capture-*.jsonl.gz"))
+	require.NoError(t, err)
+	sort.Strings(matches)
+	return matches
+}
+
+func TestRotatingFileSize(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)}
+	f, err := openRotatingFile(rotatingFileSettings{
+		Settings:    rotatingfile.Settings{Path: filepath.Join(dir, "capture.jsonl.gz"), MaxSize: 1, MaxBackups: 2},
+		format:      otlpfile.FormatJSON,
+		compression: configcompression.Gzip,
+	}, clock.Now)
+	require.NoError(t, err)
+
+	for i := 1; i <= 4; i++ {
+		writeLogs(t, f, i)
+		clock.now = clock.now.Add(time.Second)
+	}
+	require.NoError(t, f.Close())
+	require.NoError(t, f.Close())
+
+	// Each record reaches the maximum size, the file is rotated before the next
+	// one, and only the 2 most recent rotated files are kept.
+	backups := listBackups(t, dir)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "capture-20221101T100002.000000000.jsonl.gz"),
+		filepath.Join(dir, "capture-20221101T100003.000000000.jsonl.gz"),
+	}, backups)
+	records := readFile(t, backups[0], otlpfile.FormatJSON)
+	require.Len(t, records, 1)
+	assert.Equal(t, 2, records[0].Logs.LogRecordCount())
+	records = readFile(t, filepath.Join(dir, "capture.jsonl.gz"), otlpfile.FormatJSON)
+	require.Len(t, records, 1)
+	assert.Equal(t, 4, records[0].Logs.LogRecordCount())
+}
+
+func TestRotatingFileInterval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "capture.jsonl.gz")
+	// The size of an existing file is accounted for, and it is appended to.
+	require.NoError(t, os.WriteFile(path, nil, 0600))
+	clock := &fakeClock{now: time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)}
+	f, err := openRotatingFile(rotatingFileSettings{
+		Settings:    rotatingfile.Settings{Path: path, Interval: time.Minute},
+		format:      otlpfile.FormatProto,
+		compression: configcompression.Gzip,
+	}, clock.Now)
+	require.NoError(t, err)
+
+	writeLogs(t, f, 1)
+	clock.now = clock.now.Add(30 * time.Second)
+	writeLogs(t, f, 2)
+	assert.Empty(t, listBackups(t, dir))
+	clock.now = clock.now.Add(30 * time.Second)
+	writeLogs(t, f, 3)
+	require.NoError(t, f.Close())
+
+	backups := listBackups(t, dir)
+	assert.Equal(t, []string{filepath.Join(dir, "capture-20221101T100100.000000000.jsonl.gz")}, backups)
+	assert.Len(t, readFile(t, backups[0], otlpfile.FormatProto), 2)
+	assert.Len(t, readFile(t, path, otlpfile.FormatProto), 1)
+}
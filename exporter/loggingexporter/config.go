@@ -15,6 +15,7 @@
 package loggingexporter // import "go.opentelemetry.io/collector/exporter/loggingexporter"
 
 import (
+	"errors"
 	"fmt"
 
 	"go.uber.org/zap/zapcore"
@@ -34,6 +35,17 @@ var (
 	}
 )
 
+const (
+	formatText   = "text"
+	formatJSON   = "json"
+	formatLogfmt = "logfmt"
+
+	destinationLogger = "logger"
+	destinationStdout = "stdout"
+	destinationStderr = "stderr"
+	destinationFile   = "file"
+)
+
 // Config defines configuration for logging exporter.
 type Config struct {
 	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -51,10 +63,37 @@ type Config struct {
 	// SamplingThereafter defines the sampling rate after the initial samples are logged.
 	SamplingThereafter int `mapstructure:"sampling_thereafter"`
 
+	// Format of the data output; options are text, json, logfmt.
+	Format string `mapstructure:"format"`
+
+	// Destination of the data output; options are logger, stdout, stderr, file.
+	// The data written to the collector logger is subject to Verbosity and sampling,
+	// the other destinations are written all the data, independently of the logger.
+	Destination string `mapstructure:"destination"`
+
+	// File configures the file the data is written to when Destination is file.
+	File FileSettings `mapstructure:"file"`
+
+	// ResourceAttributes restricts the data output to the resources having all
+	// these attribute values.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+
 	// warnLogLevel is set on unmarshaling to warn users about `loglevel` usage.
 	warnLogLevel bool
 }
 
+// FileSettings defines the rotating file the data is written to.
+type FileSettings struct {
+	// Path of the file.
+	Path string `mapstructure:"path"`
+
+	// MaxSizeMiB is the size in MiB the file is rotated at, 0 disables rotation.
+	MaxSizeMiB int `mapstructure:"max_size_mib"`
+
+	// MaxBackups is the number of rotated files kept, 0 keeps all of them.
+	MaxBackups int `mapstructure:"max_backups"`
+}
+
 var _ config.Exporter = (*Config)(nil)
 var _ confmap.Unmarshaler = (*Config)(nil)
 
@@ -103,5 +142,27 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("verbosity level %q is not supported", cfg.Verbosity)
 	}
 
+	switch cfg.Format {
+	case "", formatText, formatJSON, formatLogfmt:
+	default:
+		return fmt.Errorf("format %q is not supported", cfg.Format)
+	}
+
+	switch cfg.Destination {
+	case "", destinationLogger, destinationStdout, destinationStderr:
+	case destinationFile:
+		if cfg.File.Path == "" {
+			return errors.New("'file.path' is required when destination is 'file'")
+		}
+		if cfg.File.MaxSizeMiB < 0 {
+			return fmt.Errorf("invalid 'file.max_size_mib' %d, must not be negative", cfg.File.MaxSizeMiB)
+		}
+		if cfg.File.MaxBackups < 0 {
+			return fmt.Errorf("invalid 'file.max_backups' %d, must not be negative", cfg.File.MaxBackups)
+		}
+	default:
+		return fmt.Errorf("destination %q is not supported", cfg.Destination)
+	}
+
 	return nil
 }
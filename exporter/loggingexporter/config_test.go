@@ -49,6 +49,9 @@ func TestUnmarshalConfig(t *testing.T) {
 				Verbosity:          configtelemetry.LevelDetailed,
 				SamplingInitial:    10,
 				SamplingThereafter: 50,
+				Format:             "text",
+				Destination:        "logger",
+				File:               FileSettings{MaxSizeMiB: 100, MaxBackups: 3},
 				warnLogLevel:       true,
 			},
 		},
@@ -60,6 +63,9 @@ func TestUnmarshalConfig(t *testing.T) {
 				Verbosity:          configtelemetry.LevelDetailed,
 				SamplingInitial:    10,
 				SamplingThereafter: 50,
+				Format:             "text",
+				Destination:        "logger",
+				File:               FileSettings{MaxSizeMiB: 100, MaxBackups: 3},
 			},
 		},
 		{
@@ -70,9 +76,30 @@ func TestUnmarshalConfig(t *testing.T) {
 				Verbosity:          configtelemetry.LevelNormal,
 				SamplingInitial:    2,
 				SamplingThereafter: 500,
+				Format:             "text",
+				Destination:        "logger",
+				File:               FileSettings{MaxSizeMiB: 100, MaxBackups: 3},
 				warnLogLevel:       true,
 			},
 		},
+		{
+			filename: "config_output.yaml",
+			cfg: &Config{
+				ExporterSettings:   config.NewExporterSettings(config.NewComponentID(typeStr)),
+				LogLevel:           zapcore.InfoLevel,
+				Verbosity:          configtelemetry.LevelNormal,
+				SamplingInitial:    2,
+				SamplingThereafter: 500,
+				Format:             "logfmt",
+				Destination:        "file",
+				File: FileSettings{
+					Path:       "/var/log/otelcol/data.log",
+					MaxSizeMiB: 10,
+					MaxBackups: 3,
+				},
+				ResourceAttributes: map[string]string{"service.name": "checkout"},
+			},
+		},
 		{
 			filename:    "invalid_verbosity_loglevel.yaml",
 			expectedErr: "'loglevel' and 'verbosity' are incompatible. Use only 'verbosity' instead",
@@ -115,6 +142,56 @@ func TestValidate(t *testing.T) {
 				Verbosity: configtelemetry.LevelDetailed,
 			},
 		},
+		{
+			name: "unsupported format",
+			cfg: &Config{
+				Verbosity: configtelemetry.LevelNormal,
+				Format:    "yaml",
+			},
+			expectedErr: "format \"yaml\" is not supported",
+		},
+		{
+			name: "unsupported destination",
+			cfg: &Config{
+				Verbosity:   configtelemetry.LevelNormal,
+				Destination: "syslog",
+			},
+			expectedErr: "destination \"syslog\" is not supported",
+		},
+		{
+			name: "file without path",
+			cfg: &Config{
+				Verbosity:   configtelemetry.LevelNormal,
+				Destination: "file",
+			},
+			expectedErr: "'file.path' is required when destination is 'file'",
+		},
+		{
+			name: "negative max size",
+			cfg: &Config{
+				Verbosity:   configtelemetry.LevelNormal,
+				Destination: "file",
+				File:        FileSettings{Path: "data.log", MaxSizeMiB: -1},
+			},
+			expectedErr: "invalid 'file.max_size_mib' -1, must not be negative",
+		},
+		{
+			name: "negative max backups",
+			cfg: &Config{
+				Verbosity:   configtelemetry.LevelNormal,
+				Destination: "file",
+				File:        FileSettings{Path: "data.log", MaxBackups: -1},
+			},
+			expectedErr: "invalid 'file.max_backups' -1, must not be negative",
+		},
+		{
+			name: "json to stdout",
+			cfg: &Config{
+				Verbosity:   configtelemetry.LevelNormal,
+				Format:      "json",
+				Destination: "stdout",
+			},
+		},
 	}
 
 	for _, tt := range tests {
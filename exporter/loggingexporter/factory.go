@@ -34,6 +34,8 @@ const (
 	typeStr                   = "logging"
 	defaultSamplingInitial    = 2
 	defaultSamplingThereafter = 500
+	defaultMaxSizeMiB         = 100
+	defaultMaxBackups         = 3
 )
 
 var onceWarnLogLevel sync.Once
@@ -56,6 +58,12 @@ func createDefaultConfig() config.Exporter {
 		Verbosity:          configtelemetry.LevelNormal,
 		SamplingInitial:    defaultSamplingInitial,
 		SamplingThereafter: defaultSamplingThereafter,
+		Format:             formatText,
+		Destination:        destinationLogger,
+		File: FileSettings{
+			MaxSizeMiB: defaultMaxSizeMiB,
+			MaxBackups: defaultMaxBackups,
+		},
 	}
 }
 
@@ -63,6 +71,7 @@ func createTracesExporter(ctx context.Context, set component.ExporterCreateSetti
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
 	s := newLoggingExporter(exporterLogger, cfg.Verbosity)
+	s.configureOutput(cfg)
 	return exporterhelper.NewTracesExporter(ctx, set, cfg,
 		s.pushTraces,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -70,7 +79,8 @@ func createTracesExporter(ctx context.Context, set component.ExporterCreateSetti
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
 		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
-		exporterhelper.WithShutdown(loggerSync(exporterLogger)),
+		exporterhelper.WithStart(s.start),
+		exporterhelper.WithShutdown(s.shutdown),
 	)
 }
 
@@ -78,6 +88,7 @@ func createMetricsExporter(ctx context.Context, set component.ExporterCreateSett
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
 	s := newLoggingExporter(exporterLogger, cfg.Verbosity)
+	s.configureOutput(cfg)
 	return exporterhelper.NewMetricsExporter(ctx, set, cfg,
 		s.pushMetrics,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -85,7 +96,8 @@ func createMetricsExporter(ctx context.Context, set component.ExporterCreateSett
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
 		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
-		exporterhelper.WithShutdown(loggerSync(exporterLogger)),
+		exporterhelper.WithStart(s.start),
+		exporterhelper.WithShutdown(s.shutdown),
 	)
 }
 
@@ -93,6 +105,7 @@ func createLogsExporter(ctx context.Context, set component.ExporterCreateSetting
 	cfg := config.(*Config)
 	exporterLogger := createLogger(cfg, set.TelemetrySettings.Logger)
 	s := newLoggingExporter(exporterLogger, cfg.Verbosity)
+	s.configureOutput(cfg)
 	return exporterhelper.NewLogsExporter(ctx, set, cfg,
 		s.pushLogs,
 		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
@@ -100,7 +113,8 @@ func createLogsExporter(ctx context.Context, set component.ExporterCreateSetting
 		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
 		exporterhelper.WithRetry(exporterhelper.RetrySettings{Enabled: false}),
 		exporterhelper.WithQueue(exporterhelper.QueueSettings{Enabled: false}),
-		exporterhelper.WithShutdown(loggerSync(exporterLogger)),
+		exporterhelper.WithStart(s.start),
+		exporterhelper.WithShutdown(s.shutdown),
 	)
 }
 
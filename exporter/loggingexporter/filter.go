@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingexporter // import "go.opentelemetry.io/collector/exporter/loggingexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// matchResource returns true if the resource has all the attribute values.
+func matchResource(res pcommon.Resource, attrs map[string]string) bool {
+	for k, want := range attrs {
+		v, ok := res.Attributes().Get(k)
+		if !ok || v.AsString() != want {
+			return false
+		}
+	}
+	return true
+}
+
+// filterTraces returns the traces of the resources having all the attribute values.
+func filterTraces(td ptrace.Traces, attrs map[string]string) ptrace.Traces {
+	if len(attrs) == 0 {
+		return td
+	}
+	filtered := ptrace.NewTraces()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		if matchResource(rss.At(i).Resource(), attrs) {
+			rss.At(i).CopyTo(filtered.ResourceSpans().AppendEmpty())
+		}
+	}
+	return filtered
+}
+
+// filterMetrics returns the metrics of the resources having all the attribute values.
+func filterMetrics(md pmetric.Metrics, attrs map[string]string) pmetric.Metrics {
+	if len(attrs) == 0 {
+		return md
+	}
+	filtered := pmetric.NewMetrics()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		if matchResource(rms.At(i).Resource(), attrs) {
+			rms.At(i).CopyTo(filtered.ResourceMetrics().AppendEmpty())
+		}
+	}
+	return filtered
+}
+
+// filterLogs returns the logs of the resources having all the attribute values.
+func filterLogs(ld plog.Logs, attrs map[string]string) plog.Logs {
+	if len(attrs) == 0 {
+		return ld
+	}
+	filtered := plog.NewLogs()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		if matchResource(rls.At(i).Resource(), attrs) {
+			rls.At(i).CopyTo(filtered.ResourceLogs().AppendEmpty())
+		}
+	}
+	return filtered
+}
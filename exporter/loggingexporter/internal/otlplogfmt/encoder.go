@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlplogfmt encodes pipeline data in logfmt, one line per span, data
+// point or log record, with the attributes of its resource and scope.
+package otlplogfmt // import "go.opentelemetry.io/collector/exporter/loggingexporter/internal/otlplogfmt"
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+type encoder struct {
+	buf bytes.Buffer
+	// lineStart is the offset of the line being encoded.
+	lineStart int
+}
+
+// field appends a key=value pair to the line being encoded.
+func (e *encoder) field(key string, value string) {
+	if e.buf.Len() > e.lineStart {
+		e.buf.WriteByte(' ')
+	}
+	e.buf.WriteString(strings.Map(keyRune, key))
+	e.buf.WriteByte('=')
+	if needsQuoting(value) {
+		e.buf.WriteString(strconv.Quote(value))
+	} else {
+		e.buf.WriteString(value)
+	}
+}
+
+func (e *encoder) timestamp(key string, ts pcommon.Timestamp) {
+	e.field(key, ts.AsTime().UTC().Format(time.RFC3339Nano))
+}
+
+func (e *encoder) float(key string, f float64) {
+	e.field(key, strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+func (e *encoder) uint(key string, i uint64) {
+	e.field(key, strconv.FormatUint(i, 10))
+}
+
+// attributes appends the attributes sorted by key, their keys prefixed with prefix.
+func (e *encoder) attributes(prefix string, m pcommon.Map) {
+	keys := make([]string, 0, m.Len())
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		e.field(prefix+k, v.AsString())
+	}
+}
+
+// resource appends the attributes of the resource and the name of the scope
+// starting every line.
+func (e *encoder) resource(res pcommon.Resource, scope pcommon.InstrumentationScope) {
+	e.attributes("resource.", res.Attributes())
+	if scope.Name() != "" {
+		e.field("scope", scope.Name())
+	}
+}
+
+func (e *encoder) endLine() {
+	e.buf.WriteByte('\n')
+	e.lineStart = e.buf.Len()
+}
+
+func keyRune(r rune) rune {
+	if r <= ' ' || r == '=' || r == '"' || !unicode.IsPrint(r) {
+		return '_'
+	}
+	return r
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
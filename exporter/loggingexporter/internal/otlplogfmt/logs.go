@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlplogfmt // import "go.opentelemetry.io/collector/exporter/loggingexporter/internal/otlplogfmt"
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// NewLogfmtLogsMarshaler returns a plog.Marshaler to encode to logfmt bytes, one line per log record.
+func NewLogfmtLogsMarshaler() plog.Marshaler {
+	return logfmtLogsMarshaler{}
+}
+
+type logfmtLogsMarshaler struct{}
+
+// MarshalLogs plog.Logs to logfmt.
+func (logfmtLogsMarshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	enc := encoder{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		ills := rl.ScopeLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ils := ills.At(j)
+			logs := ils.LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				enc.resource(rl.Resource(), ils.Scope())
+				if lr.Timestamp() != 0 {
+					enc.timestamp("ts", lr.Timestamp())
+				}
+				if lr.ObservedTimestamp() != 0 {
+					enc.timestamp("observed_ts", lr.ObservedTimestamp())
+				}
+				if lr.SeverityText() != "" {
+					enc.field("severity", lr.SeverityText())
+				} else {
+					enc.field("severity", lr.SeverityNumber().String())
+				}
+				enc.field("body", lr.Body().AsString())
+				if !lr.TraceID().IsEmpty() {
+					enc.field("trace_id", lr.TraceID().HexString())
+				}
+				if !lr.SpanID().IsEmpty() {
+					enc.field("span_id", lr.SpanID().HexString())
+				}
+				enc.attributes("attr.", lr.Attributes())
+				enc.endLine()
+			}
+		}
+	}
+	return enc.buf.Bytes(), nil
+}
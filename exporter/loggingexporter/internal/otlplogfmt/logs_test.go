@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlplogfmt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestLogsLogfmt(t *testing.T) {
+	buf, err := NewLogfmtLogsMarshaler().MarshalLogs(plog.NewLogs())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	buf, err = NewLogfmtLogsMarshaler().MarshalLogs(testdata.GenerateLogs(2))
+	require.NoError(t, err)
+	assert.Equal(t,
+		`resource.resource-attr=resource-attr-val-1 ts=2020-02-11T20:26:13.000000789Z severity=Info body="This is a log message" `+
+			`trace_id=08040201000000000000000000000000 span_id=0102040800000000 attr.app=server attr.instance_num=1`+"\n"+
+			`resource.resource-attr=resource-attr-val-1 ts=2020-02-11T20:26:13.000000789Z severity=Info body="something happened" `+
+			`attr.customer=acme attr.env=dev`+"\n",
+		string(buf))
+}
+
+func TestLogfmtQuoting(t *testing.T) {
+	enc := encoder{}
+	enc.field("empty", "")
+	enc.field("plain", "value")
+	enc.field("spaced key", "a b")
+	enc.field("quote", `say "hi"`)
+	enc.field("equal", "a=b")
+	enc.field("newline", "a\nb")
+	enc.endLine()
+	enc.field("next", "line")
+	enc.endLine()
+	assert.Equal(t, strings.Join([]string{
+		`empty="" plain=value spaced_key="a b" quote="say \"hi\"" equal="a=b" newline="a\nb"`,
+		`next=line`,
+		``,
+	}, "\n"), enc.buf.String())
+}
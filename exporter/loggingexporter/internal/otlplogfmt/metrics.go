@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlplogfmt // import "go.opentelemetry.io/collector/exporter/loggingexporter/internal/otlplogfmt"
+
+import (
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// NewLogfmtMetricsMarshaler returns a pmetric.Marshaler to encode to logfmt bytes, one line per data point.
+func NewLogfmtMetricsMarshaler() pmetric.Marshaler {
+	return logfmtMetricsMarshaler{}
+}
+
+type logfmtMetricsMarshaler struct{}
+
+// MarshalMetrics pmetric.Metrics to logfmt.
+func (logfmtMetricsMarshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	enc := encoder{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		ilms := rm.ScopeMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				dp := dataPointEncoder{encoder: &enc, res: rm.Resource(), scope: ilm.Scope(), metric: metrics.At(k)}
+				dp.encode()
+			}
+		}
+	}
+	return enc.buf.Bytes(), nil
+}
+
+// dataPointEncoder encodes the data points of a metric.
+type dataPointEncoder struct {
+	*encoder
+	res    pcommon.Resource
+	scope  pcommon.InstrumentationScope
+	metric pmetric.Metric
+}
+
+func (e dataPointEncoder) encode() {
+	switch e.metric.Type() {
+	case pmetric.MetricTypeGauge:
+		e.numberDataPoints(e.metric.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		e.numberDataPoints(e.metric.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		dps := e.metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			e.start(dp.Timestamp())
+			e.uint("count", dp.Count())
+			if dp.HasSum() {
+				e.float("sum", dp.Sum())
+			}
+			if dp.HasMin() {
+				e.float("min", dp.Min())
+			}
+			if dp.HasMax() {
+				e.float("max", dp.Max())
+			}
+			e.end(dp.Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := e.metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			e.start(dp.Timestamp())
+			e.uint("count", dp.Count())
+			if dp.HasSum() {
+				e.float("sum", dp.Sum())
+			}
+			if dp.HasMin() {
+				e.float("min", dp.Min())
+			}
+			if dp.HasMax() {
+				e.float("max", dp.Max())
+			}
+			e.end(dp.Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := e.metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			e.start(dp.Timestamp())
+			e.uint("count", dp.Count())
+			e.float("sum", dp.Sum())
+			e.end(dp.Attributes())
+		}
+	}
+}
+
+func (e dataPointEncoder) numberDataPoints(dps pmetric.NumberDataPointSlice) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		e.start(dp.Timestamp())
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			e.field("value", strconv.FormatInt(dp.IntValue(), 10))
+		case pmetric.NumberDataPointValueTypeDouble:
+			e.float("value", dp.DoubleValue())
+		}
+		e.end(dp.Attributes())
+	}
+}
+
+func (e dataPointEncoder) start(ts pcommon.Timestamp) {
+	e.resource(e.res, e.scope)
+	e.field("metric", e.metric.Name())
+	e.field("type", e.metric.Type().String())
+	if e.metric.Unit() != "" {
+		e.field("unit", e.metric.Unit())
+	}
+	e.timestamp("ts", ts)
+}
+
+func (e dataPointEncoder) end(attrs pcommon.Map) {
+	e.attributes("attr.", attrs)
+	e.endLine()
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlplogfmt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestMetricsLogfmt(t *testing.T) {
+	buf, err := NewLogfmtMetricsMarshaler().MarshalMetrics(pmetric.NewMetrics())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	md := testdata.GenerateMetricsAllTypes()
+	buf, err = NewLogfmtMetricsMarshaler().MarshalMetrics(md)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(buf), "\n"), "\n")
+	assert.Len(t, lines, md.DataPointCount())
+	for _, line := range lines {
+		assert.True(t, strings.HasPrefix(line, "resource.resource-attr=resource-attr-val-1 metric="), line)
+	}
+	assert.Contains(t, lines[0], "metric=gauge-int type=Gauge unit=1 ts=2020-02-11T20:26:13.000000789Z value=123 attr.label-1=label-value-1")
+
+	buf, err = NewLogfmtMetricsMarshaler().MarshalMetrics(testdata.GenerateMetricsMetricTypeInvalid())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlplogfmt // import "go.opentelemetry.io/collector/exporter/loggingexporter/internal/otlplogfmt"
+
+import (
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NewLogfmtTracesMarshaler returns a ptrace.Marshaler to encode to logfmt bytes, one line per span.
+func NewLogfmtTracesMarshaler() ptrace.Marshaler {
+	return logfmtTracesMarshaler{}
+}
+
+type logfmtTracesMarshaler struct{}
+
+// MarshalTraces ptrace.Traces to logfmt.
+func (logfmtTracesMarshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	enc := encoder{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				enc.resource(rs.Resource(), ils.Scope())
+				enc.field("trace_id", span.TraceID().HexString())
+				enc.field("span_id", span.SpanID().HexString())
+				if !span.ParentSpanID().IsEmpty() {
+					enc.field("parent_span_id", span.ParentSpanID().HexString())
+				}
+				enc.field("name", span.Name())
+				enc.field("kind", span.Kind().String())
+				enc.timestamp("start", span.StartTimestamp())
+				enc.timestamp("end", span.EndTimestamp())
+				enc.field("duration", span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()).String())
+				enc.field("status", span.Status().Code().String())
+				if span.Status().Message() != "" {
+					enc.field("status_message", span.Status().Message())
+				}
+				if span.Events().Len() > 0 {
+					enc.uint("events", uint64(span.Events().Len()))
+				}
+				if span.Links().Len() > 0 {
+					enc.uint("links", uint64(span.Links().Len()))
+				}
+				enc.attributes("attr.", span.Attributes())
+				enc.endLine()
+			}
+		}
+	}
+	return enc.buf.Bytes(), nil
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlplogfmt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestTracesLogfmt(t *testing.T) {
+	buf, err := NewLogfmtTracesMarshaler().MarshalTraces(ptrace.NewTraces())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	buf, err = NewLogfmtTracesMarshaler().MarshalTraces(testdata.GenerateTraces(2))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(buf), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t,
+		`resource.resource-attr=resource-attr-val-1 trace_id=0102030405060708090a0b0c0d0e0f10 span_id=1112131415161718 `+
+			`name=operationA kind=SPAN_KIND_UNSPECIFIED start=2020-02-11T20:26:12.000000321Z end=2020-02-11T20:26:13.000000789Z `+
+			`duration=1.000000468s status=STATUS_CODE_ERROR status_message=status-cancelled events=2`,
+		lines[0])
+	assert.Contains(t, lines[1], "name=operationB")
+	assert.Contains(t, lines[1], "links=2")
+}
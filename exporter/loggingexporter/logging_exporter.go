@@ -19,9 +19,12 @@ import (
 	"errors"
 	"os"
 
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/exporter/loggingexporter/internal/otlplogfmt"
 	"go.opentelemetry.io/collector/exporter/loggingexporter/internal/otlptext"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -34,9 +37,28 @@ type loggingExporter struct {
 	logsMarshaler    plog.Marshaler
 	metricsMarshaler pmetric.Marshaler
 	tracesMarshaler  ptrace.Marshaler
+
+	// resourceAttributes restricts the data output to the matching resources.
+	resourceAttributes map[string]string
+	// outputCfg configures the destination the data is written to instead of
+	// the logger, nil to log it.
+	outputCfg *Config
+	output    *output
 }
 
 func (s *loggingExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
+	td = filterTraces(td, s.resourceAttributes)
+	if s.output != nil {
+		if td.ResourceSpans().Len() == 0 {
+			return nil
+		}
+		buf, err := s.tracesMarshaler.MarshalTraces(td)
+		if err != nil {
+			return err
+		}
+		return s.output.write(buf)
+	}
+
 	s.logger.Info("TracesExporter", zap.Int("#spans", td.SpanCount()))
 	if s.verbosity != configtelemetry.LevelDetailed {
 		return nil
@@ -51,6 +73,18 @@ func (s *loggingExporter) pushTraces(_ context.Context, td ptrace.Traces) error
 }
 
 func (s *loggingExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
+	md = filterMetrics(md, s.resourceAttributes)
+	if s.output != nil {
+		if md.ResourceMetrics().Len() == 0 {
+			return nil
+		}
+		buf, err := s.metricsMarshaler.MarshalMetrics(md)
+		if err != nil {
+			return err
+		}
+		return s.output.write(buf)
+	}
+
 	s.logger.Info("MetricsExporter", zap.Int("#metrics", md.MetricCount()))
 	if s.verbosity != configtelemetry.LevelDetailed {
 		return nil
@@ -65,6 +99,18 @@ func (s *loggingExporter) pushMetrics(_ context.Context, md pmetric.Metrics) err
 }
 
 func (s *loggingExporter) pushLogs(_ context.Context, ld plog.Logs) error {
+	ld = filterLogs(ld, s.resourceAttributes)
+	if s.output != nil {
+		if ld.ResourceLogs().Len() == 0 {
+			return nil
+		}
+		buf, err := s.logsMarshaler.MarshalLogs(ld)
+		if err != nil {
+			return err
+		}
+		return s.output.write(buf)
+	}
+
 	s.logger.Info("LogsExporter", zap.Int("#logs", ld.LogRecordCount()))
 	if s.verbosity != configtelemetry.LevelDetailed {
 		return nil
@@ -88,6 +134,42 @@ func newLoggingExporter(logger *zap.Logger, verbosity configtelemetry.Level) *lo
 	}
 }
 
+// configureOutput sets the format, filter and destination of the data output.
+func (s *loggingExporter) configureOutput(cfg *Config) {
+	switch cfg.Format {
+	case formatJSON:
+		s.logsMarshaler = plog.NewJSONMarshaler()
+		s.metricsMarshaler = pmetric.NewJSONMarshaler()
+		s.tracesMarshaler = ptrace.NewJSONMarshaler()
+	case formatLogfmt:
+		s.logsMarshaler = otlplogfmt.NewLogfmtLogsMarshaler()
+		s.metricsMarshaler = otlplogfmt.NewLogfmtMetricsMarshaler()
+		s.tracesMarshaler = otlplogfmt.NewLogfmtTracesMarshaler()
+	}
+	s.resourceAttributes = cfg.ResourceAttributes
+	if cfg.Destination != "" && cfg.Destination != destinationLogger {
+		s.outputCfg = cfg
+	}
+}
+
+func (s *loggingExporter) start(context.Context, component.Host) error {
+	if s.outputCfg == nil {
+		return nil
+	}
+	var err error
+	s.output, err = acquireOutput(s.outputCfg)
+	return err
+}
+
+func (s *loggingExporter) shutdown(ctx context.Context) error {
+	var err error
+	if s.output != nil {
+		err = s.output.release()
+		s.output = nil
+	}
+	return multierr.Append(err, loggerSync(s.logger)(ctx))
+}
+
 func loggerSync(logger *zap.Logger) func(context.Context) error {
 	return func(context.Context) error {
 		// Currently Sync() return a different error depending on the OS.
@@ -16,6 +16,9 @@ package loggingexporter
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -82,6 +85,63 @@ func TestLoggingExporterErrors(t *testing.T) {
 	assert.Equal(t, errWant, le.pushLogs(context.Background(), plog.NewLogs()))
 }
 
+func TestLoggingExporterFileOutput(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Format = formatJSON
+	cfg.Destination = destinationFile
+	cfg.File.Path = filepath.Join(t.TempDir(), "data.jsonl")
+	cfg.ResourceAttributes = map[string]string{"resource-attr": "resource-attr-val-1"}
+
+	lte, err := f.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	lle, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, lte.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, lle.Start(context.Background(), componenttest.NewNopHost()))
+
+	ignored := testdata.GenerateLogs(1)
+	ignored.ResourceLogs().At(0).Resource().Attributes().PutStr("resource-attr", "other")
+	assert.NoError(t, lle.ConsumeLogs(context.Background(), ignored))
+	assert.NoError(t, lte.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.NoError(t, lle.ConsumeLogs(context.Background(), testdata.GenerateLogs(3)))
+
+	// The exporters share the file until both are shut down.
+	assert.NoError(t, lte.Shutdown(context.Background()))
+	assert.NoError(t, lle.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	assert.NoError(t, lle.Shutdown(context.Background()))
+
+	data, err := os.ReadFile(cfg.File.Path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 3)
+	td, err := ptrace.NewJSONUnmarshaler().UnmarshalTraces([]byte(lines[0]))
+	require.NoError(t, err)
+	assert.Equal(t, 2, td.SpanCount())
+	ld, err := plog.NewJSONUnmarshaler().UnmarshalLogs([]byte(lines[1]))
+	require.NoError(t, err)
+	assert.Equal(t, 3, ld.LogRecordCount())
+	ld, err = plog.NewJSONUnmarshaler().UnmarshalLogs([]byte(lines[2]))
+	require.NoError(t, err)
+	assert.Equal(t, 1, ld.LogRecordCount())
+}
+
+func TestFilterResources(t *testing.T) {
+	td := testdata.GenerateTraces(1)
+	assert.Equal(t, td, filterTraces(td, nil))
+	assert.Equal(t, 1, filterTraces(td, map[string]string{"resource-attr": "resource-attr-val-1"}).SpanCount())
+	assert.Equal(t, 0, filterTraces(td, map[string]string{"resource-attr": "other"}).SpanCount())
+	assert.Equal(t, 0, filterTraces(td, map[string]string{"resource-attr": "resource-attr-val-1", "missing": ""}).SpanCount())
+
+	md := testdata.GenerateMetrics(2)
+	assert.Equal(t, 2, filterMetrics(md, map[string]string{"resource-attr": "resource-attr-val-1"}).MetricCount())
+	assert.Equal(t, 0, filterMetrics(md, map[string]string{"resource-attr": "other"}).MetricCount())
+
+	ld := testdata.GenerateLogs(2)
+	assert.Equal(t, 2, filterLogs(ld, map[string]string{"resource-attr": "resource-attr-val-1"}).LogRecordCount())
+	assert.Equal(t, 0, filterLogs(ld, map[string]string{"resource-attr": "other"}).LogRecordCount())
+}
+
 type errMarshaler struct {
 	err error
 }
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingexporter // import "go.opentelemetry.io/collector/exporter/loggingexporter"
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/internal/rotatingfile"
+)
+
+// output is a destination the data is written to, shared by the exporters
+// writing to the same destination so that their lines are not interleaved.
+type output struct {
+	key  string
+	refs int
+
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+var (
+	outputsMu sync.Mutex
+	outputs   = map[string]*output{}
+)
+
+// acquireOutput returns the output of the configured destination, opening it
+// if no other exporter writes to it.
+func acquireOutput(cfg *Config) (*output, error) {
+	key := cfg.Destination
+	if cfg.Destination == destinationFile {
+		key += ":" + cfg.File.Path
+	}
+
+	outputsMu.Lock()
+	defer outputsMu.Unlock()
+	if o, ok := outputs[key]; ok {
+		o.refs++
+		return o, nil
+	}
+
+	var w io.Writer
+	switch cfg.Destination {
+	case destinationStdout:
+		w = os.Stdout
+	case destinationStderr:
+		w = os.Stderr
+	case destinationFile:
+		f, err := rotatingfile.Open(rotatingfile.Settings{
+			Path:       cfg.File.Path,
+			MaxSize:    int64(cfg.File.MaxSizeMiB) << 20,
+			MaxBackups: cfg.File.MaxBackups,
+		}, time.Now)
+		if err != nil {
+			return nil, err
+		}
+		w = rotatingWriter{f}
+	}
+	o := &output{key: key, refs: 1, writer: w}
+	outputs[key] = o
+	return o, nil
+}
+
+// write writes the data, terminated by a new line if it is not already.
+func (o *output) write(buf []byte) error {
+	if len(buf) > 0 && buf[len(buf)-1] != '\n' {
+		buf = append(buf, '\n')
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, err := o.writer.Write(buf)
+	return err
+}
+
+// rotatingWriter writes to a rotating file, rotating it before a write once it
+// reached its maximum size, so that lines are not split across files.
+type rotatingWriter struct {
+	*rotatingfile.File
+}
+
+func (w rotatingWriter) Write(p []byte) (int, error) {
+	if w.ShouldRotate() {
+		if err := w.Rotate(); err != nil {
+			return 0, err
+		}
+	}
+	return w.File.Write(p)
+}
+
+// release closes the output once no exporter writes to it anymore.
+func (o *output) release() error {
+	outputsMu.Lock()
+	defer outputsMu.Unlock()
+	o.refs--
+	if o.refs > 0 {
+		return nil
+	}
+	delete(outputs, o.key)
+	if c, ok := o.writer.(io.Closer); ok && o.writer != os.Stdout && o.writer != os.Stderr {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		return c.Close()
+	}
+	return nil
+}
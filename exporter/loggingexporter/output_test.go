@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggingexporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/internal/rotatingfile"
+)
+
+func TestRotatingWriter(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.log")
+	f, err := rotatingfile.Open(rotatingfile.Settings{Path: path, MaxSize: 10}, time.Now)
+	require.NoError(t, err)
+	w := rotatingWriter{f}
+	for _, line := range []string{"1111\n", "2222\n", "3333\n"} {
+		_, err = w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	// The file is rotated before the line written once it reached its maximum size.
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "3333\n", string(data))
+	backups, err := filepath.Glob(filepath.Join(dir, "data-*.log"))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	data, err = os.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, "1111\n2222\n", string(data))
+}
@@ -87,6 +87,20 @@ func TestCreateTracesExporter(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Endpoints",
+			config: Config{
+				ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+				GRPCClientSettings: configgrpc.GRPCClientSettings{
+					Endpoints:          []string{endpoint, endpoint},
+					ResolutionInterval: time.Minute,
+					MaxConnectionAge:   time.Minute,
+					TLSSetting: configtls.TLSClientSetting{
+						Insecure: true,
+					},
+				},
+			},
+		},
 		{
 			name: "Keepalive",
 			config: Config{
@@ -62,7 +62,7 @@ type exporter struct {
 func newExporter(cfg config.Exporter, set component.ExporterCreateSettings) (*exporter, error) {
 	oCfg := cfg.(*Config)
 
-	if oCfg.Endpoint == "" {
+	if oCfg.Endpoint == "" && len(oCfg.Endpoints) == 0 {
 		return nil, errors.New("OTLP exporter config requires an Endpoint")
 	}
 
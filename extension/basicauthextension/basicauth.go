@@ -0,0 +1,232 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension // import "go.opentelemetry.io/collector/extension/basicauthextension"
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/internal/filereloader"
+)
+
+const (
+	authorizationHeader = "authorization"
+	basicScheme         = "basic"
+
+	// AttributeUsername is the name of the client.Info.Auth attribute holding the user name
+	// of authenticated requests.
+	AttributeUsername = "username"
+)
+
+var (
+	errNoHtpasswd         = errors.New("\"htpasswd\" is not configured, the extension cannot authenticate incoming requests")
+	errNoClientAuth       = errors.New("\"client_auth\" is not configured, the extension cannot authenticate outgoing requests")
+	errMissingAuth        = errors.New("missing basic authentication")
+	errInvalidFormat      = errors.New("invalid basic authentication format")
+	errInvalidCredentials = errors.New("invalid username or password")
+)
+
+var (
+	_ configauth.ServerAuthenticator = (*basicAuth)(nil)
+	_ configauth.ClientAuthenticator = (*basicAuth)(nil)
+)
+
+// basicAuth authenticates incoming requests against htpasswd entries, and outgoing requests
+// with the configured username and password.
+type basicAuth struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+
+	mu    sync.RWMutex
+	users htpasswd
+
+	reloadInterval time.Duration
+	reloader       *filereloader.Reloader
+}
+
+func newBasicAuth(cfg *Config, telemetry component.TelemetrySettings) *basicAuth {
+	return &basicAuth{
+		config:         cfg,
+		telemetry:      telemetry,
+		users:          htpasswd{},
+		reloadInterval: filereloader.DefaultInterval,
+	}
+}
+
+// Start loads the htpasswd entries, and watches the htpasswd file for changes.
+func (a *basicAuth) Start(context.Context, component.Host) error {
+	if a.config.Htpasswd == nil {
+		return nil
+	}
+	if a.config.Htpasswd.File == "" {
+		return a.loadHtpasswd(nil)
+	}
+	a.reloader = filereloader.New(a.config.Htpasswd.File, a.reloadInterval, a.loadHtpasswd, a.telemetry.Logger)
+	return a.reloader.Start()
+}
+
+// Shutdown stops watching the htpasswd file.
+func (a *basicAuth) Shutdown(context.Context) error {
+	if a.reloader != nil {
+		a.reloader.Stop()
+	}
+	return nil
+}
+
+// loadHtpasswd replaces the htpasswd entries with the ones of the file content and of the inline setting.
+func (a *basicAuth) loadHtpasswd(content []byte) error {
+	users := htpasswd{}
+	if err := parseHtpasswd(string(content), users); err != nil {
+		return err
+	}
+	if err := parseHtpasswd(a.config.Htpasswd.Inline, users); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.users = users
+	return nil
+}
+
+// Authenticate checks the basic authentication credentials of the "authorization" header, and adds
+// the authenticated user name to the client.Info of the returned context.
+func (a *basicAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	if a.config.Htpasswd == nil {
+		return ctx, errNoHtpasswd
+	}
+	values := getHeader(headers, authorizationHeader)
+	if len(values) == 0 {
+		return ctx, errMissingAuth
+	}
+	username, password, err := parseBasicAuth(values[0])
+	if err != nil {
+		return ctx, err
+	}
+
+	a.mu.RLock()
+	users := a.users
+	a.mu.RUnlock()
+	if !users.matches(username, password) {
+		return ctx, errInvalidCredentials
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{username: username}
+	return client.NewContext(ctx, cl), nil
+}
+
+// RoundTripper returns an http.RoundTripper adding the credentials to the requests.
+func (a *basicAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	if a.config.ClientAuth == nil {
+		return nil, errNoClientAuth
+	}
+	return &roundTripper{base: base, settings: a.config.ClientAuth}, nil
+}
+
+// PerRPCCredentials returns credentials adding the credentials to the RPCs.
+func (a *basicAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	if a.config.ClientAuth == nil {
+		return nil, errNoClientAuth
+	}
+	return &perRPCCredentials{header: "Basic " + encodeBasicAuth(a.config.ClientAuth)}, nil
+}
+
+// parseBasicAuth extracts the user name and the password from an "authorization" header value.
+func parseBasicAuth(value string) (string, string, error) {
+	scheme, encoded, ok := strings.Cut(value, " ")
+	if !ok || !strings.EqualFold(scheme, basicScheme) {
+		return "", "", errInvalidFormat
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return "", "", errInvalidFormat
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", errInvalidFormat
+	}
+	return username, password, nil
+}
+
+func encodeBasicAuth(settings *ClientAuthSettings) string {
+	return base64.StdEncoding.EncodeToString([]byte(settings.Username + ":" + settings.Password))
+}
+
+// getHeader returns the values of the header with the given name, ignoring case. gRPC
+// metadata keys are lowercase, whereas HTTP headers are in canonical form.
+func getHeader(headers map[string][]string, name string) []string {
+	if v, ok := headers[name]; ok {
+		return v
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+type roundTripper struct {
+	base     http.RoundTripper
+	settings *ClientAuthSettings
+}
+
+// RoundTrip sends a copy of the request with the basic authentication credentials set.
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.SetBasicAuth(rt.settings.Username, rt.settings.Password)
+	return rt.base.RoundTrip(req)
+}
+
+type perRPCCredentials struct {
+	header string
+}
+
+// GetRequestMetadata returns the "authorization" metadata to add to the RPCs.
+func (c *perRPCCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authorizationHeader: c.header}, nil
+}
+
+// RequireTransportSecurity returns true, so that credentials are never sent over insecure connections.
+func (c *perRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+type authData struct {
+	username string
+}
+
+func (d *authData) GetAttribute(name string) interface{} {
+	if name == AttributeUsername {
+		return d.username
+	}
+	return nil
+}
+
+func (d *authData) GetAttributeNames() []string {
+	return []string{AttributeUsername}
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func basicAuthHeader(username, password string) map[string][]string {
+	return map[string][]string{
+		"authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))},
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	cfg := &Config{
+		Htpasswd: &HtpasswdSettings{
+			File:   filepath.Join("testdata", "htpasswd"),
+			Inline: "inline-user:{SHA}MNLW6wfRtawHZ/atRhQOJCUt398=",
+		},
+	}
+	a := newBasicAuth(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, a.Shutdown(context.Background())) }()
+
+	tests := []struct {
+		name     string
+		headers  map[string][]string
+		username string
+		err      error
+	}{
+		{
+			name:     "file user",
+			headers:  basicAuthHeader("md5-user", "md5-password"),
+			username: "md5-user",
+		},
+		{
+			name:     "inline user",
+			headers:  basicAuthHeader("inline-user", "sha-password"),
+			username: "inline-user",
+		},
+		{
+			name:     "http header",
+			headers:  map[string][]string{"Authorization": basicAuthHeader("sha-user", "sha-password")["authorization"]},
+			username: "sha-user",
+		},
+		{
+			name:    "wrong password",
+			headers: basicAuthHeader("md5-user", "wrong"),
+			err:     errInvalidCredentials,
+		},
+		{
+			name:    "missing",
+			headers: map[string][]string{},
+			err:     errMissingAuth,
+		},
+		{
+			name:    "wrong scheme",
+			headers: map[string][]string{"authorization": {"Bearer token"}},
+			err:     errInvalidFormat,
+		},
+		{
+			name:    "invalid encoding",
+			headers: map[string][]string{"authorization": {"Basic not-base64"}},
+			err:     errInvalidFormat,
+		},
+		{
+			name:    "missing password",
+			headers: map[string][]string{"authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte("md5-user"))}},
+			err:     errInvalidFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := a.Authenticate(context.Background(), tt.headers)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Nil(t, client.FromContext(ctx).Auth)
+				return
+			}
+			require.NoError(t, err)
+			auth := client.FromContext(ctx).Auth
+			require.NotNil(t, auth)
+			assert.Equal(t, tt.username, auth.GetAttribute(AttributeUsername))
+			assert.Equal(t, []string{AttributeUsername}, auth.GetAttributeNames())
+		})
+	}
+}
+
+func TestHtpasswdFileReload(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "htpasswd")
+	require.NoError(t, os.WriteFile(filename, []byte("user:{SHA}MNLW6wfRtawHZ/atRhQOJCUt398=\n"), 0600))
+
+	a := newBasicAuth(&Config{Htpasswd: &HtpasswdSettings{File: filename}}, componenttest.NewNopTelemetrySettings())
+	a.reloadInterval = time.Millisecond
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, a.Shutdown(context.Background())) }()
+
+	_, err := a.Authenticate(context.Background(), basicAuthHeader("user", "sha-password"))
+	assert.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filename, []byte("user:$apr1$8charsal$yQFIVDsIUkbaSqpc1/zpW1\n"), 0600))
+	require.Eventually(t, func() bool {
+		_, err = a.Authenticate(context.Background(), basicAuthHeader("user", "md5-password"))
+		return err == nil
+	}, time.Second, time.Millisecond)
+	_, err = a.Authenticate(context.Background(), basicAuthHeader("user", "sha-password"))
+	assert.ErrorIs(t, err, errInvalidCredentials)
+}
+
+func TestHtpasswdErrors(t *testing.T) {
+	a := newBasicAuth(&Config{Htpasswd: &HtpasswdSettings{Inline: "user:plaintext"}}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, a.Start(context.Background(), componenttest.NewNopHost()), "unsupported hash")
+	assert.NoError(t, a.Shutdown(context.Background()))
+
+	a = newBasicAuth(&Config{Htpasswd: &HtpasswdSettings{File: filepath.Join(t.TempDir(), "missing")}}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, a.Start(context.Background(), componenttest.NewNopHost()), "failed to read")
+	assert.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestClientAuth(t *testing.T) {
+	a := newBasicAuth(&Config{ClientAuth: &ClientAuthSettings{Username: "username", Password: "password"}}, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, a.Shutdown(context.Background())) }()
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("username:password"))
+
+	var received string
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		received = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	rt, err := a.RoundTripper(base)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, expected, received)
+	assert.Empty(t, req.Header.Get("Authorization"))
+
+	creds, err := a.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": expected}, md)
+
+	// The server side isn't configured.
+	_, err = a.Authenticate(context.Background(), basicAuthHeader("username", "password"))
+	assert.ErrorIs(t, err, errNoHtpasswd)
+}
+
+func TestClientAuthNotConfigured(t *testing.T) {
+	a := newBasicAuth(&Config{Htpasswd: &HtpasswdSettings{Inline: "# no users"}}, componenttest.NewNopTelemetrySettings())
+
+	_, err := a.RoundTripper(http.DefaultTransport)
+	assert.ErrorIs(t, err, errNoClientAuth)
+	_, err = a.PerRPCCredentials()
+	assert.ErrorIs(t, err, errNoClientAuth)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension // import "go.opentelemetry.io/collector/extension/basicauthextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// HtpasswdSettings defines the credentials accepted by servers, in the htpasswd format.
+type HtpasswdSettings struct {
+	// File is the path of an htpasswd file, reloaded when the file changes.
+	File string `mapstructure:"file"`
+
+	// Inline is the content of an htpasswd file. Its entries are added to the ones of File.
+	Inline string `mapstructure:"inline"`
+}
+
+// ClientAuthSettings defines the credentials sent by clients.
+type ClientAuthSettings struct {
+	// Username is the user name to authenticate with.
+	Username string `mapstructure:"username"`
+
+	// Password is the password to authenticate with.
+	Password string `mapstructure:"password"`
+}
+
+// Config has the configuration for the basic authenticator extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Htpasswd configures the server side of the authenticator.
+	Htpasswd *HtpasswdSettings `mapstructure:"htpasswd"`
+
+	// ClientAuth configures the client side of the authenticator.
+	ClientAuth *ClientAuthSettings `mapstructure:"client_auth"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Htpasswd == nil && cfg.ClientAuth == nil {
+		return errors.New("either \"htpasswd\" or \"client_auth\" is required when using the \"basicauth\" extension")
+	}
+	if cfg.Htpasswd != nil && cfg.Htpasswd.File == "" && cfg.Htpasswd.Inline == "" {
+		return errors.New("\"htpasswd\" requires either \"file\" or \"inline\" to be set")
+	}
+	if cfg.ClientAuth != nil && cfg.ClientAuth.Username == "" {
+		return errors.New("\"client_auth\" requires \"username\" to be set")
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalExtension(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalExtension(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+			Htpasswd: &HtpasswdSettings{
+				File:   "/etc/otelcol/.htpasswd",
+				Inline: "bcrypt-user:$2a$04$XnD/5DvVRAPVwuz3i5DN.OS/6xNP1XQIDYd8gN8J48cd3V.kYPJg.\n",
+			},
+			ClientAuth: &ClientAuthSettings{
+				Username: "username",
+				Password: "password",
+			},
+		}, cfg)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		err  string
+	}{
+		{
+			name: "empty",
+			cfg:  &Config{},
+			err:  "either \"htpasswd\" or \"client_auth\" is required when using the \"basicauth\" extension",
+		},
+		{
+			name: "empty htpasswd",
+			cfg:  &Config{Htpasswd: &HtpasswdSettings{}},
+			err:  "\"htpasswd\" requires either \"file\" or \"inline\" to be set",
+		},
+		{
+			name: "missing username",
+			cfg:  &Config{ClientAuth: &ClientAuthSettings{Password: "password"}},
+			err:  "\"client_auth\" requires \"username\" to be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.cfg.Validate(), tt.err)
+		})
+	}
+}
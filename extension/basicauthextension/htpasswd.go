@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension // import "go.opentelemetry.io/collector/extension/basicauthextension"
+
+import (
+	"bufio"
+	"crypto/md5"  // #nosec G501 -- required to verify htpasswd MD5 hashes
+	"crypto/sha1" // #nosec G505 -- required to verify htpasswd SHA-1 hashes
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	apr1Prefix = "$apr1$"
+	shaPrefix  = "{SHA}"
+
+	// itoa64 is the alphabet used to encode MD5 crypt hashes.
+	itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// dummyHash is a bcrypt hash, with the default cost, compared against the passwords of unknown
+	// users so that the response time does not tell whether a user exists.
+	dummyHash = "$2a$10$X86GCezK5Ut1IatTU3kxK.W7euwc8Z0d1LPHoPvaIMkWq5XXR1fGS"
+)
+
+// htpasswd maps user names to password hashes, as read from an htpasswd file.
+type htpasswd map[string]string
+
+// parseHtpasswd parses the "user:hash" lines of an htpasswd file. Empty lines and lines
+// starting with "#" are ignored. Only bcrypt, MD5 ("$apr1$") and SHA-1 ("{SHA}") hashes
+// are supported.
+func parseHtpasswd(content string, into htpasswd) error {
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		user, hash, ok := strings.Cut(entry, ":")
+		if !ok || user == "" {
+			return fmt.Errorf("line %d: invalid entry, expected \"user:hash\"", line)
+		}
+		if !isBcrypt(hash) && !strings.HasPrefix(hash, apr1Prefix) && !strings.HasPrefix(hash, shaPrefix) {
+			return fmt.Errorf("line %d: unsupported hash for user %q, only bcrypt, MD5 and SHA-1 hashes are supported", line, user)
+		}
+		into[user] = hash
+	}
+	return scanner.Err()
+}
+
+// matches returns whether the password is valid for the user.
+func (h htpasswd) matches(user, password string) bool {
+	hash, ok := h[user]
+	if !ok {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyHash), []byte(password))
+		return false
+	}
+	switch {
+	case isBcrypt(hash):
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	case strings.HasPrefix(hash, apr1Prefix):
+		salt, _, _ := strings.Cut(strings.TrimPrefix(hash, apr1Prefix), "$")
+		return subtle.ConstantTimeCompare([]byte(apr1(password, salt)), []byte(hash)) == 1
+	case strings.HasPrefix(hash, shaPrefix):
+		sum := sha1.Sum([]byte(password)) // #nosec G401
+		return subtle.ConstantTimeCompare([]byte(shaPrefix+base64.StdEncoding.EncodeToString(sum[:])), []byte(hash)) == 1
+	}
+	return false
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+// apr1 computes the Apache variant of the MD5 crypt hash of the password, as generated by "htpasswd -m".
+func apr1(password, salt string) string {
+	if len(salt) > 8 {
+		salt = salt[:8]
+	}
+	pw := []byte(password)
+
+	alternate := md5.Sum([]byte(password + salt + password)) // #nosec G401
+	h := md5.New()                                           // #nosec G401
+	h.Write([]byte(password + apr1Prefix + salt))
+	for i := len(pw); i > 0; i -= 16 {
+		if i > 16 {
+			h.Write(alternate[:])
+		} else {
+			h.Write(alternate[:i])
+		}
+	}
+	for i := len(pw); i > 0; i >>= 1 {
+		if i&1 == 1 {
+			h.Write([]byte{0})
+		} else {
+			h.Write(pw[:1])
+		}
+	}
+	sum := h.Sum(nil)
+
+	for i := 0; i < 1000; i++ {
+		h = md5.New() // #nosec G401
+		if i&1 == 1 {
+			h.Write(pw)
+		} else {
+			h.Write(sum)
+		}
+		if i%3 != 0 {
+			h.Write([]byte(salt))
+		}
+		if i%7 != 0 {
+			h.Write(pw)
+		}
+		if i&1 == 1 {
+			h.Write(sum)
+		} else {
+			h.Write(pw)
+		}
+		sum = h.Sum(nil)
+	}
+
+	var b strings.Builder
+	b.WriteString(apr1Prefix + salt + "$")
+	encode := func(v uint, n int) {
+		for ; n > 0; n-- {
+			b.WriteByte(itoa64[v&0x3f])
+			v >>= 6
+		}
+	}
+	for _, i := range [][3]int{{0, 6, 12}, {1, 7, 13}, {2, 8, 14}, {3, 9, 15}, {4, 10, 5}} {
+		encode(uint(sum[i[0]])<<16|uint(sum[i[1]])<<8|uint(sum[i[2]]), 4)
+	}
+	encode(uint(sum[11]), 2)
+	return b.String()
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basicauthextension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHtpasswdMatches(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "htpasswd"))
+	require.NoError(t, err)
+	users := htpasswd{}
+	require.NoError(t, parseHtpasswd(string(content), users))
+	assert.Len(t, users, 4)
+
+	tests := []struct {
+		user     string
+		password string
+		matches  bool
+	}{
+		{user: "bcrypt-user", password: "bcrypt-password", matches: true},
+		{user: "bcrypt-user", password: "wrong", matches: false},
+		{user: "bcrypt-2y-user", password: "bcrypt-password", matches: true},
+		{user: "md5-user", password: "md5-password", matches: true},
+		{user: "md5-user", password: "wrong", matches: false},
+		{user: "sha-user", password: "sha-password", matches: true},
+		{user: "sha-user", password: "wrong", matches: false},
+		{user: "unknown-user", password: "bcrypt-password", matches: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.user+"/"+tt.password, func(t *testing.T) {
+			assert.Equal(t, tt.matches, users.matches(tt.user, tt.password))
+		})
+	}
+}
+
+func TestDummyHash(t *testing.T) {
+	// Unknown users are compared against a well-formed hash, costing as much as the default one.
+	cost, err := bcrypt.Cost([]byte(dummyHash))
+	require.NoError(t, err)
+	assert.Equal(t, bcrypt.DefaultCost, cost)
+}
+
+func TestParseHtpasswdErrors(t *testing.T) {
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{
+			content: "# comment\n\nuser",
+			err:     "line 3: invalid entry, expected \"user:hash\"",
+		},
+		{
+			content: ":$apr1$8charsal$yQFIVDsIUkbaSqpc1/zpW1",
+			err:     "line 1: invalid entry, expected \"user:hash\"",
+		},
+		{
+			content: "user:plaintext",
+			err:     "line 1: unsupported hash for user \"user\", only bcrypt, MD5 and SHA-1 hashes are supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err, func(t *testing.T) {
+			assert.EqualError(t, parseHtpasswd(tt.content, htpasswd{}), tt.err)
+		})
+	}
+}
+
+func TestApr1(t *testing.T) {
+	// Hashes generated with "openssl passwd -apr1".
+	assert.Equal(t, "$apr1$r31abcde$kl9eNjSys8oZ/nHjspdaj0", apr1("myPassword", "r31abcde"))
+	assert.Equal(t, "$apr1$ab$vCRbZwmfS.hgWzhQUeJHd1", apr1("a-much-longer-password-than-sixteen-bytes", "ab"))
+}
@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/internal/filereloader"
+)
+
+const (
+	authorizationHeader = "authorization"
+
+	// AttributeSubject is the name of the client.Info.Auth attribute holding the principal
+	// authenticated by the request's token. For the token set with "token" or "filename",
+	// it is the ID of the extension, such as "bearertokenauth/tenant-a".
+	AttributeSubject = "subject"
+)
+
+var (
+	errMissingToken = errors.New("missing bearer token")
+	errInvalidToken = errors.New("invalid bearer token")
+	errEmptyToken   = errors.New("empty bearer token")
+	errNoToken      = errors.New("no token to send, \"token\" or \"filename\" must be set")
+)
+
+var (
+	_ configauth.ServerAuthenticator = (*bearerTokenAuth)(nil)
+	_ configauth.ClientAuthenticator = (*bearerTokenAuth)(nil)
+)
+
+// bearerTokenAuth authenticates outgoing requests by adding the token to their "authorization" header,
+// and incoming requests by checking that header against the token.
+type bearerTokenAuth struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	authData  *authData
+
+	// header is the value of the "authorization" header, built from the scheme and the current token.
+	header *atomic.String
+	// principals holds the []principalHeader accepted from clients in addition to header.
+	principals *atomic.Value
+
+	reloadInterval     time.Duration
+	reloader           *filereloader.Reloader
+	principalsReloader *filereloader.Reloader
+}
+
+// principalHeader is the "authorization" header value authenticating a principal.
+type principalHeader struct {
+	header   []byte
+	authData *authData
+}
+
+func newBearerTokenAuth(cfg *Config, telemetry component.TelemetrySettings) *bearerTokenAuth {
+	a := &bearerTokenAuth{
+		config:     cfg,
+		telemetry:  telemetry,
+		authData:   &authData{subject: cfg.ID().String()},
+		header:     atomic.NewString(""),
+		principals: &atomic.Value{},
+
+		reloadInterval: filereloader.DefaultInterval,
+	}
+	if cfg.BearerToken != "" {
+		a.setToken(cfg.BearerToken)
+	}
+	a.setPrincipals(cfg.Principals)
+	return a
+}
+
+// Start loads the token and the principals from the configured files, if any, and watches the files for changes.
+func (a *bearerTokenAuth) Start(context.Context, component.Host) error {
+	if a.config.Filename != "" {
+		a.reloader = filereloader.New(a.config.Filename, a.reloadInterval, a.loadToken, a.telemetry.Logger)
+		if err := a.reloader.Start(); err != nil {
+			return err
+		}
+	}
+	if a.config.PrincipalsFile != "" {
+		a.principalsReloader = filereloader.New(a.config.PrincipalsFile, a.reloadInterval, a.loadPrincipals, a.telemetry.Logger)
+		if err := a.principalsReloader.Start(); err != nil {
+			// Shutdown is not called when Start fails, stop watching the token file here.
+			if a.reloader != nil {
+				a.reloader.Stop()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown stops watching the token and principals files.
+func (a *bearerTokenAuth) Shutdown(context.Context) error {
+	if a.reloader != nil {
+		a.reloader.Stop()
+	}
+	if a.principalsReloader != nil {
+		a.principalsReloader.Stop()
+	}
+	return nil
+}
+
+func (a *bearerTokenAuth) loadToken(content []byte) error {
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return errEmptyToken
+	}
+	a.setToken(token)
+	return nil
+}
+
+func (a *bearerTokenAuth) setToken(token string) {
+	a.header.Store(a.headerValue(token))
+}
+
+// loadPrincipals parses a principals file, holding one "<token> <principal>" pair per line.
+// Empty lines and lines starting with "#" are ignored.
+func (a *bearerTokenAuth) loadPrincipals(content []byte) error {
+	principals := map[string]string{}
+	tokens := map[string]struct{}{}
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return fmt.Errorf("line %d: expected \"<token> <principal>\"", i+1)
+		}
+		token, principal := fields[0], fields[1]
+		if _, ok := tokens[token]; ok {
+			return fmt.Errorf("line %d: duplicate token", i+1)
+		}
+		if _, ok := principals[principal]; ok {
+			return fmt.Errorf("line %d: duplicate principal %q", i+1, principal)
+		}
+		tokens[token] = struct{}{}
+		principals[principal] = token
+	}
+	a.setPrincipals(principals)
+	return nil
+}
+
+func (a *bearerTokenAuth) setPrincipals(principals map[string]string) {
+	headers := make([]principalHeader, 0, len(principals))
+	for principal, token := range principals {
+		headers = append(headers, principalHeader{
+			header:   []byte(a.headerValue(token)),
+			authData: &authData{subject: principal},
+		})
+	}
+	a.principals.Store(headers)
+}
+
+// headerValue returns the "authorization" header value for token.
+func (a *bearerTokenAuth) headerValue(token string) string {
+	if a.config.Scheme == "" {
+		return token
+	}
+	return a.config.Scheme + " " + token
+}
+
+// Authenticate checks that the "authorization" header holds one of the tokens, and adds the
+// principal it authenticates to the client.Info of the returned context.
+func (a *bearerTokenAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	values := getHeader(headers, authorizationHeader)
+	if len(values) == 0 {
+		return ctx, errMissingToken
+	}
+	received := []byte(values[0])
+
+	// Compare with every candidate, so that the time taken doesn't tell which one matched.
+	var matched *authData
+	if expected := a.header.Load(); expected != "" && subtle.ConstantTimeCompare(received, []byte(expected)) == 1 {
+		matched = a.authData
+	}
+	for _, p := range a.principals.Load().([]principalHeader) {
+		if subtle.ConstantTimeCompare(received, p.header) == 1 && matched == nil {
+			matched = p.authData
+		}
+	}
+	if matched == nil {
+		return ctx, errInvalidToken
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = matched
+	return client.NewContext(ctx, cl), nil
+}
+
+// RoundTripper returns an http.RoundTripper adding the token to the requests.
+func (a *bearerTokenAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	if !a.hasClientToken() {
+		return nil, errNoToken
+	}
+	return &roundTripper{base: base, auth: a}, nil
+}
+
+// PerRPCCredentials returns credentials adding the token to the RPCs.
+func (a *bearerTokenAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	if !a.hasClientToken() {
+		return nil, errNoToken
+	}
+	return &perRPCCredentials{auth: a}, nil
+}
+
+// hasClientToken returns whether a token to send is configured. Principals are only used by servers.
+func (a *bearerTokenAuth) hasClientToken() bool {
+	return a.config.BearerToken != "" || a.config.Filename != ""
+}
+
+// getHeader returns the values of the header with the given name, ignoring case. gRPC
+// metadata keys are lowercase, whereas HTTP headers are in canonical form.
+func getHeader(headers map[string][]string, name string) []string {
+	if v, ok := headers[name]; ok {
+		return v
+	}
+	for k, v := range headers {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+type roundTripper struct {
+	base http.RoundTripper
+	auth *bearerTokenAuth
+}
+
+// RoundTrip sends a copy of the request with the "authorization" header set.
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(authorizationHeader, rt.auth.header.Load())
+	return rt.base.RoundTrip(req)
+}
+
+type perRPCCredentials struct {
+	auth *bearerTokenAuth
+}
+
+// GetRequestMetadata returns the "authorization" metadata to add to the RPCs.
+func (c *perRPCCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authorizationHeader: c.auth.header.Load()}, nil
+}
+
+// RequireTransportSecurity returns true, so that tokens are never sent over insecure connections.
+func (c *perRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+type authData struct {
+	subject string
+}
+
+func (d *authData) GetAttribute(name string) interface{} {
+	if name == AttributeSubject {
+		return d.subject
+	}
+	return nil
+}
+
+func (d *authData) GetAttributeNames() []string {
+	return []string{AttributeSubject}
+}
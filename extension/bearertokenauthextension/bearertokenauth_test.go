@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+)
+
+func newTestAuth(t *testing.T, cfg *Config) *bearerTokenAuth {
+	cfg.ExtensionSettings = config.NewExtensionSettings(config.NewComponentIDWithName(typeStr, "tenant-a"))
+	if cfg.Scheme == "" {
+		cfg.Scheme = defaultScheme
+	}
+	a := newBearerTokenAuth(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, a.Shutdown(context.Background())) })
+	return a
+}
+
+func TestAuthenticate(t *testing.T) {
+	a := newTestAuth(t, &Config{BearerToken: "sometoken"})
+
+	tests := []struct {
+		name    string
+		headers map[string][]string
+		err     error
+	}{
+		{
+			name:    "grpc metadata",
+			headers: map[string][]string{"authorization": {"Bearer sometoken"}},
+		},
+		{
+			name:    "http header",
+			headers: map[string][]string{"Authorization": {"Bearer sometoken"}},
+		},
+		{
+			name:    "missing",
+			headers: map[string][]string{},
+			err:     errMissingToken,
+		},
+		{
+			name:    "wrong token",
+			headers: map[string][]string{"authorization": {"Bearer othertoken"}},
+			err:     errInvalidToken,
+		},
+		{
+			name:    "wrong scheme",
+			headers: map[string][]string{"authorization": {"Basic sometoken"}},
+			err:     errInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := a.Authenticate(context.Background(), tt.headers)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Nil(t, client.FromContext(ctx).Auth)
+				return
+			}
+			require.NoError(t, err)
+			auth := client.FromContext(ctx).Auth
+			require.NotNil(t, auth)
+			assert.Equal(t, "bearertokenauth/tenant-a", auth.GetAttribute(AttributeSubject))
+			assert.Equal(t, []string{AttributeSubject}, auth.GetAttributeNames())
+		})
+	}
+}
+
+func TestAuthenticatePrincipals(t *testing.T) {
+	a := newTestAuth(t, &Config{
+		BearerToken: "sometoken",
+		Principals:  map[string]string{"tenant-b": "tokenb", "tenant-c": "tokenc"},
+	})
+
+	tests := []struct {
+		token   string
+		subject string
+	}{
+		{token: "sometoken", subject: "bearertokenauth/tenant-a"},
+		{token: "tokenb", subject: "tenant-b"},
+		{token: "tokenc", subject: "tenant-c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			ctx, err := a.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer " + tt.token}})
+			require.NoError(t, err)
+			assert.Equal(t, tt.subject, client.FromContext(ctx).Auth.GetAttribute(AttributeSubject))
+		})
+	}
+
+	_, err := a.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer tokend"}})
+	assert.ErrorIs(t, err, errInvalidToken)
+}
+
+func TestPrincipalsOnlyCannotSendTokens(t *testing.T) {
+	a := newTestAuth(t, &Config{Principals: map[string]string{"tenant-b": "tokenb"}})
+
+	_, err := a.RoundTripper(http.DefaultTransport)
+	assert.ErrorIs(t, err, errNoToken)
+	_, err = a.PerRPCCredentials()
+	assert.ErrorIs(t, err, errNoToken)
+}
+
+func TestPrincipalsFileReload(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "principals")
+	require.NoError(t, os.WriteFile(filename, []byte("# token principal\ntokenb tenant-b\n\ntokenc tenant-c\n"), 0600))
+
+	cfg := &Config{PrincipalsFile: filename}
+	cfg.ExtensionSettings = config.NewExtensionSettings(config.NewComponentID(typeStr))
+	cfg.Scheme = defaultScheme
+	a := newBearerTokenAuth(cfg, componenttest.NewNopTelemetrySettings())
+	a.reloadInterval = time.Millisecond
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, a.Shutdown(context.Background())) }()
+
+	ctx, err := a.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer tokenc"}})
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-c", client.FromContext(ctx).Auth.GetAttribute(AttributeSubject))
+
+	require.NoError(t, os.WriteFile(filename, []byte("tokend tenant-d\n"), 0600))
+	require.Eventually(t, func() bool {
+		_, err = a.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer tokend"}})
+		return err == nil
+	}, time.Second, time.Millisecond)
+	_, err = a.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer tokenc"}})
+	assert.ErrorIs(t, err, errInvalidToken)
+}
+
+func TestPrincipalsFileErrors(t *testing.T) {
+	tests := []struct {
+		content string
+		err     string
+	}{
+		{content: "tokenb\n", err: `line 1: expected "<token> <principal>"`},
+		{content: "tokenb tenant-b\ntokenb tenant-c\n", err: "line 2: duplicate token"},
+		{content: "tokenb tenant-b\ntokenc tenant-b\n", err: `line 2: duplicate principal "tenant-b"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.err, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "principals")
+			require.NoError(t, os.WriteFile(filename, []byte(tt.content), 0600))
+			a := newBearerTokenAuth(&Config{PrincipalsFile: filename}, componenttest.NewNopTelemetrySettings())
+			assert.ErrorContains(t, a.Start(context.Background(), componenttest.NewNopHost()), tt.err)
+			assert.NoError(t, a.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestPrincipalsFileErrorStopsTokenFile(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(filename, []byte("sometoken\n"), 0600))
+
+	a := newBearerTokenAuth(&Config{Scheme: defaultScheme, Filename: filename, PrincipalsFile: filepath.Join(dir, "missing")}, componenttest.NewNopTelemetrySettings())
+	a.reloadInterval = time.Millisecond
+	assert.ErrorContains(t, a.Start(context.Background(), componenttest.NewNopHost()), "failed to read")
+
+	// The token file is no longer watched once Start failed.
+	require.NoError(t, os.WriteFile(filename, []byte("othertoken\n"), 0600))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, "Bearer sometoken", a.header.Load())
+}
+
+func TestRoundTripper(t *testing.T) {
+	a := newTestAuth(t, &Config{Scheme: "Token", BearerToken: "sometoken"})
+
+	var received string
+	base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		received = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	rt, err := a.RoundTripper(base)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Token sometoken", received)
+	// The original request is left untouched.
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestPerRPCCredentials(t *testing.T) {
+	a := newTestAuth(t, &Config{BearerToken: "sometoken"})
+
+	creds, err := a.PerRPCCredentials()
+	require.NoError(t, err)
+	assert.True(t, creds.RequireTransportSecurity())
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer sometoken"}, md)
+}
+
+func TestTokenFileReload(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(filename, []byte("firsttoken\n"), 0600))
+
+	cfg := &Config{Filename: filename}
+	cfg.ExtensionSettings = config.NewExtensionSettings(config.NewComponentID(typeStr))
+	cfg.Scheme = defaultScheme
+	a := newBearerTokenAuth(cfg, componenttest.NewNopTelemetrySettings())
+	a.reloadInterval = time.Millisecond
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, a.Shutdown(context.Background())) }()
+
+	_, err := a.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer firsttoken"}})
+	assert.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filename, []byte("secondtoken\n"), 0600))
+	require.Eventually(t, func() bool {
+		_, err = a.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer secondtoken"}})
+		return err == nil
+	}, time.Second, time.Millisecond)
+	_, err = a.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer firsttoken"}})
+	assert.ErrorIs(t, err, errInvalidToken)
+}
+
+func TestTokenFileErrors(t *testing.T) {
+	cfg := &Config{Filename: filepath.Join(t.TempDir(), "missing")}
+	a := newBearerTokenAuth(cfg, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, a.Start(context.Background(), componenttest.NewNopHost()), "failed to read")
+	assert.NoError(t, a.Shutdown(context.Background()))
+
+	filename := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(filename, []byte("\n"), 0600))
+	a = newBearerTokenAuth(&Config{Filename: filename}, componenttest.NewNopTelemetrySettings())
+	assert.ErrorIs(t, a.Start(context.Background(), componenttest.NewNopHost()), errEmptyToken)
+	assert.NoError(t, a.Shutdown(context.Background()))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
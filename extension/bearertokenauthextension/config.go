@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension // import "go.opentelemetry.io/collector/extension/bearertokenauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config has the configuration for the bearer token authenticator extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Scheme is the authentication scheme preceding the token in the "authorization" header.
+	Scheme string `mapstructure:"scheme"`
+
+	// BearerToken is the token sent by clients, and expected from clients by servers.
+	BearerToken string `mapstructure:"token"`
+
+	// Filename is the path of a file containing the token, reloaded when the file changes.
+	// It cannot be used together with BearerToken.
+	Filename string `mapstructure:"filename"`
+
+	// Principals maps principals to their tokens. Servers authenticate a request presenting one of
+	// the tokens as the corresponding principal. Tokens are the values rather than the keys, so that
+	// they are not part of the configuration structure.
+	Principals map[string]string `mapstructure:"principals"`
+
+	// PrincipalsFile is the path of a file holding one "<token> <principal>" pair per line, reloaded
+	// when the file changes. It cannot be used together with Principals.
+	PrincipalsFile string `mapstructure:"principals_file"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.BearerToken == "" && cfg.Filename == "" && len(cfg.Principals) == 0 && cfg.PrincipalsFile == "" {
+		return errors.New("one of \"token\", \"filename\", \"principals\" or \"principals_file\" is required when using the \"bearertokenauth\" extension")
+	}
+	if cfg.BearerToken != "" && cfg.Filename != "" {
+		return errors.New("\"token\" and \"filename\" cannot be set at the same time")
+	}
+	if len(cfg.Principals) > 0 && cfg.PrincipalsFile != "" {
+		return errors.New("\"principals\" and \"principals_file\" cannot be set at the same time")
+	}
+	principals := make([]string, 0, len(cfg.Principals))
+	for principal := range cfg.Principals {
+		principals = append(principals, principal)
+	}
+	sort.Strings(principals)
+	tokens := make(map[string]string, len(cfg.Principals))
+	for _, principal := range principals {
+		token := cfg.Principals[principal]
+		if token == "" {
+			return fmt.Errorf("empty token for principal %q", principal)
+		}
+		if other, ok := tokens[token]; ok {
+			return fmt.Errorf("principals %q and %q have the same token", other, principal)
+		}
+		if token == cfg.BearerToken {
+			return fmt.Errorf("principal %q has the same token as \"token\"", principal)
+		}
+		tokens[token] = principal
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bearertokenauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalExtension(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalExtension(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+			Scheme:            "Token",
+			BearerToken:       "sometoken",
+			Principals:        map[string]string{"tenant-a": "tokena", "tenant-b": "tokenb"},
+		}, cfg)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := &Config{}
+	assert.EqualError(t, cfg.Validate(), "one of \"token\", \"filename\", \"principals\" or \"principals_file\" is required when using the \"bearertokenauth\" extension")
+
+	cfg = &Config{BearerToken: "sometoken", Filename: "token.txt"}
+	assert.EqualError(t, cfg.Validate(), "\"token\" and \"filename\" cannot be set at the same time")
+
+	cfg = &Config{Filename: "token.txt"}
+	assert.NoError(t, cfg.Validate())
+
+	cfg = &Config{Principals: map[string]string{"tenant-a": "tokena"}, PrincipalsFile: "principals.txt"}
+	assert.EqualError(t, cfg.Validate(), "\"principals\" and \"principals_file\" cannot be set at the same time")
+
+	cfg = &Config{Principals: map[string]string{"tenant-a": ""}}
+	assert.EqualError(t, cfg.Validate(), "empty token for principal \"tenant-a\"")
+
+	cfg = &Config{Principals: map[string]string{"tenant-a": "sometoken", "tenant-b": "sometoken"}}
+	assert.EqualError(t, cfg.Validate(), "principals \"tenant-a\" and \"tenant-b\" have the same token")
+
+	cfg = &Config{BearerToken: "sometoken", Principals: map[string]string{"tenant-a": "sometoken"}}
+	assert.EqualError(t, cfg.Validate(), "principal \"tenant-a\" has the same token as \"token\"")
+
+	cfg = &Config{PrincipalsFile: "principals.txt"}
+	assert.NoError(t, cfg.Validate())
+}
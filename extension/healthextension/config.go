@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration for the health extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// HTTPServerSettings configures the server serving the health endpoints.
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// LivenessPath is the path of the liveness endpoint, failing when a component reported
+	// a permanent or fatal error, or a recoverable error for longer than RecoveryDuration.
+	LivenessPath string `mapstructure:"liveness_path"`
+
+	// ReadinessPath is the path of the readiness endpoint, failing until the collector is
+	// running, and while a component does not report an OK status.
+	ReadinessPath string `mapstructure:"readiness_path"`
+
+	// RecoveryDuration is how long a component can report a recoverable error, e.g. an
+	// exporter failing to send data, before the liveness endpoint fails.
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"health\" extension")
+	}
+	if !strings.HasPrefix(cfg.LivenessPath, "/") {
+		return fmt.Errorf("invalid liveness_path %q, must start with \"/\"", cfg.LivenessPath)
+	}
+	if !strings.HasPrefix(cfg.ReadinessPath, "/") {
+		return fmt.Errorf("invalid readiness_path %q, must start with \"/\"", cfg.ReadinessPath)
+	}
+	if cfg.LivenessPath == cfg.ReadinessPath {
+		return errors.New("liveness_path and readiness_path must be different")
+	}
+	if cfg.RecoveryDuration <= 0 {
+		return fmt.Errorf("invalid recovery_duration %v, must be positive", cfg.RecoveryDuration)
+	}
+	return nil
+}
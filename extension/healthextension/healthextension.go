@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// stateRunning is the state of a running collector, see service.State.
+const stateRunning = "Running"
+
+// statusPriority orders the statuses from the healthiest to the least healthy, for the
+// rollups of the pipelines.
+var statusPriority = map[component.Status]int{
+	component.StatusNone:             0,
+	component.StatusOK:               1,
+	component.StatusStarting:         2,
+	component.StatusStopping:         3,
+	component.StatusStopped:          4,
+	component.StatusRecoverableError: 5,
+	component.StatusPermanentError:   6,
+	component.StatusFatalError:       7,
+}
+
+// componentState is the last status reported for a component instance.
+type componentState struct {
+	source *component.StatusSource
+	event  *component.StatusEvent
+	// since is when the instance started reporting its current status.
+	since time.Time
+}
+
+// componentStatus is the status of a component instance in the health responses.
+type componentStatus struct {
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+	Since  time.Time `json:"since"`
+
+	status component.Status
+}
+
+// pipelineStatus is the status of a pipeline in the health responses, rolled up from its components.
+type pipelineStatus struct {
+	Status     string                      `json:"status"`
+	Components map[string]*componentStatus `json:"components"`
+
+	status component.Status
+}
+
+// healthStatus is the body of the health responses.
+type healthStatus struct {
+	Live           bool                        `json:"live"`
+	Ready          bool                        `json:"ready"`
+	CollectorState string                      `json:"collector_state,omitempty"`
+	PipelinesReady bool                        `json:"pipelines_ready"`
+	Pipelines      map[string]*pipelineStatus  `json:"pipelines"`
+	Extensions     map[string]*componentStatus `json:"extensions"`
+}
+
+type healthExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	server    *http.Server
+	stopCh    chan struct{}
+
+	// collectorState returns the state of the collector, or an empty string if unknown.
+	collectorState func() string
+	now            func() time.Time
+
+	mu             sync.Mutex
+	pipelinesReady bool
+	components     map[component.Kind]map[string]*componentState
+}
+
+var _ component.PipelineWatcher = (*healthExtension)(nil)
+var _ component.StatusWatcher = (*healthExtension)(nil)
+
+func newHealthExtension(config *Config, telemetry component.TelemetrySettings) *healthExtension {
+	return &healthExtension{
+		config:         config,
+		telemetry:      telemetry,
+		collectorState: func() string { return "" },
+		now:            time.Now,
+		components:     make(map[component.Kind]map[string]*componentState),
+	}
+}
+
+func (he *healthExtension) Start(_ context.Context, host component.Host) error {
+	if stateHost, ok := host.(interface {
+		GetCollectorState() string
+	}); ok {
+		he.collectorState = stateHost.GetCollectorState
+	} else {
+		he.telemetry.Logger.Warn("Collector state not available")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(he.config.LivenessPath, func(w http.ResponseWriter, r *http.Request) {
+		status := he.status()
+		writeStatus(w, status, status.Live)
+	})
+	mux.HandleFunc(he.config.ReadinessPath, func(w http.ResponseWriter, r *http.Request) {
+		status := he.status()
+		writeStatus(w, status, status.Ready)
+	})
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := he.config.ToListener()
+	if err != nil {
+		return err
+	}
+	if he.server, err = he.config.ToServer(host, he.telemetry, mux); err != nil {
+		return err
+	}
+
+	he.telemetry.Logger.Info("Starting health extension", zap.String("endpoint", he.config.Endpoint))
+	he.stopCh = make(chan struct{})
+	go func() {
+		defer close(he.stopCh)
+
+		if errHTTP := he.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (he *healthExtension) Shutdown(context.Context) error {
+	if he.server == nil {
+		return nil
+	}
+	err := he.server.Close()
+	if he.stopCh != nil {
+		<-he.stopCh
+	}
+	return err
+}
+
+// Ready implements the component.PipelineWatcher interface.
+func (he *healthExtension) Ready() error {
+	he.mu.Lock()
+	defer he.mu.Unlock()
+	he.pipelinesReady = true
+	return nil
+}
+
+// NotReady implements the component.PipelineWatcher interface.
+func (he *healthExtension) NotReady() error {
+	he.mu.Lock()
+	defer he.mu.Unlock()
+	he.pipelinesReady = false
+	return nil
+}
+
+// ComponentStatusChanged implements the component.StatusWatcher interface.
+func (he *healthExtension) ComponentStatusChanged(source *component.StatusSource, event *component.StatusEvent) {
+	he.mu.Lock()
+	defer he.mu.Unlock()
+	byKey, ok := he.components[source.Kind]
+	if !ok {
+		byKey = make(map[string]*componentState)
+		he.components[source.Kind] = byKey
+	}
+	key := source.InstanceKey()
+	state, ok := byKey[key]
+	if !ok || state.event.Status != event.Status {
+		state = &componentState{since: event.Timestamp}
+		byKey[key] = state
+	}
+	// Repeated reports of the same status keep its start time, and update its error.
+	state.source = source
+	state.event = event
+}
+
+// status returns the current health of the collector.
+func (he *healthExtension) status() *healthStatus {
+	now := he.now()
+	status := &healthStatus{
+		Live:           true,
+		CollectorState: he.collectorState(),
+		Pipelines:      make(map[string]*pipelineStatus),
+		Extensions:     make(map[string]*componentStatus),
+	}
+
+	he.mu.Lock()
+	defer he.mu.Unlock()
+	status.PipelinesReady = he.pipelinesReady
+	status.Ready = he.pipelinesReady && (status.CollectorState == "" || status.CollectorState == stateRunning)
+	for kind, byKey := range he.components {
+		for _, state := range byKey {
+			switch state.event.Status {
+			case component.StatusOK:
+			case component.StatusRecoverableError:
+				status.Ready = false
+				if now.Sub(state.since) > he.config.RecoveryDuration {
+					status.Live = false
+				}
+			case component.StatusPermanentError, component.StatusFatalError:
+				status.Ready = false
+				status.Live = false
+			default:
+				status.Ready = false
+			}
+
+			cs := &componentStatus{Status: state.event.Status.String(), Since: state.since, status: state.event.Status}
+			if state.event.Err != nil {
+				cs.Error = state.event.Err.Error()
+			}
+			if kind == component.KindExtension {
+				status.Extensions[state.source.ID.String()] = cs
+				continue
+			}
+			name := kind.String() + "/" + state.source.ID.String()
+			for _, pipelineID := range state.source.Pipelines {
+				ps, ok := status.Pipelines[pipelineID.String()]
+				if !ok {
+					ps = &pipelineStatus{Components: make(map[string]*componentStatus)}
+					status.Pipelines[pipelineID.String()] = ps
+				}
+				// The instances of a connector emitting several data types in the pipeline report the worst status.
+				if prev, ok := ps.Components[name]; !ok || statusPriority[state.event.Status] >= statusPriority[prev.status] {
+					ps.Components[name] = cs
+				}
+				if statusPriority[state.event.Status] > statusPriority[ps.status] {
+					ps.status = state.event.Status
+				}
+			}
+		}
+	}
+	for _, ps := range status.Pipelines {
+		ps.Status = ps.status.String()
+	}
+	return status
+}
+
+func writeStatus(w http.ResponseWriter, status *healthStatus, healthy bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(status)
+}
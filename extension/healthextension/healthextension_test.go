@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+type stateHost struct {
+	component.Host
+	state string
+}
+
+func (h *stateHost) GetCollectorState() string {
+	return h.state
+}
+
+func getStatus(t *testing.T, url string) (int, *healthStatus) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	status := &healthStatus{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(status))
+	return resp.StatusCode, status
+}
+
+func TestHealthExtension(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	he := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	start := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+	now := start
+	he.now = func() time.Time { return now }
+
+	host := &stateHost{Host: componenttest.NewNopHost(), state: "Starting"}
+	require.NoError(t, he.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, he.Shutdown(context.Background())) })
+	liveURL := "http://" + cfg.Endpoint + cfg.LivenessPath
+	readyURL := "http://" + cfg.Endpoint + cfg.ReadinessPath
+
+	tracesID := config.NewComponentID("traces")
+	metricsID := config.NewComponentID("metrics")
+	receiver := &component.StatusSource{Kind: component.KindReceiver, ID: config.NewComponentID("otlp"), DataType: config.TracesDataType,
+		Pipelines: []config.ComponentID{tracesID}}
+	exporter := &component.StatusSource{Kind: component.KindExporter, ID: config.NewComponentID("otlp"), DataType: config.TracesDataType,
+		Pipelines: []config.ComponentID{tracesID}}
+	processor := &component.StatusSource{Kind: component.KindProcessor, ID: config.NewComponentID("batch"), DataType: config.MetricsDataType,
+		Pipelines: []config.ComponentID{metricsID}}
+	extension := &component.StatusSource{Kind: component.KindExtension, ID: config.NewComponentID("health")}
+	report := func(source *component.StatusSource, status component.Status, err error) {
+		he.ComponentStatusChanged(source, &component.StatusEvent{Status: status, Err: err, Timestamp: now})
+	}
+
+	for _, source := range []*component.StatusSource{extension, exporter, processor} {
+		report(source, component.StatusStarting, nil)
+		report(source, component.StatusOK, nil)
+	}
+	report(receiver, component.StatusStarting, nil)
+
+	// Live while starting, but not ready.
+	code, status := getStatus(t, liveURL)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, status.Live)
+	assert.False(t, status.Ready)
+	assert.Equal(t, "Starting", status.CollectorState)
+	assert.Equal(t, "Starting", status.Pipelines["traces"].Status)
+	code, _ = getStatus(t, readyURL)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	report(receiver, component.StatusOK, nil)
+	require.NoError(t, he.Ready())
+	host.state = "Running"
+	code, status = getStatus(t, readyURL)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, &healthStatus{
+		Live:           true,
+		Ready:          true,
+		CollectorState: "Running",
+		PipelinesReady: true,
+		Pipelines: map[string]*pipelineStatus{
+			"traces": {Status: "OK", Components: map[string]*componentStatus{
+				"receiver/otlp": {Status: "OK", Since: start},
+				"exporter/otlp": {Status: "OK", Since: start},
+			}},
+			"metrics": {Status: "OK", Components: map[string]*componentStatus{
+				"processor/batch": {Status: "OK", Since: start},
+			}},
+		},
+		Extensions: map[string]*componentStatus{
+			"health": {Status: "OK", Since: start},
+		},
+	}, status)
+
+	// A failing exporter makes the collector not ready right away, and not live after the recovery duration.
+	failedAt := now
+	report(exporter, component.StatusRecoverableError, errors.New("connection refused"))
+	now = now.Add(cfg.RecoveryDuration)
+	report(exporter, component.StatusRecoverableError, errors.New("deadline exceeded"))
+	code, status = getStatus(t, readyURL)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.True(t, status.Live)
+	assert.Equal(t, "RecoverableError", status.Pipelines["traces"].Status)
+	assert.Equal(t, "OK", status.Pipelines["metrics"].Status)
+	exporterStatus := status.Pipelines["traces"].Components["exporter/otlp"]
+	assert.Equal(t, "deadline exceeded", exporterStatus.Error)
+	assert.True(t, failedAt.Equal(exporterStatus.Since))
+
+	now = now.Add(time.Second)
+	code, status = getStatus(t, liveURL)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, status.Live)
+
+	// The collector is live and ready again once the exporter recovers.
+	report(exporter, component.StatusOK, nil)
+	code, _ = getStatus(t, liveURL)
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = getStatus(t, readyURL)
+	assert.Equal(t, http.StatusOK, code)
+
+	// Permanent errors make the collector not live right away.
+	report(processor, component.StatusPermanentError, errors.New("invalid configuration"))
+	code, status = getStatus(t, liveURL)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "PermanentError", status.Pipelines["metrics"].Status)
+
+	require.NoError(t, he.NotReady())
+	_, status = getStatus(t, readyURL)
+	assert.False(t, status.PipelinesReady)
+}
+
+func TestHealthExtensionComponentInstances(t *testing.T) {
+	he := newHealthExtension(createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+	now := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+	he.now = func() time.Time { return now }
+	require.NoError(t, he.Ready())
+
+	tracesA := config.NewComponentIDWithName("traces", "a")
+	tracesB := config.NewComponentIDWithName("traces", "b")
+	report := func(source *component.StatusSource, status component.Status) {
+		he.ComponentStatusChanged(source, &component.StatusEvent{Status: status, Timestamp: now})
+	}
+	// The same processor in two pipelines, and a connector emitting two data types in the same pipeline.
+	report(&component.StatusSource{Kind: component.KindProcessor, ID: config.NewComponentID("batch"), DataType: config.TracesDataType,
+		Pipelines: []config.ComponentID{tracesA}}, component.StatusOK)
+	report(&component.StatusSource{Kind: component.KindProcessor, ID: config.NewComponentID("batch"), DataType: config.TracesDataType,
+		Pipelines: []config.ComponentID{tracesB}}, component.StatusPermanentError)
+	report(&component.StatusSource{Kind: component.KindConnector, ID: config.NewComponentID("count"), DataType: config.TracesDataType,
+		EmittedDataType: config.MetricsDataType, Pipelines: []config.ComponentID{tracesA}}, component.StatusRecoverableError)
+	report(&component.StatusSource{Kind: component.KindConnector, ID: config.NewComponentID("count"), DataType: config.TracesDataType,
+		EmittedDataType: config.LogsDataType, Pipelines: []config.ComponentID{tracesA}}, component.StatusOK)
+
+	status := he.status()
+	assert.False(t, status.Ready)
+	assert.False(t, status.Live)
+	require.Contains(t, status.Pipelines, "traces/a")
+	require.Contains(t, status.Pipelines, "traces/b")
+	assert.Equal(t, "RecoverableError", status.Pipelines["traces/a"].Status)
+	assert.Equal(t, "OK", status.Pipelines["traces/a"].Components["processor/batch"].Status)
+	assert.Equal(t, "RecoverableError", status.Pipelines["traces/a"].Components["connector/count"].Status)
+	assert.Equal(t, "PermanentError", status.Pipelines["traces/b"].Status)
+}
+
+func TestHealthExtensionPortAlreadyInUse(t *testing.T) {
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	ln, err := net.Listen("tcp", endpoint)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	he := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.Error(t, he.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, he.Shutdown(context.Background()))
+}
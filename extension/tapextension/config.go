@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapextension // import "go.opentelemetry.io/collector/extension/tapextension"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration for the tap extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// HTTPServerSettings configures the server serving the tap endpoint.
+	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// Path is the path of the WebSocket endpoint streaming the tapped data.
+	Path string `mapstructure:"path"`
+
+	// MaxRate is the maximum number of batches per second streamed to a session,
+	// and the rate of the sessions not requesting a lower one.
+	MaxRate float64 `mapstructure:"max_rate"`
+
+	// MaxSessions is the maximum number of sessions streaming data at the same time.
+	MaxSessions int `mapstructure:"max_sessions"`
+
+	// BufferSize is the number of batches buffered for a session, the batches are
+	// dropped while its buffer is full.
+	BufferSize int `mapstructure:"buffer_size"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"tap\" extension")
+	}
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return fmt.Errorf("invalid path %q, must start with \"/\"", cfg.Path)
+	}
+	if cfg.MaxRate <= 0 {
+		return fmt.Errorf("invalid max_rate %v, must be positive", cfg.MaxRate)
+	}
+	if cfg.MaxSessions <= 0 {
+		return fmt.Errorf("invalid max_sessions %d, must be positive", cfg.MaxSessions)
+	}
+	if cfg.BufferSize <= 0 {
+		return fmt.Errorf("invalid buffer_size %d, must be positive", cfg.BufferSize)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapextension // import "go.opentelemetry.io/collector/extension/tapextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+const (
+	// The value of extension "type" in configuration.
+	typeStr = "tap"
+
+	defaultEndpoint    = "localhost:55690"
+	defaultPath        = "/tap"
+	defaultMaxRate     = 10
+	defaultMaxSessions = 5
+	defaultBufferSize  = 100
+)
+
+// NewFactory creates a factory for the tap extension.
+func NewFactory() component.ExtensionFactory {
+	return component.NewExtensionFactory(typeStr, createDefaultConfig, createExtension, component.StabilityLevelInDevelopment)
+}
+
+func createDefaultConfig() config.Extension {
+	return &Config{
+		ExtensionSettings: config.NewExtensionSettings(config.NewComponentID(typeStr)),
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: defaultEndpoint,
+		},
+		Path:        defaultPath,
+		MaxRate:     defaultMaxRate,
+		MaxSessions: defaultMaxSessions,
+		BufferSize:  defaultBufferSize,
+	}
+}
+
+// createExtension creates the extension based on this config.
+func createExtension(_ context.Context, set component.ExtensionCreateSettings, cfg config.Extension) (component.Extension, error) {
+	return newTapExtension(cfg.(*Config), set.TelemetrySettings), nil
+}
@@ -0,0 +1,328 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapextension // import "go.opentelemetry.io/collector/extension/tapextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// The query parameters of the sessions.
+const (
+	pipelineParam          = "pipeline"
+	receiverParam          = "receiver"
+	processorParam         = "processor"
+	samplingRatioParam     = "sampling_ratio"
+	rateParam              = "rate"
+	resourceAttributeParam = "resource_attribute"
+)
+
+var (
+	tracesMarshaler  = ptrace.NewJSONMarshaler()
+	metricsMarshaler = pmetric.NewJSONMarshaler()
+	logsMarshaler    = plog.NewJSONMarshaler()
+)
+
+// sessionParams are the parameters of a session, from the query of its request.
+type sessionParams struct {
+	pipelineID config.ComponentID
+	// kind and id identify the receiver or processor after which the data is tapped.
+	kind component.Kind
+	id   config.ComponentID
+
+	samplingRatio      float64
+	rate               float64
+	resourceAttributes map[string]string
+}
+
+func parseSessionParams(query url.Values, maxRate float64) (*sessionParams, error) {
+	params := &sessionParams{
+		samplingRatio:      1,
+		rate:               maxRate,
+		resourceAttributes: map[string]string{},
+	}
+
+	var err error
+	if params.pipelineID, err = config.NewComponentIDFromString(query.Get(pipelineParam)); err != nil {
+		return nil, fmt.Errorf("invalid %q parameter: %w", pipelineParam, err)
+	}
+
+	receiver, processor := query.Get(receiverParam), query.Get(processorParam)
+	switch {
+	case receiver != "" && processor != "":
+		return nil, fmt.Errorf("only one of the %q and %q parameters can be set", receiverParam, processorParam)
+	case receiver != "":
+		params.kind = component.KindReceiver
+		params.id, err = config.NewComponentIDFromString(receiver)
+	case processor != "":
+		params.kind = component.KindProcessor
+		params.id, err = config.NewComponentIDFromString(processor)
+	default:
+		return nil, fmt.Errorf("one of the %q and %q parameters is required", receiverParam, processorParam)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %q parameter: %w", params.kind, err)
+	}
+
+	if ratio := query.Get(samplingRatioParam); ratio != "" {
+		params.samplingRatio, err = strconv.ParseFloat(ratio, 64)
+		if err != nil || params.samplingRatio <= 0 || params.samplingRatio > 1 {
+			return nil, fmt.Errorf("invalid %q parameter %q, must be in (0, 1]", samplingRatioParam, ratio)
+		}
+	}
+
+	if rate := query.Get(rateParam); rate != "" {
+		params.rate, err = strconv.ParseFloat(rate, 64)
+		if err != nil || params.rate <= 0 {
+			return nil, fmt.Errorf("invalid %q parameter %q, must be positive", rateParam, rate)
+		}
+		params.rate = math.Min(params.rate, maxRate)
+	}
+
+	for _, attr := range query[resourceAttributeParam] {
+		key, value, found := strings.Cut(attr, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid %q parameter %q, must be key=value", resourceAttributeParam, attr)
+		}
+		params.resourceAttributes[key] = value
+	}
+	return params, nil
+}
+
+// rateLimiter is a token bucket allowing rate events per second, with bursts of up to rate events.
+type rateLimiter struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newRateLimiter(rate float64, now func() time.Time) *rateLimiter {
+	burst := math.Max(rate, 1)
+	return &rateLimiter{rate: rate, burst: burst, tokens: burst, last: now(), now: now}
+}
+
+func (rl *rateLimiter) allow() bool {
+	now := rl.now()
+	rl.tokens = math.Min(rl.burst, rl.tokens+now.Sub(rl.last).Seconds()*rl.rate)
+	rl.last = now
+	if rl.tokens < 1 {
+		return false
+	}
+	rl.tokens--
+	return true
+}
+
+// session is attached to a pipeline, and streams the JSON encoded copies of the data it samples.
+type session struct {
+	params *sessionParams
+	// random returns a number in [0, 1) to sample the batches.
+	random func() float64
+	out    chan []byte
+
+	mu      sync.Mutex
+	limiter *rateLimiter
+	dropped int
+
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ consumer.Traces = (*session)(nil)
+var _ consumer.Metrics = (*session)(nil)
+var _ consumer.Logs = (*session)(nil)
+
+func newSession(params *sessionParams, bufferSize int) *session {
+	return &session{
+		params:  params,
+		random:  rand.Float64, // #nosec G404 -- sampling does not need a secure random number generator.
+		out:     make(chan []byte, bufferSize),
+		limiter: newRateLimiter(params.rate, time.Now),
+		done:    make(chan struct{}),
+	}
+}
+
+// Capabilities implements the consumer interfaces, the session never modifies the data.
+func (s *session) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces streams a copy of td, keeping only the resources matching the session filters.
+func (s *session) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	if !s.sample() {
+		return nil
+	}
+	rss := td.ResourceSpans()
+	if matching := s.countMatching(rss.Len(), func(i int) pcommon.Resource { return rss.At(i).Resource() }); matching == 0 {
+		return nil
+	} else if matching < rss.Len() {
+		filtered := ptrace.NewTraces()
+		td.CopyTo(filtered)
+		filtered.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool { return !s.matches(rs.Resource()) })
+		td = filtered
+	}
+	if !s.allow() {
+		return nil
+	}
+	return s.send(tracesMarshaler.MarshalTraces(td))
+}
+
+// ConsumeMetrics streams a copy of md, keeping only the resources matching the session filters.
+func (s *session) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if !s.sample() {
+		return nil
+	}
+	rms := md.ResourceMetrics()
+	if matching := s.countMatching(rms.Len(), func(i int) pcommon.Resource { return rms.At(i).Resource() }); matching == 0 {
+		return nil
+	} else if matching < rms.Len() {
+		filtered := pmetric.NewMetrics()
+		md.CopyTo(filtered)
+		filtered.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool { return !s.matches(rm.Resource()) })
+		md = filtered
+	}
+	if !s.allow() {
+		return nil
+	}
+	return s.send(metricsMarshaler.MarshalMetrics(md))
+}
+
+// ConsumeLogs streams a copy of ld, keeping only the resources matching the session filters.
+func (s *session) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	if !s.sample() {
+		return nil
+	}
+	rls := ld.ResourceLogs()
+	if matching := s.countMatching(rls.Len(), func(i int) pcommon.Resource { return rls.At(i).Resource() }); matching == 0 {
+		return nil
+	} else if matching < rls.Len() {
+		filtered := plog.NewLogs()
+		ld.CopyTo(filtered)
+		filtered.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool { return !s.matches(rl.Resource()) })
+		ld = filtered
+	}
+	if !s.allow() {
+		return nil
+	}
+	return s.send(logsMarshaler.MarshalLogs(ld))
+}
+
+func (s *session) sample() bool {
+	return s.params.samplingRatio >= 1 || s.random() < s.params.samplingRatio
+}
+
+func (s *session) countMatching(n int, resource func(int) pcommon.Resource) int {
+	if len(s.params.resourceAttributes) == 0 {
+		return n
+	}
+	matching := 0
+	for i := 0; i < n; i++ {
+		if s.matches(resource(i)) {
+			matching++
+		}
+	}
+	return matching
+}
+
+// matches returns true if the resource has all the attributes the session filters on.
+func (s *session) matches(resource pcommon.Resource) bool {
+	for key, value := range s.params.resourceAttributes {
+		v, ok := resource.Attributes().Get(key)
+		if !ok || v.AsString() != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *session) allow() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limiter.allow() {
+		return true
+	}
+	s.dropped++
+	return false
+}
+
+// send queues the encoded batch to be streamed, it is dropped if the buffer of the session is full.
+// The errors are not returned to the pipeline, which must not be affected by the session.
+func (s *session) send(buf []byte, err error) error {
+	if err != nil {
+		return nil
+	}
+	select {
+	case s.out <- buf:
+	default:
+		s.mu.Lock()
+		s.dropped++
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// droppedBatches returns the number of batches sampled but not streamed, because of the rate or of a full buffer.
+func (s *session) droppedBatches() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+func (s *session) stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+var errTapNotSupported = errors.New("the host does not support tapping the pipelines")
+
+// tapHost is implemented by the hosts allowing to tap the pipelines.
+type tapHost interface {
+	TapTraces(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, tc consumer.Traces) (func(), error)
+	TapMetrics(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, mc consumer.Metrics) (func(), error)
+	TapLogs(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, lc consumer.Logs) (func(), error)
+}
+
+// attach attaches the session to its pipeline on host, and returns the function detaching it.
+func (s *session) attach(host tapHost) (func(), error) {
+	if host == nil {
+		return nil, errTapNotSupported
+	}
+	p := s.params
+	switch p.pipelineID.Type() {
+	case config.TracesDataType:
+		return host.TapTraces(p.pipelineID, p.kind, p.id, s)
+	case config.MetricsDataType:
+		return host.TapMetrics(p.pipelineID, p.kind, p.id, s)
+	case config.LogsDataType:
+		return host.TapLogs(p.pipelineID, p.kind, p.id, s)
+	}
+	return nil, fmt.Errorf("pipeline %q has an unsupported data type", p.pipelineID)
+}
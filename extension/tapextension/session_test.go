@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapextension
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestParseSessionParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected *sessionParams
+		err      string
+	}{
+		{
+			name:  "receiver",
+			query: "pipeline=traces/1&receiver=otlp",
+			expected: &sessionParams{
+				pipelineID:         config.NewComponentIDWithName("traces", "1"),
+				kind:               component.KindReceiver,
+				id:                 config.NewComponentID("otlp"),
+				samplingRatio:      1,
+				rate:               10,
+				resourceAttributes: map[string]string{},
+			},
+		},
+		{
+			name:  "processor with options",
+			query: "pipeline=logs&processor=batch/2&sampling_ratio=0.25&rate=2&resource_attribute=service.name=front=end&resource_attribute=host.name=",
+			expected: &sessionParams{
+				pipelineID:         config.NewComponentID("logs"),
+				kind:               component.KindProcessor,
+				id:                 config.NewComponentIDWithName("batch", "2"),
+				samplingRatio:      0.25,
+				rate:               2,
+				resourceAttributes: map[string]string{"service.name": "front=end", "host.name": ""},
+			},
+		},
+		{
+			name:  "rate capped",
+			query: "pipeline=metrics&receiver=otlp&rate=100",
+			expected: &sessionParams{
+				pipelineID:         config.NewComponentID("metrics"),
+				kind:               component.KindReceiver,
+				id:                 config.NewComponentID("otlp"),
+				samplingRatio:      1,
+				rate:               10,
+				resourceAttributes: map[string]string{},
+			},
+		},
+		{
+			name:  "missing pipeline",
+			query: "receiver=otlp",
+			err:   `invalid "pipeline" parameter: id must not be empty`,
+		},
+		{
+			name:  "missing position",
+			query: "pipeline=traces",
+			err:   `one of the "receiver" and "processor" parameters is required`,
+		},
+		{
+			name:  "both positions",
+			query: "pipeline=traces&receiver=otlp&processor=batch",
+			err:   `only one of the "receiver" and "processor" parameters can be set`,
+		},
+		{
+			name:  "invalid sampling ratio",
+			query: "pipeline=traces&receiver=otlp&sampling_ratio=0",
+			err:   `invalid "sampling_ratio" parameter "0", must be in (0, 1]`,
+		},
+		{
+			name:  "invalid rate",
+			query: "pipeline=traces&receiver=otlp&rate=fast",
+			err:   `invalid "rate" parameter "fast", must be positive`,
+		},
+		{
+			name:  "invalid resource attribute",
+			query: "pipeline=traces&receiver=otlp&resource_attribute=service.name",
+			err:   `invalid "resource_attribute" parameter "service.name", must be key=value`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+			params, err := parseSessionParams(query, 10)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, params)
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2022, 9, 1, 10, 0, 0, 0, time.UTC)
+	rl := newRateLimiter(2, func() time.Time { return now })
+	// Bursts of up to the rate are allowed.
+	assert.True(t, rl.allow())
+	assert.True(t, rl.allow())
+	assert.False(t, rl.allow())
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, rl.allow())
+	assert.False(t, rl.allow())
+
+	now = now.Add(time.Hour)
+	assert.True(t, rl.allow())
+	assert.True(t, rl.allow())
+	assert.False(t, rl.allow())
+
+	// Rates lower than one event per second allow one event at a time.
+	rl = newRateLimiter(0.5, func() time.Time { return now })
+	assert.True(t, rl.allow())
+	assert.False(t, rl.allow())
+	now = now.Add(time.Second)
+	assert.False(t, rl.allow())
+	now = now.Add(time.Second)
+	assert.True(t, rl.allow())
+}
+
+func newTestSession(params *sessionParams) *session {
+	if params.resourceAttributes == nil {
+		params.resourceAttributes = map[string]string{}
+	}
+	if params.rate == 0 {
+		params.rate = 1000
+	}
+	if params.samplingRatio == 0 {
+		params.samplingRatio = 1
+	}
+	return newSession(params, 10)
+}
+
+func generateTraces(services ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, service := range services {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func TestSessionFilter(t *testing.T) {
+	s := newTestSession(&sessionParams{resourceAttributes: map[string]string{"service.name": "front"}})
+
+	td := generateTraces("front", "back")
+	require.NoError(t, s.ConsumeTraces(context.Background(), td))
+	// The data of the pipeline is not modified.
+	assert.Equal(t, 2, td.ResourceSpans().Len())
+	got, err := ptrace.NewJSONUnmarshaler().UnmarshalTraces(<-s.out)
+	require.NoError(t, err)
+	assert.Equal(t, generateTraces("front"), got)
+
+	// The batches without matching resources are not streamed.
+	require.NoError(t, s.ConsumeTraces(context.Background(), generateTraces("back")))
+	assert.Len(t, s.out, 0)
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("service.name", "front")
+	md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutInt("service.name", 1)
+	require.NoError(t, s.ConsumeMetrics(context.Background(), md))
+	gotMetrics, err := pmetric.NewJSONUnmarshaler().UnmarshalMetrics(<-s.out)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotMetrics.ResourceMetrics().Len())
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("service.name", "front")
+	require.NoError(t, s.ConsumeLogs(context.Background(), ld))
+	gotLogs, err := plog.NewJSONUnmarshaler().UnmarshalLogs(<-s.out)
+	require.NoError(t, err)
+	assert.Equal(t, ld, gotLogs)
+}
+
+func TestSessionSampling(t *testing.T) {
+	s := newTestSession(&sessionParams{samplingRatio: 0.5})
+	random := []float64{0.2, 0.7, 0.5, 0.1}
+	s.random = func() float64 {
+		r := random[0]
+		random = random[1:]
+		return r
+	}
+	for i := 0; i < 4; i++ {
+		require.NoError(t, s.ConsumeTraces(context.Background(), generateTraces("front")))
+	}
+	assert.Len(t, s.out, 2)
+	assert.Equal(t, 0, s.droppedBatches())
+}
+
+func TestSessionDrops(t *testing.T) {
+	// The batches over the rate are dropped.
+	s := newTestSession(&sessionParams{rate: 2})
+	for i := 0; i < 5; i++ {
+		require.NoError(t, s.ConsumeTraces(context.Background(), generateTraces("front")))
+	}
+	assert.Len(t, s.out, 2)
+	assert.Equal(t, 3, s.droppedBatches())
+
+	// The batches are dropped while the buffer is full.
+	s = newTestSession(&sessionParams{})
+	for i := 0; i < 15; i++ {
+		require.NoError(t, s.ConsumeTraces(context.Background(), generateTraces("front")))
+	}
+	assert.Len(t, s.out, 10)
+	assert.Equal(t, 5, s.droppedBatches())
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapextension // import "go.opentelemetry.io/collector/extension/tapextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type tapExtension struct {
+	config    *Config
+	telemetry component.TelemetrySettings
+	server    *http.Server
+	stopCh    chan struct{}
+	host      tapHost
+
+	mu       sync.Mutex
+	closed   bool
+	sessions map[*session]struct{}
+	wg       sync.WaitGroup
+}
+
+func newTapExtension(config *Config, telemetry component.TelemetrySettings) *tapExtension {
+	return &tapExtension{
+		config:    config,
+		telemetry: telemetry,
+		sessions:  make(map[*session]struct{}),
+	}
+}
+
+func (te *tapExtension) Start(_ context.Context, host component.Host) error {
+	if th, ok := host.(tapHost); ok {
+		te.host = th
+	} else {
+		te.telemetry.Logger.Warn("Host's pipelines cannot be tapped")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(te.config.Path, te.handleSession)
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := te.config.ToListener()
+	if err != nil {
+		return err
+	}
+	if te.server, err = te.config.ToServer(host, te.telemetry, mux); err != nil {
+		return err
+	}
+
+	te.telemetry.Logger.Info("Starting tap extension", zap.String("endpoint", te.config.Endpoint))
+	te.stopCh = make(chan struct{})
+	go func() {
+		defer close(te.stopCh)
+
+		if errHTTP := te.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+func (te *tapExtension) Shutdown(context.Context) error {
+	if te.server == nil {
+		return nil
+	}
+	// The WebSocket connections are not closed with the server, stop the sessions first.
+	te.mu.Lock()
+	te.closed = true
+	for s := range te.sessions {
+		s.stop()
+	}
+	te.mu.Unlock()
+	err := te.server.Close()
+	if te.stopCh != nil {
+		<-te.stopCh
+	}
+	te.wg.Wait()
+	return err
+}
+
+// handleSession attaches a session to the pipeline requested, and streams the data it taps
+// over a WebSocket connection until the client or the extension closes it.
+func (te *tapExtension) handleSession(w http.ResponseWriter, r *http.Request) {
+	params, err := parseSessionParams(r.URL.Query(), te.config.MaxRate)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s := newSession(params, te.config.BufferSize)
+	if err = te.addSession(s); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer te.removeSession(s)
+
+	detach, err := s.attach(te.host)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer detach()
+
+	logger := te.telemetry.Logger.With(
+		zap.String("pipeline", params.pipelineID.String()),
+		zap.String(params.kind.String(), params.id.String()))
+	logger.Info("Tap session started")
+	websocket.Server{Handshake: checkOrigin, Handler: s.stream}.ServeHTTP(w, r)
+	logger.Info("Tap session ended", zap.Int("dropped_batches", s.droppedBatches()))
+}
+
+func (te *tapExtension) addSession(s *session) error {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	if te.closed {
+		return errors.New("the extension is shutting down")
+	}
+	if len(te.sessions) >= te.config.MaxSessions {
+		return fmt.Errorf("too many sessions, at most %d are allowed", te.config.MaxSessions)
+	}
+	te.sessions[s] = struct{}{}
+	te.wg.Add(1)
+	return nil
+}
+
+func (te *tapExtension) removeSession(s *session) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	delete(te.sessions, s)
+	te.wg.Done()
+}
+
+// stream sends the batches of the session as text messages, until the session is stopped or the connection closed.
+func (s *session) stream(ws *websocket.Conn) {
+	go func() {
+		// The clients are not expected to send messages, reading fails once they close the connection.
+		_, _ = io.Copy(io.Discard, ws)
+		s.stop()
+	}()
+	go func() {
+		// Closing the connection interrupts the pending writes once the session is stopped.
+		<-s.done
+		_ = ws.Close()
+	}()
+	defer s.stop()
+
+	for {
+		select {
+		case buf := <-s.out:
+			if err := websocket.Message.Send(ws, string(buf)); err != nil {
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// checkOrigin rejects the requests from browsers on other origins than the extension's one,
+// the clients which are not browsers do not send an Origin header.
+func checkOrigin(cfg *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return err
+	}
+	if u.Host != r.Host {
+		return fmt.Errorf("origin %q not allowed", origin)
+	}
+	cfg.Origin = u
+	return nil
+}
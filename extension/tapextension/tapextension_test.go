@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapextension
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// tapsHost is a host with a traces pipeline, which can be tapped after its otlp receiver.
+type tapsHost struct {
+	component.Host
+
+	mu   sync.Mutex
+	taps []consumer.Traces
+}
+
+func (h *tapsHost) TapTraces(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, tc consumer.Traces) (func(), error) {
+	if pipelineID != config.NewComponentID(config.TracesDataType) || kind != component.KindReceiver || id != config.NewComponentID("otlp") {
+		return nil, errors.New("not in pipeline")
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.taps = append(h.taps, tc)
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		for i, tap := range h.taps {
+			if tap == tc {
+				h.taps = append(h.taps[:i], h.taps[i+1:]...)
+				return
+			}
+		}
+	}, nil
+}
+
+func (h *tapsHost) TapMetrics(config.ComponentID, component.Kind, config.ComponentID, consumer.Metrics) (func(), error) {
+	return nil, errors.New("not in pipeline")
+}
+
+func (h *tapsHost) TapLogs(config.ComponentID, component.Kind, config.ComponentID, consumer.Logs) (func(), error) {
+	return nil, errors.New("not in pipeline")
+}
+
+func (h *tapsHost) attached() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.taps)
+}
+
+func (h *tapsHost) consume(t *testing.T, td ptrace.Traces) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, tap := range h.taps {
+		require.NoError(t, tap.ConsumeTraces(context.Background(), td))
+	}
+}
+
+func startTapExtension(t *testing.T, host component.Host) (*tapExtension, string) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.MaxSessions = 1
+	te := newTapExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, te.Start(context.Background(), host))
+	t.Cleanup(func() { assert.NoError(t, te.Shutdown(context.Background())) })
+	return te, cfg.Endpoint
+}
+
+func TestTapExtension(t *testing.T) {
+	host := &tapsHost{Host: componenttest.NewNopHost()}
+	_, endpoint := startTapExtension(t, host)
+
+	ws, err := websocket.Dial("ws://"+endpoint+"/tap?pipeline=traces&receiver=otlp", "", "http://"+endpoint)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return host.attached() == 1 }, time.Second, time.Millisecond)
+
+	// Only one session is allowed.
+	resp, err := http.Get("http://" + endpoint + "/tap?pipeline=traces&receiver=otlp")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.NoError(t, resp.Body.Close())
+
+	td := generateTraces("front")
+	host.consume(t, td)
+	var msg string
+	require.NoError(t, websocket.Message.Receive(ws, &msg))
+	got, err := ptrace.NewJSONUnmarshaler().UnmarshalTraces([]byte(msg))
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+
+	// The session is detached once the client closes the connection.
+	require.NoError(t, ws.Close())
+	assert.Eventually(t, func() bool { return host.attached() == 0 }, time.Second, time.Millisecond)
+}
+
+func TestTapExtensionShutdown(t *testing.T) {
+	host := &tapsHost{Host: componenttest.NewNopHost()}
+	te, endpoint := startTapExtension(t, host)
+
+	ws, err := websocket.Dial("ws://"+endpoint+"/tap?pipeline=traces&receiver=otlp", "", "http://"+endpoint)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return host.attached() == 1 }, time.Second, time.Millisecond)
+
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Equal(t, 0, host.attached())
+	_, err = io.ReadAll(ws)
+	assert.NoError(t, err)
+}
+
+func TestTapExtensionErrors(t *testing.T) {
+	host := &tapsHost{Host: componenttest.NewNopHost()}
+	_, endpoint := startTapExtension(t, host)
+	_, noTapEndpoint := startTapExtension(t, componenttest.NewNopHost())
+
+	tests := []struct {
+		name       string
+		url        string
+		origin     string
+		statusCode int
+		body       string
+	}{
+		{
+			name:       "invalid parameters",
+			url:        "http://" + endpoint + "/tap?pipeline=traces",
+			statusCode: http.StatusBadRequest,
+			body:       `one of the "receiver" and "processor" parameters is required`,
+		},
+		{
+			name:       "not in pipeline",
+			url:        "http://" + endpoint + "/tap?pipeline=traces&processor=batch",
+			statusCode: http.StatusBadRequest,
+			body:       "not in pipeline",
+		},
+		{
+			name:       "tap not supported",
+			url:        "http://" + noTapEndpoint + "/tap?pipeline=traces&receiver=otlp",
+			statusCode: http.StatusBadRequest,
+			body:       errTapNotSupported.Error(),
+		},
+		{
+			name:       "other origin",
+			url:        "http://" + endpoint + "/tap?pipeline=traces&receiver=otlp",
+			origin:     "http://example.com",
+			statusCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			req.Header.Set("Connection", "Upgrade")
+			req.Header.Set("Upgrade", "websocket")
+			req.Header.Set("Sec-WebSocket-Version", "13")
+			req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.statusCode, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, strings.TrimSpace(string(body)))
+			assert.Equal(t, 0, host.attached())
+		})
+	}
+}
+
+func TestTapExtensionPortAlreadyInUse(t *testing.T) {
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	ln, err := net.Listen("tcp", endpoint)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	te := newTapExtension(cfg, componenttest.NewNopTelemetrySettings())
+	assert.Error(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, te.Shutdown(context.Background()))
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package cgroups // import "go.opentelemetry.io/collector/internal/cgroups"
+
+import (
+	"regexp"
+)
+
+var (
+	// _cgroupContainerIDPattern matches the 64 hexadecimal digits container
+	// IDs ending the CGroup paths of the Docker, containerd and CRI-O
+	// runtimes, such as "/docker/<id>", "/kubepods/besteffort/pod<uid>/<id>"
+	// or "/system.slice/cri-containerd-<id>.scope".
+	_cgroupContainerIDPattern = regexp.MustCompile(`(?:^|[/:-])([0-9a-f]{64})(?:\.scope)?$`)
+	// _mountContainerIDPattern matches the container IDs in the roots of the
+	// files mounted by the Docker and containerd runtimes, such as
+	// "/var/lib/docker/containers/<id>/hostname".
+	_mountContainerIDPattern = regexp.MustCompile(`/containers/([0-9a-f]{64})/`)
+)
+
+// ContainerIDForCurrentProcess returns the ID of the container the current
+// process runs in, and false when it is not found.
+func ContainerIDForCurrentProcess() (string, bool, error) {
+	return containerID(_procPathCGroup, _procPathMountInfo)
+}
+
+// containerID looks up the container ID in the CGroup paths of procPathCGroup,
+// then in the mount points of procPathMountInfo: with CGroup-V2 and a CGroup
+// namespace, the CGroup path of the process is "/".
+func containerID(procPathCGroup, procPathMountInfo string) (string, bool, error) {
+	cgroupSubsystems, err := parseCGroupSubsystems(procPathCGroup)
+	if err != nil {
+		return "", false, err
+	}
+	for _, subsys := range cgroupSubsystems {
+		if match := _cgroupContainerIDPattern.FindStringSubmatch(subsys.Name); match != nil {
+			return match[1], true, nil
+		}
+	}
+
+	var id string
+	newMountPoint := func(mp *MountPoint) error {
+		if match := _mountContainerIDPattern.FindStringSubmatch(mp.Root); id == "" && match != nil {
+			id = match[1]
+		}
+		return nil
+	}
+	if err := parseMountInfo(procPathMountInfo, newMountPoint); err != nil {
+		return "", false, err
+	}
+	return id, id != "", nil
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package cgroups
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerID(t *testing.T) {
+	testTable := []struct {
+		name   string
+		proc   string
+		id     string
+		exists bool
+	}{
+		{name: "cgroup v1 without container", proc: "cgroups"},
+		{name: "docker", proc: "docker", id: "8d0f3a07c1b5e9f2a46d1c3b7e5f9a0b2c4d6e8f1a3b5c7d9e0f2a4b6c8d0e1f", exists: true},
+		{name: "kubernetes systemd", proc: "kubepods", id: "3e9a1f5c7b2d4e6f8a0b1c3d5e7f9a2b4c6d8e0f1a3b5c7d9e2f4a6b8c0d1e3f", exists: true},
+		{name: "cgroup namespace", proc: "cgroupns", id: "8d0f3a07c1b5e9f2a46d1c3b7e5f9a0b2c4d6e8f1a3b5c7d9e0f2a4b6c8d0e1f", exists: true},
+	}
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(testDataProcPath, tt.proc)
+			id, exists, err := containerID(filepath.Join(dir, "cgroup"), filepath.Join(dir, "mountinfo"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.exists, exists)
+			assert.Equal(t, tt.id, id)
+		})
+	}
+
+	_, _, err := containerID(filepath.Join(testDataProcPath, "invalid-cgroup", "cgroup"), filepath.Join(testDataProcPath, "cgroups", "mountinfo"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filereloader exposes util functionality for components that need to load
+// credentials from files, and to reload them when the files change.
+package filereloader // import "go.opentelemetry.io/collector/internal/filereloader"
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultInterval is the interval at which files are read to check for changes.
+const DefaultInterval = 5 * time.Second
+
+// Reloader reads a file periodically, and calls a function every time its content changes.
+// Polling rather than watching file system events makes it work across atomic replacements
+// of the file, such as Kubernetes secret updates.
+type Reloader struct {
+	path     string
+	interval time.Duration
+	onChange func(content []byte) error
+	logger   *zap.Logger
+
+	last   []byte
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New returns a Reloader for the file at path. The onChange function is called with the content
+// of the file each time it changes. When it returns an error, the previous content is kept in use.
+func New(path string, interval time.Duration, onChange func(content []byte) error, logger *zap.Logger) *Reloader {
+	return &Reloader{
+		path:     path,
+		interval: interval,
+		onChange: onChange,
+		logger:   logger,
+	}
+}
+
+// Start reads the file, calls onChange with its content, and then watches it for changes in
+// the background. Errors are only returned for the initial read.
+func (r *Reloader) Start() error {
+	if err := r.reload(); err != nil {
+		return err
+	}
+	r.stopCh = make(chan struct{})
+	r.wg.Add(1)
+	go r.run()
+	return nil
+}
+
+// Stop stops watching the file. It is safe to call even if Start failed or wasn't called.
+func (r *Reloader) Stop() {
+	if r.stopCh == nil {
+		return
+	}
+	close(r.stopCh)
+	r.wg.Wait()
+	r.stopCh = nil
+}
+
+func (r *Reloader) run() {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stopCh:
+			return
+		case <-ticker.C:
+			if err := r.reload(); err != nil {
+				r.logger.Warn("Failed to reload file, keeping the previous content", zap.String("path", r.path), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (r *Reloader) reload() error {
+	content, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to read %q: %w", r.path, err)
+	}
+	if r.last != nil && bytes.Equal(content, r.last) {
+		return nil
+	}
+	if err = r.onChange(content); err != nil {
+		return fmt.Errorf("failed to load %q: %w", r.path, err)
+	}
+	if r.last != nil {
+		r.logger.Info("Reloaded file", zap.String("path", r.path))
+	}
+	r.last = content
+	return nil
+}
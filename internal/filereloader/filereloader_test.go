@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereloader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recorder struct {
+	mu       sync.Mutex
+	contents []string
+}
+
+func (r *recorder) onChange(content []byte) error {
+	if string(content) == "invalid" {
+		return errors.New("invalid content")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contents = append(r.contents, string(content))
+	return nil
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.contents...)
+}
+
+func TestReloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0600))
+
+	rec := &recorder{}
+	r := New(path, time.Millisecond, rec.onChange, zap.NewNop())
+	require.NoError(t, r.Start())
+	defer r.Stop()
+	assert.Equal(t, []string{"first"}, rec.get())
+
+	// Invalid content is ignored.
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, []string{"first"}, rec.get())
+
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0600))
+	require.Eventually(t, func() bool {
+		return len(rec.get()) == 2
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"first", "second"}, rec.get())
+}
+
+func TestReloaderStartErrors(t *testing.T) {
+	rec := &recorder{}
+	r := New(filepath.Join(t.TempDir(), "missing"), time.Millisecond, rec.onChange, zap.NewNop())
+	assert.ErrorContains(t, r.Start(), "failed to read")
+	r.Stop()
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+	r = New(path, time.Millisecond, rec.onChange, zap.NewNop())
+	assert.EqualError(t, r.Start(), `failed to load "`+path+`": invalid content`)
+	r.Stop()
+	assert.Empty(t, rec.get())
+}
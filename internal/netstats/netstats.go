@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netstats carries the number of bytes of the requests handled by the
+// confighttp and configgrpc helpers, through the context, to the obsreport
+// helpers recording them.
+package netstats // import "go.opentelemetry.io/collector/internal/netstats"
+
+import (
+	"context"
+
+	"go.uber.org/atomic"
+)
+
+type ctxKey struct{}
+
+// Counter accumulates the number of bytes of the requests handled with a context.
+type Counter struct {
+	bytes     atomic.Int64
+	wireBytes atomic.Int64
+}
+
+// AddBytes adds n to the number of bytes of the requests, without compression.
+func (c *Counter) AddBytes(n int64) {
+	c.bytes.Add(n)
+}
+
+// AddWireBytes adds n to the number of bytes of the requests, as transferred over the network.
+func (c *Counter) AddWireBytes(n int64) {
+	c.wireBytes.Add(n)
+}
+
+// Swap returns the number of bytes counted since the previous call, and resets them.
+func (c *Counter) Swap() (bytes int64, wireBytes int64) {
+	return c.bytes.Swap(0), c.wireBytes.Swap(0)
+}
+
+// NewContext returns a context carrying the counter.
+func NewContext(ctx context.Context, c *Counter) context.Context {
+	return context.WithValue(ctx, ctxKey{}, c)
+}
+
+// FromContext returns the counter carried by the context, or nil if there is none.
+func FromContext(ctx context.Context) *Counter {
+	c, _ := ctx.Value(ctxKey{}).(*Counter)
+	return c
+}
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netstats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	ctx := NewContext(context.Background(), &Counter{})
+	c := FromContext(ctx)
+	assert.NotNil(t, c)
+
+	c.AddBytes(10)
+	c.AddBytes(5)
+	c.AddWireBytes(7)
+	bytes, wireBytes := c.Swap()
+	assert.Equal(t, int64(15), bytes)
+	assert.Equal(t, int64(7), wireBytes)
+
+	bytes, wireBytes = c.Swap()
+	assert.Zero(t, bytes)
+	assert.Zero(t, wireBytes)
+}
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreportconfig // import "go.opentelemetry.io/collector/internal/obsreportconfig"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// OverflowValue is the value recorded for a dimension once the number of its distinct
+// values reached the configured maximum cardinality.
+const OverflowValue = "other"
+
+// DefaultMaxCardinality is the maximum number of distinct values recorded for a dimension
+// whose MaxCardinality isn't set.
+const DefaultMaxCardinality = 100
+
+const maxValueLength = 255
+
+// Dimension is an additional dimension of the receiver and exporter metrics. Its value is
+// read from the client.Info of the context the telemetry data is received with.
+type Dimension struct {
+	// Name is the name of the tag the value is recorded with.
+	Name string
+	// MetadataKey is the key of the client metadata the value is read from.
+	MetadataKey string
+	// AuthAttribute is the attribute of the client authentication data the value is read from.
+	AuthAttribute string
+	// MaxCardinality is the maximum number of distinct values recorded for the dimension,
+	// after which other values are recorded as OverflowValue. Zero means DefaultMaxCardinality.
+	MaxCardinality int
+}
+
+// dimension tracks the values recorded for a configured Dimension.
+type dimension struct {
+	Dimension
+	key tag.Key
+
+	mu     sync.Mutex
+	values map[string]struct{}
+}
+
+var (
+	dimensionsMu sync.RWMutex
+	dimensions   []*dimension
+)
+
+// setDimensions replaces the configured dimensions, forgetting the values recorded so far.
+// Dimensions with an invalid name are ignored, they are rejected by the config validation.
+func setDimensions(dims []Dimension) {
+	var ds []*dimension
+	for _, d := range dims {
+		key, err := tag.NewKey(d.Name)
+		if err != nil {
+			continue
+		}
+		if d.MaxCardinality <= 0 {
+			d.MaxCardinality = DefaultMaxCardinality
+		}
+		ds = append(ds, &dimension{Dimension: d, key: key, values: map[string]struct{}{}})
+	}
+
+	dimensionsMu.Lock()
+	defer dimensionsMu.Unlock()
+	dimensions = ds
+}
+
+// DimensionTagKeys returns the tag keys of the configured dimensions.
+func DimensionTagKeys() []tag.Key {
+	dimensionsMu.RLock()
+	defer dimensionsMu.RUnlock()
+	keys := make([]tag.Key, 0, len(dimensions))
+	for _, d := range dimensions {
+		keys = append(keys, d.key)
+	}
+	return keys
+}
+
+// DimensionTags returns the values of the configured dimensions for the given client.
+// Dimensions whose value is missing are omitted.
+func DimensionTags(info client.Info) []tag.Tag {
+	dimensionsMu.RLock()
+	defer dimensionsMu.RUnlock()
+	if len(dimensions) == 0 {
+		return nil
+	}
+	var tags []tag.Tag
+	for _, d := range dimensions {
+		if v := d.value(info); v != "" {
+			tags = append(tags, tag.Tag{Key: d.key, Value: v})
+		}
+	}
+	return tags
+}
+
+type dimensionTagsKey struct{}
+
+// ContextWithDimensionTags returns a context whose dimension values are the given tags, rather than
+// the ones of its client.Info. It is used for the data of several clients with the same dimension
+// values, e.g. batched together, which no longer has the client.Info of a single client.
+func ContextWithDimensionTags(ctx context.Context, tags []tag.Tag) context.Context {
+	return context.WithValue(ctx, dimensionTagsKey{}, tags)
+}
+
+// DimensionTagsFromContext returns the values of the configured dimensions for the data of the
+// context, set either with ContextWithDimensionTags or read from its client.Info.
+func DimensionTagsFromContext(ctx context.Context) []tag.Tag {
+	if tags, ok := ctx.Value(dimensionTagsKey{}).([]tag.Tag); ok {
+		return tags
+	}
+	return DimensionTags(client.FromContext(ctx))
+}
+
+// DimensionsKey returns a string identifying the dimension values, to group the data of the clients
+// with the same values.
+func DimensionsKey(tags []tag.Tag) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for _, t := range tags {
+		// Tag names and values are printable ASCII characters, so newlines can separate them.
+		b.WriteString(t.Key.Name())
+		b.WriteByte('=')
+		b.WriteString(t.Value)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (d *dimension) value(info client.Info) string {
+	var v string
+	switch {
+	case d.MetadataKey != "":
+		v = strings.Join(info.Metadata.Get(d.MetadataKey), ",")
+	case d.AuthAttribute != "" && info.Auth != nil:
+		switch attr := info.Auth.GetAttribute(d.AuthAttribute).(type) {
+		case nil:
+		case string:
+			v = attr
+		default:
+			v = fmt.Sprint(attr)
+		}
+	}
+	if v == "" {
+		return ""
+	}
+	return d.bucket(sanitizeValue(v))
+}
+
+// sanitizeValue makes v a valid tag value, which is limited to 255 printable ASCII characters.
+func sanitizeValue(v string) string {
+	v = strings.Map(func(r rune) rune {
+		if r < ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, v)
+	if len(v) > maxValueLength {
+		v = v[:maxValueLength]
+	}
+	return v
+}
+
+// bucket returns v, or OverflowValue if v would exceed the maximum cardinality of the dimension.
+func (d *dimension) bucket(v string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.values[v]; ok {
+		return v
+	}
+	if len(d.values) >= d.MaxCardinality {
+		return OverflowValue
+	}
+	d.values[v] = struct{}{}
+	return v
+}
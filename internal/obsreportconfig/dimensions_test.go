@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreportconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opencensus.io/tag"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	var names []string
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestDimensionTags(t *testing.T) {
+	Configure(configtelemetry.LevelNormal,
+		Dimension{Name: "tenant", MetadataKey: "X-Tenant"},
+		Dimension{Name: "user", AuthAttribute: "subject"},
+	)
+	t.Cleanup(func() { Configure(configtelemetry.LevelNormal) })
+
+	tenant := tag.MustNewKey("tenant")
+	user := tag.MustNewKey("user")
+	assert.Equal(t, []tag.Key{tenant, user}, DimensionTagKeys())
+
+	tests := []struct {
+		name string
+		info client.Info
+		want []tag.Tag
+	}{
+		{
+			name: "no client",
+		},
+		{
+			name: "metadata",
+			info: client.Info{Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"team-a"}})},
+			want: []tag.Tag{{Key: tenant, Value: "team-a"}},
+		},
+		{
+			name: "metadata and auth",
+			info: client.Info{
+				Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"team-a", "team-b"}}),
+				Auth:     authData{"subject": "alice"},
+			},
+			want: []tag.Tag{{Key: tenant, Value: "team-a,team-b"}, {Key: user, Value: "alice"}},
+		},
+		{
+			name: "non-string auth attribute",
+			info: client.Info{Auth: authData{"subject": 42}},
+			want: []tag.Tag{{Key: user, Value: "42"}},
+		},
+		{
+			name: "invalid characters",
+			info: client.Info{Auth: authData{"subject": "é\n" + strings.Repeat("a", 300)}},
+			want: []tag.Tag{{Key: user, Value: ("__" + strings.Repeat("a", 300))[:255]}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DimensionTags(tt.info))
+		})
+	}
+}
+
+func TestDimensionMaxCardinality(t *testing.T) {
+	Configure(configtelemetry.LevelNormal, Dimension{Name: "tenant", MetadataKey: "x-tenant", MaxCardinality: 2})
+	t.Cleanup(func() { Configure(configtelemetry.LevelNormal) })
+
+	valueFor := func(tenant string) string {
+		tags := DimensionTags(client.Info{Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}})})
+		return tags[0].Value
+	}
+	assert.Equal(t, "a", valueFor("a"))
+	assert.Equal(t, "b", valueFor("b"))
+	assert.Equal(t, OverflowValue, valueFor("c"))
+	assert.Equal(t, "a", valueFor("a"))
+	assert.Equal(t, OverflowValue, valueFor("d"))
+
+	// Configuring the dimensions again resets the values.
+	Configure(configtelemetry.LevelNormal, Dimension{Name: "tenant", MetadataKey: "x-tenant", MaxCardinality: 2})
+	assert.Equal(t, "c", valueFor("c"))
+}
+
+func TestDimensionDefaultMaxCardinality(t *testing.T) {
+	Configure(configtelemetry.LevelNormal, Dimension{Name: "tenant", MetadataKey: "x-tenant"})
+	t.Cleanup(func() { Configure(configtelemetry.LevelNormal) })
+
+	var last string
+	for i := 0; i <= DefaultMaxCardinality; i++ {
+		tags := DimensionTags(client.Info{Metadata: client.NewMetadata(map[string][]string{"x-tenant": {fmt.Sprint(i)}})})
+		last = tags[0].Value
+	}
+	assert.Equal(t, OverflowValue, last)
+}
+
+func TestDimensionTagsFromContext(t *testing.T) {
+	Configure(configtelemetry.LevelNormal, Dimension{Name: "tenant", MetadataKey: "x-tenant"})
+	t.Cleanup(func() { Configure(configtelemetry.LevelNormal) })
+
+	tenant := tag.MustNewKey("tenant")
+	ctx := client.NewContext(context.Background(), client.Info{Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"a"}})})
+	assert.Equal(t, []tag.Tag{{Key: tenant, Value: "a"}}, DimensionTagsFromContext(ctx))
+
+	// Tags set explicitly take precedence over the client.Info.
+	pinned := []tag.Tag{{Key: tenant, Value: "b"}}
+	assert.Equal(t, pinned, DimensionTagsFromContext(ContextWithDimensionTags(ctx, pinned)))
+	assert.Empty(t, DimensionTagsFromContext(ContextWithDimensionTags(ctx, nil)))
+
+	assert.Equal(t, "", DimensionsKey(nil))
+	assert.Equal(t, "tenant=b\n", DimensionsKey(pinned))
+}
+
+func TestConfigureDimensionViews(t *testing.T) {
+	obsMetrics := Configure(configtelemetry.LevelNormal, Dimension{Name: "tenant", MetadataKey: "x-tenant"})
+	t.Cleanup(func() { Configure(configtelemetry.LevelNormal) })
+
+	tenant := tag.MustNewKey("tenant")
+	for _, v := range obsMetrics.Views {
+		switch v.Measure {
+		case obsmetrics.ReceiverAcceptedSpans:
+			assert.Equal(t, []tag.Key{obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport, tenant}, v.TagKeys)
+		case obsmetrics.ExporterSentSpans:
+			assert.Equal(t, []tag.Key{obsmetrics.TagKeyExporter, tenant}, v.TagKeys)
+		case obsmetrics.ProcessorAcceptedSpans:
+			assert.Equal(t, []tag.Key{obsmetrics.TagKeyProcessor}, v.TagKeys)
+		}
+	}
+}
@@ -37,6 +37,12 @@ const (
 	SentLogRecordsKey = "sent_log_records"
 	// FailedToSendLogRecordsKey used to track logs that failed to be sent by exporters.
 	FailedToSendLogRecordsKey = "send_failed_log_records"
+
+	// SentBytesKey used to track the size of the requests sent by exporters, before compression.
+	SentBytesKey = "sent_bytes"
+	// SentWireBytesKey used to track the size of the requests sent by exporters, as transferred
+	// over the network.
+	SentWireBytesKey = "sent_wire_bytes"
 )
 
 var (
@@ -76,4 +82,12 @@ var (
 		ExporterPrefix+FailedToSendLogRecordsKey,
 		"Number of log records in failed attempts to send to destination.",
 		stats.UnitDimensionless)
+	ExporterSentBytes = stats.Int64(
+		ExporterPrefix+SentBytesKey,
+		"Number of bytes sent to destination, including failed attempts, before compression.",
+		stats.UnitBytes)
+	ExporterSentWireBytes = stats.Int64(
+		ExporterPrefix+SentWireBytesKey,
+		"Number of bytes sent to destination, including failed attempts, as transferred over the network after compression.",
+		stats.UnitBytes)
 )
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsmetrics // import "go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
+const (
+	// PipelineKey is the key used to identify pipelines in metrics and traces.
+	PipelineKey = "pipeline"
+
+	// FanoutKey is the key used to identify the fanouts of pipelines to their exporters in metrics.
+	FanoutKey = "fanout"
+
+	// BranchSucceededRequestsKey is the key used to identify the requests successfully passed to a branch of a fanout.
+	BranchSucceededRequestsKey = "branch_succeeded_requests"
+
+	// BranchFailedRequestsKey is the key used to identify the requests a branch of a fanout failed to consume.
+	BranchFailedRequestsKey = "branch_failed_requests"
+)
+
+var (
+	TagKeyPipeline, _ = tag.NewKey(PipelineKey)
+
+	FanoutPrefix = FanoutKey + NameSep
+
+	// Fanout metrics, recorded for each exporter of the pipelines passing their data to their
+	// exporters asynchronously.
+	FanoutBranchSucceededRequests = stats.Int64(
+		FanoutPrefix+BranchSucceededRequestsKey,
+		"Number of requests successfully consumed by the exporter of a fanout branch.",
+		stats.UnitDimensionless)
+	FanoutBranchFailedRequests = stats.Int64(
+		FanoutPrefix+BranchFailedRequestsKey,
+		"Number of requests the exporter of a fanout branch failed to consume.",
+		stats.UnitDimensionless)
+)
@@ -38,6 +38,10 @@ var (
 
 	ProcessorPrefix = ProcessorKey + NameSep
 
+	ProcessTraceDataOperationSuffix = NameSep + "traces"
+	ProcessMetricsOperationSuffix   = NameSep + "metrics"
+	ProcessLogsOperationSuffix      = NameSep + "logs"
+
 	// Processor metrics. Any count of data items below is in the internal format
 	// of the collector since processors only deal with internal format.
 	ProcessorAcceptedSpans = stats.Int64(
@@ -43,6 +43,13 @@ const (
 	// RefusedLogRecordsKey used to identify log records refused (ie.: not ingested) by the
 	// Collector.
 	RefusedLogRecordsKey = "refused_log_records"
+
+	// ReceivedBytesKey used to track the size of the requests received by the Collector,
+	// after decompression.
+	ReceivedBytesKey = "received_bytes"
+	// ReceivedWireBytesKey used to track the size of the requests received by the Collector,
+	// as transferred over the network.
+	ReceivedWireBytesKey = "received_wire_bytes"
 )
 
 var (
@@ -83,4 +90,12 @@ var (
 		ReceiverPrefix+RefusedLogRecordsKey,
 		"Number of log records that could not be pushed into the pipeline.",
 		stats.UnitDimensionless)
+	ReceiverReceivedBytes = stats.Int64(
+		ReceiverPrefix+ReceivedBytesKey,
+		"Number of bytes received, after decompression.",
+		stats.UnitBytes)
+	ReceiverReceivedWireBytes = stats.Int64(
+		ReceiverPrefix+ReceivedWireBytesKey,
+		"Number of bytes received, as transferred over the network before decompression.",
+		stats.UnitBytes)
 )
@@ -40,7 +40,7 @@ func RegisterInternalMetricFeatureGate(registry *featuregate.Registry) {
 	registry.MustRegister(featuregate.Gate{
 		ID:          UseOtelForInternalMetricsfeatureGateID,
 		Description: "controls whether the collector uses OpenTelemetry for internal metrics",
-		Enabled:     false,
+		Enabled:     true,
 	})
 }
 
@@ -50,8 +50,9 @@ type ObsMetrics struct {
 }
 
 // Configure is used to control the settings that will be used by the obsreport
-// package.
-func Configure(level configtelemetry.Level) *ObsMetrics {
+// package. The given dimensions are added to the receiver and exporter metrics.
+func Configure(level configtelemetry.Level, dims ...Dimension) *ObsMetrics {
+	setDimensions(dims)
 	ret := &ObsMetrics{}
 	if level == configtelemetry.LevelNone {
 		return ret
@@ -86,8 +87,10 @@ func allViews() []*view.View {
 		obsmetrics.ExporterFailedToSendMetricPoints,
 		obsmetrics.ExporterSentLogRecords,
 		obsmetrics.ExporterFailedToSendLogRecords,
+		obsmetrics.ExporterSentBytes,
+		obsmetrics.ExporterSentWireBytes,
 	}
-	tagKeys = []tag.Key{obsmetrics.TagKeyExporter}
+	tagKeys = append([]tag.Key{obsmetrics.TagKeyExporter}, DimensionTagKeys()...)
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
 	errorNumberView := &view.View{
@@ -113,14 +116,18 @@ func allViews() []*view.View {
 	tagKeys = []tag.Key{obsmetrics.TagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	// Fanout views.
+	measures = []*stats.Int64Measure{
+		obsmetrics.FanoutBranchSucceededRequests,
+		obsmetrics.FanoutBranchFailedRequests,
+	}
+	tagKeys = []tag.Key{obsmetrics.TagKeyPipeline, obsmetrics.TagKeyExporter}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+
 	return views
 }
 
 func receiverViews() []*view.View {
-	if featuregate.GetRegistry().IsEnabled(UseOtelForInternalMetricsfeatureGateID) {
-		return nil
-	}
-
 	measures := []*stats.Int64Measure{
 		obsmetrics.ReceiverAcceptedSpans,
 		obsmetrics.ReceiverRefusedSpans,
@@ -128,10 +135,13 @@ func receiverViews() []*view.View {
 		obsmetrics.ReceiverRefusedMetricPoints,
 		obsmetrics.ReceiverAcceptedLogRecords,
 		obsmetrics.ReceiverRefusedLogRecords,
+		obsmetrics.ReceiverReceivedBytes,
+		obsmetrics.ReceiverReceivedWireBytes,
 	}
 	tagKeys := []tag.Key{
 		obsmetrics.TagKeyReceiver, obsmetrics.TagKeyTransport,
 	}
+	tagKeys = append(tagKeys, DimensionTagKeys()...)
 
 	return genViews(measures, tagKeys, view.Sum())
 }
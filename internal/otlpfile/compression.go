@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfile // import "go.opentelemetry.io/collector/internal/otlpfile"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// ValidateCompression returns an error if the compression is not supported for files.
+func ValidateCompression(compression configcompression.CompressionType) error {
+	if configcompression.IsCompressed(compression) && compression != configcompression.Gzip && compression != configcompression.Zstd {
+		return fmt.Errorf("compression %q is not supported", compression)
+	}
+	return nil
+}
+
+// CompressWriter is an io.Writer compressing the data written to an underlying writer.
+type CompressWriter interface {
+	io.WriteCloser
+	// Flush writes the data compressed so far to the underlying writer.
+	Flush() error
+}
+
+// NewCompressWriter returns a CompressWriter writing to w with the compression.
+// Closing it does not close w.
+func NewCompressWriter(w io.Writer, compression configcompression.CompressionType) (CompressWriter, error) {
+	switch compression {
+	case configcompression.Gzip:
+		return gzip.NewWriter(w), nil
+	case configcompression.Zstd:
+		return zstd.NewWriter(w)
+	default:
+		if err := ValidateCompression(compression); err != nil {
+			return nil, err
+		}
+		return nopCompressWriter{w}, nil
+	}
+}
+
+type nopCompressWriter struct {
+	io.Writer
+}
+
+func (nopCompressWriter) Flush() error { return nil }
+
+func (nopCompressWriter) Close() error { return nil }
+
+// NewDecompressReader returns an io.ReadCloser decompressing the data read from
+// r, with the compression detected from the first bytes. Closing it does not close r.
+func NewDecompressReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(len(zstdMagic))
+	if err != nil && len(header) < len(gzipMagic) {
+		// Too short to be compressed.
+		return io.NopCloser(br), nil
+	}
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return gzip.NewReader(br)
+	case bytes.HasPrefix(header, zstdMagic):
+		dec, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return io.NopCloser(br), nil
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlpfile exposes util functionality for components that write pipeline
+// data to files, or read it back from them, as OTLP JSON lines or length-delimited
+// OTLP protobuf records.
+package otlpfile // import "go.opentelemetry.io/collector/internal/otlpfile"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Format is the encoding of the records of a file.
+type Format string
+
+const (
+	// FormatJSON encodes each record as a line of OTLP JSON TracesData, MetricsData or LogsData.
+	FormatJSON Format = "json"
+	// FormatProto encodes each record as a protobuf message prefixed by its varint
+	// encoded size. The message has a single field holding the OTLP TracesData (1),
+	// MetricsData (2) or LogsData (3).
+	FormatProto Format = "proto"
+)
+
+// Validate returns an error if the format is not supported.
+func (f Format) Validate() error {
+	switch f {
+	case FormatJSON, FormatProto:
+		return nil
+	default:
+		return fmt.Errorf("format %q is not supported", f)
+	}
+}
+
+// Field numbers of the data in the protobuf records, and JSON keys identifying it.
+const (
+	tracesField  protowire.Number = 1
+	metricsField protowire.Number = 2
+	logsField    protowire.Number = 3
+
+	tracesKey  = "resourceSpans"
+	metricsKey = "resourceMetrics"
+	logsKey    = "resourceLogs"
+)
+
+// maxRecordSize bounds the size read for a protobuf record, to not allocate
+// unbounded memory for a corrupted size.
+const maxRecordSize = 1 << 30
+
+// Writer encodes pipeline data in records written to an io.Writer.
+type Writer struct {
+	w      io.Writer
+	format Format
+	buf    []byte
+}
+
+// NewWriter returns a Writer writing records in the format to w.
+func NewWriter(w io.Writer, format Format) *Writer {
+	return &Writer{w: w, format: format}
+}
+
+// WriteTraces writes the traces in a record.
+func (w *Writer) WriteTraces(td ptrace.Traces) error {
+	var (
+		payload []byte
+		err     error
+	)
+	if w.format == FormatJSON {
+		payload, err = ptrace.NewJSONMarshaler().MarshalTraces(td)
+	} else {
+		payload, err = ptrace.NewProtoMarshaler().MarshalTraces(td)
+	}
+	if err != nil {
+		return err
+	}
+	return w.write(tracesField, payload)
+}
+
+// WriteMetrics writes the metrics in a record.
+func (w *Writer) WriteMetrics(md pmetric.Metrics) error {
+	var (
+		payload []byte
+		err     error
+	)
+	if w.format == FormatJSON {
+		payload, err = pmetric.NewJSONMarshaler().MarshalMetrics(md)
+	} else {
+		payload, err = pmetric.NewProtoMarshaler().MarshalMetrics(md)
+	}
+	if err != nil {
+		return err
+	}
+	return w.write(metricsField, payload)
+}
+
+// WriteLogs writes the logs in a record.
+func (w *Writer) WriteLogs(ld plog.Logs) error {
+	var (
+		payload []byte
+		err     error
+	)
+	if w.format == FormatJSON {
+		payload, err = plog.NewJSONMarshaler().MarshalLogs(ld)
+	} else {
+		payload, err = plog.NewProtoMarshaler().MarshalLogs(ld)
+	}
+	if err != nil {
+		return err
+	}
+	return w.write(logsField, payload)
+}
+
+func (w *Writer) write(field protowire.Number, payload []byte) error {
+	w.buf = w.buf[:0]
+	if w.format == FormatJSON {
+		w.buf = append(w.buf, payload...)
+		w.buf = append(w.buf, '\n')
+	} else {
+		size := protowire.SizeTag(field) + protowire.SizeBytes(len(payload))
+		w.buf = protowire.AppendVarint(w.buf, uint64(size))
+		w.buf = protowire.AppendTag(w.buf, field, protowire.BytesType)
+		w.buf = protowire.AppendBytes(w.buf, payload)
+	}
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+// Record is a batch of pipeline data read from a file. Only one of its
+// Traces, Metrics and Logs is set.
+type Record struct {
+	Traces  *ptrace.Traces
+	Metrics *pmetric.Metrics
+	Logs    *plog.Logs
+}
+
+// Reader decodes the records of pipeline data read from an io.Reader.
+type Reader struct {
+	r      *bufio.Reader
+	format Format
+}
+
+// NewReader returns a Reader reading records in the format from r.
+func NewReader(r io.Reader, format Format) *Reader {
+	return &Reader{r: bufio.NewReader(r), format: format}
+}
+
+// Read returns the next record, or io.EOF once all the records have been read.
+func (r *Reader) Read() (Record, error) {
+	if r.format == FormatJSON {
+		return r.readJSON()
+	}
+	return r.readProto()
+}
+
+func (r *Reader) readJSON() (Record, error) {
+	for {
+		line, err := r.r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			err = nil
+		}
+		if err != nil {
+			return Record{}, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		key, err := firstKey(line)
+		if err != nil {
+			return Record{}, err
+		}
+		switch key {
+		case tracesKey:
+			td, err := ptrace.NewJSONUnmarshaler().UnmarshalTraces(line)
+			return Record{Traces: &td}, err
+		case metricsKey:
+			md, err := pmetric.NewJSONUnmarshaler().UnmarshalMetrics(line)
+			return Record{Metrics: &md}, err
+		case logsKey:
+			ld, err := plog.NewJSONUnmarshaler().UnmarshalLogs(line)
+			return Record{Logs: &ld}, err
+		case "":
+			// Empty data written as an empty object.
+			continue
+		default:
+			return Record{}, fmt.Errorf("unknown record with key %q", key)
+		}
+	}
+}
+
+// firstKey returns the first key of the JSON object, or an empty string if it has none.
+func firstKey(line []byte) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return "", errors.New("invalid record, not a JSON object")
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return "", fmt.Errorf("invalid record: %w", err)
+	}
+	if key, ok := tok.(string); ok {
+		return key, nil
+	}
+	return "", nil
+}
+
+func (r *Reader) readProto() (Record, error) {
+	size, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return Record{}, io.EOF
+		}
+		return Record{}, fmt.Errorf("invalid record size: %w", err)
+	}
+	if size > maxRecordSize {
+		return Record{}, fmt.Errorf("invalid record size %d", size)
+	}
+	msg := make([]byte, size)
+	if _, err = io.ReadFull(r.r, msg); err != nil {
+		return Record{}, fmt.Errorf("truncated record: %w", io.ErrUnexpectedEOF)
+	}
+	field, typ, n := protowire.ConsumeTag(msg)
+	if n < 0 || typ != protowire.BytesType {
+		return Record{}, errors.New("invalid record, the data is not a bytes field")
+	}
+	payload, m := protowire.ConsumeBytes(msg[n:])
+	if m < 0 {
+		return Record{}, errors.New("invalid record, the data is truncated")
+	}
+	switch field {
+	case tracesField:
+		td, err := ptrace.NewProtoUnmarshaler().UnmarshalTraces(payload)
+		return Record{Traces: &td}, err
+	case metricsField:
+		md, err := pmetric.NewProtoUnmarshaler().UnmarshalMetrics(payload)
+		return Record{Metrics: &md}, err
+	case logsField:
+		ld, err := plog.NewProtoUnmarshaler().UnmarshalLogs(payload)
+		return Record{Logs: &ld}, err
+	default:
+		return Record{}, fmt.Errorf("unknown record field %d", field)
+	}
+}
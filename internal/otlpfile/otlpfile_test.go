@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfile
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func writeRecords(t *testing.T, w io.Writer, format Format) {
+	writer := NewWriter(w, format)
+	require.NoError(t, writer.WriteTraces(testdata.GenerateTraces(2)))
+	require.NoError(t, writer.WriteTraces(ptrace.NewTraces()))
+	require.NoError(t, writer.WriteMetrics(testdata.GenerateMetrics(3)))
+	require.NoError(t, writer.WriteLogs(testdata.GenerateLogs(4)))
+}
+
+func readRecords(t *testing.T, r io.Reader, format Format) []Record {
+	reader := NewReader(r, format)
+	var records []Record
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			return records
+		}
+		require.NoError(t, err)
+		records = append(records, rec)
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	for _, format := range []Format{FormatJSON, FormatProto} {
+		t.Run(string(format), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			writeRecords(t, buf, format)
+			records := readRecords(t, buf, format)
+
+			// Empty traces are skipped in JSON, where they are written as an empty object.
+			offset := 0
+			if format == FormatProto {
+				require.Len(t, records, 4)
+				require.NotNil(t, records[1].Traces)
+				assert.Equal(t, 0, records[1].Traces.SpanCount())
+				offset = 1
+			} else {
+				require.Len(t, records, 3)
+			}
+			require.NotNil(t, records[0].Traces)
+			assert.Equal(t, testdata.GenerateTraces(2), *records[0].Traces)
+			require.NotNil(t, records[offset+1].Metrics)
+			assert.Equal(t, testdata.GenerateMetrics(3), *records[offset+1].Metrics)
+			require.NotNil(t, records[offset+2].Logs)
+			assert.Equal(t, testdata.GenerateLogs(4), *records[offset+2].Logs)
+		})
+	}
+}
+
+func TestReadJSONLines(t *testing.T) {
+	records := readRecords(t, bytes.NewBufferString("\n{}\n"+`{"resourceLogs":[]}`), FormatJSON)
+	require.Len(t, records, 1)
+	assert.NotNil(t, records[0].Logs)
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		data   []byte
+		err    string
+	}{
+		{name: "json not an object", format: FormatJSON, data: []byte("[]\n"), err: "invalid record, not a JSON object"},
+		{name: "json unknown key", format: FormatJSON, data: []byte(`{"resourceProfiles":[]}`), err: `unknown record with key "resourceProfiles"`},
+		{name: "proto truncated", format: FormatProto, data: []byte{0x05, 0x0a}, err: "truncated record: unexpected EOF"},
+		{name: "proto size too large", format: FormatProto, data: []byte{0xff, 0xff, 0xff, 0xff, 0x0f}, err: "invalid record size 4294967295"},
+		{name: "proto not bytes", format: FormatProto, data: []byte{0x02, 0x08, 0x01}, err: "invalid record, the data is not a bytes field"},
+		{name: "proto unknown field", format: FormatProto, data: []byte{0x02, 0x22, 0x00}, err: "unknown record field 4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(bytes.NewReader(tt.data), tt.format).Read()
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestFormatValidate(t *testing.T) {
+	assert.NoError(t, FormatJSON.Validate())
+	assert.NoError(t, FormatProto.Validate())
+	assert.EqualError(t, Format("yaml").Validate(), `format "yaml" is not supported`)
+}
+
+func TestCompression(t *testing.T) {
+	for _, compression := range []configcompression.CompressionType{"", "none", configcompression.Gzip, configcompression.Zstd} {
+		t.Run(string(compression), func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			// Compressed streams written one after the other are read as one.
+			for i := 0; i < 2; i++ {
+				cw, err := NewCompressWriter(buf, compression)
+				require.NoError(t, err)
+				writeRecords(t, cw, FormatProto)
+				require.NoError(t, cw.Flush())
+				require.NoError(t, cw.Close())
+			}
+
+			r, err := NewDecompressReader(buf)
+			require.NoError(t, err)
+			assert.Len(t, readRecords(t, r, FormatProto), 8)
+			assert.NoError(t, r.Close())
+		})
+	}
+
+	_, err := NewCompressWriter(&bytes.Buffer{}, configcompression.Snappy)
+	assert.EqualError(t, err, `compression "snappy" is not supported`)
+
+	r, err := NewDecompressReader(bytes.NewReader(nil))
+	require.NoError(t, err)
+	assert.Empty(t, readRecords(t, r, FormatJSON))
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proxy selects the HTTP proxy used by the confighttp and configgrpc clients
+// from their proxy_url and no_proxy settings.
+package proxy // import "go.opentelemetry.io/collector/internal/proxy"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Selector returns the proxy to use for each destination.
+type Selector struct {
+	// URL is the configured proxy.
+	URL      *url.URL
+	proxyFor func(*url.URL) (*url.URL, error)
+}
+
+// errNoProxyWithoutURL is returned when no_proxy is set without proxy_url: the environment proxy is
+// only configured by the environment, including its exclusions.
+var errNoProxyWithoutURL = errors.New("no_proxy requires proxy_url, set NO_PROXY to exclude hosts from the environment proxy")
+
+// NewSelector validates proxyURL and returns a Selector sending all the requests to it,
+// except the ones to the hosts matching an entry of noProxy.
+func NewSelector(proxyURL string, noProxy []string) (*Selector, error) {
+	if proxyURL == "" && len(noProxy) > 0 {
+		return nil, errNoProxyWithoutURL
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy_url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid proxy_url: unsupported scheme %q, must be http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy_url: missing host in %q", proxyURL)
+	}
+	return &Selector{
+		URL: u,
+		proxyFor: (&httpproxy.Config{
+			HTTPProxy:  proxyURL,
+			HTTPSProxy: proxyURL,
+			NoProxy:    strings.Join(noProxy, ","),
+		}).ProxyFunc(),
+	}, nil
+}
+
+// ProxyFor returns the proxy for the destination u, or nil if u must be reached directly.
+// The no_proxy entries are matched against the host of u as given, so domain names must
+// be checked before they are resolved to IP addresses.
+func (s *Selector) ProxyFor(u *url.URL) (*url.URL, error) {
+	return s.proxyFor(u)
+}
+
+// ProxyForAddr is like ProxyFor for a destination in the "host:port" form.
+func (s *Selector) ProxyForAddr(addr string) (*url.URL, error) {
+	return s.proxyFor(&url.URL{Scheme: "https", Host: addr})
+}
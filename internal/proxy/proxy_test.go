@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSelectorInvalid(t *testing.T) {
+	tests := []struct {
+		proxyURL string
+		noProxy  []string
+		err      string
+	}{
+		{proxyURL: "socks5://proxy:1080", err: `invalid proxy_url: unsupported scheme "socks5", must be http or https`},
+		{proxyURL: "http://", err: `invalid proxy_url: missing host in "http://"`},
+		{proxyURL: "http://proxy:port", err: `invalid proxy_url: parse "http://proxy:port": invalid port ":port" after host`},
+		{noProxy: []string{"internal.test"}, err: "no_proxy requires proxy_url, set NO_PROXY to exclude hosts from the environment proxy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.proxyURL, func(t *testing.T) {
+			_, err := NewSelector(tt.proxyURL, tt.noProxy)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestSelectorNoProxy(t *testing.T) {
+	s, err := NewSelector("http://proxy:3128", []string{".example.com", "10.0.0.0/8", "collector"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		addr    string
+		proxied bool
+	}{
+		{addr: "backend.test:4317", proxied: true},
+		{addr: "otlp.example.com:4317", proxied: false},
+		{addr: "example.org:4317", proxied: true},
+		{addr: "10.1.2.3:4317", proxied: false},
+		{addr: "192.168.1.1:4317", proxied: true},
+		{addr: "collector:4317", proxied: false},
+		{addr: "localhost:4317", proxied: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			proxy, err := s.ProxyForAddr(tt.addr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.proxied, proxy != nil)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package resourcedetection // import "go.opentelemetry.io/collector/internal/resourcedetection"
+
+import (
+	"errors"
+	"io/fs"
+
+	"go.opentelemetry.io/collector/internal/cgroups"
+)
+
+// containerIDForCurrentProcess returns the ID of the container the collector
+// runs in. A process without a readable /proc runs outside of a container.
+func containerIDForCurrentProcess() (string, bool, error) {
+	id, ok, err := cgroups.ContainerIDForCurrentProcess()
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	return id, ok, err
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package resourcedetection detects the resource attributes of the
+// environment the collector runs in.
+package resourcedetection // import "go.opentelemetry.io/collector/internal/resourcedetection"
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.12.0"
+)
+
+const (
+	// DetectorHost detects the host.name and os.type attributes.
+	DetectorHost = "host"
+	// DetectorContainer detects the container.id attribute from the CGroup
+	// of the collector, on Linux.
+	DetectorContainer = "container"
+	// DetectorK8s detects the k8s.node.name, k8s.namespace.name, k8s.pod.name
+	// and k8s.pod.uid attributes from environment variables, set with the
+	// Kubernetes downward API.
+	DetectorK8s = "k8s"
+)
+
+// The environment variables read by DetectorK8s.
+const (
+	EnvK8sNodeName     = "K8S_NODE_NAME"
+	EnvK8sPodNamespace = "K8S_POD_NAMESPACE"
+	EnvK8sPodName      = "K8S_POD_NAME"
+	EnvK8sPodUID       = "K8S_POD_UID"
+)
+
+// goosToOSType maps the values of runtime.GOOS differing from the values of
+// the os.type attribute.
+var goosToOSType = map[string]string{
+	"dragonfly": conventions.AttributeOSTypeDragonflyBSD,
+	"illumos":   conventions.AttributeOSTypeSolaris,
+	"zos":       conventions.AttributeOSTypeZOS,
+}
+
+// The sources of the detectors, replaced by tests.
+var (
+	hostname    = os.Hostname
+	goos        = runtime.GOOS
+	containerID = containerIDForCurrentProcess
+	lookupEnv   = os.LookupEnv
+)
+
+var detectors = map[string]func(attrs map[string]string) error{
+	DetectorHost:      detectHost,
+	DetectorContainer: detectContainer,
+	DetectorK8s:       detectK8s,
+}
+
+// Validate checks the names of the detectors.
+func Validate(names []string) error {
+	for _, name := range names {
+		if _, ok := detectors[name]; !ok {
+			return fmt.Errorf("unknown resource detector %q, must be %q, %q or %q", name, DetectorHost, DetectorContainer, DetectorK8s)
+		}
+	}
+	return nil
+}
+
+// Detect returns the attributes detected by the named detectors. An attribute
+// detected by several detectors has the value of the first one.
+func Detect(names []string) (map[string]string, error) {
+	if err := Validate(names); err != nil {
+		return nil, err
+	}
+	attrs := map[string]string{}
+	for _, name := range names {
+		detected := map[string]string{}
+		if err := detectors[name](detected); err != nil {
+			return nil, fmt.Errorf("resource detector %q: %w", name, err)
+		}
+		for k, v := range detected {
+			if _, ok := attrs[k]; !ok {
+				attrs[k] = v
+			}
+		}
+	}
+	return attrs, nil
+}
+
+// Merge puts the attributes in the resource. When override is false, the
+// attributes already present in the resource are preserved.
+func Merge(resource pcommon.Resource, attrs map[string]string, override bool) {
+	dest := resource.Attributes()
+	for k, v := range attrs {
+		if !override {
+			if _, ok := dest.Get(k); ok {
+				continue
+			}
+		}
+		dest.PutStr(k, v)
+	}
+}
+
+func detectHost(attrs map[string]string) error {
+	name, err := hostname()
+	if err != nil {
+		return err
+	}
+	attrs[conventions.AttributeHostName] = name
+	osType, ok := goosToOSType[goos]
+	if !ok {
+		osType = goos
+	}
+	attrs[conventions.AttributeOSType] = osType
+	return nil
+}
+
+func detectContainer(attrs map[string]string) error {
+	id, ok, err := containerID()
+	if err != nil {
+		return err
+	}
+	if ok {
+		attrs[conventions.AttributeContainerID] = id
+	}
+	return nil
+}
+
+func detectK8s(attrs map[string]string) error {
+	for env, attr := range map[string]string{
+		EnvK8sNodeName:     conventions.AttributeK8SNodeName,
+		EnvK8sPodNamespace: conventions.AttributeK8SNamespaceName,
+		EnvK8sPodName:      conventions.AttributeK8SPodName,
+		EnvK8sPodUID:       conventions.AttributeK8SPodUID,
+	} {
+		if v, ok := lookupEnv(env); ok && v != "" {
+			attrs[attr] = v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func setSources(t *testing.T, host string, os string, container string, env map[string]string) {
+	prevHostname, prevGOOS, prevContainerID, prevLookupEnv := hostname, goos, containerID, lookupEnv
+	t.Cleanup(func() {
+		hostname, goos, containerID, lookupEnv = prevHostname, prevGOOS, prevContainerID, prevLookupEnv
+	})
+	hostname = func() (string, error) { return host, nil }
+	goos = os
+	containerID = func() (string, bool, error) { return container, container != "", nil }
+	lookupEnv = func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+func TestDetect(t *testing.T) {
+	setSources(t, "node-1", "linux", "8d0f3a07c1b5", map[string]string{
+		EnvK8sNodeName:     "node-1.example.com",
+		EnvK8sPodNamespace: "observability",
+		EnvK8sPodName:      "otelcol-agent-x7k2p",
+		EnvK8sPodUID:       "",
+	})
+
+	attrs, err := Detect([]string{DetectorHost, DetectorContainer, DetectorK8s})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"host.name":          "node-1",
+		"os.type":            "linux",
+		"container.id":       "8d0f3a07c1b5",
+		"k8s.node.name":      "node-1.example.com",
+		"k8s.namespace.name": "observability",
+		"k8s.pod.name":       "otelcol-agent-x7k2p",
+	}, attrs)
+
+	attrs, err = Detect(nil)
+	require.NoError(t, err)
+	assert.Empty(t, attrs)
+}
+
+func TestDetectOutsideOfContainer(t *testing.T) {
+	setSources(t, "laptop", "dragonfly", "", nil)
+
+	attrs, err := Detect([]string{DetectorContainer, DetectorK8s, DetectorHost})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"host.name": "laptop",
+		"os.type":   "dragonflybsd",
+	}, attrs)
+}
+
+func TestDetectErrors(t *testing.T) {
+	setSources(t, "", "linux", "", nil)
+
+	_, err := Detect([]string{DetectorHost, "ec2"})
+	assert.EqualError(t, err, `unknown resource detector "ec2", must be "host", "container" or "k8s"`)
+
+	hostname = func() (string, error) { return "", errors.New("no hostname") }
+	_, err = Detect([]string{DetectorHost})
+	assert.EqualError(t, err, `resource detector "host": no hostname`)
+}
+
+func TestDetectCurrentProcess(t *testing.T) {
+	// The container detection of the current process never fails for a lack of /proc.
+	_, err := Detect([]string{DetectorHost, DetectorContainer, DetectorK8s})
+	assert.NoError(t, err)
+}
+
+func TestMerge(t *testing.T) {
+	detected := map[string]string{"host.name": "node-1", "os.type": "linux"}
+
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("host.name", "app-host")
+	resource.Attributes().PutStr("service.name", "checkout")
+	Merge(resource, detected, false)
+	assert.Equal(t, map[string]interface{}{"host.name": "app-host", "os.type": "linux", "service.name": "checkout"}, resource.Attributes().AsRaw())
+
+	resource = pcommon.NewResource()
+	resource.Attributes().PutStr("host.name", "app-host")
+	resource.Attributes().PutStr("service.name", "checkout")
+	Merge(resource, detected, true)
+	assert.Equal(t, map[string]interface{}{"host.name": "node-1", "os.type": "linux", "service.name": "checkout"}, resource.Attributes().AsRaw())
+}
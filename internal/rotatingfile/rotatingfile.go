@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rotatingfile exposes util functionality for components that write
+// to a file rotated once it reaches a maximum size or age.
+package rotatingfile // import "go.opentelemetry.io/collector/internal/rotatingfile"
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat is the format of the rotation time in the name of the rotated
+// files, which sorts them in the order they were rotated.
+const backupTimeFormat = "20060102T150405.000000000"
+
+// Settings defines the file, when it is rotated, and how many rotated files are kept.
+type Settings struct {
+	// Path of the file.
+	Path string
+	// MaxSize is the size in bytes the file is rotated at, 0 to not rotate it by size.
+	MaxSize int64
+	// Interval is the age the file is rotated at, 0 to not rotate it by time.
+	Interval time.Duration
+	// MaxBackups is the number of rotated files kept, 0 to keep all of them.
+	MaxBackups int
+}
+
+// File is a file renamed with the time it is rotated at, inserted before the
+// extensions of its name, and reopened empty once it reaches its maximum size
+// or age. The callers check if it must be rotated before writing a record, so
+// that records are not split across files. It is not safe for concurrent use.
+type File struct {
+	Settings
+	now func() time.Time
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// Open opens the file for appending, creating it if needed.
+func Open(set Settings, now func() time.Time) (*File, error) {
+	f := &File{Settings: set, now: now}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	return nil
+}
+
+// Write implements io.Writer, it never rotates the file.
+func (f *File) Write(p []byte) (int, error) {
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// ShouldRotate returns whether the file is not empty and reached its maximum size or age.
+func (f *File) ShouldRotate() bool {
+	if f.size == 0 {
+		return false
+	}
+	return (f.MaxSize > 0 && f.size >= f.MaxSize) ||
+		(f.Interval > 0 && f.now().Sub(f.openedAt) >= f.Interval)
+}
+
+// Rotate renames the file with the current time, removes the oldest rotated
+// files, and reopens it empty.
+func (f *File) Rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Path, f.backupPath(f.now())); err != nil {
+		return err
+	}
+	if err := f.removeOldBackups(); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+// Close implements io.Closer.
+func (f *File) Close() error {
+	return f.file.Close()
+}
+
+// backupPath returns the path the file is renamed to when rotated at t, with
+// the time inserted before the extensions of its name.
+func (f *File) backupPath(t time.Time) string {
+	prefix, ext := f.splitPath()
+	return prefix + "-" + t.UTC().Format(backupTimeFormat) + ext
+}
+
+func (f *File) splitPath() (prefix string, ext string) {
+	dir, name := filepath.Split(f.Path)
+	if i := strings.Index(name, "."); i > 0 {
+		return dir + name[:i], name[i:]
+	}
+	return f.Path, ""
+}
+
+func (f *File) removeOldBackups() error {
+	if f.MaxBackups == 0 {
+		return nil
+	}
+	prefix, ext := f.splitPath()
+	backups, err := filepath.Glob(prefix + "-*" + ext)
+	if err != nil {
+		return err
+	}
+	var valid []string
+	for _, backup := range backups {
+		ts := strings.TrimSuffix(strings.TrimPrefix(backup, prefix+"-"), ext)
+		if _, perr := time.Parse(backupTimeFormat, ts); perr == nil {
+			valid = append(valid, backup)
+		}
+	}
+	sort.Strings(valid)
+	for len(valid) > f.MaxBackups {
+		if err := os.Remove(valid[0]); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		valid = valid[1:]
+	}
+	return nil
+}
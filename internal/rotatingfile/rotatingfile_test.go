@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rotatingfile
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func listBackups(t *testing.T, dir string) []string {
+	matches, err := filepath.Glob(filepath.Join(dir, "data-*.log"))
+	require.NoError(t, err)
+	sort.Strings(matches)
+	return matches
+}
+
+// write writes a line, after rotating the file if needed.
+func write(t *testing.T, f *File, line string) {
+	if f.ShouldRotate() {
+		require.NoError(t, f.Rotate())
+	}
+	n, err := f.Write([]byte(line))
+	require.NoError(t, err)
+	assert.Equal(t, len(line), n)
+}
+
+func TestFileSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.log")
+	// The size of an existing file is accounted for, and it is appended to.
+	require.NoError(t, os.WriteFile(path, []byte("0000\n"), 0600))
+	clock := &fakeClock{now: time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)}
+	f, err := Open(Settings{Path: path, MaxSize: 10, MaxBackups: 2}, clock.Now)
+	require.NoError(t, err)
+
+	for _, line := range []string{"1111\n", "2222\n", "3333\n", "4444\n", "5555\n", "6666\n"} {
+		write(t, f, line)
+		clock.now = clock.now.Add(time.Second)
+	}
+	require.NoError(t, f.Close())
+
+	// The file is rotated once it reached its maximum size, and only the 2
+	// most recent rotated files are kept.
+	backups := listBackups(t, dir)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "data-20221101T100003.000000000.log"),
+		filepath.Join(dir, "data-20221101T100005.000000000.log"),
+	}, backups)
+	assert.Equal(t, "2222\n3333\n", readFile(t, backups[0]))
+	assert.Equal(t, "4444\n5555\n", readFile(t, backups[1]))
+	assert.Equal(t, "6666\n", readFile(t, path))
+}
+
+func TestFileInterval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.log")
+	clock := &fakeClock{now: time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)}
+	f, err := Open(Settings{Path: path, Interval: time.Minute}, clock.Now)
+	require.NoError(t, err)
+
+	write(t, f, "1111\n")
+	clock.now = clock.now.Add(30 * time.Second)
+	write(t, f, "2222\n")
+	assert.Empty(t, listBackups(t, dir))
+	clock.now = clock.now.Add(30 * time.Second)
+	write(t, f, "3333\n")
+	require.NoError(t, f.Close())
+
+	backups := listBackups(t, dir)
+	assert.Equal(t, []string{filepath.Join(dir, "data-20221101T100100.000000000.log")}, backups)
+	assert.Equal(t, "1111\n2222\n", readFile(t, backups[0]))
+	assert.Equal(t, "3333\n", readFile(t, path))
+}
+
+func TestFileUnlimited(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.log")
+	f, err := Open(Settings{Path: path, MaxBackups: 1}, time.Now)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		write(t, f, "line\n")
+	}
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "line\nline\nline\n", readFile(t, path))
+	assert.Empty(t, listBackups(t, dir))
+}
+
+func TestFileOpenError(t *testing.T) {
+	_, err := Open(Settings{Path: filepath.Join(t.TempDir(), "missing", "data.log")}, time.Now)
+	assert.Error(t, err)
+}
+
+func TestFileBackupPath(t *testing.T) {
+	at := time.Date(2022, 11, 1, 10, 0, 0, 5, time.UTC)
+	for path, expected := range map[string]string{
+		"capture":                "capture-20221101T100000.000000005",
+		"dir.d/capture.jsonl":    "dir.d/capture-20221101T100000.000000005.jsonl",
+		"dir/capture.jsonl.zst":  "dir/capture-20221101T100000.000000005.jsonl.zst",
+		"dir/.hidden":            "dir/.hidden-20221101T100000.000000005",
+		"/var/lib/capture.proto": "/var/lib/capture-20221101T100000.000000005.proto",
+	} {
+		f := &File{Settings: Settings{Path: filepath.FromSlash(path)}}
+		assert.Equal(t, filepath.FromSlash(expected), f.backupPath(at))
+	}
+}
@@ -15,8 +15,14 @@
 package obsreport // import "go.opentelemetry.io/collector/obsreport"
 
 import (
+	"context"
+
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 )
 
 const (
@@ -30,3 +36,32 @@ func recordError(span trace.Span, err error) {
 		span.SetStatus(codes.Error, err.Error())
 	}
 }
+
+// withDimensions returns the mutators followed by the ones setting the configured
+// dimensions to their values for the client of ctx.
+func withDimensions(ctx context.Context, mutators []tag.Mutator) []tag.Mutator {
+	tags := obsreportconfig.DimensionTagsFromContext(ctx)
+	if len(tags) == 0 {
+		return mutators
+	}
+	ret := make([]tag.Mutator, 0, len(mutators)+len(tags))
+	ret = append(ret, mutators...)
+	for _, t := range tags {
+		ret = append(ret, tag.Upsert(t.Key, t.Value, tag.WithTTL(tag.TTLNoPropagation)))
+	}
+	return ret
+}
+
+// withDimensionAttributes is the equivalent of withDimensions for OpenTelemetry attributes.
+func withDimensionAttributes(ctx context.Context, attrs []attribute.KeyValue) []attribute.KeyValue {
+	tags := obsreportconfig.DimensionTagsFromContext(ctx)
+	if len(tags) == 0 {
+		return attrs
+	}
+	ret := make([]attribute.KeyValue, 0, len(attrs)+len(tags))
+	ret = append(ret, attrs...)
+	for _, t := range tags {
+		ret = append(ret, attribute.String(t.Key.Name(), t.Value))
+	}
+	return ret
+}
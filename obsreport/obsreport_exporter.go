@@ -20,20 +20,49 @@ import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/netstats"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
+const (
+	exporterName = "exporter"
+
+	exporterScope = scopeName + nameSep + exporterName
+)
+
 // Exporter is a helper to add observability to a component.Exporter.
 type Exporter struct {
 	level          configtelemetry.Level
 	spanNamePrefix string
 	mutators       []tag.Mutator
 	tracer         trace.Tracer
+	meter          metric.Meter
+	logger         *zap.Logger
+
+	useOtelForMetrics bool
+	otelAttrs         []attribute.KeyValue
+
+	sentSpans                syncint64.Counter
+	failedToSendSpans        syncint64.Counter
+	sentMetricPoints         syncint64.Counter
+	failedToSendMetricPoints syncint64.Counter
+	sentLogRecords           syncint64.Counter
+	failedToSendLogRecords   syncint64.Counter
+	sentBytes                syncint64.Counter
+	sentWireBytes            syncint64.Counter
+	failedRequests           syncint64.Counter
 }
 
 // ExporterSettings are settings for creating an Exporter.
@@ -44,11 +73,63 @@ type ExporterSettings struct {
 
 // NewExporter creates a new Exporter.
 func NewExporter(cfg ExporterSettings) *Exporter {
-	return &Exporter{
+	return newExporter(cfg, featuregate.GetRegistry())
+}
+
+func newExporter(cfg ExporterSettings, registry *featuregate.Registry) *Exporter {
+	exp := &Exporter{
 		level:          cfg.ExporterCreateSettings.TelemetrySettings.MetricsLevel,
 		spanNamePrefix: obsmetrics.ExporterPrefix + cfg.ExporterID.String(),
 		mutators:       []tag.Mutator{tag.Upsert(obsmetrics.TagKeyExporter, cfg.ExporterID.String(), tag.WithTTL(tag.TTLNoPropagation))},
 		tracer:         cfg.ExporterCreateSettings.TracerProvider.Tracer(cfg.ExporterID.String()),
+		meter:          cfg.ExporterCreateSettings.MeterProvider.Meter(exporterScope),
+		logger:         cfg.ExporterCreateSettings.Logger,
+
+		useOtelForMetrics: registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID),
+		otelAttrs: []attribute.KeyValue{
+			attribute.String(obsmetrics.ExporterKey, cfg.ExporterID.String()),
+		},
+	}
+
+	exp.createOtelMetrics()
+
+	return exp
+}
+
+func (exp *Exporter) createOtelMetrics() {
+	if !exp.useOtelForMetrics {
+		return
+	}
+
+	newCounter := func(measure *stats.Int64Measure) syncint64.Counter {
+		counter, err := exp.meter.SyncInt64().Counter(
+			measure.Name(),
+			instrument.WithDescription(measure.Description()),
+			instrument.WithUnit(unit.Unit(measure.Unit())),
+		)
+		if err != nil {
+			exp.logger.Warn("failed to create otel instrument", zap.Error(err), zap.String("metric", measure.Name()))
+		}
+		return counter
+	}
+
+	exp.sentSpans = newCounter(obsmetrics.ExporterSentSpans)
+	exp.failedToSendSpans = newCounter(obsmetrics.ExporterFailedToSendSpans)
+	exp.sentMetricPoints = newCounter(obsmetrics.ExporterSentMetricPoints)
+	exp.failedToSendMetricPoints = newCounter(obsmetrics.ExporterFailedToSendMetricPoints)
+	exp.sentLogRecords = newCounter(obsmetrics.ExporterSentLogRecords)
+	exp.failedToSendLogRecords = newCounter(obsmetrics.ExporterFailedToSendLogRecords)
+	exp.sentBytes = newCounter(obsmetrics.ExporterSentBytes)
+	exp.sentWireBytes = newCounter(obsmetrics.ExporterSentWireBytes)
+
+	var err error
+	exp.failedRequests, err = exp.meter.SyncInt64().Counter(
+		obsmetrics.ExporterPrefix+"send_failed_requests",
+		instrument.WithDescription("number of times exporters failed to send requests to the destination"),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		exp.logger.Warn("failed to create otel instrument", zap.Error(err), zap.String("metric", obsmetrics.ExporterPrefix+"send_failed_requests"))
 	}
 }
 
@@ -62,7 +143,7 @@ func (exp *Exporter) StartTracesOp(ctx context.Context) context.Context {
 // EndTracesOp completes the export operation that was started with StartTracesOp.
 func (exp *Exporter) EndTracesOp(ctx context.Context, numSpans int, err error) {
 	numSent, numFailedToSend := toNumItems(numSpans, err)
-	exp.recordMetrics(ctx, numSent, numFailedToSend, obsmetrics.ExporterSentSpans, obsmetrics.ExporterFailedToSendSpans)
+	exp.recordMetrics(ctx, config.TracesDataType, numSent, numFailedToSend)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentSpansKey, obsmetrics.FailedToSendSpansKey)
 }
 
@@ -77,7 +158,7 @@ func (exp *Exporter) StartMetricsOp(ctx context.Context) context.Context {
 // StartMetricsOp.
 func (exp *Exporter) EndMetricsOp(ctx context.Context, numMetricPoints int, err error) {
 	numSent, numFailedToSend := toNumItems(numMetricPoints, err)
-	exp.recordMetrics(ctx, numSent, numFailedToSend, obsmetrics.ExporterSentMetricPoints, obsmetrics.ExporterFailedToSendMetricPoints)
+	exp.recordMetrics(ctx, config.MetricsDataType, numSent, numFailedToSend)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentMetricPointsKey, obsmetrics.FailedToSendMetricPointsKey)
 }
 
@@ -91,7 +172,7 @@ func (exp *Exporter) StartLogsOp(ctx context.Context) context.Context {
 // EndLogsOp completes the export operation that was started with StartLogsOp.
 func (exp *Exporter) EndLogsOp(ctx context.Context, numLogRecords int, err error) {
 	numSent, numFailedToSend := toNumItems(numLogRecords, err)
-	exp.recordMetrics(ctx, numSent, numFailedToSend, obsmetrics.ExporterSentLogRecords, obsmetrics.ExporterFailedToSendLogRecords)
+	exp.recordMetrics(ctx, config.LogsDataType, numSent, numFailedToSend)
 	endSpan(ctx, err, numSent, numFailedToSend, obsmetrics.SentLogRecordsKey, obsmetrics.FailedToSendLogRecordsKey)
 }
 
@@ -100,19 +181,75 @@ func (exp *Exporter) EndLogsOp(ctx context.Context, numLogRecords int, err error
 func (exp *Exporter) startOp(ctx context.Context, operationSuffix string) context.Context {
 	spanName := exp.spanNamePrefix + operationSuffix
 	ctx, _ = exp.tracer.Start(ctx, spanName)
-	return ctx
+	// Let the confighttp and configgrpc clients count the size of the requests of the operation.
+	return netstats.NewContext(ctx, &netstats.Counter{})
 }
 
-func (exp *Exporter) recordMetrics(ctx context.Context, numSent, numFailedToSend int64, sentMeasure, failedToSendMeasure *stats.Int64Measure) {
+func (exp *Exporter) recordMetrics(ctx context.Context, dataType config.DataType, numSent, numFailedToSend int64) {
 	if exp.level == configtelemetry.LevelNone {
 		return
 	}
-	// Ignore the error for now. This should not happen.
-	if numFailedToSend > 0 {
-		_ = stats.RecordWithTags(ctx, exp.mutators, sentMeasure.M(numSent), failedToSendMeasure.M(numFailedToSend))
+	var bytes, wireBytes int64
+	if counter := netstats.FromContext(ctx); counter != nil {
+		bytes, wireBytes = counter.Swap()
+	}
+	if exp.useOtelForMetrics {
+		exp.recordWithOtel(ctx, dataType, numSent, numFailedToSend, bytes, wireBytes)
 	} else {
-		_ = stats.RecordWithTags(ctx, exp.mutators, sentMeasure.M(numSent))
+		exp.recordWithOC(ctx, dataType, numSent, numFailedToSend, bytes, wireBytes)
+	}
+}
+
+func (exp *Exporter) recordWithOtel(ctx context.Context, dataType config.DataType, numSent, numFailedToSend, bytes, wireBytes int64) {
+	var sentMeasure, failedToSendMeasure syncint64.Counter
+	switch dataType {
+	case config.TracesDataType:
+		sentMeasure = exp.sentSpans
+		failedToSendMeasure = exp.failedToSendSpans
+	case config.MetricsDataType:
+		sentMeasure = exp.sentMetricPoints
+		failedToSendMeasure = exp.failedToSendMetricPoints
+	case config.LogsDataType:
+		sentMeasure = exp.sentLogRecords
+		failedToSendMeasure = exp.failedToSendLogRecords
+	}
+
+	attrs := withDimensionAttributes(ctx, exp.otelAttrs)
+	sentMeasure.Add(ctx, numSent, attrs...)
+	failedToSendMeasure.Add(ctx, numFailedToSend, attrs...)
+	if dataType == config.TracesDataType && numFailedToSend > 0 {
+		// Matches the "send_failed_requests" OpenCensus view, which counts the failed span measurements.
+		exp.failedRequests.Add(ctx, 1, exp.otelAttrs...)
 	}
+	if bytes != 0 || wireBytes != 0 {
+		exp.sentBytes.Add(ctx, bytes, attrs...)
+		exp.sentWireBytes.Add(ctx, wireBytes, attrs...)
+	}
+}
+
+func (exp *Exporter) recordWithOC(ctx context.Context, dataType config.DataType, numSent, numFailedToSend, bytes, wireBytes int64) {
+	var sentMeasure, failedToSendMeasure *stats.Int64Measure
+	switch dataType {
+	case config.TracesDataType:
+		sentMeasure = obsmetrics.ExporterSentSpans
+		failedToSendMeasure = obsmetrics.ExporterFailedToSendSpans
+	case config.MetricsDataType:
+		sentMeasure = obsmetrics.ExporterSentMetricPoints
+		failedToSendMeasure = obsmetrics.ExporterFailedToSendMetricPoints
+	case config.LogsDataType:
+		sentMeasure = obsmetrics.ExporterSentLogRecords
+		failedToSendMeasure = obsmetrics.ExporterFailedToSendLogRecords
+	}
+
+	measurements := []stats.Measurement{sentMeasure.M(numSent)}
+	if numFailedToSend > 0 {
+		measurements = append(measurements, failedToSendMeasure.M(numFailedToSend))
+	}
+	if bytes != 0 || wireBytes != 0 {
+		measurements = append(measurements, obsmetrics.ExporterSentBytes.M(bytes), obsmetrics.ExporterSentWireBytes.M(wireBytes))
+	}
+	// Ignore the error for now. This should not happen.
+	_ = stats.RecordWithTags(ctx, withDimensions(ctx, exp.mutators), measurements...)
 }
 
 func endSpan(ctx context.Context, err error, numSent, numFailedToSend int64, sentItemsKey, failedToSendItemsKey string) {
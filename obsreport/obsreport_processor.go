@@ -20,13 +20,27 @@ import (
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
 
+const (
+	processorName = "processor"
+
+	processorScope = scopeName + nameSep + processorName
+)
+
 // BuildProcessorCustomMetricName is used to be build a metric name following
 // the standards used in the Collector. The configType should be the same
 // value used to identify the type on the config.
@@ -45,6 +59,21 @@ func BuildProcessorCustomMetricName(configType, metric string) string {
 type Processor struct {
 	level    configtelemetry.Level
 	mutators []tag.Mutator
+	meter    metric.Meter
+	logger   *zap.Logger
+
+	useOtelForMetrics bool
+	otelAttrs         []attribute.KeyValue
+
+	acceptedSpans        syncint64.Counter
+	refusedSpans         syncint64.Counter
+	droppedSpans         syncint64.Counter
+	acceptedMetricPoints syncint64.Counter
+	refusedMetricPoints  syncint64.Counter
+	droppedMetricPoints  syncint64.Counter
+	acceptedLogRecords   syncint64.Counter
+	refusedLogRecords    syncint64.Counter
+	droppedLogRecords    syncint64.Counter
 }
 
 // ProcessorSettings are settings for creating a Processor.
@@ -55,134 +84,156 @@ type ProcessorSettings struct {
 
 // NewProcessor creates a new Processor.
 func NewProcessor(cfg ProcessorSettings) *Processor {
-	return &Processor{
+	return newProcessor(cfg, featuregate.GetRegistry())
+}
+
+func newProcessor(cfg ProcessorSettings, registry *featuregate.Registry) *Processor {
+	por := &Processor{
 		level:    cfg.ProcessorCreateSettings.MetricsLevel,
 		mutators: []tag.Mutator{tag.Upsert(obsmetrics.TagKeyProcessor, cfg.ProcessorID.String(), tag.WithTTL(tag.TTLNoPropagation))},
+		meter:    cfg.ProcessorCreateSettings.MeterProvider.Meter(processorScope),
+		logger:   cfg.ProcessorCreateSettings.Logger,
+
+		useOtelForMetrics: registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID),
+		otelAttrs: []attribute.KeyValue{
+			attribute.String(obsmetrics.ProcessorKey, cfg.ProcessorID.String()),
+		},
 	}
+
+	por.createOtelMetrics()
+
+	return por
 }
 
-// TracesAccepted reports that the trace data was accepted.
-func (por *Processor) TracesAccepted(ctx context.Context, numSpans int) {
-	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			obsmetrics.ProcessorAcceptedSpans.M(int64(numSpans)),
-			obsmetrics.ProcessorRefusedSpans.M(0),
-			obsmetrics.ProcessorDroppedSpans.M(0),
+func (por *Processor) createOtelMetrics() {
+	if !por.useOtelForMetrics {
+		return
+	}
+
+	newCounter := func(measure *stats.Int64Measure) syncint64.Counter {
+		counter, err := por.meter.SyncInt64().Counter(
+			measure.Name(),
+			instrument.WithDescription(measure.Description()),
+			instrument.WithUnit(unit.Unit(measure.Unit())),
 		)
+		if err != nil {
+			por.logger.Warn("failed to create otel instrument", zap.Error(err), zap.String("metric", measure.Name()))
+		}
+		return counter
+	}
+
+	por.acceptedSpans = newCounter(obsmetrics.ProcessorAcceptedSpans)
+	por.refusedSpans = newCounter(obsmetrics.ProcessorRefusedSpans)
+	por.droppedSpans = newCounter(obsmetrics.ProcessorDroppedSpans)
+	por.acceptedMetricPoints = newCounter(obsmetrics.ProcessorAcceptedMetricPoints)
+	por.refusedMetricPoints = newCounter(obsmetrics.ProcessorRefusedMetricPoints)
+	por.droppedMetricPoints = newCounter(obsmetrics.ProcessorDroppedMetricPoints)
+	por.acceptedLogRecords = newCounter(obsmetrics.ProcessorAcceptedLogRecords)
+	por.refusedLogRecords = newCounter(obsmetrics.ProcessorRefusedLogRecords)
+	por.droppedLogRecords = newCounter(obsmetrics.ProcessorDroppedLogRecords)
+}
+
+func (por *Processor) recordData(ctx context.Context, dataType config.DataType, accepted, refused, dropped int64) {
+	if por.level == configtelemetry.LevelNone {
+		return
+	}
+	if por.useOtelForMetrics {
+		por.recordWithOtel(ctx, dataType, accepted, refused, dropped)
+	} else {
+		por.recordWithOC(ctx, dataType, accepted, refused, dropped)
 	}
 }
 
+func (por *Processor) recordWithOtel(ctx context.Context, dataType config.DataType, accepted, refused, dropped int64) {
+	var acceptedCount, refusedCount, droppedCount syncint64.Counter
+	switch dataType {
+	case config.TracesDataType:
+		acceptedCount = por.acceptedSpans
+		refusedCount = por.refusedSpans
+		droppedCount = por.droppedSpans
+	case config.MetricsDataType:
+		acceptedCount = por.acceptedMetricPoints
+		refusedCount = por.refusedMetricPoints
+		droppedCount = por.droppedMetricPoints
+	case config.LogsDataType:
+		acceptedCount = por.acceptedLogRecords
+		refusedCount = por.refusedLogRecords
+		droppedCount = por.droppedLogRecords
+	}
+
+	acceptedCount.Add(ctx, accepted, por.otelAttrs...)
+	refusedCount.Add(ctx, refused, por.otelAttrs...)
+	droppedCount.Add(ctx, dropped, por.otelAttrs...)
+}
+
+func (por *Processor) recordWithOC(ctx context.Context, dataType config.DataType, accepted, refused, dropped int64) {
+	var acceptedMeasure, refusedMeasure, droppedMeasure *stats.Int64Measure
+	switch dataType {
+	case config.TracesDataType:
+		acceptedMeasure = obsmetrics.ProcessorAcceptedSpans
+		refusedMeasure = obsmetrics.ProcessorRefusedSpans
+		droppedMeasure = obsmetrics.ProcessorDroppedSpans
+	case config.MetricsDataType:
+		acceptedMeasure = obsmetrics.ProcessorAcceptedMetricPoints
+		refusedMeasure = obsmetrics.ProcessorRefusedMetricPoints
+		droppedMeasure = obsmetrics.ProcessorDroppedMetricPoints
+	case config.LogsDataType:
+		acceptedMeasure = obsmetrics.ProcessorAcceptedLogRecords
+		refusedMeasure = obsmetrics.ProcessorRefusedLogRecords
+		droppedMeasure = obsmetrics.ProcessorDroppedLogRecords
+	}
+
+	// ignore the error for now; should not happen
+	_ = stats.RecordWithTags(
+		ctx,
+		por.mutators,
+		acceptedMeasure.M(accepted),
+		refusedMeasure.M(refused),
+		droppedMeasure.M(dropped),
+	)
+}
+
+// TracesAccepted reports that the trace data was accepted.
+func (por *Processor) TracesAccepted(ctx context.Context, numSpans int) {
+	por.recordData(ctx, config.TracesDataType, int64(numSpans), 0, 0)
+}
+
 // TracesRefused reports that the trace data was refused.
 func (por *Processor) TracesRefused(ctx context.Context, numSpans int) {
-	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			obsmetrics.ProcessorAcceptedSpans.M(0),
-			obsmetrics.ProcessorRefusedSpans.M(int64(numSpans)),
-			obsmetrics.ProcessorDroppedSpans.M(0),
-		)
-	}
+	por.recordData(ctx, config.TracesDataType, 0, int64(numSpans), 0)
 }
 
 // TracesDropped reports that the trace data was dropped.
 func (por *Processor) TracesDropped(ctx context.Context, numSpans int) {
-	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			obsmetrics.ProcessorAcceptedSpans.M(0),
-			obsmetrics.ProcessorRefusedSpans.M(0),
-			obsmetrics.ProcessorDroppedSpans.M(int64(numSpans)),
-		)
-	}
+	por.recordData(ctx, config.TracesDataType, 0, 0, int64(numSpans))
 }
 
 // MetricsAccepted reports that the metrics were accepted.
 func (por *Processor) MetricsAccepted(ctx context.Context, numPoints int) {
-	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			obsmetrics.ProcessorAcceptedMetricPoints.M(int64(numPoints)),
-			obsmetrics.ProcessorRefusedMetricPoints.M(0),
-			obsmetrics.ProcessorDroppedMetricPoints.M(0),
-		)
-	}
+	por.recordData(ctx, config.MetricsDataType, int64(numPoints), 0, 0)
 }
 
 // MetricsRefused reports that the metrics were refused.
 func (por *Processor) MetricsRefused(ctx context.Context, numPoints int) {
-	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			obsmetrics.ProcessorAcceptedMetricPoints.M(0),
-			obsmetrics.ProcessorRefusedMetricPoints.M(int64(numPoints)),
-			obsmetrics.ProcessorDroppedMetricPoints.M(0),
-		)
-	}
+	por.recordData(ctx, config.MetricsDataType, 0, int64(numPoints), 0)
 }
 
 // MetricsDropped reports that the metrics were dropped.
 func (por *Processor) MetricsDropped(ctx context.Context, numPoints int) {
-	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			obsmetrics.ProcessorAcceptedMetricPoints.M(0),
-			obsmetrics.ProcessorRefusedMetricPoints.M(0),
-			obsmetrics.ProcessorDroppedMetricPoints.M(int64(numPoints)),
-		)
-	}
+	por.recordData(ctx, config.MetricsDataType, 0, 0, int64(numPoints))
 }
 
 // LogsAccepted reports that the logs were accepted.
 func (por *Processor) LogsAccepted(ctx context.Context, numRecords int) {
-	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			obsmetrics.ProcessorAcceptedLogRecords.M(int64(numRecords)),
-			obsmetrics.ProcessorRefusedLogRecords.M(0),
-			obsmetrics.ProcessorDroppedLogRecords.M(0),
-		)
-	}
+	por.recordData(ctx, config.LogsDataType, int64(numRecords), 0, 0)
 }
 
 // LogsRefused reports that the logs were refused.
 func (por *Processor) LogsRefused(ctx context.Context, numRecords int) {
-	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			obsmetrics.ProcessorAcceptedLogRecords.M(0),
-			obsmetrics.ProcessorRefusedLogRecords.M(int64(numRecords)),
-			obsmetrics.ProcessorDroppedMetricPoints.M(0),
-		)
-	}
+	por.recordData(ctx, config.LogsDataType, 0, int64(numRecords), 0)
 }
 
 // LogsDropped reports that the logs were dropped.
 func (por *Processor) LogsDropped(ctx context.Context, numRecords int) {
-	if por.level != configtelemetry.LevelNone {
-		// ignore the error for now; should not happen
-		_ = stats.RecordWithTags(
-			ctx,
-			por.mutators,
-			obsmetrics.ProcessorAcceptedLogRecords.M(0),
-			obsmetrics.ProcessorRefusedLogRecords.M(0),
-			obsmetrics.ProcessorDroppedLogRecords.M(int64(numRecords)),
-		)
-	}
+	por.recordData(ctx, config.LogsDataType, 0, 0, int64(numRecords))
 }
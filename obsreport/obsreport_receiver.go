@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/netstats"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 )
@@ -61,6 +62,8 @@ type Receiver struct {
 	refusedMetricPointsCounter  syncint64.Counter
 	acceptedLogRecordsCounter   syncint64.Counter
 	refusedLogRecordsCounter    syncint64.Counter
+	receivedBytesCounter        syncint64.Counter
+	receivedWireBytesCounter    syncint64.Counter
 }
 
 // ReceiverSettings are settings for creating an Receiver.
@@ -160,6 +163,20 @@ func (rec *Receiver) createOtelMetrics() {
 		instrument.WithUnit(unit.Dimensionless),
 	)
 	handleError(obsmetrics.ReceiverPrefix+obsmetrics.RefusedLogRecordsKey, err)
+
+	rec.receivedBytesCounter, err = rec.meter.SyncInt64().Counter(
+		obsmetrics.ReceiverPrefix+obsmetrics.ReceivedBytesKey,
+		instrument.WithDescription("Number of bytes received, after decompression."),
+		instrument.WithUnit(unit.Bytes),
+	)
+	handleError(obsmetrics.ReceiverPrefix+obsmetrics.ReceivedBytesKey, err)
+
+	rec.receivedWireBytesCounter, err = rec.meter.SyncInt64().Counter(
+		obsmetrics.ReceiverPrefix+obsmetrics.ReceivedWireBytesKey,
+		instrument.WithDescription("Number of bytes received, as transferred over the network before decompression."),
+		instrument.WithUnit(unit.Bytes),
+	)
+	handleError(obsmetrics.ReceiverPrefix+obsmetrics.ReceivedWireBytesKey, err)
 }
 
 // StartTracesOp is called when a request is received from a client.
@@ -219,7 +236,7 @@ func (rec *Receiver) EndMetricsOp(
 // startOp creates the span used to trace the operation. Returning
 // the updated context with the created span.
 func (rec *Receiver) startOp(receiverCtx context.Context, operationSuffix string) context.Context {
-	ctx, _ := tag.New(receiverCtx, rec.mutators...)
+	ctx, _ := tag.New(receiverCtx, withDimensions(receiverCtx, rec.mutators)...)
 	var span trace.Span
 	spanName := rec.spanNamePrefix + operationSuffix
 	if !rec.longLivedCtx {
@@ -260,6 +277,7 @@ func (rec *Receiver) endOp(
 
 	if rec.level != configtelemetry.LevelNone {
 		rec.recordMetrics(receiverCtx, dataType, numAccepted, numRefused)
+		rec.recordBytes(receiverCtx)
 	}
 
 	// end span according to errors
@@ -309,8 +327,9 @@ func (rec *Receiver) recordWithOtel(receiverCtx context.Context, dataType config
 		refusedMeasure = rec.refusedLogRecordsCounter
 	}
 
-	acceptedMeasure.Add(receiverCtx, int64(numAccepted), rec.otelAttrs...)
-	refusedMeasure.Add(receiverCtx, int64(numRefused), rec.otelAttrs...)
+	attrs := withDimensionAttributes(receiverCtx, rec.otelAttrs)
+	acceptedMeasure.Add(receiverCtx, int64(numAccepted), attrs...)
+	refusedMeasure.Add(receiverCtx, int64(numRefused), attrs...)
 }
 
 func (rec *Receiver) recordWithOC(receiverCtx context.Context, dataType config.DataType, numAccepted, numRefused int) {
@@ -332,3 +351,27 @@ func (rec *Receiver) recordWithOC(receiverCtx context.Context, dataType config.D
 		acceptedMeasure.M(int64(numAccepted)),
 		refusedMeasure.M(int64(numRefused)))
 }
+
+// recordBytes records the size of the requests received since the previous operation with
+// the same context, as counted by the confighttp and configgrpc servers.
+func (rec *Receiver) recordBytes(receiverCtx context.Context) {
+	counter := netstats.FromContext(receiverCtx)
+	if counter == nil {
+		return
+	}
+	bytes, wireBytes := counter.Swap()
+	if bytes == 0 && wireBytes == 0 {
+		return
+	}
+
+	if rec.useOtelForMetrics {
+		attrs := withDimensionAttributes(receiverCtx, rec.otelAttrs)
+		rec.receivedBytesCounter.Add(receiverCtx, bytes, attrs...)
+		rec.receivedWireBytesCounter.Add(receiverCtx, wireBytes, attrs...)
+		return
+	}
+	stats.Record(
+		receiverCtx,
+		obsmetrics.ReceiverReceivedBytes.M(bytes),
+		obsmetrics.ReceiverReceivedWireBytes.M(wireBytes))
+}
@@ -21,11 +21,18 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/netstats"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
@@ -214,210 +221,202 @@ func TestReceiveMetricsOp(t *testing.T) {
 }
 
 func TestScrapeMetricsDataOp(t *testing.T) {
-	tt, err := obsreporttest.SetupTelemetry()
-	require.NoError(t, err)
-	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
-
-	parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-	defer parentSpan.End()
+	testTelemetry(t, func(tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
 
-	params := []testParams{
-		{items: 23, err: partialErrFake},
-		{items: 29, err: errFake},
-		{items: 15, err: nil},
-	}
-	for i := range params {
-		scrp := NewScraper(ScraperSettings{
-			ReceiverID:             receiver,
-			Scraper:                scraper,
-			ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
-		})
-		ctx := scrp.StartMetricsOp(parentCtx)
-		assert.NotNil(t, ctx)
-		scrp.EndMetricsOp(ctx, params[i].items, params[i].err)
-	}
+		params := []testParams{
+			{items: 23, err: partialErrFake},
+			{items: 29, err: errFake},
+			{items: 15, err: nil},
+		}
+		for i := range params {
+			scrp := newScraper(ScraperSettings{
+				ReceiverID:             receiver,
+				Scraper:                scraper,
+				ReceiverCreateSettings: tt.ToReceiverCreateSettings(),
+			}, registry)
+			ctx := scrp.StartMetricsOp(parentCtx)
+			assert.NotNil(t, ctx)
+			scrp.EndMetricsOp(ctx, params[i].items, params[i].err)
+		}
 
-	spans := tt.SpanRecorder.Ended()
-	require.Equal(t, len(params), len(spans))
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
 
-	var scrapedMetricPoints, erroredMetricPoints int
-	for i, span := range spans {
-		assert.Equal(t, "scraper/"+receiver.String()+"/"+scraper.String()+"/MetricsScraped", span.Name())
-		switch {
-		case params[i].err == nil:
-			scrapedMetricPoints += params[i].items
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(0)})
-			assert.Equal(t, codes.Unset, span.Status().Code)
-		case errors.Is(params[i].err, errFake):
-			erroredMetricPoints += params[i].items
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(0)})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-			assert.Equal(t, codes.Error, span.Status().Code)
-			assert.Equal(t, params[i].err.Error(), span.Status().Description)
+		var scrapedMetricPoints, erroredMetricPoints int
+		for i, span := range spans {
+			assert.Equal(t, "scraper/"+receiver.String()+"/"+scraper.String()+"/MetricsScraped", span.Name())
+			switch {
+			case params[i].err == nil:
+				scrapedMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				erroredMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
 
-		case errors.Is(params[i].err, partialErrFake):
-			scrapedMetricPoints += params[i].items
-			erroredMetricPoints++
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(1)})
-			assert.Equal(t, codes.Error, span.Status().Code)
-			assert.Equal(t, params[i].err.Error(), span.Status().Description)
-		default:
-			t.Fatalf("unexpected err param: %v", params[i].err)
+			case errors.Is(params[i].err, partialErrFake):
+				scrapedMetricPoints += params[i].items
+				erroredMetricPoints++
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ScrapedMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.ErroredMetricPointsKey, Value: attribute.Int64Value(1)})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected err param: %v", params[i].err)
+			}
 		}
-	}
 
-	require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiver, scraper, int64(scrapedMetricPoints), int64(erroredMetricPoints)))
+		require.NoError(t, obsreporttest.CheckScraperMetrics(tt, receiver, scraper, int64(scrapedMetricPoints), int64(erroredMetricPoints)))
+	})
 }
 
 func TestExportTraceDataOp(t *testing.T) {
-	tt, err := obsreporttest.SetupTelemetry()
-	require.NoError(t, err)
-	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
-
-	parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-	defer parentSpan.End()
+	testTelemetry(t, func(tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
 
-	obsrep := NewExporter(ExporterSettings{
-		ExporterID:             exporter,
-		ExporterCreateSettings: tt.ToExporterCreateSettings(),
-	})
+		obsrep := newExporter(ExporterSettings{
+			ExporterID:             exporter,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, registry)
 
-	params := []testParams{
-		{items: 22, err: nil},
-		{items: 14, err: errFake},
-	}
-	for i := range params {
-		ctx := obsrep.StartTracesOp(parentCtx)
-		assert.NotNil(t, ctx)
-		obsrep.EndTracesOp(ctx, params[i].items, params[i].err)
-	}
+		params := []testParams{
+			{items: 22, err: nil},
+			{items: 14, err: errFake},
+		}
+		for i := range params {
+			ctx := obsrep.StartTracesOp(parentCtx)
+			assert.NotNil(t, ctx)
+			obsrep.EndTracesOp(ctx, params[i].items, params[i].err)
+		}
 
-	spans := tt.SpanRecorder.Ended()
-	require.Equal(t, len(params), len(spans))
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
 
-	var sentSpans, failedToSendSpans int
-	for i, span := range spans {
-		assert.Equal(t, "exporter/"+exporter.String()+"/traces", span.Name())
-		switch {
-		case params[i].err == nil:
-			sentSpans += params[i].items
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(0)})
-			assert.Equal(t, codes.Unset, span.Status().Code)
-		case errors.Is(params[i].err, errFake):
-			failedToSendSpans += params[i].items
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(0)})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
-			assert.Equal(t, codes.Error, span.Status().Code)
-			assert.Equal(t, params[i].err.Error(), span.Status().Description)
-		default:
-			t.Fatalf("unexpected error: %v", params[i].err)
+		var sentSpans, failedToSendSpans int
+		for i, span := range spans {
+			assert.Equal(t, "exporter/"+exporter.String()+"/traces", span.Name())
+			switch {
+			case params[i].err == nil:
+				sentSpans += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				failedToSendSpans += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentSpansKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendSpansKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected error: %v", params[i].err)
+			}
 		}
-	}
 
-	require.NoError(t, obsreporttest.CheckExporterTraces(tt, exporter, int64(sentSpans), int64(failedToSendSpans)))
+		require.NoError(t, obsreporttest.CheckExporterTraces(tt, exporter, int64(sentSpans), int64(failedToSendSpans)))
+	})
 }
 
 func TestExportMetricsOp(t *testing.T) {
-	tt, err := obsreporttest.SetupTelemetry()
-	require.NoError(t, err)
-	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
-
-	parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-	defer parentSpan.End()
+	testTelemetry(t, func(tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
 
-	obsrep := NewExporter(ExporterSettings{
-		ExporterID:             exporter,
-		ExporterCreateSettings: tt.ToExporterCreateSettings(),
-	})
+		obsrep := newExporter(ExporterSettings{
+			ExporterID:             exporter,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, registry)
 
-	params := []testParams{
-		{items: 17, err: nil},
-		{items: 23, err: errFake},
-	}
-	for i := range params {
-		ctx := obsrep.StartMetricsOp(parentCtx)
-		assert.NotNil(t, ctx)
+		params := []testParams{
+			{items: 17, err: nil},
+			{items: 23, err: errFake},
+		}
+		for i := range params {
+			ctx := obsrep.StartMetricsOp(parentCtx)
+			assert.NotNil(t, ctx)
 
-		obsrep.EndMetricsOp(ctx, params[i].items, params[i].err)
-	}
+			obsrep.EndMetricsOp(ctx, params[i].items, params[i].err)
+		}
 
-	spans := tt.SpanRecorder.Ended()
-	require.Equal(t, len(params), len(spans))
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
 
-	var sentMetricPoints, failedToSendMetricPoints int
-	for i, span := range spans {
-		assert.Equal(t, "exporter/"+exporter.String()+"/metrics", span.Name())
-		switch {
-		case params[i].err == nil:
-			sentMetricPoints += params[i].items
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendMetricPointsKey, Value: attribute.Int64Value(0)})
-			assert.Equal(t, codes.Unset, span.Status().Code)
-		case errors.Is(params[i].err, errFake):
-			failedToSendMetricPoints += params[i].items
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentMetricPointsKey, Value: attribute.Int64Value(0)})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
-			assert.Equal(t, codes.Error, span.Status().Code)
-			assert.Equal(t, params[i].err.Error(), span.Status().Description)
-		default:
-			t.Fatalf("unexpected error: %v", params[i].err)
+		var sentMetricPoints, failedToSendMetricPoints int
+		for i, span := range spans {
+			assert.Equal(t, "exporter/"+exporter.String()+"/metrics", span.Name())
+			switch {
+			case params[i].err == nil:
+				sentMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendMetricPointsKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				failedToSendMetricPoints += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentMetricPointsKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendMetricPointsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected error: %v", params[i].err)
+			}
 		}
-	}
 
-	require.NoError(t, obsreporttest.CheckExporterMetrics(tt, exporter, int64(sentMetricPoints), int64(failedToSendMetricPoints)))
+		require.NoError(t, obsreporttest.CheckExporterMetrics(tt, exporter, int64(sentMetricPoints), int64(failedToSendMetricPoints)))
+	})
 }
 
 func TestExportLogsOp(t *testing.T) {
-	tt, err := obsreporttest.SetupTelemetry()
-	require.NoError(t, err)
-	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
-
-	parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
-	defer parentSpan.End()
+	testTelemetry(t, func(tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
+		parentCtx, parentSpan := tt.TracerProvider.Tracer("test").Start(context.Background(), t.Name())
+		defer parentSpan.End()
 
-	obsrep := NewExporter(ExporterSettings{
-		ExporterID:             exporter,
-		ExporterCreateSettings: tt.ToExporterCreateSettings(),
-	})
+		obsrep := newExporter(ExporterSettings{
+			ExporterID:             exporter,
+			ExporterCreateSettings: tt.ToExporterCreateSettings(),
+		}, registry)
 
-	params := []testParams{
-		{items: 17, err: nil},
-		{items: 23, err: errFake},
-	}
-	for i := range params {
-		ctx := obsrep.StartLogsOp(parentCtx)
-		assert.NotNil(t, ctx)
+		params := []testParams{
+			{items: 17, err: nil},
+			{items: 23, err: errFake},
+		}
+		for i := range params {
+			ctx := obsrep.StartLogsOp(parentCtx)
+			assert.NotNil(t, ctx)
 
-		obsrep.EndLogsOp(ctx, params[i].items, params[i].err)
-	}
+			obsrep.EndLogsOp(ctx, params[i].items, params[i].err)
+		}
 
-	spans := tt.SpanRecorder.Ended()
-	require.Equal(t, len(params), len(spans))
+		spans := tt.SpanRecorder.Ended()
+		require.Equal(t, len(params), len(spans))
 
-	var sentLogRecords, failedToSendLogRecords int
-	for i, span := range spans {
-		assert.Equal(t, "exporter/"+exporter.String()+"/logs", span.Name())
-		switch {
-		case params[i].err == nil:
-			sentLogRecords += params[i].items
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendLogRecordsKey, Value: attribute.Int64Value(0)})
-			assert.Equal(t, codes.Unset, span.Status().Code)
-		case errors.Is(params[i].err, errFake):
-			failedToSendLogRecords += params[i].items
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentLogRecordsKey, Value: attribute.Int64Value(0)})
-			require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
-			assert.Equal(t, codes.Error, span.Status().Code)
-			assert.Equal(t, params[i].err.Error(), span.Status().Description)
-		default:
-			t.Fatalf("unexpected error: %v", params[i].err)
+		var sentLogRecords, failedToSendLogRecords int
+		for i, span := range spans {
+			assert.Equal(t, "exporter/"+exporter.String()+"/logs", span.Name())
+			switch {
+			case params[i].err == nil:
+				sentLogRecords += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendLogRecordsKey, Value: attribute.Int64Value(0)})
+				assert.Equal(t, codes.Unset, span.Status().Code)
+			case errors.Is(params[i].err, errFake):
+				failedToSendLogRecords += params[i].items
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.SentLogRecordsKey, Value: attribute.Int64Value(0)})
+				require.Contains(t, span.Attributes(), attribute.KeyValue{Key: obsmetrics.FailedToSendLogRecordsKey, Value: attribute.Int64Value(int64(params[i].items))})
+				assert.Equal(t, codes.Error, span.Status().Code)
+				assert.Equal(t, params[i].err.Error(), span.Status().Description)
+			default:
+				t.Fatalf("unexpected error: %v", params[i].err)
+			}
 		}
-	}
 
-	require.NoError(t, obsreporttest.CheckExporterLogs(tt, exporter, int64(sentLogRecords), int64(failedToSendLogRecords)))
+		require.NoError(t, obsreporttest.CheckExporterLogs(tt, exporter, int64(sentLogRecords), int64(failedToSendLogRecords)))
+	})
 }
 
 func TestReceiveWithLongLivedCtx(t *testing.T) {
@@ -473,44 +472,127 @@ func TestReceiveWithLongLivedCtx(t *testing.T) {
 	}
 }
 
-func TestProcessorTraceData(t *testing.T) {
+func TestReceiveAndExportBytes(t *testing.T) {
 	tt, err := obsreporttest.SetupTelemetry()
 	require.NoError(t, err)
 	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
 
-	const acceptedSpans = 27
-	const refusedSpans = 19
-	const droppedSpans = 13
+	rec := newReceiver(ReceiverSettings{ReceiverID: receiver, Transport: transport, ReceiverCreateSettings: tt.ToReceiverCreateSettings()}, featuregate.NewRegistry())
+	exp := newExporter(ExporterSettings{ExporterID: exporter, ExporterCreateSettings: tt.ToExporterCreateSettings()}, featuregate.NewRegistry())
+
+	// The counter is filled by the confighttp and configgrpc helpers.
+	counter := &netstats.Counter{}
+	counter.AddBytes(100)
+	counter.AddWireBytes(40)
+	ctx := rec.StartTracesOp(netstats.NewContext(context.Background(), counter))
+	exportCtx := exp.StartTracesOp(ctx)
+	netstats.FromContext(exportCtx).AddBytes(90)
+	netstats.FromContext(exportCtx).AddWireBytes(30)
+	exp.EndTracesOp(exportCtx, 2, nil)
+	rec.EndTracesOp(ctx, format, 2, nil)
+
+	// Operations without counted bytes record nothing.
+	ctx = rec.StartTracesOp(context.Background())
+	exp.EndTracesOp(exp.StartTracesOp(ctx), 2, nil)
+	rec.EndTracesOp(ctx, format, 2, nil)
+
+	sum := func(m *stats.Int64Measure) float64 {
+		rows, err := view.RetrieveData(m.Name())
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		return rows[0].Data.(*view.SumData).Value
+	}
+	assert.Equal(t, float64(100), sum(obsmetrics.ReceiverReceivedBytes))
+	assert.Equal(t, float64(40), sum(obsmetrics.ReceiverReceivedWireBytes))
+	assert.Equal(t, float64(90), sum(obsmetrics.ExporterSentBytes))
+	assert.Equal(t, float64(30), sum(obsmetrics.ExporterSentWireBytes))
+}
 
-	obsrep := NewProcessor(ProcessorSettings{
-		ProcessorID:             processor,
-		ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+func TestReceiveAndExportWithDimensions(t *testing.T) {
+	obsMetrics := obsreportconfig.Configure(configtelemetry.LevelNormal, obsreportconfig.Dimension{
+		Name:           "tenant",
+		MetadataKey:    "x-tenant",
+		MaxCardinality: 2,
+	})
+	require.NoError(t, view.Register(obsMetrics.Views...))
+	t.Cleanup(func() {
+		view.Unregister(obsMetrics.Views...)
+		obsreportconfig.Configure(configtelemetry.LevelNormal)
 	})
-	obsrep.TracesAccepted(context.Background(), acceptedSpans)
-	obsrep.TracesRefused(context.Background(), refusedSpans)
-	obsrep.TracesDropped(context.Background(), droppedSpans)
-
-	require.NoError(t, obsreporttest.CheckProcessorTraces(tt, processor, acceptedSpans, refusedSpans, droppedSpans))
-}
 
-func TestProcessorMetricsData(t *testing.T) {
-	tt, err := obsreporttest.SetupTelemetry()
-	require.NoError(t, err)
-	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	recSet := componenttest.NewNopReceiverCreateSettings()
+	recSet.MetricsLevel = configtelemetry.LevelNormal
+	rec := newReceiver(ReceiverSettings{ReceiverID: receiver, Transport: transport, ReceiverCreateSettings: recSet}, featuregate.NewRegistry())
+	expSet := componenttest.NewNopExporterCreateSettings()
+	expSet.MetricsLevel = configtelemetry.LevelNormal
+	exp := newExporter(ExporterSettings{ExporterID: exporter, ExporterCreateSettings: expSet}, featuregate.NewRegistry())
+
+	for _, tenant := range []string{"a", "b", "a", "c", ""} {
+		ctx := context.Background()
+		if tenant != "" {
+			ctx = client.NewContext(ctx, client.Info{Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}})})
+		}
+		ctx = rec.StartTracesOp(ctx)
+		exportCtx := exp.StartTracesOp(ctx)
+		exp.EndTracesOp(exportCtx, 2, nil)
+		rec.EndTracesOp(ctx, format, 2, nil)
+	}
 
-	const acceptedPoints = 29
-	const refusedPoints = 11
-	const droppedPoints = 17
+	tenantKey := tag.MustNewKey("tenant")
+	sums := func(viewName string, key tag.Key) map[string]float64 {
+		rows, err := view.RetrieveData(viewName)
+		require.NoError(t, err)
+		ret := map[string]float64{}
+		for _, row := range rows {
+			tenant := ""
+			for _, tg := range row.Tags {
+				if tg.Key == key {
+					tenant = tg.Value
+				}
+			}
+			ret[tenant] += row.Data.(*view.SumData).Value
+		}
+		return ret
+	}
+	want := map[string]float64{"a": 4, "b": 2, obsreportconfig.OverflowValue: 2, "": 2}
+	assert.Equal(t, want, sums(obsmetrics.ReceiverAcceptedSpans.Name(), tenantKey))
+	assert.Equal(t, want, sums(obsmetrics.ExporterSentSpans.Name(), tenantKey))
+}
 
-	obsrep := NewProcessor(ProcessorSettings{
-		ProcessorID:             processor,
-		ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+func TestProcessorTraceData(t *testing.T) {
+	testTelemetry(t, func(tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
+		const acceptedSpans = 27
+		const refusedSpans = 19
+		const droppedSpans = 13
+
+		obsrep := newProcessor(ProcessorSettings{
+			ProcessorID:             processor,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, registry)
+		obsrep.TracesAccepted(context.Background(), acceptedSpans)
+		obsrep.TracesRefused(context.Background(), refusedSpans)
+		obsrep.TracesDropped(context.Background(), droppedSpans)
+
+		require.NoError(t, obsreporttest.CheckProcessorTraces(tt, processor, acceptedSpans, refusedSpans, droppedSpans))
 	})
-	obsrep.MetricsAccepted(context.Background(), acceptedPoints)
-	obsrep.MetricsRefused(context.Background(), refusedPoints)
-	obsrep.MetricsDropped(context.Background(), droppedPoints)
+}
 
-	require.NoError(t, obsreporttest.CheckProcessorMetrics(tt, processor, acceptedPoints, refusedPoints, droppedPoints))
+func TestProcessorMetricsData(t *testing.T) {
+	testTelemetry(t, func(tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
+		const acceptedPoints = 29
+		const refusedPoints = 11
+		const droppedPoints = 17
+
+		obsrep := newProcessor(ProcessorSettings{
+			ProcessorID:             processor,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, registry)
+		obsrep.MetricsAccepted(context.Background(), acceptedPoints)
+		obsrep.MetricsRefused(context.Background(), refusedPoints)
+		obsrep.MetricsDropped(context.Background(), droppedPoints)
+
+		require.NoError(t, obsreporttest.CheckProcessorMetrics(tt, processor, acceptedPoints, refusedPoints, droppedPoints))
+	})
 }
 
 func TestBuildProcessorCustomMetricName(t *testing.T) {
@@ -536,21 +618,19 @@ func TestBuildProcessorCustomMetricName(t *testing.T) {
 }
 
 func TestProcessorLogRecords(t *testing.T) {
-	tt, err := obsreporttest.SetupTelemetry()
-	require.NoError(t, err)
-	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
-
-	const acceptedRecords = 29
-	const refusedRecords = 11
-	const droppedRecords = 17
-
-	obsrep := NewProcessor(ProcessorSettings{
-		ProcessorID:             processor,
-		ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+	testTelemetry(t, func(tt obsreporttest.TestTelemetry, registry *featuregate.Registry) {
+		const acceptedRecords = 29
+		const refusedRecords = 11
+		const droppedRecords = 17
+
+		obsrep := newProcessor(ProcessorSettings{
+			ProcessorID:             processor,
+			ProcessorCreateSettings: tt.ToProcessorCreateSettings(),
+		}, registry)
+		obsrep.LogsAccepted(context.Background(), acceptedRecords)
+		obsrep.LogsRefused(context.Background(), refusedRecords)
+		obsrep.LogsDropped(context.Background(), droppedRecords)
+
+		require.NoError(t, obsreporttest.CheckProcessorLogs(tt, processor, acceptedRecords, refusedRecords, droppedRecords))
 	})
-	obsrep.LogsAccepted(context.Background(), acceptedRecords)
-	obsrep.LogsRefused(context.Background(), refusedRecords)
-	obsrep.LogsDropped(context.Background(), droppedRecords)
-
-	require.NoError(t, obsreporttest.CheckProcessorLogs(tt, processor, acceptedRecords, refusedRecords, droppedRecords))
 }
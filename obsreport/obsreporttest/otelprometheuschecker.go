@@ -55,6 +55,55 @@ func (pc *prometheusChecker) checkReceiverMetrics(receiver config.ComponentID, p
 		pc.checkCounter("receiver_refused_metric_points", droppedMetricPoints, receiverAttrs))
 }
 
+func (pc *prometheusChecker) checkScraperMetrics(receiver config.ComponentID, scraper config.ComponentID, scrapedMetricPoints, erroredMetricPoints int64) error {
+	scraperAttrs := attributesForScraperMetrics(receiver, scraper)
+	return multierr.Combine(
+		pc.checkCounter("scraper_scraped_metric_points", scrapedMetricPoints, scraperAttrs),
+		pc.checkCounter("scraper_errored_metric_points", erroredMetricPoints, scraperAttrs))
+}
+
+func (pc *prometheusChecker) checkProcessorTraces(processor config.ComponentID, acceptedSpans, refusedSpans, droppedSpans int64) error {
+	return pc.checkProcessor(processor, "spans", acceptedSpans, refusedSpans, droppedSpans)
+}
+
+func (pc *prometheusChecker) checkProcessorMetrics(processor config.ComponentID, acceptedMetricPoints, refusedMetricPoints, droppedMetricPoints int64) error {
+	return pc.checkProcessor(processor, "metric_points", acceptedMetricPoints, refusedMetricPoints, droppedMetricPoints)
+}
+
+func (pc *prometheusChecker) checkProcessorLogs(processor config.ComponentID, acceptedLogRecords, refusedLogRecords, droppedLogRecords int64) error {
+	return pc.checkProcessor(processor, "log_records", acceptedLogRecords, refusedLogRecords, droppedLogRecords)
+}
+
+func (pc *prometheusChecker) checkProcessor(processor config.ComponentID, datatype string, accepted, refused, dropped int64) error {
+	processorAttrs := attributesForProcessorMetrics(processor)
+	return multierr.Combine(
+		pc.checkCounter(fmt.Sprintf("processor_accepted_%s", datatype), accepted, processorAttrs),
+		pc.checkCounter(fmt.Sprintf("processor_refused_%s", datatype), refused, processorAttrs),
+		pc.checkCounter(fmt.Sprintf("processor_dropped_%s", datatype), dropped, processorAttrs))
+}
+
+func (pc *prometheusChecker) checkExporterTraces(exporter config.ComponentID, sentSpans, sendFailedSpans int64) error {
+	return pc.checkExporter(exporter, "spans", sentSpans, sendFailedSpans)
+}
+
+func (pc *prometheusChecker) checkExporterMetrics(exporter config.ComponentID, sentMetricsPoints, sendFailedMetricsPoints int64) error {
+	return pc.checkExporter(exporter, "metric_points", sentMetricsPoints, sendFailedMetricsPoints)
+}
+
+func (pc *prometheusChecker) checkExporterLogs(exporter config.ComponentID, sentLogRecords, sendFailedLogRecords int64) error {
+	return pc.checkExporter(exporter, "log_records", sentLogRecords, sendFailedLogRecords)
+}
+
+func (pc *prometheusChecker) checkExporter(exporter config.ComponentID, datatype string, sent, sendFailed int64) error {
+	exporterAttrs := attributesForExporterMetrics(exporter)
+	errs := pc.checkCounter(fmt.Sprintf("exporter_sent_%s", datatype), sent, exporterAttrs)
+	if sendFailed > 0 {
+		errs = multierr.Append(errs,
+			pc.checkCounter(fmt.Sprintf("exporter_send_failed_%s", datatype), sendFailed, exporterAttrs))
+	}
+	return errs
+}
+
 func (pc *prometheusChecker) checkCounter(expectedMetric string, value int64, attrs []attribute.KeyValue) error {
 	// Forces a flush for the opencensus view data.
 	_, _ = view.RetrieveData(expectedMetric)
@@ -131,3 +180,25 @@ func attributesForReceiverMetrics(receiver config.ComponentID, transport string)
 		attribute.String(transportTag.Name(), transport),
 	}
 }
+
+// attributesForScraperMetrics returns the attributes that are needed for the scraper metrics.
+func attributesForScraperMetrics(receiver config.ComponentID, scraper config.ComponentID) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(receiverTag.Name(), receiver.String()),
+		attribute.String(scraperTag.Name(), scraper.String()),
+	}
+}
+
+// attributesForProcessorMetrics returns the attributes that are needed for the processor metrics.
+func attributesForProcessorMetrics(processor config.ComponentID) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(processorTag.Name(), processor.String()),
+	}
+}
+
+// attributesForExporterMetrics returns the attributes that are needed for the exporter metrics.
+func attributesForExporterMetrics(exporter config.ComponentID) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(exporterTag.Name(), exporter.String()),
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor // import "go.opentelemetry.io/collector/processor/attributesprocessor"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// action is an ActionConfig ready to be applied to attributes.
+type action struct {
+	key           string
+	action        Action
+	target        Target
+	value         pcommon.Value
+	fromAttribute string
+	newKey        string
+}
+
+func newActions(cfgs []ActionConfig) []action {
+	actions := make([]action, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		a := action{
+			key:           cfg.Key,
+			action:        cfg.Action,
+			target:        cfg.Target,
+			fromAttribute: cfg.FromAttribute,
+			newKey:        cfg.NewKey,
+		}
+		if a.target == "" {
+			a.target = TargetRecord
+		}
+		if cfg.Value != nil {
+			a.value = pcommon.NewValueEmpty()
+			a.value.FromRaw(cfg.Value)
+		}
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+// appliesTo returns whether the action applies to the attributes of target,
+// one of the targets other than TargetRecord.
+func (a *action) appliesTo(target Target) bool {
+	if a.target == TargetRecord {
+		return target == TargetSpan || target == TargetLog || target == TargetDataPoint
+	}
+	return a.target == target
+}
+
+// applyActions applies the actions targeting target to attrs.
+func applyActions(actions []action, target Target, attrs pcommon.Map) {
+	for i := range actions {
+		if actions[i].appliesTo(target) {
+			actions[i].apply(attrs)
+		}
+	}
+}
+
+func (a *action) apply(attrs pcommon.Map) {
+	switch a.action {
+	case Insert:
+		if _, ok := attrs.Get(a.key); !ok {
+			a.set(attrs)
+		}
+	case Update:
+		if _, ok := attrs.Get(a.key); ok {
+			a.set(attrs)
+		}
+	case Upsert:
+		a.set(attrs)
+	case Delete:
+		attrs.Remove(a.key)
+	case Hash:
+		if v, ok := attrs.Get(a.key); ok {
+			sum := sha256.Sum256([]byte(v.AsString()))
+			v.SetStr(hex.EncodeToString(sum[:]))
+		}
+	case Rename:
+		if v, ok := attrs.Get(a.key); ok {
+			copyAttribute(v, attrs, a.newKey)
+			attrs.Remove(a.key)
+		}
+	}
+}
+
+// set sets the attribute to the value of the action, or to the value of its
+// source attribute when present.
+func (a *action) set(attrs pcommon.Map) {
+	if a.fromAttribute == "" {
+		a.value.CopyTo(attrs.PutEmpty(a.key))
+		return
+	}
+	if from, ok := attrs.Get(a.fromAttribute); ok {
+		copyAttribute(from, attrs, a.key)
+	}
+}
+
+// copyAttribute sets the attribute key of attrs to v, one of their values.
+func copyAttribute(v pcommon.Value, attrs pcommon.Map, key string) {
+	// PutEmpty may reallocate the attributes and invalidate v.
+	tmp := pcommon.NewValueEmpty()
+	v.CopyTo(tmp)
+	tmp.CopyTo(attrs.PutEmpty(key))
+}
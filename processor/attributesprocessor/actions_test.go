@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestApplyActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   ActionConfig
+		expected map[string]interface{}
+	}{
+		{
+			name:     "insert absent",
+			action:   ActionConfig{Key: "env", Value: "prod", Action: Insert},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "http.target": "/cart", "count": int64(3), "env": "prod"},
+		},
+		{
+			name:     "insert present",
+			action:   ActionConfig{Key: "count", Value: 4, Action: Insert},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "http.target": "/cart", "count": int64(3)},
+		},
+		{
+			name:     "update absent",
+			action:   ActionConfig{Key: "env", Value: "prod", Action: Update},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "http.target": "/cart", "count": int64(3)},
+		},
+		{
+			name:     "update present",
+			action:   ActionConfig{Key: "count", Value: []interface{}{1, "a"}, Action: Update},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "http.target": "/cart", "count": []interface{}{int64(1), "a"}},
+		},
+		{
+			name:     "upsert from attribute",
+			action:   ActionConfig{Key: "http.url", FromAttribute: "http.target", Action: Upsert},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "http.target": "/cart", "count": int64(3), "http.url": "/cart"},
+		},
+		{
+			name:     "upsert from absent attribute",
+			action:   ActionConfig{Key: "count", FromAttribute: "size", Action: Upsert},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "http.target": "/cart", "count": int64(3)},
+		},
+		{
+			name:     "delete",
+			action:   ActionConfig{Key: "user.email", Action: Delete},
+			expected: map[string]interface{}{"http.target": "/cart", "count": int64(3)},
+		},
+		{
+			name:   "hash",
+			action: ActionConfig{Key: "count", Action: Hash},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "http.target": "/cart",
+				"count": "4e07408562bedb8b60ce05c1decfe3ad16b72230967de01f640b7e4729b49fce"},
+		},
+		{
+			name:     "hash absent",
+			action:   ActionConfig{Key: "size", Action: Hash},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "http.target": "/cart", "count": int64(3)},
+		},
+		{
+			name:     "rename",
+			action:   ActionConfig{Key: "http.target", NewKey: "url.path", Action: Rename},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "url.path": "/cart", "count": int64(3)},
+		},
+		{
+			name:     "rename over existing",
+			action:   ActionConfig{Key: "http.target", NewKey: "count", Action: Rename},
+			expected: map[string]interface{}{"user.email": "jane@example.com", "count": "/cart"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := pcommon.NewMap()
+			attrs.PutStr("user.email", "jane@example.com")
+			attrs.PutStr("http.target", "/cart")
+			attrs.PutInt("count", 3)
+			applyActions(newActions([]ActionConfig{tt.action}), TargetSpan, attrs)
+			assert.Equal(t, tt.expected, attrs.AsRaw())
+		})
+	}
+}
+
+func TestActionTargets(t *testing.T) {
+	actions := newActions([]ActionConfig{
+		{Key: "record", Value: true, Action: Insert},
+		{Key: "resource", Value: true, Action: Insert, Target: TargetResource},
+		{Key: "scope", Value: true, Action: Insert, Target: TargetScope},
+		{Key: "span", Value: true, Action: Insert, Target: TargetSpan},
+		{Key: "log", Value: true, Action: Insert, Target: TargetLog},
+		{Key: "datapoint", Value: true, Action: Insert, Target: TargetDataPoint},
+	})
+	for target, expected := range map[Target][]string{
+		TargetResource:  {"resource"},
+		TargetScope:     {"scope"},
+		TargetSpan:      {"record", "span"},
+		TargetLog:       {"record", "log"},
+		TargetDataPoint: {"record", "datapoint"},
+	} {
+		attrs := pcommon.NewMap()
+		applyActions(actions, target, attrs)
+		var keys []string
+		attrs.Range(func(k string, _ pcommon.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		assert.Equal(t, expected, keys, target)
+	}
+}
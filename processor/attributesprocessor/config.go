@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor // import "go.opentelemetry.io/collector/processor/attributesprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Action is the operation applied to an attribute.
+type Action string
+
+const (
+	// Insert sets the attribute when it is not already present.
+	Insert Action = "insert"
+	// Update sets the attribute when it is already present.
+	Update Action = "update"
+	// Upsert sets the attribute, whether it is present or not.
+	Upsert Action = "upsert"
+	// Delete removes the attribute.
+	Delete Action = "delete"
+	// Hash replaces the value of the attribute with the hexadecimal SHA-256
+	// hash of its string representation.
+	Hash Action = "hash"
+	// Rename moves the value of the attribute to NewKey.
+	Rename Action = "rename"
+)
+
+// Target is the kind of telemetry whose attributes an action applies to.
+type Target string
+
+const (
+	// TargetRecord applies the action to the spans, log records and metric data points.
+	// It is the default target.
+	TargetRecord Target = "record"
+	// TargetResource applies the action to the resources.
+	TargetResource Target = "resource"
+	// TargetScope applies the action to the instrumentation scopes.
+	TargetScope Target = "scope"
+	// TargetSpan applies the action to the spans.
+	TargetSpan Target = "span"
+	// TargetLog applies the action to the log records.
+	TargetLog Target = "log"
+	// TargetDataPoint applies the action to the metric data points.
+	TargetDataPoint Target = "datapoint"
+)
+
+// MatchType is how the names and attribute values of a MatchConfig are compared.
+type MatchType string
+
+const (
+	// MatchTypeStrict compares them for equality.
+	MatchTypeStrict MatchType = "strict"
+	// MatchTypeRegexp matches them with regular expressions.
+	MatchTypeRegexp MatchType = "regexp"
+)
+
+// Config has the configuration for the attributes processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Actions are applied in order to the attributes of the telemetry matching
+	// Include and not matching Exclude.
+	Actions []ActionConfig `mapstructure:"actions"`
+
+	// Include restricts the actions to the matching telemetry, when set.
+	Include *MatchConfig `mapstructure:"include"`
+
+	// Exclude excludes the matching telemetry from the actions, when set.
+	Exclude *MatchConfig `mapstructure:"exclude"`
+}
+
+// ActionConfig configures an operation on an attribute.
+type ActionConfig struct {
+	// Key of the attribute.
+	Key string `mapstructure:"key"`
+
+	// Action applied to the attribute.
+	Action Action `mapstructure:"action"`
+
+	// Target is the kind of telemetry whose attributes the action applies to,
+	// by default the spans, log records and metric data points.
+	Target Target `mapstructure:"target"`
+
+	// Value set by the insert, update and upsert actions.
+	Value interface{} `mapstructure:"value"`
+
+	// FromAttribute is the key of the attribute whose value is set by the
+	// insert, update and upsert actions instead of Value, in the same attributes.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// NewKey is the key the rename action moves the attribute to.
+	NewKey string `mapstructure:"new_key"`
+}
+
+// MatchConfig selects telemetry by its resource attributes and names. All
+// the configured criteria must match.
+type MatchConfig struct {
+	// MatchType is "strict" or "regexp".
+	MatchType MatchType `mapstructure:"match_type"`
+
+	// Names are the span and metric names, one of which must match.
+	Names []string `mapstructure:"names"`
+
+	// ResourceAttributes are the values of the resource attributes, all of
+	// which must match.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Actions) == 0 {
+		return errors.New("at least one action must be configured")
+	}
+	for i, a := range cfg.Actions {
+		if err := a.validate(); err != nil {
+			return fmt.Errorf("action %d: %w", i, err)
+		}
+	}
+	if cfg.Include != nil {
+		if err := cfg.Include.validate(); err != nil {
+			return fmt.Errorf("include: %w", err)
+		}
+	}
+	if cfg.Exclude != nil {
+		if err := cfg.Exclude.validate(); err != nil {
+			return fmt.Errorf("exclude: %w", err)
+		}
+	}
+	return nil
+}
+
+func (a *ActionConfig) validate() error {
+	if a.Key == "" {
+		return errors.New("key must not be empty")
+	}
+	switch a.Target {
+	case "", TargetRecord, TargetResource, TargetScope, TargetSpan, TargetLog, TargetDataPoint:
+	default:
+		return fmt.Errorf("invalid target %q, must be %q, %q, %q, %q, %q or %q", a.Target, TargetRecord, TargetResource, TargetScope, TargetSpan, TargetLog, TargetDataPoint)
+	}
+	switch a.Action {
+	case Insert, Update, Upsert:
+		if (a.Value == nil) == (a.FromAttribute == "") {
+			return fmt.Errorf("exactly one of value and from_attribute is required by %s actions", a.Action)
+		}
+		if a.NewKey != "" {
+			return errors.New("new_key is only used by rename actions")
+		}
+	case Delete, Hash, Rename:
+		if a.Value != nil || a.FromAttribute != "" {
+			return errors.New("value and from_attribute are only used by insert, update and upsert actions")
+		}
+		if a.Action == Rename && (a.NewKey == "" || a.NewKey == a.Key) {
+			return errors.New("new_key must be set to another key by rename actions")
+		}
+		if a.Action != Rename && a.NewKey != "" {
+			return errors.New("new_key is only used by rename actions")
+		}
+	default:
+		return fmt.Errorf("invalid action %q, must be %q, %q, %q, %q, %q or %q", a.Action, Insert, Update, Upsert, Delete, Hash, Rename)
+	}
+	return nil
+}
+
+func (m *MatchConfig) validate() error {
+	if len(m.Names) == 0 && len(m.ResourceAttributes) == 0 {
+		return errors.New("at least one of names and resource_attributes is required")
+	}
+	switch m.MatchType {
+	case MatchTypeStrict:
+	case MatchTypeRegexp:
+		for _, name := range m.Names {
+			if _, err := regexp.Compile(name); err != nil {
+				return fmt.Errorf("invalid name regexp: %w", err)
+			}
+		}
+		for key, value := range m.ResourceAttributes {
+			if _, err := regexp.Compile(value); err != nil {
+				return fmt.Errorf("invalid regexp of resource attribute %q: %w", key, err)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid match_type %q, must be %q or %q", m.MatchType, MatchTypeStrict, MatchTypeRegexp)
+	}
+	return nil
+}
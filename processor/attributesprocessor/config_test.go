@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestUnmarshalDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalProcessor(confmap.New(), cfg))
+	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
+}
+
+func TestUnmarshalConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalProcessor(cm, cfg))
+	assert.Equal(t,
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+			Actions: []ActionConfig{
+				{Key: "user.email", Action: Delete},
+				{Key: "enduser.id", Action: Hash},
+				{Key: "deployment.environment", Value: "production", Action: Insert, Target: TargetResource},
+				{Key: "http.url", FromAttribute: "http.target", Action: Upsert, Target: TargetSpan},
+				{Key: "hostname", NewKey: "host.name", Action: Rename, Target: TargetResource},
+			},
+			Include: &MatchConfig{
+				MatchType:          MatchTypeRegexp,
+				Names:              []string{"^GET .*"},
+				ResourceAttributes: map[string]string{"service.name": "^checkout"},
+			},
+			Exclude: &MatchConfig{
+				MatchType: MatchTypeStrict,
+				Names:     []string{"GET /health"},
+			},
+		}, cfg)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected string
+	}{
+		{
+			name:     "default",
+			modify:   func(cfg *Config) { cfg.Actions = nil },
+			expected: "at least one action must be configured",
+		},
+		{
+			name:   "valid",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:     "missing key",
+			modify:   func(cfg *Config) { cfg.Actions[0].Key = "" },
+			expected: "action 0: key must not be empty",
+		},
+		{
+			name:     "invalid action",
+			modify:   func(cfg *Config) { cfg.Actions[0].Action = "extract" },
+			expected: `action 0: invalid action "extract", must be "insert", "update", "upsert", "delete", "hash" or "rename"`,
+		},
+		{
+			name:   "record target",
+			modify: func(cfg *Config) { cfg.Actions[0].Target = TargetRecord },
+		},
+		{
+			name:     "invalid target",
+			modify:   func(cfg *Config) { cfg.Actions[0].Target = "metric" },
+			expected: `action 0: invalid target "metric", must be "record", "resource", "scope", "span", "log" or "datapoint"`,
+		},
+		{
+			name:     "insert without value",
+			modify:   func(cfg *Config) { cfg.Actions[1].Value = nil },
+			expected: "action 1: exactly one of value and from_attribute is required by insert actions",
+		},
+		{
+			name:     "upsert with value and from_attribute",
+			modify:   func(cfg *Config) { cfg.Actions[2].Value = "x" },
+			expected: "action 2: exactly one of value and from_attribute is required by upsert actions",
+		},
+		{
+			name:     "update with new_key",
+			modify:   func(cfg *Config) { cfg.Actions[2].NewKey = "x" },
+			expected: "action 2: new_key is only used by rename actions",
+		},
+		{
+			name:     "delete with value",
+			modify:   func(cfg *Config) { cfg.Actions[0].Value = "x" },
+			expected: "action 0: value and from_attribute are only used by insert, update and upsert actions",
+		},
+		{
+			name:     "hash with new_key",
+			modify:   func(cfg *Config) { cfg.Actions[0].Action = Hash; cfg.Actions[0].NewKey = "x" },
+			expected: "action 0: new_key is only used by rename actions",
+		},
+		{
+			name:     "rename without new_key",
+			modify:   func(cfg *Config) { cfg.Actions[3].NewKey = "" },
+			expected: "action 3: new_key must be set to another key by rename actions",
+		},
+		{
+			name:     "rename to the same key",
+			modify:   func(cfg *Config) { cfg.Actions[3].NewKey = cfg.Actions[3].Key },
+			expected: "action 3: new_key must be set to another key by rename actions",
+		},
+		{
+			name:     "empty include",
+			modify:   func(cfg *Config) { cfg.Include = &MatchConfig{MatchType: MatchTypeStrict} },
+			expected: "include: at least one of names and resource_attributes is required",
+		},
+		{
+			name:     "invalid match_type",
+			modify:   func(cfg *Config) { cfg.Exclude = &MatchConfig{MatchType: "glob", Names: []string{"*"}} },
+			expected: `exclude: invalid match_type "glob", must be "strict" or "regexp"`,
+		},
+		{
+			name:     "invalid name regexp",
+			modify:   func(cfg *Config) { cfg.Include = &MatchConfig{MatchType: MatchTypeRegexp, Names: []string{"("}} },
+			expected: "include: invalid name regexp: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			name: "invalid resource attribute regexp",
+			modify: func(cfg *Config) {
+				cfg.Include = &MatchConfig{MatchType: MatchTypeRegexp, ResourceAttributes: map[string]string{"service.name": "["}}
+			},
+			expected: `include: invalid regexp of resource attribute "service.name": error parsing regexp: missing closing ]: ` + "`[`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Actions = []ActionConfig{
+				{Key: "user.email", Action: Delete},
+				{Key: "env", Value: "prod", Action: Insert},
+				{Key: "http.url", FromAttribute: "http.target", Action: Upsert},
+				{Key: "hostname", NewKey: "host.name", Action: Rename},
+			}
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
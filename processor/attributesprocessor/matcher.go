@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor // import "go.opentelemetry.io/collector/processor/attributesprocessor"
+
+import (
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// stringMatcher matches names and attribute values.
+type stringMatcher interface {
+	MatchString(s string) bool
+}
+
+type strictMatcher string
+
+func (m strictMatcher) MatchString(s string) bool {
+	return string(m) == s
+}
+
+// matcher is a MatchConfig ready to be matched.
+type matcher struct {
+	names              []stringMatcher
+	resourceAttributes map[string]stringMatcher
+}
+
+func newMatcher(cfg *MatchConfig) (*matcher, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	newStringMatcher := func(s string) (stringMatcher, error) {
+		if cfg.MatchType == MatchTypeRegexp {
+			return regexp.Compile(s)
+		}
+		return strictMatcher(s), nil
+	}
+	m := &matcher{resourceAttributes: make(map[string]stringMatcher, len(cfg.ResourceAttributes))}
+	for _, name := range cfg.Names {
+		sm, err := newStringMatcher(name)
+		if err != nil {
+			return nil, err
+		}
+		m.names = append(m.names, sm)
+	}
+	for key, value := range cfg.ResourceAttributes {
+		sm, err := newStringMatcher(value)
+		if err != nil {
+			return nil, err
+		}
+		m.resourceAttributes[key] = sm
+	}
+	return m, nil
+}
+
+// matchResource returns whether all the resource attributes match.
+func (m *matcher) matchResource(resource pcommon.Resource) bool {
+	attrs := resource.Attributes()
+	for key, sm := range m.resourceAttributes {
+		v, ok := attrs.Get(key)
+		if !ok || !sm.MatchString(v.AsString()) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchName returns whether one of the names matches, or whether no name is
+// configured.
+func (m *matcher) matchName(name string) bool {
+	if len(m.names) == 0 {
+		return true
+	}
+	for _, sm := range m.names {
+		if sm.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor // import "go.opentelemetry.io/collector/processor/attributesprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type attributesProcessor struct {
+	actions []action
+	include *matcher
+	exclude *matcher
+}
+
+func newAttributesProcessor(cfg *Config) (*attributesProcessor, error) {
+	include, err := newMatcher(cfg.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := newMatcher(cfg.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	return &attributesProcessor{
+		actions: newActions(cfg.Actions),
+		include: include,
+		exclude: exclude,
+	}, nil
+}
+
+// matchResource matches the resource against the resource attributes of the
+// include and exclude criteria. It returns whether the resource is included,
+// and whether it is excluded, then depending on the names of its spans or
+// metrics when the exclude criteria have names. The resource is matched
+// before any action changes its attributes.
+func (ap *attributesProcessor) matchResource(resource pcommon.Resource) (included, excluded bool) {
+	included = ap.include == nil || ap.include.matchResource(resource)
+	excluded = ap.exclude != nil && ap.exclude.matchResource(resource)
+	return included, excluded
+}
+
+// matchName returns whether the actions apply to the span or metric named
+// name, in an included resource.
+func (ap *attributesProcessor) matchName(name string, excluded bool) bool {
+	if ap.include != nil && !ap.include.matchName(name) {
+		return false
+	}
+	return !excluded || !ap.exclude.matchName(name)
+}
+
+// processResource returns whether the actions apply to the attributes of an
+// included resource and of its scopes, which have no name: an exclusion by
+// names only excludes the matching spans or metrics.
+func (ap *attributesProcessor) processResource(excluded bool) bool {
+	return !excluded || len(ap.exclude.names) > 0
+}
+
+func (ap *attributesProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		included, excluded := ap.matchResource(rs.Resource())
+		if !included {
+			continue
+		}
+		matched := ap.processResource(excluded)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if ap.matchName(span.Name(), excluded) {
+					applyActions(ap.actions, TargetSpan, span.Attributes())
+				}
+			}
+			if matched {
+				applyActions(ap.actions, TargetScope, ss.Scope().Attributes())
+			}
+		}
+		if matched {
+			applyActions(ap.actions, TargetResource, rs.Resource().Attributes())
+		}
+	}
+	return td, nil
+}
+
+func (ap *attributesProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	// The log records have no names, so include criteria with names match none of them.
+	if ap.include != nil && len(ap.include.names) > 0 {
+		return ld, nil
+	}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		// The log records have no names, so exclude criteria without resource
+		// attributes, which would match every resource, do not exclude them.
+		included, excluded := ap.matchResource(rl.Resource())
+		if !included || excluded && len(ap.exclude.resourceAttributes) > 0 {
+			continue
+		}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				applyActions(ap.actions, TargetLog, lrs.At(k).Attributes())
+			}
+			applyActions(ap.actions, TargetScope, sl.Scope().Attributes())
+		}
+		applyActions(ap.actions, TargetResource, rl.Resource().Attributes())
+	}
+	return ld, nil
+}
+
+func (ap *attributesProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		included, excluded := ap.matchResource(rm.Resource())
+		if !included {
+			continue
+		}
+		matched := ap.processResource(excluded)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if ap.matchName(metric.Name(), excluded) {
+					ap.processDataPoints(metric)
+				}
+			}
+			if matched {
+				applyActions(ap.actions, TargetScope, sm.Scope().Attributes())
+			}
+		}
+		if matched {
+			applyActions(ap.actions, TargetResource, rm.Resource().Attributes())
+		}
+	}
+	return md, nil
+}
+
+func (ap *attributesProcessor) processDataPoints(metric pmetric.Metric) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(ap.actions, TargetDataPoint, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(ap.actions, TargetDataPoint, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(ap.actions, TargetDataPoint, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(ap.actions, TargetDataPoint, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			applyActions(ap.actions, TargetDataPoint, dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeEmpty:
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var testActions = []ActionConfig{
+	{Key: "user.email", Action: Delete},
+	{Key: "deployment.environment", Value: "production", Action: Insert, Target: TargetResource},
+	{Key: "library", NewKey: "otel.library", Action: Rename, Target: TargetScope},
+}
+
+func generateTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, service := range []string{"checkout", "cart"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().Attributes().PutStr("library", "http")
+		for _, name := range []string{"GET /cart", "GET /health", "POST /cart"} {
+			span := ss.Spans().AppendEmpty()
+			span.SetName(name)
+			span.Attributes().PutStr("user.email", "jane@example.com")
+		}
+	}
+	return td
+}
+
+// processedTraces returns the spans processed, by service and span name, and
+// the services whose resource and scope were processed.
+func processedTraces(td ptrace.Traces) (spans []string, resources []string) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		service, _ := rs.Resource().Attributes().Get("service.name")
+		_, resource := rs.Resource().Attributes().Get("deployment.environment")
+		_, scope := rs.ScopeSpans().At(0).Scope().Attributes().Get("otel.library")
+		if resource && scope {
+			resources = append(resources, service.Str())
+		}
+		ss := rs.ScopeSpans().At(0).Spans()
+		for j := 0; j < ss.Len(); j++ {
+			if _, ok := ss.At(j).Attributes().Get("user.email"); !ok {
+				spans = append(spans, service.Str()+" "+ss.At(j).Name())
+			}
+		}
+	}
+	return spans, resources
+}
+
+func TestProcessTraces(t *testing.T) {
+	tests := []struct {
+		name      string
+		include   *MatchConfig
+		exclude   *MatchConfig
+		spans     []string
+		resources []string
+	}{
+		{
+			name:      "all",
+			spans:     []string{"checkout GET /cart", "checkout GET /health", "checkout POST /cart", "cart GET /cart", "cart GET /health", "cart POST /cart"},
+			resources: []string{"checkout", "cart"},
+		},
+		{
+			name:      "include resource",
+			include:   &MatchConfig{MatchType: MatchTypeStrict, ResourceAttributes: map[string]string{"service.name": "checkout"}},
+			spans:     []string{"checkout GET /cart", "checkout GET /health", "checkout POST /cart"},
+			resources: []string{"checkout"},
+		},
+		{
+			name:      "include names",
+			include:   &MatchConfig{MatchType: MatchTypeRegexp, Names: []string{"^GET "}},
+			spans:     []string{"checkout GET /cart", "checkout GET /health", "cart GET /cart", "cart GET /health"},
+			resources: []string{"checkout", "cart"},
+		},
+		{
+			name:      "include resource and names",
+			include:   &MatchConfig{MatchType: MatchTypeRegexp, Names: []string{"^GET "}, ResourceAttributes: map[string]string{"service.name": "^ca"}},
+			spans:     []string{"cart GET /cart", "cart GET /health"},
+			resources: []string{"cart"},
+		},
+		{
+			name:      "exclude resource",
+			exclude:   &MatchConfig{MatchType: MatchTypeStrict, ResourceAttributes: map[string]string{"service.name": "checkout"}},
+			spans:     []string{"cart GET /cart", "cart GET /health", "cart POST /cart"},
+			resources: []string{"cart"},
+		},
+		{
+			name:      "exclude names",
+			exclude:   &MatchConfig{MatchType: MatchTypeStrict, Names: []string{"GET /health"}},
+			spans:     []string{"checkout GET /cart", "checkout POST /cart", "cart GET /cart", "cart POST /cart"},
+			resources: []string{"checkout", "cart"},
+		},
+		{
+			name:      "exclude resource and names",
+			exclude:   &MatchConfig{MatchType: MatchTypeStrict, Names: []string{"GET /health"}, ResourceAttributes: map[string]string{"service.name": "cart"}},
+			spans:     []string{"checkout GET /cart", "checkout GET /health", "checkout POST /cart", "cart GET /cart", "cart POST /cart"},
+			resources: []string{"checkout", "cart"},
+		},
+		{
+			name:      "include and exclude",
+			include:   &MatchConfig{MatchType: MatchTypeStrict, ResourceAttributes: map[string]string{"service.name": "checkout"}},
+			exclude:   &MatchConfig{MatchType: MatchTypeRegexp, Names: []string{"/health$"}},
+			spans:     []string{"checkout GET /cart", "checkout POST /cart"},
+			resources: []string{"checkout"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Actions = testActions
+			cfg.Include = tt.include
+			cfg.Exclude = tt.exclude
+			require.NoError(t, cfg.Validate())
+			ap, err := newAttributesProcessor(cfg)
+			require.NoError(t, err)
+
+			td, err := ap.processTraces(context.Background(), generateTraces())
+			require.NoError(t, err)
+			spans, resources := processedTraces(td)
+			assert.Equal(t, tt.spans, spans)
+			assert.Equal(t, tt.resources, resources)
+		})
+	}
+}
+
+func TestProcessTracesMatchesBeforeActions(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Actions = []ActionConfig{
+		{Key: "service.name", Value: "cart", Action: Update, Target: TargetResource},
+		{Key: "user.email", Action: Delete},
+	}
+	cfg.Include = &MatchConfig{MatchType: MatchTypeStrict, ResourceAttributes: map[string]string{"service.name": "checkout"}}
+	ap, err := newAttributesProcessor(cfg)
+	require.NoError(t, err)
+
+	td, err := ap.processTraces(context.Background(), generateTraces())
+	require.NoError(t, err)
+	spans, _ := processedTraces(td)
+	assert.Equal(t, []string{"cart GET /cart", "cart GET /health", "cart POST /cart"}, spans)
+	assert.Equal(t, 1, td.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0).Attributes().Len())
+}
+
+func TestProcessLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Actions = testActions
+	cfg.Exclude = &MatchConfig{MatchType: MatchTypeStrict, ResourceAttributes: map[string]string{"service.name": "cart"}}
+	ap, err := newAttributesProcessor(cfg)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	for _, service := range []string{"checkout", "cart"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().Attributes().PutStr("library", "http")
+		sl.LogRecords().AppendEmpty().Attributes().PutStr("user.email", "jane@example.com")
+	}
+
+	ld, err = ap.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	checkout := ld.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "checkout", "deployment.environment": "production"}, checkout.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"otel.library": "http"}, checkout.ScopeLogs().At(0).Scope().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{}, checkout.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+
+	cart := ld.ResourceLogs().At(1)
+	assert.Equal(t, map[string]interface{}{"service.name": "cart"}, cart.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"library": "http"}, cart.ScopeLogs().At(0).Scope().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"user.email": "jane@example.com"}, cart.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+}
+
+func TestProcessLogsExcludeNames(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Actions = testActions
+	cfg.Exclude = &MatchConfig{MatchType: MatchTypeStrict, Names: []string{"GET /health"}}
+	ap, err := newAttributesProcessor(cfg)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("user.email", "jane@example.com")
+
+	// The names cannot match the log records, which are not excluded.
+	ld, err = ap.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	rl = ld.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "checkout", "deployment.environment": "production"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{}, rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+}
+
+func TestProcessLogsIncludeNames(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Actions = testActions
+	cfg.Include = &MatchConfig{MatchType: MatchTypeStrict, Names: []string{"GET /health"}}
+	ap, err := newAttributesProcessor(cfg)
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("user.email", "jane@example.com")
+
+	// The names cannot match the log records, which are not included.
+	ld, err = ap.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	rl = ld.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "checkout"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]interface{}{"user.email": "jane@example.com"}, rl.ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw())
+}
+
+func TestProcessMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Actions = []ActionConfig{
+		{Key: "user.email", Action: Hash, Target: TargetDataPoint},
+		{Key: "user.email", Action: Delete, Target: TargetSpan},
+	}
+	cfg.Include = &MatchConfig{MatchType: MatchTypeRegexp, Names: []string{"^http\\."}}
+	ap, err := newAttributesProcessor(cfg)
+	require.NoError(t, err)
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	m := metrics.AppendEmpty()
+	m.SetName("http.requests")
+	m.SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("user.email", "jane@example.com")
+	m = metrics.AppendEmpty()
+	m.SetName("http.duration")
+	m.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("user.email", "jane@example.com")
+	m = metrics.AppendEmpty()
+	m.SetName("http.size")
+	m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes().PutStr("user.email", "jane@example.com")
+	m = metrics.AppendEmpty()
+	m.SetName("http.latency")
+	m.SetEmptySummary().DataPoints().AppendEmpty().Attributes().PutStr("user.email", "jane@example.com")
+	m = metrics.AppendEmpty()
+	m.SetName("http.active")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("user.email", "jane@example.com")
+	m = metrics.AppendEmpty()
+	m.SetName("rpc.active")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutStr("user.email", "jane@example.com")
+
+	md, err = ap.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	hashed := "8c87b489ce35cf2e2f39f80e282cb2e804932a56a213983eeeb428407d43b52d"
+	metrics = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	values := map[string]string{}
+	values["http.requests"] = metrics.At(0).Sum().DataPoints().At(0).Attributes().AsRaw()["user.email"].(string)
+	values["http.duration"] = metrics.At(1).Histogram().DataPoints().At(0).Attributes().AsRaw()["user.email"].(string)
+	values["http.size"] = metrics.At(2).ExponentialHistogram().DataPoints().At(0).Attributes().AsRaw()["user.email"].(string)
+	values["http.latency"] = metrics.At(3).Summary().DataPoints().At(0).Attributes().AsRaw()["user.email"].(string)
+	values["http.active"] = metrics.At(4).Gauge().DataPoints().At(0).Attributes().AsRaw()["user.email"].(string)
+	values["rpc.active"] = metrics.At(5).Gauge().DataPoints().At(0).Attributes().AsRaw()["user.email"].(string)
+	assert.Equal(t, map[string]string{
+		"http.requests": hashed,
+		"http.duration": hashed,
+		"http.size":     hashed,
+		"http.latency":  hashed,
+		"http.active":   hashed,
+		"rpc.active":    "jane@example.com",
+	}, values)
+}
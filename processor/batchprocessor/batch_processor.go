@@ -16,22 +16,31 @@ package batchprocessor // import "go.opentelemetry.io/collector/processor/batchp
 
 import (
 	"context"
+	"fmt"
 	"runtime"
 	"sync"
 	"time"
 
-	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
+// maxBatchLinks is the maximum number of spans of the batched data linked from the span of an export.
+const maxBatchLinks = 128
+
 // batch_processor is a component that accepts spans and metrics, places them
 // into batches and sends downstream.
 //
@@ -40,6 +49,9 @@ import (
 // Batches are sent out with any of the following conditions:
 // - batch size reaches cfg.SendBatchSize
 // - cfg.Timeout is elapsed since the timestamp when the previous batch was sent out.
+//
+// The data of clients with different values for the telemetry dimensions, see obsreportconfig.Dimension,
+// is batched separately, so that the exporters can record their metrics per client.
 type batchProcessor struct {
 	logger           *zap.Logger
 	exportCtx        context.Context
@@ -48,13 +60,18 @@ type batchProcessor struct {
 	sendBatchSize    int
 	sendBatchMaxSize int
 
-	newItem chan interface{}
-	batch   batch
+	newItem  chan batchItem
+	newBatch func() batch
+	// batches are the current batches, keyed by their dimension values.
+	batches map[string]*dimensionBatch
+
+	tracer   trace.Tracer
+	spanName string
 
 	shutdownC  chan struct{}
 	goroutines sync.WaitGroup
 
-	telemetryLevel configtelemetry.Level
+	telemetry *batchProcessorTelemetry
 }
 
 type batch interface {
@@ -68,25 +85,44 @@ type batch interface {
 	add(item interface{})
 }
 
+// batchItem is the data consumed by the processor, with the span context and the dimension values of the caller.
+type batchItem struct {
+	data        interface{}
+	spanContext trace.SpanContext
+	tags        []tag.Tag
+}
+
+// dimensionBatch is the batch of the data with the same dimension values.
+type dimensionBatch struct {
+	batch
+	tags []tag.Tag
+	// links are the spans of the data added to the batch.
+	links []trace.Link
+}
+
 var _ consumer.Traces = (*batchProcessor)(nil)
 var _ consumer.Metrics = (*batchProcessor)(nil)
 var _ consumer.Logs = (*batchProcessor)(nil)
 
-func newBatchProcessor(set component.ProcessorCreateSettings, cfg *Config, batch batch, telemetryLevel configtelemetry.Level) (*batchProcessor, error) {
-	exportCtx, err := tag.New(context.Background(), tag.Insert(processorTagKey, cfg.ID().String()))
+func newBatchProcessor(set component.ProcessorCreateSettings, cfg *Config, newBatch func() batch, telemetryLevel configtelemetry.Level, registry *featuregate.Registry) (*batchProcessor, error) {
+	bpt, err := newBatchProcessorTelemetry(set, cfg.ID(), telemetryLevel, registry)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error to create batch processor telemetry %w", err)
 	}
+
 	return &batchProcessor{
-		logger:         set.Logger,
-		exportCtx:      exportCtx,
-		telemetryLevel: telemetryLevel,
+		logger:    set.Logger,
+		exportCtx: bpt.exportCtx,
+		telemetry: bpt,
 
 		sendBatchSize:    int(cfg.SendBatchSize),
 		sendBatchMaxSize: int(cfg.SendBatchMaxSize),
 		timeout:          cfg.Timeout,
-		newItem:          make(chan interface{}, runtime.NumCPU()),
-		batch:            batch,
+		newItem:          make(chan batchItem, runtime.NumCPU()),
+		newBatch:         newBatch,
+		batches:          map[string]*dimensionBatch{},
+		tracer:           set.TracerProvider.Tracer(cfg.ID().String()),
+		spanName:         obsmetrics.ProcessorPrefix + cfg.ID().String() + obsmetrics.NameSep + "batch",
 		shutdownC:        make(chan struct{}, 1),
 	}, nil
 }
@@ -127,40 +163,60 @@ func (bp *batchProcessor) startProcessingCycle() {
 				}
 			}
 			// This is the close of the channel
-			if bp.batch.itemCount() > 0 {
-				// TODO: Set a timeout on sendTraces or
-				// make it cancellable using the context that Shutdown gets as a parameter
-				bp.sendItems(statTimeoutTriggerSend)
-			}
+			// TODO: Set a timeout on sendTraces or
+			// make it cancellable using the context that Shutdown gets as a parameter
+			bp.sendAll(triggerTimeout)
 			return
 		case item := <-bp.newItem:
-			if item == nil {
+			if item.data == nil {
 				continue
 			}
 			bp.processItem(item)
 		case <-bp.timer.C:
-			if bp.batch.itemCount() > 0 {
-				bp.sendItems(statTimeoutTriggerSend)
-			}
+			bp.sendAll(triggerTimeout)
 			bp.resetTimer()
 		}
 	}
 }
 
-func (bp *batchProcessor) processItem(item interface{}) {
-	bp.batch.add(item)
+func (bp *batchProcessor) processItem(item batchItem) {
+	key := obsreportconfig.DimensionsKey(item.tags)
+	b, ok := bp.batches[key]
+	if !ok {
+		b = &dimensionBatch{batch: bp.newBatch(), tags: item.tags}
+		bp.batches[key] = b
+	}
+
+	b.add(item.data)
+	if item.spanContext.IsValid() && len(b.links) < maxBatchLinks {
+		b.links = append(b.links, trace.Link{SpanContext: item.spanContext})
+	}
 	sent := false
-	for bp.batch.itemCount() >= bp.sendBatchSize {
+	for b.itemCount() >= bp.sendBatchSize {
 		sent = true
-		bp.sendItems(statBatchSizeTriggerSend)
+		bp.sendItems(b, triggerBatchSize)
+	}
+	if b.itemCount() == 0 {
+		delete(bp.batches, key)
 	}
 
-	if sent {
+	// The timer also sends the other batches, only restart it when they are empty.
+	if sent && len(bp.batches) <= 1 {
 		bp.stopTimer()
 		bp.resetTimer()
 	}
 }
 
+// sendAll sends all the batches holding data.
+func (bp *batchProcessor) sendAll(trigger trigger) {
+	for key, b := range bp.batches {
+		if b.itemCount() > 0 {
+			bp.sendItems(b, trigger)
+		}
+		delete(bp.batches, key)
+	}
+}
+
 func (bp *batchProcessor) stopTimer() {
 	if !bp.timer.Stop() {
 		<-bp.timer.C
@@ -171,52 +227,57 @@ func (bp *batchProcessor) resetTimer() {
 	bp.timer.Reset(bp.timeout)
 }
 
-func (bp *batchProcessor) sendItems(triggerMeasure *stats.Int64Measure) {
-	detailed := bp.telemetryLevel == configtelemetry.LevelDetailed
-	sent, bytes, err := bp.batch.export(bp.exportCtx, bp.sendBatchMaxSize, detailed)
+func (bp *batchProcessor) sendItems(b *dimensionBatch, trigger trigger) {
+	// The data of a batch comes from several requests, their spans are linked rather than parents of the export.
+	ctx, span := bp.tracer.Start(bp.exportCtx, bp.spanName, trace.WithLinks(b.links...))
+	defer span.End()
+	b.links = nil
+	if len(b.tags) > 0 {
+		ctx = obsreportconfig.ContextWithDimensionTags(ctx, b.tags)
+	}
+
+	sent, bytes, err := b.export(ctx, bp.sendBatchMaxSize, bp.telemetry.detailed)
+	span.SetAttributes(attribute.Int("batch_size", sent))
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		bp.logger.Warn("Sender failed", zap.Error(err))
 	} else {
-		// Add that it came form the trace pipeline?
-		stats.Record(bp.exportCtx, triggerMeasure.M(1), statBatchSendSize.M(int64(sent)))
-		if detailed {
-			stats.Record(bp.exportCtx, statBatchSendSizeBytes.M(int64(bytes)))
-		}
+		bp.telemetry.record(trigger, int64(sent), int64(bytes))
 	}
 }
 
 // ConsumeTraces implements TracesProcessor
-func (bp *batchProcessor) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
-	bp.newItem <- td
+func (bp *batchProcessor) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	bp.newItem <- batchItem{data: td, spanContext: trace.SpanContextFromContext(ctx), tags: obsreportconfig.DimensionTagsFromContext(ctx)}
 	return nil
 }
 
 // ConsumeMetrics implements MetricsProcessor
-func (bp *batchProcessor) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+func (bp *batchProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	// First thing is convert into a different internal format
-	bp.newItem <- md
+	bp.newItem <- batchItem{data: md, spanContext: trace.SpanContextFromContext(ctx), tags: obsreportconfig.DimensionTagsFromContext(ctx)}
 	return nil
 }
 
 // ConsumeLogs implements LogsProcessor
-func (bp *batchProcessor) ConsumeLogs(_ context.Context, ld plog.Logs) error {
-	bp.newItem <- ld
+func (bp *batchProcessor) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	bp.newItem <- batchItem{data: ld, spanContext: trace.SpanContextFromContext(ctx), tags: obsreportconfig.DimensionTagsFromContext(ctx)}
 	return nil
 }
 
 // newBatchTracesProcessor creates a new batch processor that batches traces by size or with timeout
 func newBatchTracesProcessor(set component.ProcessorCreateSettings, next consumer.Traces, cfg *Config, telemetryLevel configtelemetry.Level) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, newBatchTraces(next), telemetryLevel)
+	return newBatchProcessor(set, cfg, func() batch { return newBatchTraces(next) }, telemetryLevel, featuregate.GetRegistry())
 }
 
 // newBatchMetricsProcessor creates a new batch processor that batches metrics by size or with timeout
 func newBatchMetricsProcessor(set component.ProcessorCreateSettings, next consumer.Metrics, cfg *Config, telemetryLevel configtelemetry.Level) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, newBatchMetrics(next), telemetryLevel)
+	return newBatchProcessor(set, cfg, func() batch { return newBatchMetrics(next) }, telemetryLevel, featuregate.GetRegistry())
 }
 
 // newBatchLogsProcessor creates a new batch processor that batches logs by size or with timeout
 func newBatchLogsProcessor(set component.ProcessorCreateSettings, next consumer.Logs, cfg *Config, telemetryLevel configtelemetry.Level) (*batchProcessor, error) {
-	return newBatchProcessor(set, cfg, newBatchLogs(next), telemetryLevel)
+	return newBatchProcessor(set, cfg, func() batch { return newBatchLogs(next) }, telemetryLevel, featuregate.GetRegistry())
 }
 
 type batchTraces struct {
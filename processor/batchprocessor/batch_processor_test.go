@@ -25,12 +25,18 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -78,6 +84,39 @@ func TestBatchProcessorSpansDelivered(t *testing.T) {
 	}
 }
 
+func TestBatchProcessorDimensions(t *testing.T) {
+	obsreportconfig.Configure(configtelemetry.LevelNormal, obsreportconfig.Dimension{Name: "tenant", MetadataKey: "X-Tenant"})
+	t.Cleanup(func() { obsreportconfig.Configure(configtelemetry.LevelNormal) })
+
+	var mu sync.Mutex
+	spansByTenant := map[string]int{}
+	next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		mu.Lock()
+		defer mu.Unlock()
+		tags := obsreportconfig.DimensionTagsFromContext(ctx)
+		require.Len(t, tags, 1)
+		spansByTenant[tags[0].Value] += td.SpanCount()
+		return nil
+	})
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 10
+	batcher, err := newBatchTracesProcessor(componenttest.NewNopProcessorCreateSettings(), next, cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for _, tenant := range []string{"a", "b", "a", "a", "b"} {
+		ctx := client.NewContext(context.Background(), client.Info{
+			Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {tenant}}),
+		})
+		assert.NoError(t, batcher.ConsumeTraces(ctx, testdata.GenerateTraces(4)))
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	assert.Equal(t, map[string]int{"a": 12, "b": 8}, spansByTenant)
+}
+
 func TestBatchProcessorSpansDeliveredEnforceBatchSize(t *testing.T) {
 	sink := new(consumertest.TracesSink)
 	cfg := createDefaultConfig().(*Config)
@@ -133,7 +172,7 @@ func TestBatchProcessorSentBySize(t *testing.T) {
 	cfg.SendBatchSize = uint32(sendBatchSize)
 	cfg.Timeout = 500 * time.Millisecond
 	creationSet := componenttest.NewNopProcessorCreateSettings()
-	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, configtelemetry.LevelDetailed)
+	batcher, err := newBatchProcessor(creationSet, cfg, func() batch { return newBatchTraces(sink) }, configtelemetry.LevelDetailed, featuregate.NewRegistry())
 	require.NoError(t, err)
 	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
 
@@ -197,7 +236,7 @@ func TestBatchProcessorSentBySize_withMaxSize(t *testing.T) {
 	cfg.SendBatchMaxSize = uint32(sendBatchMaxSize)
 	cfg.Timeout = 500 * time.Millisecond
 	creationSet := componenttest.NewNopProcessorCreateSettings()
-	batcher, err := newBatchTracesProcessor(creationSet, sink, cfg, configtelemetry.LevelDetailed)
+	batcher, err := newBatchProcessor(creationSet, cfg, func() batch { return newBatchTraces(sink) }, configtelemetry.LevelDetailed, featuregate.NewRegistry())
 	require.NoError(t, err)
 	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
 
@@ -309,6 +348,44 @@ func TestBatchProcessorTraceSendWhenClosing(t *testing.T) {
 	require.Equal(t, 1, len(sink.AllTraces()))
 }
 
+func TestBatchProcessorSpanLinks(t *testing.T) {
+	cfg := Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Timeout:           3 * time.Second,
+		SendBatchSize:     2,
+	}
+	sink := new(consumertest.TracesSink)
+	sr := new(tracetest.SpanRecorder)
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+	creationSet.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	batcher, err := newBatchTracesProcessor(creationSet, sink, &cfg, configtelemetry.LevelDetailed)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	tracer := creationSet.TracerProvider.Tracer("test")
+	var parents []trace.SpanContext
+	for i := 0; i < 2; i++ {
+		ctx, span := tracer.Start(context.Background(), "receive")
+		parents = append(parents, span.SpanContext())
+		assert.NoError(t, batcher.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+		span.End()
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	var batchSpans []sdktrace.ReadOnlySpan
+	for _, span := range sr.Ended() {
+		if span.Name() == "processor/batch/batch" {
+			batchSpans = append(batchSpans, span)
+		}
+	}
+	require.Len(t, batchSpans, 1)
+	assert.False(t, batchSpans[0].Parent().IsValid())
+	require.Len(t, batchSpans[0].Links(), 2)
+	for i, link := range batchSpans[0].Links() {
+		assert.Equal(t, parents[i], link.SpanContext)
+	}
+}
+
 func TestBatchMetricProcessor_ReceivingData(t *testing.T) {
 	// Instantiate the batch processor with low config values to test data
 	// gets sent through the processor.
@@ -379,7 +456,7 @@ func TestBatchMetricProcessor_BatchSize(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
 
 	creationSet := componenttest.NewNopProcessorCreateSettings()
-	batcher, err := newBatchMetricsProcessor(creationSet, sink, &cfg, configtelemetry.LevelDetailed)
+	batcher, err := newBatchProcessor(creationSet, &cfg, func() batch { return newBatchMetrics(sink) }, configtelemetry.LevelDetailed, featuregate.NewRegistry())
 	require.NoError(t, err)
 	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
 
@@ -695,7 +772,7 @@ func TestBatchLogProcessor_BatchSize(t *testing.T) {
 	sink := new(consumertest.LogsSink)
 
 	creationSet := componenttest.NewNopProcessorCreateSettings()
-	batcher, err := newBatchLogsProcessor(creationSet, sink, &cfg, configtelemetry.LevelDetailed)
+	batcher, err := newBatchProcessor(creationSet, &cfg, func() batch { return newBatchLogs(sink) }, configtelemetry.LevelDetailed, featuregate.NewRegistry())
 	require.NoError(t, err)
 	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
 
@@ -15,20 +15,50 @@
 package batchprocessor // import "go.opentelemetry.io/collector/processor/batchprocessor"
 
 import (
+	"context"
+
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	sdkview "go.opentelemetry.io/otel/sdk/metric/view"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
+const (
+	scopeName = "go.opentelemetry.io/collector/processor/batchprocessor"
+)
+
+type trigger int
+
+const (
+	triggerTimeout trigger = iota
+	triggerBatchSize
+)
+
 var (
 	processorTagKey          = tag.MustNewKey(obsmetrics.ProcessorKey)
 	statBatchSizeTriggerSend = stats.Int64("batch_size_trigger_send", "Number of times the batch was sent due to a size trigger", stats.UnitDimensionless)
 	statTimeoutTriggerSend   = stats.Int64("timeout_trigger_send", "Number of times the batch was sent due to a timeout trigger", stats.UnitDimensionless)
 	statBatchSendSize        = stats.Int64("batch_send_size", "Number of units in the batch", stats.UnitDimensionless)
 	statBatchSendSizeBytes   = stats.Int64("batch_send_size_bytes", "Number of bytes in batch that was sent", stats.UnitBytes)
+
+	batchSendSizeBoundaries      = []float64{10, 25, 50, 75, 100, 250, 500, 750, 1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000, 20000, 30000, 50000, 100000}
+	batchSendSizeBytesBoundaries = []float64{10, 25, 50, 75, 100, 250, 500, 750, 1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000, 20000, 30000, 50000,
+		100_000, 200_000, 300_000, 400_000, 500_000, 600_000, 700_000, 800_00, 900_000,
+		1000_000, 2000_000, 3000_000, 4000_000, 5000_000, 6000_000, 7000_000, 8000_000, 9000_000}
 )
 
 // MetricViews returns the metrics views related to batching
@@ -56,7 +86,7 @@ func MetricViews() []*view.View {
 		Measure:     statBatchSendSize,
 		Description: statBatchSendSize.Description(),
 		TagKeys:     processorTagKeys,
-		Aggregation: view.Distribution(10, 25, 50, 75, 100, 250, 500, 750, 1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000, 20000, 30000, 50000, 100000),
+		Aggregation: view.Distribution(batchSendSizeBoundaries...),
 	}
 
 	distributionBatchSendSizeBytesView := &view.View{
@@ -64,9 +94,7 @@ func MetricViews() []*view.View {
 		Measure:     statBatchSendSizeBytes,
 		Description: statBatchSendSizeBytes.Description(),
 		TagKeys:     processorTagKeys,
-		Aggregation: view.Distribution(10, 25, 50, 75, 100, 250, 500, 750, 1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000, 20000, 30000, 50000,
-			100_000, 200_000, 300_000, 400_000, 500_000, 600_000, 700_000, 800_00, 900_000,
-			1000_000, 2000_000, 3000_000, 4000_000, 5000_000, 6000_000, 7000_000, 8000_000, 9000_000),
+		Aggregation: view.Distribution(batchSendSizeBytesBoundaries...),
 	}
 
 	return []*view.View{
@@ -76,3 +104,144 @@ func MetricViews() []*view.View {
 		distributionBatchSendSizeBytesView,
 	}
 }
+
+// OtelMetricViews returns the OpenTelemetry views setting the bucket boundaries of the batch size
+// histograms, used when the collector uses OpenTelemetry for internal metrics.
+func OtelMetricViews() ([]sdkview.View, error) {
+	var views []sdkview.View
+	for _, hist := range []struct {
+		measure    *stats.Int64Measure
+		boundaries []float64
+	}{
+		{measure: statBatchSendSize, boundaries: batchSendSizeBoundaries},
+		{measure: statBatchSendSizeBytes, boundaries: batchSendSizeBytesBoundaries},
+	} {
+		v, err := sdkview.New(
+			sdkview.MatchInstrumentName(obsreport.BuildProcessorCustomMetricName(typeStr, hist.measure.Name())),
+			sdkview.MatchInstrumentationScope(instrumentation.Scope{Name: scopeName}),
+			sdkview.WithSetAggregation(aggregation.ExplicitBucketHistogram{Boundaries: hist.boundaries}),
+		)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	return views, nil
+}
+
+type batchProcessorTelemetry struct {
+	level    configtelemetry.Level
+	detailed bool
+
+	exportCtx context.Context
+
+	useOtelForMetrics bool
+	otelAttrs         []attribute.KeyValue
+
+	batchSizeTriggerSend syncint64.Counter
+	timeoutTriggerSend   syncint64.Counter
+	batchSendSize        syncint64.Histogram
+	batchSendSizeBytes   syncint64.Histogram
+}
+
+func newBatchProcessorTelemetry(set component.ProcessorCreateSettings, id config.ComponentID, level configtelemetry.Level, registry *featuregate.Registry) (*batchProcessorTelemetry, error) {
+	exportCtx, err := tag.New(context.Background(), tag.Insert(processorTagKey, id.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	bpt := &batchProcessorTelemetry{
+		level:     level,
+		detailed:  level == configtelemetry.LevelDetailed,
+		exportCtx: exportCtx,
+
+		useOtelForMetrics: registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID),
+		otelAttrs:         []attribute.KeyValue{attribute.String(obsmetrics.ProcessorKey, id.String())},
+	}
+
+	if err = bpt.createOtelMetrics(set); err != nil {
+		return nil, err
+	}
+
+	return bpt, nil
+}
+
+func (bpt *batchProcessorTelemetry) createOtelMetrics(set component.ProcessorCreateSettings) error {
+	if !bpt.useOtelForMetrics {
+		return nil
+	}
+
+	meter := set.MeterProvider.Meter(scopeName)
+	var err error
+
+	bpt.batchSizeTriggerSend, err = meter.SyncInt64().Counter(
+		obsreport.BuildProcessorCustomMetricName(typeStr, statBatchSizeTriggerSend.Name()),
+		instrument.WithDescription(statBatchSizeTriggerSend.Description()),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		return err
+	}
+
+	bpt.timeoutTriggerSend, err = meter.SyncInt64().Counter(
+		obsreport.BuildProcessorCustomMetricName(typeStr, statTimeoutTriggerSend.Name()),
+		instrument.WithDescription(statTimeoutTriggerSend.Description()),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		return err
+	}
+
+	bpt.batchSendSize, err = meter.SyncInt64().Histogram(
+		obsreport.BuildProcessorCustomMetricName(typeStr, statBatchSendSize.Name()),
+		instrument.WithDescription(statBatchSendSize.Description()),
+		instrument.WithUnit(unit.Dimensionless),
+	)
+	if err != nil {
+		return err
+	}
+
+	bpt.batchSendSizeBytes, err = meter.SyncInt64().Histogram(
+		obsreport.BuildProcessorCustomMetricName(typeStr, statBatchSendSizeBytes.Name()),
+		instrument.WithDescription(statBatchSendSizeBytes.Description()),
+		instrument.WithUnit(unit.Bytes),
+	)
+	return err
+}
+
+func (bpt *batchProcessorTelemetry) record(trigger trigger, sent, bytes int64) {
+	if bpt.useOtelForMetrics {
+		bpt.recordWithOtel(trigger, sent, bytes)
+	} else {
+		bpt.recordWithOC(trigger, sent, bytes)
+	}
+}
+
+func (bpt *batchProcessorTelemetry) recordWithOC(trigger trigger, sent, bytes int64) {
+	var triggerMeasure *stats.Int64Measure
+	switch trigger {
+	case triggerBatchSize:
+		triggerMeasure = statBatchSizeTriggerSend
+	case triggerTimeout:
+		triggerMeasure = statTimeoutTriggerSend
+	}
+
+	stats.Record(bpt.exportCtx, triggerMeasure.M(1), statBatchSendSize.M(sent))
+	if bpt.detailed {
+		stats.Record(bpt.exportCtx, statBatchSendSizeBytes.M(bytes))
+	}
+}
+
+func (bpt *batchProcessorTelemetry) recordWithOtel(trigger trigger, sent, bytes int64) {
+	switch trigger {
+	case triggerBatchSize:
+		bpt.batchSizeTriggerSend.Add(bpt.exportCtx, 1, bpt.otelAttrs...)
+	case triggerTimeout:
+		bpt.timeoutTriggerSend.Add(bpt.exportCtx, 1, bpt.otelAttrs...)
+	}
+
+	bpt.batchSendSize.Record(bpt.exportCtx, sent, bpt.otelAttrs...)
+	if bpt.detailed {
+		bpt.batchSendSizeBytes.Record(bpt.exportCtx, bytes, bpt.otelAttrs...)
+	}
+}
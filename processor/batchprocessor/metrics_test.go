@@ -15,9 +15,21 @@
 package batchprocessor
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/testdata"
 )
 
 func TestBatchProcessorMetrics(t *testing.T) {
@@ -32,3 +44,59 @@ func TestBatchProcessorMetrics(t *testing.T) {
 		assert.Equal(t, "processor/batch/"+viewName, views[i].Name)
 	}
 }
+
+func TestBatchProcessorOtelMetrics(t *testing.T) {
+	registry := featuregate.NewRegistry()
+	obsreportconfig.RegisterInternalMetricFeatureGate(registry)
+	require.NoError(t, registry.Apply(map[string]bool{obsreportconfig.UseOtelForInternalMetricsfeatureGateID: true}))
+
+	views, err := OtelMetricViews()
+	require.NoError(t, err)
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader, views...))
+
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 20
+	creationSet := componenttest.NewNopProcessorCreateSettings()
+	creationSet.MeterProvider = mp
+	batcher, err := newBatchProcessor(creationSet, cfg, func() batch { return newBatchTraces(sink) }, configtelemetry.LevelDetailed, registry)
+	require.NoError(t, err)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+	for requestNum := 0; requestNum < 10; requestNum++ {
+		require.NoError(t, batcher.ConsumeTraces(context.Background(), testdata.GenerateTraces(5)))
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	require.Equal(t, 50, sink.SpanCount())
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, scopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	attrs := attribute.NewSet(attribute.String("processor", cfg.ID().String()))
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	sizeTrigger := metrics["processor/batch/batch_size_trigger_send"].(metricdata.Sum[int64])
+	require.Len(t, sizeTrigger.DataPoints, 1)
+	assert.Equal(t, attrs, sizeTrigger.DataPoints[0].Attributes)
+	assert.Equal(t, int64(2), sizeTrigger.DataPoints[0].Value)
+
+	timeoutTrigger := metrics["processor/batch/timeout_trigger_send"].(metricdata.Sum[int64])
+	require.Len(t, timeoutTrigger.DataPoints, 1)
+	assert.Equal(t, int64(1), timeoutTrigger.DataPoints[0].Value)
+
+	sendSize := metrics["processor/batch/batch_send_size"].(metricdata.Histogram)
+	require.Len(t, sendSize.DataPoints, 1)
+	assert.Equal(t, uint64(3), sendSize.DataPoints[0].Count)
+	assert.Equal(t, float64(50), sendSize.DataPoints[0].Sum)
+	assert.Equal(t, batchSendSizeBoundaries, sendSize.DataPoints[0].Bounds)
+
+	sendSizeBytes := metrics["processor/batch/batch_send_size_bytes"].(metricdata.Histogram)
+	require.Len(t, sendSizeBytes.DataPoints, 1)
+	assert.Equal(t, uint64(3), sendSizeBytes.DataPoints[0].Count)
+	assert.Equal(t, batchSendSizeBytesBoundaries, sendSizeBytes.DataPoints[0].Bounds)
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+
+import (
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config has the configuration for the probabilistic sampler processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// SamplingPercentage is the percentage of the traces and logs kept, from 0 to 100.
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+
+	// HashSeed is mixed in the hash of the trace IDs. The collectors of the
+	// different tiers of a deployment must use the same seed to make the same
+	// sampling decisions.
+	HashSeed uint32 `mapstructure:"hash_seed"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if math.IsNaN(cfg.SamplingPercentage) || cfg.SamplingPercentage < 0 || cfg.SamplingPercentage > 100 {
+		return fmt.Errorf("invalid sampling_percentage %v, must be between 0 and 100", cfg.SamplingPercentage)
+	}
+	return nil
+}
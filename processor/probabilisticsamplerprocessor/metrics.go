@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	scopeName = "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+)
+
+var (
+	processorTagKey         = tag.MustNewKey(obsmetrics.ProcessorKey)
+	statSampledSpans        = stats.Int64("sampled_spans", "Number of spans sampled", stats.UnitDimensionless)
+	statUnsampledSpans      = stats.Int64("unsampled_spans", "Number of spans dropped by the sampler", stats.UnitDimensionless)
+	statSampledLogRecords   = stats.Int64("sampled_log_records", "Number of log records sampled", stats.UnitDimensionless)
+	statUnsampledLogRecords = stats.Int64("unsampled_log_records", "Number of log records dropped by the sampler", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views related to sampling
+func MetricViews() []*view.View {
+	processorTagKeys := []tag.Key{processorTagKey}
+
+	var views []*view.View
+	for _, measure := range []*stats.Int64Measure{statSampledSpans, statUnsampledSpans, statSampledLogRecords, statUnsampledLogRecords} {
+		views = append(views, &view.View{
+			Name:        obsreport.BuildProcessorCustomMetricName(typeStr, measure.Name()),
+			Measure:     measure,
+			Description: measure.Description(),
+			TagKeys:     processorTagKeys,
+			Aggregation: view.Sum(),
+		})
+	}
+	return views
+}
+
+type samplerTelemetry struct {
+	exportCtx context.Context
+
+	useOtelForMetrics bool
+	otelAttrs         []attribute.KeyValue
+
+	sampledSpans        syncint64.Counter
+	unsampledSpans      syncint64.Counter
+	sampledLogRecords   syncint64.Counter
+	unsampledLogRecords syncint64.Counter
+}
+
+func newSamplerTelemetry(set component.ProcessorCreateSettings, id config.ComponentID, registry *featuregate.Registry) (*samplerTelemetry, error) {
+	exportCtx, err := tag.New(context.Background(), tag.Insert(processorTagKey, id.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	st := &samplerTelemetry{
+		exportCtx: exportCtx,
+
+		useOtelForMetrics: registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID),
+		otelAttrs:         []attribute.KeyValue{attribute.String(obsmetrics.ProcessorKey, id.String())},
+	}
+
+	if err = st.createOtelMetrics(set); err != nil {
+		return nil, err
+	}
+
+	return st, nil
+}
+
+func (st *samplerTelemetry) createOtelMetrics(set component.ProcessorCreateSettings) error {
+	if !st.useOtelForMetrics {
+		return nil
+	}
+
+	meter := set.MeterProvider.Meter(scopeName)
+	for _, c := range []struct {
+		counter *syncint64.Counter
+		measure *stats.Int64Measure
+	}{
+		{counter: &st.sampledSpans, measure: statSampledSpans},
+		{counter: &st.unsampledSpans, measure: statUnsampledSpans},
+		{counter: &st.sampledLogRecords, measure: statSampledLogRecords},
+		{counter: &st.unsampledLogRecords, measure: statUnsampledLogRecords},
+	} {
+		var err error
+		*c.counter, err = meter.SyncInt64().Counter(
+			obsreport.BuildProcessorCustomMetricName(typeStr, c.measure.Name()),
+			instrument.WithDescription(c.measure.Description()),
+			instrument.WithUnit(unit.Dimensionless),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st *samplerTelemetry) recordSpans(ctx context.Context, sampled, unsampled int64) {
+	if st.useOtelForMetrics {
+		st.sampledSpans.Add(ctx, sampled, st.otelAttrs...)
+		st.unsampledSpans.Add(ctx, unsampled, st.otelAttrs...)
+		return
+	}
+	stats.Record(st.exportCtx, statSampledSpans.M(sampled), statUnsampledSpans.M(unsampled))
+}
+
+func (st *samplerTelemetry) recordLogRecords(ctx context.Context, sampled, unsampled int64) {
+	if st.useOtelForMetrics {
+		st.sampledLogRecords.Add(ctx, sampled, st.otelAttrs...)
+		st.unsampledLogRecords.Add(ctx, unsampled, st.otelAttrs...)
+		return
+	}
+	stats.Record(st.exportCtx, statSampledLogRecords.M(sampled), statUnsampledLogRecords.M(unsampled))
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+// sampler keeps the items whose 56 bits randomness is greater than or equal
+// to its threshold, the randomness of an item being derived from its trace ID.
+type sampler struct {
+	// threshold is maxThreshold minus the sampled fraction of the randomness
+	// space: 0 keeps everything, maxThreshold drops everything.
+	threshold uint64
+	seed      [4]byte
+	telemetry *samplerTelemetry
+}
+
+func newSampler(set component.ProcessorCreateSettings, cfg *Config, registry *featuregate.Registry) (*sampler, error) {
+	telemetry, err := newSamplerTelemetry(set, cfg.ID(), registry)
+	if err != nil {
+		return nil, err
+	}
+	s := &sampler{
+		threshold: thresholdFromPercentage(cfg.SamplingPercentage),
+		telemetry: telemetry,
+	}
+	binary.BigEndian.PutUint32(s.seed[:], cfg.HashSeed)
+	return s, nil
+}
+
+func thresholdFromPercentage(percentage float64) uint64 {
+	rejected := math.Round((1 - percentage/100) * float64(maxThreshold))
+	if rejected <= 0 {
+		return 0
+	}
+	if rejected >= float64(maxThreshold) {
+		return maxThreshold
+	}
+	return uint64(rejected)
+}
+
+// randomness returns the 56 bits randomness of a trace ID, hashed so that
+// trace IDs which are not fully random are still sampled uniformly. FNV-1a
+// poorly mixes its last bytes into the high bits, which are the ones kept, so
+// its hash goes through the finalizer of MurmurHash3.
+func (s *sampler) randomness(traceID pcommon.TraceID) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(s.seed[:])
+	_, _ = h.Write(traceID[:])
+	return fmix64(h.Sum64()) >> (64 - randomnessBits)
+}
+
+func fmix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
+
+func (s *sampler) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	var sampled, unsampled int64
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				if s.sampleSpan(span) {
+					sampled++
+					return false
+				}
+				unsampled++
+				return true
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	s.telemetry.recordSpans(ctx, sampled, unsampled)
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+// sampleSpan returns whether the span is sampled, and then records the
+// threshold it was sampled with in its tracestate, along with the randomness
+// derived from its trace ID when the tracestate had none, so that downstream
+// samplers use the same randomness whatever their hash seed. The randomness and
+// the threshold of a tracestate written by an upstream sampler are honored, a
+// span is never sampled with a lower threshold than upstream.
+func (s *sampler) sampleSpan(span ptrace.Span) bool {
+	ot, others := parseTraceState(span.TraceState().AsRaw())
+
+	rv := ot.randomness
+	if !ot.hasRandomness {
+		rv = s.randomness(span.TraceID())
+	}
+	th := s.threshold
+	if ot.hasThreshold && ot.threshold > th {
+		th = ot.threshold
+	}
+	if rv < th {
+		return false
+	}
+
+	if th > 0 && (!ot.hasThreshold || ot.threshold != th || !ot.hasRandomness) {
+		ot.threshold, ot.hasThreshold = th, true
+		ot.randomness, ot.hasRandomness = rv, true
+		span.TraceState().FromRaw(formatTraceState(ot, others))
+	}
+	return true
+}
+
+func (s *sampler) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	var sampled, unsampled int64
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				if s.sampleLogRecord(lr) {
+					sampled++
+					return false
+				}
+				unsampled++
+				return true
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	s.telemetry.recordLogRecords(ctx, sampled, unsampled)
+	if ld.ResourceLogs().Len() == 0 {
+		return ld, processorhelper.ErrSkipProcessingData
+	}
+	return ld, nil
+}
+
+// sampleLogRecord returns whether the log record is sampled. The log records
+// have no tracestate, so their randomness is always the hash of their trace ID:
+// they are sampled as the spans of their trace unless an upstream sampler set
+// an rv in the tracestate of the spans. The log records without a trace ID are
+// always sampled.
+func (s *sampler) sampleLogRecord(lr plog.LogRecord) bool {
+	if lr.TraceID().IsEmpty() {
+		return true
+	}
+	return s.randomness(lr.TraceID()) >= s.threshold
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func newTestSampler(t *testing.T, percentage float64, seed uint32) *sampler {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SamplingPercentage = percentage
+	cfg.HashSeed = seed
+	s, err := newSampler(componenttest.NewNopProcessorCreateSettings(), cfg, featuregate.NewRegistry())
+	require.NoError(t, err)
+	return s
+}
+
+func traceID(i int) pcommon.TraceID {
+	var id pcommon.TraceID
+	binary.BigEndian.PutUint64(id[8:], uint64(i+1))
+	return id
+}
+
+func generateTraces(count int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < count; i++ {
+		span := spans.AppendEmpty()
+		span.SetTraceID(traceID(i))
+	}
+	return td
+}
+
+func TestThresholdFromPercentage(t *testing.T) {
+	assert.Equal(t, uint64(0), thresholdFromPercentage(100))
+	assert.Equal(t, maxThreshold, thresholdFromPercentage(0))
+	assert.Equal(t, maxThreshold/2, thresholdFromPercentage(50))
+	assert.Equal(t, maxThreshold/4*3, thresholdFromPercentage(25))
+}
+
+func TestSamplerProcessTraces(t *testing.T) {
+	const count = 10000
+	s := newTestSampler(t, 10, 0)
+	td, err := s.processTraces(context.Background(), generateTraces(count))
+	require.NoError(t, err)
+
+	sampled := td.SpanCount()
+	assert.InDelta(t, count/10, sampled, count/100)
+
+	// The threshold is recorded with the randomness derived from the trace ID.
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		ot, others := parseTraceState(spans.At(i).TraceState().AsRaw())
+		assert.Empty(t, others)
+		assert.Equal(t, uint64(0xe6666666666668), ot.threshold)
+		assert.True(t, ot.hasRandomness)
+		assert.Equal(t, s.randomness(spans.At(i).TraceID()), ot.randomness)
+	}
+
+	// The same configuration takes the same decisions.
+	again, err := newTestSampler(t, 10, 0).processTraces(context.Background(), generateTraces(count))
+	require.NoError(t, err)
+	assert.Equal(t, td, again)
+
+	// Another seed takes other decisions.
+	other, err := newTestSampler(t, 10, 1).processTraces(context.Background(), generateTraces(count))
+	require.NoError(t, err)
+	assert.NotEqual(t, td, other)
+}
+
+func TestSamplerTiersAgree(t *testing.T) {
+	const count = 10000
+	first, err := newTestSampler(t, 50, 0).processTraces(context.Background(), generateTraces(count))
+	require.NoError(t, err)
+	second, err := newTestSampler(t, 20, 0).processTraces(context.Background(), first)
+	require.NoError(t, err)
+	direct, err := newTestSampler(t, 20, 0).processTraces(context.Background(), generateTraces(count))
+	require.NoError(t, err)
+	assert.Equal(t, direct, second)
+
+	// A tier sampling more than upstream keeps the upstream decisions and threshold.
+	third, err := newTestSampler(t, 50, 0).processTraces(context.Background(), second)
+	require.NoError(t, err)
+	assert.Equal(t, direct, third)
+
+	// A tier with another seed uses the randomness recorded upstream.
+	first, err = newTestSampler(t, 50, 0).processTraces(context.Background(), generateTraces(count))
+	require.NoError(t, err)
+	reseeded, err := newTestSampler(t, 20, 1).processTraces(context.Background(), first)
+	require.NoError(t, err)
+	assert.Equal(t, direct, reseeded)
+}
+
+func TestSamplerTraceState(t *testing.T) {
+	s := newTestSampler(t, 50, 0)
+	tests := []struct {
+		name       string
+		traceState string
+		sampled    bool
+		expected   string
+	}{
+		{
+			name:       "randomness above threshold",
+			traceState: "rojo=00f067aa0ba902b7,ot=rv:c0000000000000;p:8",
+			sampled:    true,
+			expected:   "ot=th:8;rv:c0000000000000;p:8,rojo=00f067aa0ba902b7",
+		},
+		{
+			name:       "randomness below threshold",
+			traceState: "ot=rv:40000000000000",
+		},
+		{
+			name:       "higher upstream threshold",
+			traceState: "ot=th:c;rv:d0000000000000",
+			sampled:    true,
+			expected:   "ot=th:c;rv:d0000000000000",
+		},
+		{
+			name:       "higher upstream threshold below randomness",
+			traceState: "ot=th:c;rv:a0000000000000",
+		},
+		{
+			name:       "lower upstream threshold",
+			traceState: "ot=th:4;rv:a0000000000000",
+			sampled:    true,
+			expected:   "ot=th:8;rv:a0000000000000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := ptrace.NewSpan()
+			span.TraceState().FromRaw(tt.traceState)
+			assert.Equal(t, tt.sampled, s.sampleSpan(span))
+			if tt.sampled {
+				assert.Equal(t, tt.expected, span.TraceState().AsRaw())
+			}
+		})
+	}
+
+	// Sampling everything leaves the tracestate untouched.
+	span := ptrace.NewSpan()
+	span.TraceState().FromRaw("rojo=00f067aa0ba902b7")
+	assert.True(t, newTestSampler(t, 100, 0).sampleSpan(span))
+	assert.Equal(t, "rojo=00f067aa0ba902b7", span.TraceState().AsRaw())
+}
+
+func TestSamplerProcessLogs(t *testing.T) {
+	const count = 10000
+	s := newTestSampler(t, 10, 0)
+	ld := plog.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < count; i++ {
+		logs.AppendEmpty().SetTraceID(traceID(i))
+	}
+	logs.AppendEmpty().Body().SetStr("without trace ID")
+
+	td, err := s.processTraces(context.Background(), generateTraces(count))
+	require.NoError(t, err)
+	ld, err = s.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	// Logs are sampled as the spans of their trace, and kept without trace ID.
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	logs = ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, spans.Len()+1, logs.Len())
+	for i := 0; i < spans.Len(); i++ {
+		assert.Equal(t, spans.At(i).TraceID(), logs.At(i).TraceID())
+	}
+	assert.Equal(t, "without trace ID", logs.At(spans.Len()).Body().Str())
+}
+
+func TestSamplerDropsAll(t *testing.T) {
+	s := newTestSampler(t, 0, 0)
+	_, err := s.processTraces(context.Background(), generateTraces(100))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().SetTraceID(traceID(0))
+	_, err = s.processLogs(context.Background(), ld)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.SamplingPercentage = 0
+	tp, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), generateTraces(100)))
+	assert.Empty(t, sink.AllTraces())
+}
+
+func TestSamplerMetricViews(t *testing.T) {
+	viewNames := []string{
+		"sampled_spans",
+		"unsampled_spans",
+		"sampled_log_records",
+		"unsampled_log_records",
+	}
+	views := MetricViews()
+	require.Len(t, views, len(viewNames))
+	for i, viewName := range viewNames {
+		assert.Equal(t, "processor/probabilistic_sampler/"+viewName, views[i].Name)
+	}
+}
+
+func TestSamplerOtelMetrics(t *testing.T) {
+	registry := featuregate.NewRegistry()
+	obsreportconfig.RegisterInternalMetricFeatureGate(registry)
+	require.NoError(t, registry.Apply(map[string]bool{obsreportconfig.UseOtelForInternalMetricsfeatureGateID: true}))
+
+	reader := sdkmetric.NewManualReader()
+	set := componenttest.NewNopProcessorCreateSettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	cfg := createDefaultConfig().(*Config)
+	cfg.SamplingPercentage = 10
+	s, err := newSampler(set, cfg, registry)
+	require.NoError(t, err)
+
+	td, err := s.processTraces(context.Background(), generateTraces(1000))
+	require.NoError(t, err)
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	_, err = s.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, scopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	attrs := attribute.NewSet(attribute.String("processor", cfg.ID().String()))
+	values := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		sum := m.Data.(metricdata.Sum[int64])
+		require.Len(t, sum.DataPoints, 1)
+		assert.Equal(t, attrs, sum.DataPoints[0].Attributes)
+		values[m.Name] = sum.DataPoints[0].Value
+	}
+	assert.Equal(t, map[string]int64{
+		"processor/probabilistic_sampler/sampled_spans":         int64(td.SpanCount()),
+		"processor/probabilistic_sampler/unsampled_spans":       int64(1000 - td.SpanCount()),
+		"processor/probabilistic_sampler/sampled_log_records":   1,
+		"processor/probabilistic_sampler/unsampled_log_records": 0,
+	}, values)
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	// otelTraceStateKey is the key of the OpenTelemetry entry of the W3C tracestate.
+	otelTraceStateKey = "ot"
+
+	// The sampling threshold and randomness are 56 bits values, encoded with
+	// up to 14 hexadecimal digits.
+	randomnessBits   = 56
+	maxThreshold     = uint64(1) << randomnessBits
+	randomnessMask   = maxThreshold - 1
+	thresholdDigits  = randomnessBits / 4
+	thresholdSubKey  = "th"
+	randomnessSubKey = "rv"
+)
+
+// otelTraceState is the OpenTelemetry entry of a W3C tracestate, as in
+// "ot=th:c;rv:1a2b3c4d5e6f70". Only the sampling threshold and randomness
+// sub-keys are interpreted, the other ones are kept as they are.
+type otelTraceState struct {
+	threshold    uint64
+	hasThreshold bool
+
+	randomness    uint64
+	hasRandomness bool
+
+	extra []string
+}
+
+// parseTraceState splits a W3C tracestate into its OpenTelemetry entry and
+// the entries of the other vendors.
+func parseTraceState(raw string) (otelTraceState, []string) {
+	var ot otelTraceState
+	var others []string
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		value, ok := cutPrefix(entry, otelTraceStateKey+"=")
+		if !ok {
+			others = append(others, entry)
+			continue
+		}
+		for _, field := range strings.Split(value, ";") {
+			if v, ok := cutPrefix(field, thresholdSubKey+":"); ok {
+				if th, err := parseThreshold(v); err == nil {
+					ot.threshold, ot.hasThreshold = th, true
+					continue
+				}
+			}
+			if v, ok := cutPrefix(field, randomnessSubKey+":"); ok {
+				if rv, err := parseRandomness(v); err == nil {
+					ot.randomness, ot.hasRandomness = rv, true
+					continue
+				}
+			}
+			if field != "" {
+				ot.extra = append(ot.extra, field)
+			}
+		}
+	}
+	return ot, others
+}
+
+// formatTraceState returns the W3C tracestate with the OpenTelemetry entry
+// first, as the entry updated last, followed by the other entries.
+func formatTraceState(ot otelTraceState, others []string) string {
+	var fields []string
+	if ot.hasThreshold {
+		fields = append(fields, thresholdSubKey+":"+formatThreshold(ot.threshold))
+	}
+	if ot.hasRandomness {
+		fields = append(fields, randomnessSubKey+":"+formatRandomness(ot.randomness))
+	}
+	fields = append(fields, ot.extra...)
+
+	entries := make([]string, 0, len(others)+1)
+	if len(fields) > 0 {
+		entries = append(entries, otelTraceStateKey+"="+strings.Join(fields, ";"))
+	}
+	entries = append(entries, others...)
+	return strings.Join(entries, ",")
+}
+
+// parseThreshold parses a threshold of up to 14 hexadecimal digits, the
+// omitted trailing digits being zeros.
+func parseThreshold(s string) (uint64, error) {
+	if s == "" || len(s) > thresholdDigits {
+		return 0, strconv.ErrSyntax
+	}
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, err
+	}
+	return v << (4 * (thresholdDigits - len(s))), nil
+}
+
+// formatThreshold formats a threshold with its trailing zeros omitted.
+func formatThreshold(th uint64) string {
+	if th == 0 {
+		return "0"
+	}
+	s := strconv.FormatUint(th, 16)
+	s = strings.Repeat("0", thresholdDigits-len(s)) + s
+	return strings.TrimRight(s, "0")
+}
+
+// parseRandomness parses a randomness of exactly 14 hexadecimal digits.
+func parseRandomness(s string) (uint64, error) {
+	if len(s) != thresholdDigits {
+		return 0, strconv.ErrSyntax
+	}
+	return strconv.ParseUint(s, 16, 64)
+}
+
+func formatRandomness(rv uint64) string {
+	s := strconv.FormatUint(rv, 16)
+	return strings.Repeat("0", thresholdDigits-len(s)) + s
+}
+
+// cutPrefix is strings.CutPrefix, not available before Go 1.20.
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
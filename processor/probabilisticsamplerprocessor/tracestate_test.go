@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTraceState(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		ot       otelTraceState
+		others   []string
+		expected string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "other vendors",
+			raw:      "congo=t61rcWkgMzE, rojo=00f067aa0ba902b7",
+			others:   []string{"congo=t61rcWkgMzE", "rojo=00f067aa0ba902b7"},
+			expected: "congo=t61rcWkgMzE,rojo=00f067aa0ba902b7",
+		},
+		{
+			name:     "threshold and randomness",
+			raw:      "rojo=00f067aa0ba902b7,ot=rv:1a2b3c4d5e6f70;th:c",
+			ot:       otelTraceState{threshold: 0xc0000000000000, hasThreshold: true, randomness: 0x1a2b3c4d5e6f70, hasRandomness: true},
+			others:   []string{"rojo=00f067aa0ba902b7"},
+			expected: "ot=th:c;rv:1a2b3c4d5e6f70,rojo=00f067aa0ba902b7",
+		},
+		{
+			name:     "zero threshold",
+			raw:      "ot=th:0",
+			ot:       otelTraceState{hasThreshold: true},
+			expected: "ot=th:0",
+		},
+		{
+			name:     "extra and invalid sub-keys",
+			raw:      "ot=p:8;th:123456789abcdef0;rv:12;th:08",
+			ot:       otelTraceState{threshold: 0x08000000000000, hasThreshold: true, extra: []string{"p:8", "th:123456789abcdef0", "rv:12"}},
+			expected: "ot=th:08;p:8;th:123456789abcdef0;rv:12",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ot, others := parseTraceState(tt.raw)
+			assert.Equal(t, tt.ot, ot)
+			assert.Equal(t, tt.others, others)
+			assert.Equal(t, tt.expected, formatTraceState(ot, others))
+		})
+	}
+}
+
+func TestFormatThreshold(t *testing.T) {
+	assert.Equal(t, "0", formatThreshold(0))
+	assert.Equal(t, "8", formatThreshold(maxThreshold/2))
+	assert.Equal(t, "e6666666666668", formatThreshold(thresholdFromPercentage(10)))
+	assert.Equal(t, "ffffffffffffff", formatThreshold(randomnessMask))
+	assert.Equal(t, "00000000000001", formatThreshold(1))
+
+	for _, th := range []uint64{0, 1, maxThreshold / 2, thresholdFromPercentage(10), randomnessMask} {
+		parsed, err := parseThreshold(formatThreshold(th))
+		assert.NoError(t, err)
+		assert.Equal(t, th, parsed)
+	}
+}
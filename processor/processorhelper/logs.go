@@ -18,11 +18,12 @@ import (
 	"context"
 	"errors"
 
-	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/codes"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
@@ -39,7 +40,7 @@ type logProcessor struct {
 // NewLogsProcessor creates a component.LogsProcessor that ensure context propagation and the right tags are set.
 func NewLogsProcessor(
 	_ context.Context,
-	_ component.ProcessorCreateSettings,
+	set component.ProcessorCreateSettings,
 	cfg config.Processor,
 	nextConsumer consumer.Logs,
 	logsFunc ProcessLogsFunc,
@@ -55,9 +56,11 @@ func NewLogsProcessor(
 	}
 
 	eventOptions := spanAttributes(cfg.ID())
+	tracer := newTracer(set, cfg.ID())
 	bs := fromOptions(options)
 	logsConsumer, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
-		span := trace.SpanFromContext(ctx)
+		ctx, span := startSpan(ctx, tracer, cfg.ID(), obsmetrics.ProcessLogsOperationSuffix)
+		defer span.End()
 		span.AddEvent("Start processing.", eventOptions)
 		var err error
 		ld, err = logsFunc(ctx, ld)
@@ -66,6 +69,7 @@ func NewLogsProcessor(
 			if errors.Is(err, ErrSkipProcessingData) {
 				return nil
 			}
+			span.SetStatus(codes.Error, err.Error())
 			return err
 		}
 		return nextConsumer.ConsumeLogs(ctx, ld)
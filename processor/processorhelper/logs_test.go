@@ -42,6 +42,13 @@ func TestNewLogsProcessor(t *testing.T) {
 	assert.NoError(t, lp.Shutdown(context.Background()))
 }
 
+func TestNewLogsProcessor_ZeroSettings(t *testing.T) {
+	// Settings without a tracer provider fall back to a no-op tracer.
+	p, err := NewLogsProcessor(context.Background(), component.ProcessorCreateSettings{}, &testLogsCfg, consumertest.NewNop(), newTestLProcessor(nil))
+	require.NoError(t, err)
+	assert.NoError(t, p.ConsumeLogs(context.Background(), plog.NewLogs()))
+}
+
 func TestNewLogsProcessor_WithOptions(t *testing.T) {
 	want := errors.New("my_error")
 	lp, err := NewLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), &testLogsCfg, consumertest.NewNop(), newTestLProcessor(nil),
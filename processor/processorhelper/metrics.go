@@ -18,11 +18,12 @@ import (
 	"context"
 	"errors"
 
-	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/codes"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
@@ -39,7 +40,7 @@ type metricsProcessor struct {
 // NewMetricsProcessor creates a component.MetricsProcessor that ensure context propagation and the right tags are set.
 func NewMetricsProcessor(
 	_ context.Context,
-	_ component.ProcessorCreateSettings,
+	set component.ProcessorCreateSettings,
 	cfg config.Processor,
 	nextConsumer consumer.Metrics,
 	metricsFunc ProcessMetricsFunc,
@@ -55,9 +56,11 @@ func NewMetricsProcessor(
 	}
 
 	eventOptions := spanAttributes(cfg.ID())
+	tracer := newTracer(set, cfg.ID())
 	bs := fromOptions(options)
 	metricsConsumer, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
-		span := trace.SpanFromContext(ctx)
+		ctx, span := startSpan(ctx, tracer, cfg.ID(), obsmetrics.ProcessMetricsOperationSuffix)
+		defer span.End()
 		span.AddEvent("Start processing.", eventOptions)
 		var err error
 		md, err = metricsFunc(ctx, md)
@@ -66,6 +69,7 @@ func NewMetricsProcessor(
 			if errors.Is(err, ErrSkipProcessingData) {
 				return nil
 			}
+			span.SetStatus(codes.Error, err.Error())
 			return err
 		}
 		return nextConsumer.ConsumeMetrics(ctx, md)
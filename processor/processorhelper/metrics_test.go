@@ -42,6 +42,13 @@ func TestNewMetricsProcessor(t *testing.T) {
 	assert.NoError(t, mp.Shutdown(context.Background()))
 }
 
+func TestNewMetricsProcessor_ZeroSettings(t *testing.T) {
+	// Settings without a tracer provider fall back to a no-op tracer.
+	p, err := NewMetricsProcessor(context.Background(), component.ProcessorCreateSettings{}, &testMetricsCfg, consumertest.NewNop(), newTestMProcessor(nil))
+	require.NoError(t, err)
+	assert.NoError(t, p.ConsumeMetrics(context.Background(), pmetric.NewMetrics()))
+}
+
 func TestNewMetricsProcessor_WithOptions(t *testing.T) {
 	want := errors.New("my_error")
 	mp, err := NewMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), &testMetricsCfg, consumertest.NewNop(), newTestMProcessor(nil),
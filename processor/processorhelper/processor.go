@@ -15,6 +15,7 @@
 package processorhelper // import "go.opentelemetry.io/collector/processor/processorhelper"
 
 import (
+	"context"
 	"errors"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -81,3 +82,18 @@ func fromOptions(options []Option) *baseSettings {
 func spanAttributes(id config.ComponentID) trace.EventOption {
 	return trace.WithAttributes(attribute.String(obsmetrics.ProcessorKey, id.String()))
 }
+
+// newTracer returns the tracer of the processor, falling back to a no-op tracer when the settings
+// have no tracer provider, as zero-value settings do.
+func newTracer(set component.ProcessorCreateSettings, id config.ComponentID) trace.Tracer {
+	if set.TracerProvider == nil {
+		return trace.NewNoopTracerProvider().Tracer(id.String())
+	}
+	return set.TracerProvider.Tracer(id.String())
+}
+
+// startSpan starts the span tracing the processing of a batch of data, and the calls to the next
+// consumers which get the returned context.
+func startSpan(ctx context.Context, tracer trace.Tracer, id config.ComponentID, operationSuffix string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, obsmetrics.ProcessorPrefix+id.String()+operationSuffix)
+}
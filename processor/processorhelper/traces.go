@@ -18,11 +18,12 @@ import (
 	"context"
 	"errors"
 
-	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/codes"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 )
 
@@ -39,7 +40,7 @@ type tracesProcessor struct {
 // NewTracesProcessor creates a component.TracesProcessor that ensure context propagation and the right tags are set.
 func NewTracesProcessor(
 	_ context.Context,
-	_ component.ProcessorCreateSettings,
+	set component.ProcessorCreateSettings,
 	cfg config.Processor,
 	nextConsumer consumer.Traces,
 	tracesFunc ProcessTracesFunc,
@@ -55,9 +56,11 @@ func NewTracesProcessor(
 	}
 
 	eventOptions := spanAttributes(cfg.ID())
+	tracer := newTracer(set, cfg.ID())
 	bs := fromOptions(options)
 	traceConsumer, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
-		span := trace.SpanFromContext(ctx)
+		ctx, span := startSpan(ctx, tracer, cfg.ID(), obsmetrics.ProcessTraceDataOperationSuffix)
+		defer span.End()
 		span.AddEvent("Start processing.", eventOptions)
 		var err error
 		td, err = tracesFunc(ctx, td)
@@ -66,6 +69,7 @@ func NewTracesProcessor(
 			if errors.Is(err, ErrSkipProcessingData) {
 				return nil
 			}
+			span.SetStatus(codes.Error, err.Error())
 			return err
 		}
 		return nextConsumer.ConsumeTraces(ctx, td)
@@ -21,6 +21,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -42,6 +46,13 @@ func TestNewTracesProcessor(t *testing.T) {
 	assert.NoError(t, tp.Shutdown(context.Background()))
 }
 
+func TestNewTracesProcessor_ZeroSettings(t *testing.T) {
+	// Settings without a tracer provider fall back to a no-op tracer.
+	p, err := NewTracesProcessor(context.Background(), component.ProcessorCreateSettings{}, &testTracesCfg, consumertest.NewNop(), newTestTProcessor(nil))
+	require.NoError(t, err)
+	assert.NoError(t, p.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+}
+
 func TestNewTracesProcessor_WithOptions(t *testing.T) {
 	want := errors.New("my_error")
 	tp, err := NewTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), &testTracesCfg, consumertest.NewNop(), newTestTProcessor(nil),
@@ -76,6 +87,35 @@ func TestNewTracesProcessor_ProcessTracesErrSkipProcessingData(t *testing.T) {
 	assert.Equal(t, nil, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
 }
 
+func TestNewTracesProcessor_Spans(t *testing.T) {
+	sr := new(tracetest.SpanRecorder)
+	set := componenttest.NewNopProcessorCreateSettings()
+	set.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	want := errors.New("my_error")
+	var nextSpan trace.SpanContext
+	next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		nextSpan = trace.SpanContextFromContext(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+
+	tp, err := NewTracesProcessor(context.Background(), set, &testTracesCfg, next, newTestTProcessor(nil))
+	require.NoError(t, err)
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	tp, err = NewTracesProcessor(context.Background(), set, &testTracesCfg, next, newTestTProcessor(want))
+	require.NoError(t, err)
+	assert.Equal(t, want, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "processor/test/traces", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	// The next consumer gets the context of the span.
+	assert.Equal(t, spans[0].SpanContext(), nextSpan)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "my_error", spans[1].Status().Description)
+}
+
 func newTestTProcessor(retError error) ProcessTracesFunc {
 	return func(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
 		return td, retError
@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor // import "go.opentelemetry.io/collector/processor/resourcedetectionprocessor"
+
+import (
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/resourcedetection"
+)
+
+// Config has the configuration for the resource detection processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Detectors are the detectors of the resource attributes, among "host",
+	// "container" and "k8s", all of them when empty. An attribute detected by
+	// several detectors has the value of the first one.
+	Detectors []string `mapstructure:"detectors"`
+
+	// Override replaces the attributes already present in the resources with
+	// the detected ones, instead of preserving them.
+	Override bool `mapstructure:"override"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	return resourcedetection.Validate(cfg.Detectors)
+}
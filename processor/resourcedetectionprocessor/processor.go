@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor // import "go.opentelemetry.io/collector/processor/resourcedetectionprocessor"
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/resourcedetection"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// defaultDetectors are used when no detectors are configured.
+var defaultDetectors = []string{resourcedetection.DetectorHost, resourcedetection.DetectorContainer, resourcedetection.DetectorK8s}
+
+type resourceDetectionProcessor struct {
+	logger    *zap.Logger
+	detectors []string
+	override  bool
+
+	// attrs are the attributes detected when the processor starts.
+	attrs map[string]string
+}
+
+func newResourceDetectionProcessor(set component.ProcessorCreateSettings, cfg *Config) *resourceDetectionProcessor {
+	rdp := &resourceDetectionProcessor{
+		logger:    set.Logger,
+		detectors: cfg.Detectors,
+		override:  cfg.Override,
+	}
+	if len(rdp.detectors) == 0 {
+		rdp.detectors = defaultDetectors
+	}
+	return rdp
+}
+
+func (rdp *resourceDetectionProcessor) start(context.Context, component.Host) error {
+	attrs, err := resourcedetection.Detect(rdp.detectors)
+	if err != nil {
+		return err
+	}
+	rdp.logger.Info("Detected resource attributes", zap.Any("attributes", attrs))
+	rdp.attrs = attrs
+	return nil
+}
+
+func (rdp *resourceDetectionProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		resourcedetection.Merge(rss.At(i).Resource(), rdp.attrs, rdp.override)
+	}
+	return td, nil
+}
+
+func (rdp *resourceDetectionProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		resourcedetection.Merge(rms.At(i).Resource(), rdp.attrs, rdp.override)
+	}
+	return md, nil
+}
+
+func (rdp *resourceDetectionProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		resourcedetection.Merge(rls.At(i).Resource(), rdp.attrs, rdp.override)
+	}
+	return ld, nil
+}
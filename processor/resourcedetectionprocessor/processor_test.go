@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcedetectionprocessor
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestProcessTraces(t *testing.T) {
+	t.Setenv("K8S_POD_NAME", "otelcol-agent-x7k2p")
+	t.Setenv("K8S_POD_NAMESPACE", "observability")
+
+	for _, override := range []bool{false, true} {
+		cfg := createDefaultConfig().(*Config)
+		cfg.Detectors = []string{"k8s"}
+		cfg.Override = override
+		sink := new(consumertest.TracesSink)
+		tp, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+		require.NoError(t, err)
+		require.NoError(t, tp.Start(context.Background(), componenttest.NewNopHost()))
+
+		td := ptrace.NewTraces()
+		attrs := td.ResourceSpans().AppendEmpty().Resource().Attributes()
+		attrs.PutStr("service.name", "checkout")
+		attrs.PutStr("k8s.pod.name", "checkout-5d9c7")
+		td.ResourceSpans().AppendEmpty()
+		require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+		require.NoError(t, tp.Shutdown(context.Background()))
+
+		pod := "checkout-5d9c7"
+		if override {
+			pod = "otelcol-agent-x7k2p"
+		}
+		rss := sink.AllTraces()[0].ResourceSpans()
+		assert.Equal(t, map[string]interface{}{
+			"service.name":       "checkout",
+			"k8s.pod.name":       pod,
+			"k8s.namespace.name": "observability",
+		}, rss.At(0).Resource().Attributes().AsRaw())
+		assert.Equal(t, map[string]interface{}{
+			"k8s.pod.name":       "otelcol-agent-x7k2p",
+			"k8s.namespace.name": "observability",
+		}, rss.At(1).Resource().Attributes().AsRaw())
+	}
+}
+
+func TestProcessMetricsAndLogs(t *testing.T) {
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Detectors = []string{"host"}
+
+	msink := new(consumertest.MetricsSink)
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, msink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	hostName, ok := msink.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().Get("host.name")
+	require.True(t, ok)
+	assert.Equal(t, hostname, hostName.Str())
+
+	lsink := new(consumertest.LogsSink)
+	lp, err := NewFactory().CreateLogsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, lsink)
+	require.NoError(t, err)
+	require.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	require.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+	hostName, ok = lsink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("host.name")
+	require.True(t, ok)
+	assert.Equal(t, hostname, hostName.Str())
+}
+
+func TestDefaultDetectors(t *testing.T) {
+	rdp := newResourceDetectionProcessor(componenttest.NewNopProcessorCreateSettings(), createDefaultConfig().(*Config))
+	assert.Equal(t, []string{"host", "container", "k8s"}, rdp.detectors)
+}
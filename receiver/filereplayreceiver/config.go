@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver // import "go.opentelemetry.io/collector/receiver/filereplayreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+)
+
+const (
+	timingFast     = "fast"
+	timingOriginal = "original"
+)
+
+// Config defines configuration for the file replay receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Include are the glob patterns of the files replayed. The files are replayed
+	// one after the other, in the order they were last modified.
+	Include []string `mapstructure:"include"`
+
+	// Format of the records of the files; options are json, proto. The
+	// compression of the files is detected.
+	Format string `mapstructure:"format"`
+
+	// Timing of the replay; options are fast, to replay the records as fast as
+	// possible, and original, to replay them at the pace of the timestamps of their data.
+	Timing string `mapstructure:"timing"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Include) == 0 {
+		return errors.New("\"include\" is required when using the \"filereplay\" receiver")
+	}
+	for _, pattern := range cfg.Include {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid include pattern %q: %w", pattern, err)
+		}
+	}
+	if err := otlpfile.Format(cfg.Format).Validate(); err != nil {
+		return err
+	}
+	if cfg.Timing != timingFast && cfg.Timing != timingOriginal {
+		return fmt.Errorf("timing %q is not supported", cfg.Timing)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver // import "go.opentelemetry.io/collector/receiver/filereplayreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/internal/sharedcomponent"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "filereplay"
+	// The stability level of the receiver.
+	stability = component.StabilityLevelInDevelopment
+)
+
+// NewFactory creates a factory for the file replay receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesReceiver(createTracesReceiver, stability),
+		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, stability),
+	)
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+		Format:           string(otlpfile.FormatJSON),
+		Timing:           timingFast,
+	}
+}
+
+func createTracesReceiver(_ context.Context, set component.ReceiverCreateSettings, cfg config.Receiver, nextConsumer consumer.Traces) (component.TracesReceiver, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReplayReceiver(cfg.(*Config), set)
+	})
+	if err := r.Unwrap().(*replayReceiver).registerTracesConsumer(nextConsumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func createMetricsReceiver(_ context.Context, set component.ReceiverCreateSettings, cfg config.Receiver, nextConsumer consumer.Metrics) (component.MetricsReceiver, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReplayReceiver(cfg.(*Config), set)
+	})
+	if err := r.Unwrap().(*replayReceiver).registerMetricsConsumer(nextConsumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func createLogsReceiver(_ context.Context, set component.ReceiverCreateSettings, cfg config.Receiver, nextConsumer consumer.Logs) (component.LogsReceiver, error) {
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReplayReceiver(cfg.(*Config), set)
+	})
+	if err := r.Unwrap().(*replayReceiver).registerLogsConsumer(nextConsumer); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// This is the map of already created file replay receivers for particular configurations.
+// The traces, metrics and logs receivers of a configuration share the replay of the files,
+// which dispatches each record to the pipelines of its data type.
+var receivers = sharedcomponent.NewSharedComponents()
@@ -0,0 +1,347 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver // import "go.opentelemetry.io/collector/receiver/filereplayreceiver"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const transport = "file"
+
+// replayReceiver reads the records of the files in the background, and passes
+// their data to the consumer of its data type, if any.
+type replayReceiver struct {
+	cfg      *Config
+	settings component.ReceiverCreateSettings
+	obsrecv  *obsreport.Receiver
+
+	tracesConsumer  consumer.Traces
+	metricsConsumer consumer.Metrics
+	logsConsumer    consumer.Logs
+
+	// sleep waits for d, returning false if ctx is done first.
+	sleep  func(ctx context.Context, d time.Duration) bool
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newReplayReceiver(cfg *Config, set component.ReceiverCreateSettings) *replayReceiver {
+	return &replayReceiver{
+		cfg:      cfg,
+		settings: set,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             cfg.ID(),
+			Transport:              transport,
+			ReceiverCreateSettings: set,
+		}),
+		sleep: sleep,
+	}
+}
+
+func (r *replayReceiver) registerTracesConsumer(tc consumer.Traces) error {
+	if tc == nil {
+		return component.ErrNilNextConsumer
+	}
+	r.tracesConsumer = tc
+	return nil
+}
+
+func (r *replayReceiver) registerMetricsConsumer(mc consumer.Metrics) error {
+	if mc == nil {
+		return component.ErrNilNextConsumer
+	}
+	r.metricsConsumer = mc
+	return nil
+}
+
+func (r *replayReceiver) registerLogsConsumer(lc consumer.Logs) error {
+	if lc == nil {
+		return component.ErrNilNextConsumer
+	}
+	r.logsConsumer = lc
+	return nil
+}
+
+// Start starts replaying the files in the background.
+func (r *replayReceiver) Start(context.Context, component.Host) error {
+	files, err := r.files()
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		r.settings.Logger.Warn("No file to replay matches the include patterns", zap.Strings("include", r.cfg.Include))
+		return nil
+	}
+
+	var ctx context.Context
+	ctx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.replay(ctx, files)
+	}()
+	return nil
+}
+
+// Shutdown stops the replay.
+func (r *replayReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+// files returns the files matching the include patterns, in the order they were last modified.
+func (r *replayReceiver) files() ([]string, error) {
+	modTimes := map[string]time.Time{}
+	var files []string
+	for _, pattern := range r.cfg.Include {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if _, ok := modTimes[match]; ok {
+				continue
+			}
+			info, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if info.IsDir() {
+				continue
+			}
+			modTimes[match] = info.ModTime()
+			files = append(files, match)
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		ti, tj := modTimes[files[i]], modTimes[files[j]]
+		if ti.Equal(tj) {
+			return files[i] < files[j]
+		}
+		return ti.Before(tj)
+	})
+	return files, nil
+}
+
+func (r *replayReceiver) replay(ctx context.Context, files []string) {
+	p := pacer{receiver: r}
+	for _, file := range files {
+		if err := r.replayFile(ctx, file, &p); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.settings.Logger.Error("Failed to replay file", zap.String("path", file), zap.Error(err))
+		}
+	}
+	r.settings.Logger.Info("Finished replaying the files", zap.Int("files", len(files)))
+}
+
+func (r *replayReceiver) replayFile(ctx context.Context, path string, p *pacer) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	dr, err := otlpfile.NewDecompressReader(f)
+	if err != nil {
+		return err
+	}
+	defer dr.Close()
+
+	reader := otlpfile.NewReader(dr, otlpfile.Format(r.cfg.Format))
+	for {
+		rec, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if r.cfg.Timing == timingOriginal && !p.wait(ctx, recordTime(rec)) {
+			return ctx.Err()
+		}
+		r.consume(ctx, rec)
+	}
+}
+
+// consume passes the data of the record to the consumer of its data type. The
+// errors of the consumers are reported by obsreport, and do not stop the replay.
+func (r *replayReceiver) consume(ctx context.Context, rec otlpfile.Record) {
+	switch {
+	case rec.Traces != nil && r.tracesConsumer != nil && rec.Traces.SpanCount() > 0:
+		ctx = r.obsrecv.StartTracesOp(ctx)
+		err := r.tracesConsumer.ConsumeTraces(ctx, *rec.Traces)
+		r.obsrecv.EndTracesOp(ctx, r.cfg.Format, rec.Traces.SpanCount(), err)
+	case rec.Metrics != nil && r.metricsConsumer != nil && rec.Metrics.DataPointCount() > 0:
+		ctx = r.obsrecv.StartMetricsOp(ctx)
+		err := r.metricsConsumer.ConsumeMetrics(ctx, *rec.Metrics)
+		r.obsrecv.EndMetricsOp(ctx, r.cfg.Format, rec.Metrics.DataPointCount(), err)
+	case rec.Logs != nil && r.logsConsumer != nil && rec.Logs.LogRecordCount() > 0:
+		ctx = r.obsrecv.StartLogsOp(ctx)
+		err := r.logsConsumer.ConsumeLogs(ctx, *rec.Logs)
+		r.obsrecv.EndLogsOp(ctx, r.cfg.Format, rec.Logs.LogRecordCount(), err)
+	}
+}
+
+// pacer replays the records at the pace of their timestamps, relatively to the
+// first record with a timestamp.
+type pacer struct {
+	receiver  *replayReceiver
+	started   bool
+	firstTime pcommon.Timestamp
+	startedAt time.Time
+}
+
+// wait waits until the time of the record relatively to the first one, returning
+// false if ctx is done first. Records without a timestamp, or older than the
+// previous ones, are replayed immediately.
+func (p *pacer) wait(ctx context.Context, ts pcommon.Timestamp) bool {
+	if ts == 0 {
+		return ctx.Err() == nil
+	}
+	if !p.started {
+		p.started = true
+		p.firstTime = ts
+		p.startedAt = time.Now()
+		return ctx.Err() == nil
+	}
+	d := time.Until(p.startedAt.Add(ts.AsTime().Sub(p.firstTime.AsTime())))
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	return p.receiver.sleep(ctx, d)
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// recordTime returns the latest timestamp of the data of the record: the end of
+// its spans, the time of its data points or of its log records.
+func recordTime(rec otlpfile.Record) pcommon.Timestamp {
+	var latest pcommon.Timestamp
+	observe := func(ts pcommon.Timestamp) {
+		if ts > latest {
+			latest = ts
+		}
+	}
+	switch {
+	case rec.Traces != nil:
+		forEachSpan(*rec.Traces, func(span ptrace.Span) { observe(span.EndTimestamp()) })
+	case rec.Metrics != nil:
+		forEachMetric(*rec.Metrics, func(m pmetric.Metric) { forEachDataPointTime(m, observe) })
+	case rec.Logs != nil:
+		forEachLogRecord(*rec.Logs, func(lr plog.LogRecord) {
+			if lr.Timestamp() != 0 {
+				observe(lr.Timestamp())
+			} else {
+				observe(lr.ObservedTimestamp())
+			}
+		})
+	}
+	return latest
+}
+
+func forEachSpan(td ptrace.Traces, fn func(ptrace.Span)) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				fn(spans.At(k))
+			}
+		}
+	}
+}
+
+func forEachMetric(md pmetric.Metrics, fn func(pmetric.Metric)) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			metrics := sms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				fn(metrics.At(k))
+			}
+		}
+	}
+}
+
+func forEachDataPointTime(m pmetric.Metric, fn func(pcommon.Timestamp)) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			fn(m.Gauge().DataPoints().At(i).Timestamp())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			fn(m.Sum().DataPoints().At(i).Timestamp())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			fn(m.Histogram().DataPoints().At(i).Timestamp())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			fn(m.ExponentialHistogram().DataPoints().At(i).Timestamp())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			fn(m.Summary().DataPoints().At(i).Timestamp())
+		}
+	}
+}
+
+func forEachLogRecord(ld plog.Logs, fn func(plog.LogRecord)) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				fn(lrs.At(k))
+			}
+		}
+	}
+}
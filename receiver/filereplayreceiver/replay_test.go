@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereplayreceiver
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/otlpfile"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// writeFile writes a file with the compression, calling fn to write its records.
+func writeFile(t *testing.T, path string, format otlpfile.Format, compression configcompression.CompressionType, fn func(w *otlpfile.Writer)) {
+	f, err := os.Create(filepath.Clean(path))
+	require.NoError(t, err)
+	cw, err := otlpfile.NewCompressWriter(f, compression)
+	require.NoError(t, err)
+	fn(otlpfile.NewWriter(cw, format))
+	require.NoError(t, cw.Close())
+	require.NoError(t, f.Close())
+}
+
+type testReceiver struct {
+	*replayReceiver
+	traces  *consumertest.TracesSink
+	metrics *consumertest.MetricsSink
+	logs    *consumertest.LogsSink
+	logged  *observer.ObservedLogs
+}
+
+func newTestReceiver(t *testing.T, cfg *Config) *testReceiver {
+	core, logged := observer.New(zap.InfoLevel)
+	set := componenttest.NewNopReceiverCreateSettings()
+	set.Logger = zap.New(core)
+	tr := &testReceiver{
+		replayReceiver: newReplayReceiver(cfg, set),
+		traces:         new(consumertest.TracesSink),
+		metrics:        new(consumertest.MetricsSink),
+		logs:           new(consumertest.LogsSink),
+		logged:         logged,
+	}
+	require.NoError(t, tr.registerTracesConsumer(tr.traces))
+	require.NoError(t, tr.registerMetricsConsumer(tr.metrics))
+	require.NoError(t, tr.registerLogsConsumer(tr.logs))
+	return tr
+}
+
+// run starts the receiver and waits for the end of the replay.
+func (tr *testReceiver) run(t *testing.T) {
+	require.NoError(t, tr.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		return tr.logged.FilterMessage("Finished replaying the files").Len() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, tr.Shutdown(context.Background()))
+}
+
+func TestReplayFast(t *testing.T) {
+	for _, format := range []otlpfile.Format{otlpfile.FormatJSON, otlpfile.FormatProto} {
+		t.Run(string(format), func(t *testing.T) {
+			dir := t.TempDir()
+			first := filepath.Join(dir, "capture-1.gz")
+			second := filepath.Join(dir, "capture-2")
+			writeFile(t, second, format, "", func(w *otlpfile.Writer) {
+				require.NoError(t, w.WriteLogs(testdata.GenerateLogs(5)))
+			})
+			writeFile(t, first, format, configcompression.Gzip, func(w *otlpfile.Writer) {
+				require.NoError(t, w.WriteTraces(testdata.GenerateTraces(2)))
+				require.NoError(t, w.WriteMetrics(testdata.GenerateMetrics(3)))
+				require.NoError(t, w.WriteLogs(testdata.GenerateLogs(4)))
+			})
+			// The files are replayed in the order they were last modified.
+			now := time.Now()
+			require.NoError(t, os.Chtimes(first, now, now.Add(-time.Minute)))
+			require.NoError(t, os.Chtimes(second, now, now))
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Include = []string{filepath.Join(dir, "capture-*"), first, dir}
+			cfg.Format = string(format)
+			tr := newTestReceiver(t, cfg)
+			tr.run(t)
+
+			require.Len(t, tr.traces.AllTraces(), 1)
+			assert.Equal(t, testdata.GenerateTraces(2), tr.traces.AllTraces()[0])
+			require.Len(t, tr.metrics.AllMetrics(), 1)
+			assert.Equal(t, testdata.GenerateMetrics(3), tr.metrics.AllMetrics()[0])
+			require.Len(t, tr.logs.AllLogs(), 2)
+			assert.Equal(t, 4, tr.logs.AllLogs()[0].LogRecordCount())
+			assert.Equal(t, 5, tr.logs.AllLogs()[1].LogRecordCount())
+		})
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a-invalid.jsonl"), []byte("not json\n"), 0600))
+	writeFile(t, filepath.Join(dir, "b-valid.jsonl"), otlpfile.FormatJSON, "", func(w *otlpfile.Writer) {
+		require.NoError(t, w.WriteLogs(testdata.GenerateLogs(1)))
+		require.NoError(t, w.WriteLogs(testdata.GenerateLogs(2)))
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(dir, "*.jsonl")}
+	tr := newTestReceiver(t, cfg)
+	// The errors of the consumers do not stop the replay.
+	tr.logsConsumer = consumertest.NewErr(errors.New("unavailable"))
+	tr.run(t)
+
+	failed := tr.logged.FilterMessage("Failed to replay file")
+	require.Equal(t, 1, failed.Len())
+	assert.Equal(t, filepath.Join(dir, "a-invalid.jsonl"), failed.All()[0].ContextMap()["path"])
+}
+
+func TestReplayNoFiles(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(t.TempDir(), "*.jsonl")}
+	tr := newTestReceiver(t, cfg)
+	require.NoError(t, tr.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, tr.Shutdown(context.Background()))
+	assert.Equal(t, 1, tr.logged.FilterMessage("No file to replay matches the include patterns").Len())
+}
+
+func logsAt(ts time.Time) plog.Logs {
+	ld := testdata.GenerateLogs(1)
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	return ld
+}
+
+func TestReplayOriginalTiming(t *testing.T) {
+	start := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "capture.jsonl")
+	writeFile(t, path, otlpfile.FormatJSON, "", func(w *otlpfile.Writer) {
+		require.NoError(t, w.WriteLogs(logsAt(start)))
+		require.NoError(t, w.WriteLogs(logsAt(start.Add(time.Hour))))
+		// Records older than the previous ones are replayed immediately.
+		require.NoError(t, w.WriteLogs(logsAt(start.Add(-time.Hour))))
+		require.NoError(t, w.WriteLogs(logsAt(start.Add(2*time.Hour))))
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{path}
+	cfg.Timing = timingOriginal
+	tr := newTestReceiver(t, cfg)
+	var mu sync.Mutex
+	var waits []time.Duration
+	tr.sleep = func(_ context.Context, d time.Duration) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		waits = append(waits, d)
+		return true
+	}
+	tr.run(t)
+
+	assert.Len(t, tr.logs.AllLogs(), 4)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, waits, 2)
+	assert.InDelta(t, time.Hour, waits[0], float64(time.Second))
+	assert.InDelta(t, 2*time.Hour, waits[1], float64(time.Second))
+}
+
+func TestReplayShutdownWhileWaiting(t *testing.T) {
+	start := time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "capture.jsonl")
+	writeFile(t, path, otlpfile.FormatJSON, "", func(w *otlpfile.Writer) {
+		require.NoError(t, w.WriteLogs(logsAt(start)))
+		require.NoError(t, w.WriteLogs(logsAt(start.Add(time.Hour))))
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{path}
+	cfg.Timing = timingOriginal
+	tr := newTestReceiver(t, cfg)
+	require.NoError(t, tr.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool { return len(tr.logs.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, tr.Shutdown(context.Background()))
+	assert.Len(t, tr.logs.AllLogs(), 1)
+	assert.Equal(t, 0, tr.logged.FilterMessage("Finished replaying the files").Len())
+}
@@ -16,8 +16,10 @@ package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlprecei
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/confmap"
@@ -51,9 +53,32 @@ func (cfg *Config) Validate() error {
 	if cfg.GRPC == nil && cfg.HTTP == nil {
 		return errors.New("must specify at least one protocol when using the OTLP receiver")
 	}
+	if cfg.GRPC != nil {
+		if err := cfg.GRPC.UnixSocket.Validate(); err != nil {
+			return fmt.Errorf("grpc: %w", err)
+		}
+		if err := validateAuthorization(cfg.GRPC.Auth); err != nil {
+			return fmt.Errorf("grpc: %w", err)
+		}
+	}
+	if cfg.HTTP != nil {
+		if err := cfg.HTTP.UnixSocket.Validate(); err != nil {
+			return fmt.Errorf("http: %w", err)
+		}
+		if err := validateAuthorization(cfg.HTTP.Auth); err != nil {
+			return fmt.Errorf("http: %w", err)
+		}
+	}
 	return nil
 }
 
+func validateAuthorization(auth *configauth.Authentication) error {
+	if auth == nil || auth.Authorization == nil {
+		return nil
+	}
+	return auth.Authorization.Validate()
+}
+
 // Unmarshal a confmap.Conf into the config struct.
 func (cfg *Config) Unmarshal(conf *confmap.Conf) error {
 	// first load the config normally
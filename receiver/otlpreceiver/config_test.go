@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -170,6 +171,49 @@ func TestUnmarshalConfigUnix(t *testing.T) {
 		}, cfg)
 }
 
+func TestUnmarshalConfigAuthorization(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "auth.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NoError(t, config.UnmarshalReceiver(cm, cfg))
+	assert.Equal(t,
+		&configauth.Authentication{
+			AuthenticatorID: config.NewComponentID("basicauth"),
+			Authorization: &configauth.Authorization{
+				Rules: []configauth.AuthorizationRule{
+					{
+						Principals: []string{"blocked-team"},
+						Action:     configauth.ActionDeny,
+					},
+					{
+						Principals: []string{"logs-team"},
+						Signals:    []config.DataType{config.LogsDataType},
+					},
+					{
+						Attributes: map[string][]string{"membership": {"admins"}},
+					},
+				},
+			},
+		}, cfg.(*Config).GRPC.Auth)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidateInvalidAuthorization(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.GRPC.Auth = &configauth.Authentication{
+		AuthenticatorID: config.NewComponentID("basicauth"),
+		Authorization:   &configauth.Authorization{},
+	}
+	assert.EqualError(t, cfg.Validate(), "grpc: authorization requires at least one rule")
+}
+
+func TestValidateInvalidUnixSocketPermissions(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTP.UnixSocket.Permissions = "0999"
+	assert.EqualError(t, cfg.Validate(), `http: invalid unix socket permissions "0999", must be an octal file mode such as "0660"`)
+}
+
 func TestUnmarshalConfigTypoDefaultProtocol(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "typo_default_proto_config.yaml"))
 	require.NoError(t, err)
@@ -40,12 +40,14 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
 	"go.opentelemetry.io/collector/internal/testutil"
@@ -592,7 +594,7 @@ func TestGRPCNewPortAlreadyUsed(t *testing.T) {
 		assert.NoError(t, ln.Close())
 	})
 
-	r := newGRPCReceiver(t, otlpReceiverName, addr, consumertest.NewNop(), consumertest.NewNop())
+	r := newGRPCReceiver(t, componenttest.NewNopReceiverCreateSettings(), otlpReceiverName, addr, consumertest.NewNop(), consumertest.NewNop())
 	require.NotNil(t, r)
 
 	require.Error(t, r.Start(context.Background(), componenttest.NewNopHost()))
@@ -673,7 +675,7 @@ func TestOTLPReceiverTrace_HandleNextConsumerResponse(t *testing.T) {
 
 				sink := &errOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
 
-				ocr := newGRPCReceiver(t, exporter.receiverTag, addr, sink, nil)
+				ocr := newGRPCReceiver(t, tt.ToReceiverCreateSettings(), exporter.receiverTag, addr, sink, nil)
 				require.NotNil(t, ocr)
 				require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()))
 				t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
@@ -840,6 +842,29 @@ func testHTTPMaxRequestBodySizeJSON(t *testing.T, payload []byte, size int, expe
 	require.NoError(t, err)
 }
 
+func TestHTTPPermissionDenied(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	tSink := &errOrSinkConsumer{TracesSink: new(consumertest.TracesSink)}
+	ocr := newHTTPReceiver(t, addr, tSink, consumertest.NewNop())
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	t.Cleanup(func() { require.NoError(t, ocr.Shutdown(context.Background())) })
+
+	// The pipelines refuse the requests their authorization rules don't allow.
+	tSink.SetConsumeError(consumererror.NewPermanent(configauth.ErrPermissionDenied))
+	traceBytes, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(testdata.GenerateTraces(1))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(createHTTPProtobufRequest(t, fmt.Sprintf("http://%s/v1/traces", addr), "", traceBytes))
+	require.NoError(t, err)
+	respBytes, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	errStatus := &spb.Status{}
+	require.NoError(t, proto.Unmarshal(respBytes, errStatus))
+	assert.Equal(t, int32(codes.PermissionDenied), errStatus.Code)
+}
+
 func TestHTTPMaxRequestBodySize_OK(t *testing.T) {
 	testHTTPMaxRequestBodySizeJSON(t, traceJSON, len(traceJSON), 200)
 }
@@ -848,13 +873,13 @@ func TestHTTPMaxRequestBodySize_TooLarge(t *testing.T) {
 	testHTTPMaxRequestBodySizeJSON(t, traceJSON, len(traceJSON)-1, 400)
 }
 
-func newGRPCReceiver(t *testing.T, name string, endpoint string, tc consumer.Traces, mc consumer.Metrics) component.Component {
+func newGRPCReceiver(t *testing.T, set component.ReceiverCreateSettings, name string, endpoint string, tc consumer.Traces, mc consumer.Metrics) component.Component {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
 	cfg.SetIDName(name)
 	cfg.GRPC.NetAddr.Endpoint = endpoint
 	cfg.HTTP = nil
-	return newReceiver(t, factory, cfg, tc, mc)
+	return newReceiverWithSettings(t, set, factory, cfg, tc, mc)
 }
 
 func newHTTPReceiver(t *testing.T, endpoint string, tc consumer.Traces, mc consumer.Metrics) component.Component {
@@ -869,6 +894,10 @@ func newHTTPReceiver(t *testing.T, endpoint string, tc consumer.Traces, mc consu
 func newReceiver(t *testing.T, factory component.ReceiverFactory, cfg *Config, tc consumer.Traces, mc consumer.Metrics) component.Component {
 	set := componenttest.NewNopReceiverCreateSettings()
 	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelNormal
+	return newReceiverWithSettings(t, set, factory, cfg, tc, mc)
+}
+
+func newReceiverWithSettings(t *testing.T, set component.ReceiverCreateSettings, factory component.ReceiverFactory, cfg *Config, tc consumer.Traces, mc consumer.Metrics) component.Component {
 	var r component.Component
 	var err error
 	if tc != nil {
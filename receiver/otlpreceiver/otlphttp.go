@@ -15,6 +15,7 @@
 package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
 
 import (
+	"errors"
 	"io"
 	"net/http"
 
@@ -22,6 +23,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/logs"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
@@ -46,7 +48,7 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 
 	otlpResp, err := tracesReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusInternalServerError)
+		writeError(resp, encoder, err, exportErrorStatusCode(err))
 		return
 	}
 
@@ -72,7 +74,7 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 
 	otlpResp, err := metricsReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusInternalServerError)
+		writeError(resp, encoder, err, exportErrorStatusCode(err))
 		return
 	}
 
@@ -98,7 +100,7 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 
 	otlpResp, err := logsReceiver.Export(req.Context(), otlpReq)
 	if err != nil {
-		writeError(resp, encoder, err, http.StatusInternalServerError)
+		writeError(resp, encoder, err, exportErrorStatusCode(err))
 		return
 	}
 
@@ -110,6 +112,15 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 	writeResponse(resp, encoder.contentType(), http.StatusOK, msg)
 }
 
+// exportErrorStatusCode returns the HTTP status code of the errors returned by the pipelines: Forbidden when the
+// authorization rules allow the request in none of them, Internal Server Error otherwise.
+func exportErrorStatusCode(err error) int {
+	if errors.Is(err, configauth.ErrPermissionDenied) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
 func readAndCloseBody(resp http.ResponseWriter, req *http.Request, encoder encoder) ([]byte, bool) {
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
@@ -166,8 +177,11 @@ func writeResponse(w http.ResponseWriter, contentType string, statusCode int, ms
 }
 
 func errorMsgToStatus(errMsg string, statusCode int) *status.Status {
-	if statusCode == http.StatusBadRequest {
+	switch statusCode {
+	case http.StatusBadRequest:
 		return status.New(codes.InvalidArgument, errMsg)
+	case http.StatusForbidden:
+		return status.New(codes.PermissionDenied, errMsg)
 	}
 	return status.New(codes.Unknown, errMsg)
 }
@@ -142,6 +142,7 @@ func (col *Collector) setupConfigurationComponents(ctx context.Context) error {
 		Config:            cfg,
 		AsyncErrorChannel: col.asyncErrorChannel,
 		LoggingOptions:    col.set.LoggingOptions,
+		CollectorState:    col.GetState,
 		telemetry:         col.set.telemetry,
 	})
 	if err != nil {
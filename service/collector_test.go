@@ -18,6 +18,7 @@ package service
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"path/filepath"
@@ -615,6 +616,10 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/featurez",
+		"/debug/componentz",
+		"/debug/queuez",
+		"/debug/graphz",
 	}
 
 	testZPagePathFn := func(t *testing.T, path string) {
@@ -630,6 +635,30 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 	for _, path := range paths {
 		testZPagePathFn(t, path)
 	}
+
+	dotResp, err := http.Get("http://" + zpagesAddr + "/debug/graphz?format=dot")
+	require.NoError(t, err)
+	assert.Equal(t, "text/vnd.graphviz; charset=utf-8", dotResp.Header.Get("Content-Type"))
+	assert.NoError(t, dotResp.Body.Close())
+
+	resp, err := http.Get("http://" + zpagesAddr + "/debug/componentz?format=json")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var components struct {
+		Components []struct {
+			Kind      string     `json:"kind"`
+			FullName  string     `json:"full_name"`
+			Status    string     `json:"status"`
+			StartTime *time.Time `json:"start_time"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&components))
+	require.NotEmpty(t, components.Components)
+	for _, c := range components.Components {
+		assert.Equal(t, "OK", c.Status, "%s %s", c.Kind, c.FullName)
+		assert.NotNil(t, c.StartTime, "%s %s", c.Kind, c.FullName)
+	}
 }
 
 func startCollector(ctx context.Context, t *testing.T, col *Collector) *sync.WaitGroup {
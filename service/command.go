@@ -15,7 +15,10 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"encoding/json"
 	"errors"
+	"flag"
+	"fmt"
 
 	"github.com/spf13/cobra"
 
@@ -33,18 +36,8 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 			if err := featuregate.GetRegistry().Apply(getFeatureGatesFlag(flagSet)); err != nil {
 				return err
 			}
-			if set.ConfigProvider == nil {
-				var err error
-
-				configFlags := getConfigFlag(flagSet)
-				if len(configFlags) == 0 {
-					return errors.New("at least one config flag must be provided")
-				}
-
-				set.ConfigProvider, err = NewConfigProvider(newDefaultConfigProviderSettings(configFlags))
-				if err != nil {
-					return err
-				}
+			if err := setConfigProvider(&set, flagSet); err != nil {
+				return err
 			}
 			col, err := New(set)
 			if err != nil {
@@ -55,5 +48,68 @@ func NewCommand(set CollectorSettings) *cobra.Command {
 	}
 
 	rootCmd.Flags().AddGoFlagSet(flagSet)
+	rootCmd.AddCommand(newGraphCommand(set))
 	return rootCmd
 }
+
+const (
+	graphFormatDOT  = "dot"
+	graphFormatJSON = "json"
+)
+
+// newGraphCommand constructs the command printing the graph of the component instances of the pipelines.
+func newGraphCommand(set CollectorSettings) *cobra.Command {
+	flagSet := flags()
+	var format string
+	graphCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Prints the graph of the components of the pipelines, in Graphviz DOT or JSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != graphFormatDOT && format != graphFormatJSON {
+				return fmt.Errorf("unsupported format %q, must be %q or %q", format, graphFormatDOT, graphFormatJSON)
+			}
+			if err := featuregate.GetRegistry().Apply(getFeatureGatesFlag(flagSet)); err != nil {
+				return err
+			}
+			if err := setConfigProvider(&set, flagSet); err != nil {
+				return err
+			}
+			cfg, err := set.ConfigProvider.Get(cmd.Context(), set.Factories)
+			if err != nil {
+				return fmt.Errorf("failed to get config: %w", err)
+			}
+			if err = cfg.Validate(); err != nil {
+				return fmt.Errorf("invalid configuration: %w", err)
+			}
+			graph, err := BuildGraph(cfg, set.Factories)
+			if err != nil {
+				return err
+			}
+
+			if format == graphFormatDOT {
+				return graph.WriteDOT(cmd.OutOrStdout())
+			}
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(graph)
+		},
+	}
+	graphCmd.Flags().AddGoFlagSet(flagSet)
+	graphCmd.Flags().StringVar(&format, "format", graphFormatDOT, "The format of the graph, \"dot\" or \"json\".")
+	return graphCmd
+}
+
+// setConfigProvider creates the config provider from the config flags, unless the settings have one.
+func setConfigProvider(set *CollectorSettings, flagSet *flag.FlagSet) error {
+	if set.ConfigProvider != nil {
+		return nil
+	}
+	configFlags := getConfigFlag(flagSet)
+	if len(configFlags) == 0 {
+		return errors.New("at least one config flag must be provided")
+	}
+	var err error
+	set.ConfigProvider, err = NewConfigProvider(newDefaultConfigProviderSettings(configFlags))
+	return err
+}
@@ -15,7 +15,10 @@
 package service
 
 import (
+	"bytes"
+	"encoding/json"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +51,44 @@ func TestNewCommandInvalidComponent(t *testing.T) {
 	cmd := NewCommand(CollectorSettings{Factories: factories, ConfigProvider: cfgProvider})
 	require.Error(t, cmd.Execute())
 }
+
+func TestNewCommandGraph(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	configFlag := "--config=" + filepath.Join("testdata", "otelcol-connectors.yaml")
+
+	cmd := NewCommand(CollectorSettings{Factories: factories})
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"graph", configFlag})
+	require.NoError(t, cmd.Execute())
+	assert.True(t, strings.HasPrefix(out.String(), "digraph pipelines {"))
+	assert.Contains(t, out.String(), `"receiver:nop:traces" -> "connector:nop/conn:traces:metrics" [label="traces"];`)
+
+	cmd = NewCommand(CollectorSettings{Factories: factories})
+	out.Reset()
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{"graph", configFlag, "--format=json"})
+	require.NoError(t, cmd.Execute())
+	var graph Graph
+	require.NoError(t, json.Unmarshal(out.Bytes(), &graph))
+	assert.Len(t, graph.Nodes, 4)
+	assert.Len(t, graph.Edges, 3)
+}
+
+func TestNewCommandGraphErrors(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	cmd := NewCommand(CollectorSettings{Factories: factories})
+	cmd.SetArgs([]string{"graph", "--config=" + filepath.Join("testdata", "otelcol-connectors.yaml"), "--format=svg"})
+	assert.EqualError(t, cmd.Execute(), `unsupported format "svg", must be "dot" or "json"`)
+
+	cmd = NewCommand(CollectorSettings{Factories: factories})
+	cmd.SetArgs([]string{"graph"})
+	assert.EqualError(t, cmd.Execute(), "at least one config flag must be provided")
+
+	cmd = NewCommand(CollectorSettings{Factories: factories})
+	cmd.SetArgs([]string{"graph", "--config=" + filepath.Join("testdata", "otelcol-invalid.yaml")})
+	assert.Error(t, cmd.Execute())
+}
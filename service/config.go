@@ -42,6 +42,9 @@ type Config struct {
 	// Extensions is a map of ComponentID to extensions.
 	Extensions map[config.ComponentID]config.Extension
 
+	// Connectors is a map of ComponentID to connectors.
+	Connectors map[config.ComponentID]config.Connector
+
 	Service ConfigService
 }
 
@@ -91,6 +94,20 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	// Validate the connector configuration.
+	for connID, connCfg := range cfg.Connectors {
+		if err := connCfg.Validate(); err != nil {
+			return fmt.Errorf("connector %q has invalid configuration: %w", connID, err)
+		}
+		// The connectors are referenced as receivers and exporters in the pipelines.
+		if _, ok := cfg.Receivers[connID]; ok {
+			return fmt.Errorf("connector %q has the same ID as a receiver, rename one of them", connID)
+		}
+		if _, ok := cfg.Exporters[connID]; ok {
+			return fmt.Errorf("connector %q has the same ID as an exporter, rename one of them", connID)
+		}
+	}
+
 	return cfg.validateService()
 }
 
@@ -115,15 +132,15 @@ func (cfg *Config) validateService() error {
 			return fmt.Errorf("unknown pipeline datatype %q for %v", pipelineID.Type(), pipelineID)
 		}
 
-		// Validate pipeline has at least one receiver.
-		if len(pipeline.Receivers) == 0 {
+		// Validate pipeline has at least one receiver, unless the collector sends its own logs through it.
+		if len(pipeline.Receivers) == 0 && !isLogsExportPipeline(cfg, pipelineID) {
 			return fmt.Errorf("pipeline %q must have at least one receiver", pipelineID)
 		}
 
 		// Validate pipeline receiver name references.
 		for _, ref := range pipeline.Receivers {
-			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers.
-			if cfg.Receivers[ref] == nil {
+			// Check that the name referenced in the pipeline's receivers exists in the top-level receivers or connectors.
+			if cfg.Receivers[ref] == nil && cfg.Connectors[ref] == nil {
 				return fmt.Errorf("pipeline %q references receiver %q which does not exist", pipelineID, ref)
 			}
 		}
@@ -143,15 +160,167 @@ func (cfg *Config) validateService() error {
 
 		// Validate pipeline exporter name references.
 		for _, ref := range pipeline.Exporters {
-			// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters.
-			if cfg.Exporters[ref] == nil {
+			// Check that the name referenced in the pipeline's Exporters exists in the top-level Exporters or Connectors.
+			if cfg.Exporters[ref] == nil && cfg.Connectors[ref] == nil {
 				return fmt.Errorf("pipeline %q references exporter %q which does not exist", pipelineID, ref)
 			}
 		}
+
+		if pipeline.Routing != nil {
+			if err := validatePipelineRouting(pipeline); err != nil {
+				return fmt.Errorf("pipeline %q routing: %w", pipelineID, err)
+			}
+		}
+
+		if pipeline.Fanout != nil {
+			if err := validatePipelineFanout(pipeline); err != nil {
+				return fmt.Errorf("pipeline %q fanout: %w", pipelineID, err)
+			}
+		}
+	}
+
+	if err := cfg.validateConnectorsUse(); err != nil {
+		return err
+	}
+
+	if err := cfg.Service.Telemetry.Validate(); err != nil {
+		return fmt.Errorf("service telemetry: %w", err)
+	}
+
+	// Validate the logs export references a logs pipeline.
+	if exportCfg := cfg.Service.Telemetry.Logs.Export; exportCfg != nil && exportCfg.OTLP == nil {
+		if exportCfg.Pipeline.Type() != config.LogsDataType {
+			return fmt.Errorf("service telemetry: logs export: pipeline %q is not a logs pipeline", exportCfg.Pipeline)
+		}
+		if cfg.Service.Pipelines[exportCfg.Pipeline] == nil {
+			return fmt.Errorf("service telemetry: logs export: references pipeline %q which does not exist", exportCfg.Pipeline)
+		}
 	}
 	return nil
 }
 
+// validatePipelineRouting checks that the routes and the default route reference the exporters of
+// the pipeline, and that each of the exporters receives the data of at least one of them.
+func validatePipelineRouting(pipeline *ConfigServicePipeline) error {
+	routed := make(map[config.ComponentID]bool, len(pipeline.Exporters))
+	checkExporters := func(exporters []config.ComponentID) error {
+		for _, ref := range exporters {
+			if !containsID(pipeline.Exporters, ref) {
+				return fmt.Errorf("references exporter %q which is not an exporter of the pipeline", ref)
+			}
+			routed[ref] = true
+		}
+		return nil
+	}
+
+	for i, route := range pipeline.Routing.Routes {
+		if len(route.ResourceAttributes) == 0 && len(route.Metadata) == 0 {
+			return fmt.Errorf("route %d must match at least one resource attribute or metadata", i)
+		}
+		if len(route.Exporters) == 0 {
+			return fmt.Errorf("route %d must have at least one exporter", i)
+		}
+		if err := checkExporters(route.Exporters); err != nil {
+			return fmt.Errorf("route %d %w", i, err)
+		}
+	}
+	if len(pipeline.Routing.DefaultExporters) == 0 {
+		return errors.New("must have at least one default exporter")
+	}
+	if err := checkExporters(pipeline.Routing.DefaultExporters); err != nil {
+		return fmt.Errorf("default route %w", err)
+	}
+
+	for _, ref := range pipeline.Exporters {
+		if !routed[ref] {
+			return fmt.Errorf("exporter %q is not used by any route", ref)
+		}
+	}
+	return nil
+}
+
+// validatePipelineFanout checks that the quorum and the primary exporters are only set with the asynchronous
+// fanout, and that they can be reached with the exporters of the pipeline, or of each route with routing.
+func validatePipelineFanout(pipeline *ConfigServicePipeline) error {
+	fanout := pipeline.Fanout
+	if !fanout.Async && (fanout.Quorum != 0 || len(fanout.Primary) != 0) {
+		return errors.New("quorum and primary require async")
+	}
+	if fanout.Quorum < 0 {
+		return fmt.Errorf("quorum must be between 0 and the number of exporters, got %d", fanout.Quorum)
+	}
+	if pipeline.Routing == nil {
+		if n := countDistinct(pipeline.Exporters); fanout.Quorum > n {
+			return fmt.Errorf("quorum must be between 0 and the number of exporters %d, got %d", n, fanout.Quorum)
+		}
+	} else {
+		for i, route := range pipeline.Routing.Routes {
+			if n := countDistinct(route.Exporters); fanout.Quorum > n {
+				return fmt.Errorf("quorum must be between 0 and the number of exporters %d of route %d, got %d", n, i, fanout.Quorum)
+			}
+		}
+		if n := countDistinct(pipeline.Routing.DefaultExporters); fanout.Quorum > n {
+			return fmt.Errorf("quorum must be between 0 and the number of exporters %d of the default route, got %d", n, fanout.Quorum)
+		}
+	}
+	for _, ref := range fanout.Primary {
+		if !containsID(pipeline.Exporters, ref) {
+			return fmt.Errorf("primary references exporter %q which is not an exporter of the pipeline", ref)
+		}
+	}
+	return nil
+}
+
+// countDistinct returns the number of distinct IDs.
+func countDistinct(ids []config.ComponentID) int {
+	var distinct []config.ComponentID
+	for _, id := range ids {
+		if !containsID(distinct, id) {
+			distinct = append(distinct, id)
+		}
+	}
+	return len(distinct)
+}
+
+func containsID(ids []config.ComponentID, id config.ComponentID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// validateConnectorsUse checks that the connectors used in the pipelines are used both as an exporter,
+// to consume the data of a pipeline, and as a receiver, to emit data to another pipeline.
+func (cfg *Config) validateConnectorsUse() error {
+	asExporter := make(map[config.ComponentID]bool)
+	asReceiver := make(map[config.ComponentID]bool)
+	for _, pipeline := range cfg.Service.Pipelines {
+		for _, ref := range pipeline.Exporters {
+			asExporter[ref] = true
+		}
+		for _, ref := range pipeline.Receivers {
+			asReceiver[ref] = true
+		}
+	}
+	for connID := range cfg.Connectors {
+		if asExporter[connID] && !asReceiver[connID] {
+			return fmt.Errorf("connector %q is used as an exporter but not as a receiver in any pipeline", connID)
+		}
+		if asReceiver[connID] && !asExporter[connID] {
+			return fmt.Errorf("connector %q is used as a receiver but not as an exporter in any pipeline", connID)
+		}
+	}
+	return nil
+}
+
+// isLogsExportPipeline reports whether the collector sends its own logs through the pipeline.
+func isLogsExportPipeline(cfg *Config, pipelineID config.ComponentID) bool {
+	exportCfg := cfg.Service.Telemetry.Logs.Export
+	return exportCfg != nil && exportCfg.OTLP == nil && exportCfg.Pipeline == pipelineID
+}
+
 // ConfigService defines the configurable components of the service.
 type ConfigService struct {
 	// Telemetry is the configuration for collector's own telemetry.
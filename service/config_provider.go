@@ -114,6 +114,7 @@ func (cm *configProvider) Get(ctx context.Context, factories component.Factories
 		Processors: cfg.Processors.GetProcessors(),
 		Exporters:  cfg.Exporters.GetExporters(),
 		Extensions: cfg.Extensions.GetExtensions(),
+		Connectors: cfg.Connectors.GetConnectors(),
 		Service:    cfg.Service,
 	}, nil
 }
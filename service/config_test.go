@@ -32,6 +32,7 @@ var (
 	errInvalidExpConfig  = errors.New("invalid exporter config")
 	errInvalidProcConfig = errors.New("invalid processor config")
 	errInvalidExtConfig  = errors.New("invalid extension config")
+	errInvalidConnConfig = errors.New("invalid connector config")
 )
 
 type nopRecvConfig struct {
@@ -70,6 +71,15 @@ func (nc *nopExtConfig) Validate() error {
 	return nc.validateErr
 }
 
+type nopConnConfig struct {
+	config.ConnectorSettings
+	validateErr error
+}
+
+func (nc *nopConnConfig) Validate() error {
+	return nc.validateErr
+}
+
 func TestConfigValidate(t *testing.T) {
 	var testCases = []struct {
 		name     string // test case name (also file name containing config yaml)
@@ -224,6 +234,156 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: fmt.Errorf(`extension "nop" has invalid configuration: %w`, errInvalidExtConfig),
 		},
+		{
+			name:     "valid-connector",
+			cfgFn:    generateConfigWithConnector,
+			expected: nil,
+		},
+		{
+			name: "invalid-connector-config",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithConnector()
+				cfg.Connectors[config.NewComponentID("conn")] = &nopConnConfig{
+					ConnectorSettings: config.NewConnectorSettings(config.NewComponentID("conn")),
+					validateErr:       errInvalidConnConfig,
+				}
+				return cfg
+			},
+			expected: fmt.Errorf(`connector "conn" has invalid configuration: %w`, errInvalidConnConfig),
+		},
+		{
+			name: "ambiguous-connector-id",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithConnector()
+				cfg.Receivers[config.NewComponentID("conn")] = &nopRecvConfig{
+					ReceiverSettings: config.NewReceiverSettings(config.NewComponentID("conn")),
+				}
+				return cfg
+			},
+			expected: errors.New(`connector "conn" has the same ID as a receiver, rename one of them`),
+		},
+		{
+			name: "connector-not-used-as-receiver",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithConnector()
+				delete(cfg.Service.Pipelines, config.NewComponentID("metrics"))
+				return cfg
+			},
+			expected: errors.New(`connector "conn" is used as an exporter but not as a receiver in any pipeline`),
+		},
+		{
+			name: "connector-not-used-as-exporter",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithConnector()
+				pipe := cfg.Service.Pipelines[config.NewComponentID("traces")]
+				pipe.Exporters = []config.ComponentID{config.NewComponentID("nop")}
+				return cfg
+			},
+			expected: errors.New(`connector "conn" is used as a receiver but not as an exporter in any pipeline`),
+		},
+		{
+			name:     "valid-pipeline-routing",
+			cfgFn:    generateConfigWithRouting,
+			expected: nil,
+		},
+		{
+			name: "pipeline-routing-unknown-exporter",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithRouting()
+				route := &cfg.Service.Pipelines[config.NewComponentID("traces")].Routing.Routes[0]
+				route.Exporters = []config.ComponentID{config.NewComponentIDWithName("nop", "c")}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" routing: %w`, fmt.Errorf("route 0 %w", errors.New(`references exporter "nop/c" which is not an exporter of the pipeline`))),
+		},
+		{
+			name: "pipeline-routing-route-without-match",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithRouting()
+				cfg.Service.Pipelines[config.NewComponentID("traces")].Routing.Routes[0].ResourceAttributes = nil
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" routing: %w`, errors.New(`route 0 must match at least one resource attribute or metadata`)),
+		},
+		{
+			name: "pipeline-routing-route-without-exporters",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithRouting()
+				cfg.Service.Pipelines[config.NewComponentID("traces")].Routing.Routes[0].Exporters = nil
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" routing: %w`, errors.New(`route 0 must have at least one exporter`)),
+		},
+		{
+			name: "pipeline-routing-missing-default",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithRouting()
+				cfg.Service.Pipelines[config.NewComponentID("traces")].Routing.DefaultExporters = nil
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" routing: %w`, errors.New(`must have at least one default exporter`)),
+		},
+		{
+			name: "pipeline-routing-unused-exporter",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithRouting()
+				cfg.Service.Pipelines[config.NewComponentID("traces")].Routing.DefaultExporters = []config.ComponentID{config.NewComponentIDWithName("nop", "a")}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" routing: %w`, errors.New(`exporter "nop" is not used by any route`)),
+		},
+		{
+			name: "valid-pipeline-fanout",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithRouting()
+				cfg.Service.Pipelines[config.NewComponentID("traces")].Fanout = &config.PipelineFanout{
+					Async:   true,
+					Quorum:  1,
+					Primary: []config.ComponentID{config.NewComponentID("nop")},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "pipeline-fanout-quorum-without-async",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines[config.NewComponentID("traces")].Fanout = &config.PipelineFanout{Quorum: 1}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" fanout: %w`, errors.New("quorum and primary require async")),
+		},
+		{
+			name: "pipeline-fanout-invalid-quorum",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines[config.NewComponentID("traces")].Fanout = &config.PipelineFanout{Async: true, Quorum: 2}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" fanout: %w`, errors.New("quorum must be between 0 and the number of exporters 1, got 2")),
+		},
+		{
+			name: "pipeline-fanout-invalid-route-quorum",
+			cfgFn: func() *Config {
+				cfg := generateConfigWithRouting()
+				cfg.Service.Pipelines[config.NewComponentID("traces")].Fanout = &config.PipelineFanout{Async: true, Quorum: 2}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" fanout: %w`, errors.New("quorum must be between 0 and the number of exporters 1 of route 0, got 2")),
+		},
+		{
+			name: "pipeline-fanout-unknown-primary",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines[config.NewComponentID("traces")].Fanout = &config.PipelineFanout{
+					Async:   true,
+					Primary: []config.ComponentID{config.NewComponentIDWithName("nop", "a")},
+				}
+				return cfg
+			},
+			expected: fmt.Errorf(`pipeline "traces" fanout: %w`, errors.New(`primary references exporter "nop/a" which is not an exporter of the pipeline`)),
+		},
 		{
 			name: "invalid-service-pipeline-type",
 			cfgFn: func() *Config {
@@ -237,6 +397,198 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New(`unknown pipeline datatype "wrongtype" for wrongtype`),
 		},
+		{
+			name: "valid-telemetry-dimensions",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Dimensions = []telemetry.DimensionConfig{
+					{Name: "tenant", MetadataKey: "x-tenant", MaxCardinality: 100},
+					{Name: "user", AuthAttribute: "subject"},
+				}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-telemetry-dimension-source",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Dimensions = []telemetry.DimensionConfig{
+					{Name: "tenant", MetadataKey: "x-tenant", AuthAttribute: "subject"},
+				}
+				return cfg
+			},
+			expected: fmt.Errorf("service telemetry: %w", fmt.Errorf("dimension 0: %w", errors.New("exactly one of metadata_key or auth_attribute must be specified"))),
+		},
+		{
+			name: "reserved-telemetry-dimension",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Dimensions = []telemetry.DimensionConfig{
+					{Name: "receiver", MetadataKey: "x-tenant"},
+				}
+				return cfg
+			},
+			expected: fmt.Errorf("service telemetry: %w", fmt.Errorf("dimension 0: %w", errors.New(`name "receiver" is reserved`))),
+		},
+		{
+			name: "duplicate-telemetry-dimension",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Dimensions = []telemetry.DimensionConfig{
+					{Name: "tenant", MetadataKey: "x-tenant"},
+					{Name: "tenant", AuthAttribute: "subject"},
+				}
+				return cfg
+			},
+			expected: fmt.Errorf("service telemetry: %w", errors.New(`dimension 1: duplicate name "tenant"`)),
+		},
+		{
+			name: "valid-telemetry-resource-detectors",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.ResourceDetectors = []string{"host", "container", "k8s"}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-telemetry-resource-detector",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.ResourceDetectors = []string{"ec2"}
+				return cfg
+			},
+			expected: fmt.Errorf("service telemetry: %w", errors.New(`unknown resource detector "ec2", must be "host", "container" or "k8s"`)),
+		},
+		{
+			name: "valid-telemetry-readers-and-views",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Readers = []telemetry.MetricReaderConfig{{
+					Periodic: &telemetry.PeriodicMetricReaderConfig{
+						Exporter: telemetry.MetricExporterConfig{
+							OTLP: &telemetry.OTLPExporterConfig{Endpoint: "localhost:4317", Compression: "gzip"},
+						},
+					},
+				}}
+				cfg.Service.Telemetry.Metrics.Views = []telemetry.MetricViewConfig{{
+					Selector: telemetry.MetricViewSelectorConfig{InstrumentName: "processor/batch/batch_send_size"},
+					Stream: telemetry.MetricViewStreamConfig{
+						Aggregation: telemetry.AggregationExplicitBucketHistogram,
+						Boundaries:  []float64{10, 100, 1000},
+					},
+				}}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-telemetry-reader-protocol",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Readers = []telemetry.MetricReaderConfig{{
+					Periodic: &telemetry.PeriodicMetricReaderConfig{
+						Exporter: telemetry.MetricExporterConfig{
+							OTLP: &telemetry.OTLPExporterConfig{Protocol: "http/json", Endpoint: "localhost:4318"},
+						},
+					},
+				}}
+				return cfg
+			},
+			expected: fmt.Errorf("service telemetry: %w", fmt.Errorf("reader 0: %w", fmt.Errorf("exporter: %w",
+				errors.New(`unsupported protocol "http/json", must be "grpc" or "http/protobuf"`)))),
+		},
+		{
+			name: "invalid-telemetry-view-boundaries",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Metrics.Views = []telemetry.MetricViewConfig{{
+					Selector: telemetry.MetricViewSelectorConfig{MeterName: "go.opentelemetry.io/collector/processor/batchprocessor"},
+					Stream: telemetry.MetricViewStreamConfig{
+						Aggregation: telemetry.AggregationExplicitBucketHistogram,
+						Boundaries:  []float64{100, 10},
+					},
+				}}
+				return cfg
+			},
+			expected: fmt.Errorf("service telemetry: %w", fmt.Errorf("view 0: %w", errors.New("stream: boundaries must be in increasing order"))),
+		},
+		{
+			name: "valid-telemetry-logs-export-pipeline",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Pipelines[config.NewComponentIDWithName("logs", "self")] = &ConfigServicePipeline{
+					Exporters: []config.ComponentID{config.NewComponentID("nop")},
+				}
+				cfg.Service.Telemetry.Logs.Export = &telemetry.LogsExportConfig{Pipeline: config.NewComponentIDWithName("logs", "self")}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-telemetry-logs-export-pipeline",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Logs.Export = &telemetry.LogsExportConfig{Pipeline: config.NewComponentID("traces")}
+				return cfg
+			},
+			expected: errors.New(`service telemetry: logs export: pipeline "traces" is not a logs pipeline`),
+		},
+		{
+			name: "missing-telemetry-logs-export-pipeline",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Logs.Export = &telemetry.LogsExportConfig{Pipeline: config.NewComponentIDWithName("logs", "self")}
+				return cfg
+			},
+			expected: errors.New(`service telemetry: logs export: references pipeline "logs/self" which does not exist`),
+		},
+		{
+			name: "invalid-telemetry-logs-export",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Logs.Export = &telemetry.LogsExportConfig{}
+				return cfg
+			},
+			expected: fmt.Errorf("service telemetry: %w", fmt.Errorf("logs export: %w", errors.New("exactly one of pipeline or otlp must be specified"))),
+		},
+		{
+			name: "valid-telemetry-traces",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.Sampler = telemetry.TraceSamplerConfig{Type: telemetry.SamplerTraceIDRatio, Ratio: 0.1}
+				cfg.Service.Telemetry.Traces.Processors = []telemetry.SpanProcessorConfig{{
+					Batch: &telemetry.BatchSpanProcessorConfig{
+						Exporter: telemetry.SpanExporterConfig{
+							OTLP: &telemetry.OTLPExporterConfig{Protocol: telemetry.ProtocolGRPC, Endpoint: "localhost:4317"},
+						},
+					},
+				}}
+				return cfg
+			},
+			expected: nil,
+		},
+		{
+			name: "invalid-telemetry-traces-sampler-ratio",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.Sampler = telemetry.TraceSamplerConfig{Type: telemetry.SamplerTraceIDRatio, Ratio: 2}
+				return cfg
+			},
+			expected: fmt.Errorf("service telemetry: %w", fmt.Errorf("traces: %w", fmt.Errorf("sampler: %w",
+				errors.New("invalid ratio 2, must be between 0 and 1")))),
+		},
+		{
+			name: "invalid-telemetry-traces-processor-exporter",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Service.Telemetry.Traces.Processors = []telemetry.SpanProcessorConfig{{Batch: &telemetry.BatchSpanProcessorConfig{}}}
+				return cfg
+			},
+			expected: fmt.Errorf("service telemetry: %w", fmt.Errorf("traces: %w", fmt.Errorf("processor 0: %w", fmt.Errorf("exporter: %w",
+				errors.New("otlp must be specified"))))),
+		},
 	}
 
 	for _, test := range testCases {
@@ -297,3 +649,40 @@ func generateConfig() *Config {
 		},
 	}
 }
+
+// generateConfigWithRouting returns a valid config where the traces pipeline routes the data of
+// team "a" to the "nop/a" exporter, and the other data to the "nop" exporter.
+func generateConfigWithRouting() *Config {
+	cfg := generateConfig()
+	cfg.Exporters[config.NewComponentIDWithName("nop", "a")] = &nopExpConfig{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName("nop", "a")),
+	}
+	pipe := cfg.Service.Pipelines[config.NewComponentID("traces")]
+	pipe.Exporters = append(pipe.Exporters, config.NewComponentIDWithName("nop", "a"))
+	pipe.Routing = &config.PipelineRouting{
+		Routes: []config.PipelineRoute{{
+			ResourceAttributes: map[string]string{"team": "a"},
+			Exporters:          []config.ComponentID{config.NewComponentIDWithName("nop", "a")},
+		}},
+		DefaultExporters: []config.ComponentID{config.NewComponentID("nop")},
+	}
+	return cfg
+}
+
+// generateConfigWithConnector returns a valid config where the "conn" connector joins the traces
+// pipeline to a metrics pipeline.
+func generateConfigWithConnector() *Config {
+	cfg := generateConfig()
+	cfg.Connectors = map[config.ComponentID]config.Connector{
+		config.NewComponentID("conn"): &nopConnConfig{
+			ConnectorSettings: config.NewConnectorSettings(config.NewComponentID("conn")),
+		},
+	}
+	pipe := cfg.Service.Pipelines[config.NewComponentID("traces")]
+	pipe.Exporters = append(pipe.Exporters, config.NewComponentID("conn"))
+	cfg.Service.Pipelines[config.NewComponentID("metrics")] = &ConfigServicePipeline{
+		Receivers: []config.ComponentID{config.NewComponentID("conn")},
+		Exporters: []config.ComponentID{config.NewComponentID("nop")},
+	}
+	return cfg
+}
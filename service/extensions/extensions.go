@@ -33,8 +33,9 @@ const zExtensionName = "zextensionname"
 
 // Extensions is a map of extensions created from extension configs.
 type Extensions struct {
-	telemetry component.TelemetrySettings
-	extMap    map[config.ComponentID]component.Extension
+	telemetry      component.TelemetrySettings
+	statusReporter components.StatusReporter
+	extMap         map[config.ComponentID]component.Extension
 }
 
 // Start starts all extensions.
@@ -43,9 +44,13 @@ func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 	for extID, ext := range bes.extMap {
 		extLogger := extensionLogger(bes.telemetry.Logger, extID)
 		extLogger.Info("Extension is starting...")
-		if err := ext.Start(ctx, components.NewHostWrapper(host, extLogger)); err != nil {
+		source := &component.StatusSource{Kind: component.KindExtension, ID: extID}
+		components.ReportStatus(bes.statusReporter, source, component.StatusStarting, nil)
+		if err := ext.Start(ctx, components.NewHostWrapper(host, source, bes.statusReporter, extLogger)); err != nil {
+			components.ReportStatus(bes.statusReporter, source, component.StatusPermanentError, err)
 			return err
 		}
+		components.ReportStatus(bes.statusReporter, source, component.StatusOK, nil)
 		extLogger.Info("Extension started.")
 	}
 	return nil
@@ -55,8 +60,15 @@ func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 func (bes *Extensions) Shutdown(ctx context.Context) error {
 	bes.telemetry.Logger.Info("Stopping extensions...")
 	var errs error
-	for _, ext := range bes.extMap {
-		errs = multierr.Append(errs, ext.Shutdown(ctx))
+	for extID, ext := range bes.extMap {
+		source := &component.StatusSource{Kind: component.KindExtension, ID: extID}
+		components.ReportStatus(bes.statusReporter, source, component.StatusStopping, nil)
+		if err := ext.Shutdown(ctx); err != nil {
+			components.ReportStatus(bes.statusReporter, source, component.StatusPermanentError, err)
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		components.ReportStatus(bes.statusReporter, source, component.StatusStopped, nil)
 	}
 
 	return errs
@@ -84,6 +96,15 @@ func (bes *Extensions) NotifyPipelineNotReady() error {
 	return errs
 }
 
+// NotifyComponentStatusChanged notifies the extensions watching the statuses of the components.
+func (bes *Extensions) NotifyComponentStatusChanged(source *component.StatusSource, event *component.StatusEvent) {
+	for _, ext := range bes.extMap {
+		if sw, ok := ext.(component.StatusWatcher); ok {
+			sw.ComponentStatusChanged(source, event)
+		}
+	}
+}
+
 func (bes *Extensions) GetExtensions() map[config.ComponentID]component.Extension {
 	result := make(map[config.ComponentID]component.Extension, len(bes.extMap))
 	for extID, v := range bes.extMap {
@@ -95,21 +116,28 @@ func (bes *Extensions) GetExtensions() map[config.ComponentID]component.Extensio
 func (bes *Extensions) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	extensionName := r.URL.Query().Get(zExtensionName)
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Extensions"})
 	data := zpages.SummaryExtensionsTableData{}
 
 	data.Rows = make([]zpages.SummaryExtensionsTableRowData, 0, len(bes.extMap))
 	for id := range bes.extMap {
-		row := zpages.SummaryExtensionsTableRowData{FullName: id.String()}
+		row := zpages.SummaryExtensionsTableRowData{FullName: id.String(), Enabled: true}
 		data.Rows = append(data.Rows, row)
 	}
 
 	sort.Slice(data.Rows, func(i, j int) bool {
 		return data.Rows[i].FullName < data.Rows[j].FullName
 	})
-	zpages.WriteHTMLExtensionsSummaryTable(w, data)
 	if extensionName != "" {
+		data.Component = &zpages.SelectedComponentData{Kind: "extension", Name: extensionName}
+	}
+	if zpages.WantsJSON(r) {
+		zpages.WriteJSON(w, data)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Extensions"})
+	zpages.WriteHTMLExtensionsSummaryTable(w, data)
+	if data.Component != nil {
 		zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
 			Name: extensionName,
 		})
@@ -128,13 +156,17 @@ type Settings struct {
 
 	// Factories maps extension type names in the config to the respective component.ExtensionFactory.
 	Factories map[config.Type]component.ExtensionFactory
+
+	// StatusReporter receives the statuses of the extensions, it can be nil.
+	StatusReporter components.StatusReporter
 }
 
 // New creates a new Extensions from Config.
 func New(ctx context.Context, set Settings, cfg Config) (*Extensions, error) {
 	exts := &Extensions{
-		telemetry: set.Telemetry,
-		extMap:    make(map[config.ComponentID]component.Extension),
+		telemetry:      set.Telemetry,
+		statusReporter: set.StatusReporter,
+		extMap:         make(map[config.ComponentID]component.Extension),
 	}
 	for _, extID := range cfg {
 		extCfg, existsCfg := set.Configs[extID]
@@ -17,6 +17,8 @@ package extensions
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -105,6 +107,94 @@ func TestBuildExtensions(t *testing.T) {
 	}
 }
 
+type statusWatcherExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	statuses []component.Status
+}
+
+func (e *statusWatcherExtension) ComponentStatusChanged(source *component.StatusSource, event *component.StatusEvent) {
+	e.statuses = append(e.statuses, event.Status)
+}
+
+// extensionsReporter notifies the status watchers of the extensions, like the service does.
+type extensionsReporter struct {
+	exts *Extensions
+}
+
+func (r *extensionsReporter) ReportStatus(source *component.StatusSource, event *component.StatusEvent) {
+	r.exts.NotifyComponentStatusChanged(source, event)
+}
+
+func TestStatusWatcher(t *testing.T) {
+	ext := &statusWatcherExtension{}
+	factory := component.NewExtensionFactory(
+		"watcher",
+		func() config.Extension {
+			return &struct {
+				config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+			}{
+				ExtensionSettings: config.NewExtensionSettings(config.NewComponentID("watcher")),
+			}
+		},
+		func(ctx context.Context, set component.ExtensionCreateSettings, extension config.Extension) (component.Extension, error) {
+			return ext, nil
+		},
+		component.StabilityLevelInDevelopment,
+	)
+
+	reporter := &extensionsReporter{}
+	exts, err := New(context.Background(), Settings{
+		Telemetry:      componenttest.NewNopTelemetrySettings(),
+		BuildInfo:      component.NewDefaultBuildInfo(),
+		Configs:        map[config.ComponentID]config.Extension{config.NewComponentID("watcher"): factory.CreateDefaultConfig()},
+		Factories:      map[config.Type]component.ExtensionFactory{factory.Type(): factory},
+		StatusReporter: reporter,
+	}, []config.ComponentID{config.NewComponentID("watcher")})
+	require.NoError(t, err)
+	reporter.exts = exts
+
+	require.NoError(t, exts.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exts.Shutdown(context.Background()))
+	assert.Equal(t, []component.Status{
+		component.StatusStarting,
+		component.StatusOK,
+		component.StatusStopping,
+		component.StatusStopped,
+	}, ext.statuses)
+}
+
+func TestZPages(t *testing.T) {
+	factory := componenttest.NewNopExtensionFactory()
+	exts, err := New(context.Background(), Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Configs: map[config.ComponentID]config.Extension{
+			config.NewComponentID(factory.Type()):              factory.CreateDefaultConfig(),
+			config.NewComponentIDWithName(factory.Type(), "1"): factory.CreateDefaultConfig(),
+		},
+		Factories: map[config.Type]component.ExtensionFactory{factory.Type(): factory},
+	}, []config.ComponentID{config.NewComponentIDWithName(factory.Type(), "1"), config.NewComponentID(factory.Type())})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	exts.HandleZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/extensionz?format=json", nil))
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"extensions": [{"full_name": "nop", "enabled": true}, {"full_name": "nop/1", "enabled": true}]}`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	exts.HandleZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/extensionz?format=json&zextensionname=nop/1", nil))
+	assert.JSONEq(t, `{
+		"extensions": [{"full_name": "nop", "enabled": true}, {"full_name": "nop/1", "enabled": true}],
+		"component": {"kind": "extension", "name": "nop/1"}
+	}`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	exts.HandleZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/extensionz", nil))
+	assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), "nop/1")
+}
+
 func newBadExtensionFactory() component.ExtensionFactory {
 	return component.NewExtensionFactory(
 		"bf",
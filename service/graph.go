@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/pipelines"
+)
+
+// Graph is the topology of the component instances created for the pipelines, with the data
+// passed between them. It is encoded as JSON, or as Graphviz DOT with WriteDOT.
+type Graph = pipelines.Graph
+
+// GraphNode is a component instance of a Graph.
+type GraphNode = pipelines.GraphNode
+
+// GraphEdge is the data passed from a component instance to another one in a pipeline.
+type GraphEdge = pipelines.GraphEdge
+
+// BuildGraph returns the graph of the component instances the service creates for the pipelines
+// of the configuration, without creating them. The configuration must be valid.
+func BuildGraph(cfg *Config, factories component.Factories) (*Graph, error) {
+	return pipelines.BuildGraph(pipelines.Settings{
+		ReceiverFactories:  factories.Receivers,
+		ReceiverConfigs:    cfg.Receivers,
+		ProcessorFactories: factories.Processors,
+		ProcessorConfigs:   cfg.Processors,
+		ExporterFactories:  factories.Exporters,
+		ExporterConfigs:    cfg.Exporters,
+		ConnectorFactories: factories.Connectors,
+		ConnectorConfigs:   cfg.Connectors,
+		PipelineConfigs:    cfg.Service.Pipelines,
+	})
+}
@@ -17,16 +17,21 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/pipelines"
 )
 
 var _ component.Host = (*serviceHost)(nil)
+var _ components.StatusReporter = (*serviceHost)(nil)
 
 type serviceHost struct {
 	asyncErrorChannel chan error
 	factories         component.Factories
 	buildInfo         component.BuildInfo
+	collectorState    func() State
+	statuses          *components.Statuses
 
 	pipelines  *pipelines.Pipelines
 	extensions *extensions.Extensions
@@ -39,6 +44,43 @@ func (host *serviceHost) ReportFatalError(err error) {
 	host.asyncErrorChannel <- err
 }
 
+// ReportComponentStatus is not used, since the statuses are reported through the hosts passed
+// to the components, which identify them.
+func (host *serviceHost) ReportComponentStatus(component.Status, error) {}
+
+// ReportStatus records the statuses reported for the components, and notifies the extensions of them.
+func (host *serviceHost) ReportStatus(source *component.StatusSource, event *component.StatusEvent) {
+	if host.statuses != nil {
+		host.statuses.ReportStatus(source, event)
+	}
+	if host.extensions != nil {
+		host.extensions.NotifyComponentStatusChanged(source, event)
+	}
+}
+
+// GetCollectorState returns the state of the collector running the service, or an empty string if unknown.
+func (host *serviceHost) GetCollectorState() string {
+	if host.collectorState == nil {
+		return ""
+	}
+	return host.collectorState().String()
+}
+
+// TapTraces attaches tc after the receiver, connector or processor identified by kind and id in a traces pipeline.
+func (host *serviceHost) TapTraces(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, tc consumer.Traces) (func(), error) {
+	return host.pipelines.TapTraces(pipelineID, kind, id, tc)
+}
+
+// TapMetrics attaches mc after the receiver, connector or processor identified by kind and id in a metrics pipeline.
+func (host *serviceHost) TapMetrics(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, mc consumer.Metrics) (func(), error) {
+	return host.pipelines.TapMetrics(pipelineID, kind, id, mc)
+}
+
+// TapLogs attaches lc after the receiver, connector or processor identified by kind and id in a logs pipeline.
+func (host *serviceHost) TapLogs(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, lc consumer.Logs) (func(), error) {
+	return host.pipelines.TapLogs(pipelineID, kind, id, lc)
+}
+
 func (host *serviceHost) GetFactory(kind component.Kind, componentType config.Type) component.Factory {
 	switch kind {
 	case component.KindReceiver:
@@ -49,6 +91,8 @@ func (host *serviceHost) GetFactory(kind component.Kind, componentType config.Ty
 		return host.factories.Exporters[componentType]
 	case component.KindExtension:
 		return host.factories.Extensions[componentType]
+	case component.KindConnector:
+		return host.factories.Connectors[componentType]
 	}
 	return nil
 }
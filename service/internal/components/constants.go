@@ -20,8 +20,11 @@ const (
 	ZapKindProcessor = "processor"
 	ZapKindExporter  = "exporter"
 	ZapKindExtension = "extension"
+	ZapKindConnector = "connector"
 	ZapKindPipeline  = "pipeline"
 	ZapNameKey       = "name"
 	ZapDataTypeKey   = "data_type"
 	ZapStabilityKey  = "stability"
+
+	ZapEmittedDataTypeKey = "emitted_data_type"
 )
@@ -15,32 +15,63 @@
 package components // import "go.opentelemetry.io/collector/service/internal/components"
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
 )
 
+var errTapNotSupported = errors.New("the host does not support tapping the pipelines")
+
+// StatusReporter receives the statuses reported for the component instances.
+type StatusReporter interface {
+	ReportStatus(source *component.StatusSource, event *component.StatusEvent)
+}
+
+// ReportStatus reports a status of the component instance identified by source, if reporter is not nil.
+func ReportStatus(reporter StatusReporter, source *component.StatusSource, status component.Status, err error) {
+	if reporter == nil {
+		return
+	}
+	reporter.ReportStatus(source, &component.StatusEvent{Status: status, Err: err, Timestamp: time.Now()})
+}
+
 // hostWrapper adds behavior on top of the component.Host being passed when starting the built components.
 type hostWrapper struct {
 	component.Host
 	*zap.Logger
+	source   *component.StatusSource
+	reporter StatusReporter
 }
 
-func NewHostWrapper(host component.Host, logger *zap.Logger) component.Host {
+// NewHostWrapper returns the host passed when starting the component instance identified by source,
+// which reports the statuses of the instance to reporter.
+func NewHostWrapper(host component.Host, source *component.StatusSource, reporter StatusReporter, logger *zap.Logger) component.Host {
 	return &hostWrapper{
-		host,
-		logger,
+		Host:     host,
+		Logger:   logger,
+		source:   source,
+		reporter: reporter,
 	}
 }
 
 func (hw *hostWrapper) ReportFatalError(err error) {
 	// The logger from the built component already identifies the component.
 	hw.Logger.Error("Component fatal error", zap.Error(err))
+	ReportStatus(hw.reporter, hw.source, component.StatusFatalError, err)
 	hw.Host.ReportFatalError(err)
 }
 
+func (hw *hostWrapper) ReportComponentStatus(status component.Status, err error) {
+	hw.Logger.Debug("Component status changed", zap.Stringer("status", status), zap.Error(err))
+	ReportStatus(hw.reporter, hw.source, status, err)
+}
+
 // RegisterZPages is used by zpages extension to register handles from service.
 // When the wrapper is passed to the extension it won't be successful when casting
 // the interface, for the time being expose the interface here.
@@ -52,3 +83,47 @@ func (hw *hostWrapper) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 		zpagesHost.RegisterZPages(mux, pathPrefix)
 	}
 }
+
+// GetCollectorState is used by the health extension to report the state of the collector,
+// exposed for the same reason as RegisterZPages.
+func (hw *hostWrapper) GetCollectorState() string {
+	if stateHost, ok := hw.Host.(interface {
+		GetCollectorState() string
+	}); ok {
+		return stateHost.GetCollectorState()
+	}
+	return ""
+}
+
+// TapTraces is used by the tap extension to attach to the pipelines of the service.
+func (hw *hostWrapper) TapTraces(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, tc consumer.Traces) (func(), error) {
+	tapHost, ok := hw.Host.(interface {
+		TapTraces(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, tc consumer.Traces) (func(), error)
+	})
+	if !ok {
+		return nil, errTapNotSupported
+	}
+	return tapHost.TapTraces(pipelineID, kind, id, tc)
+}
+
+// TapMetrics is used by the tap extension to attach to the pipelines of the service.
+func (hw *hostWrapper) TapMetrics(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, mc consumer.Metrics) (func(), error) {
+	tapHost, ok := hw.Host.(interface {
+		TapMetrics(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, mc consumer.Metrics) (func(), error)
+	})
+	if !ok {
+		return nil, errTapNotSupported
+	}
+	return tapHost.TapMetrics(pipelineID, kind, id, mc)
+}
+
+// TapLogs is used by the tap extension to attach to the pipelines of the service.
+func (hw *hostWrapper) TapLogs(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, lc consumer.Logs) (func(), error) {
+	tapHost, ok := hw.Host.(interface {
+		TapLogs(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, lc consumer.Logs) (func(), error)
+	})
+	if !ok {
+		return nil, errTapNotSupported
+	}
+	return tapHost.TapLogs(pipelineID, kind, id, lc)
+}
@@ -18,12 +18,97 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 )
 
+type statusRecorder struct {
+	sources []*component.StatusSource
+	events  []*component.StatusEvent
+}
+
+func (r *statusRecorder) ReportStatus(source *component.StatusSource, event *component.StatusEvent) {
+	r.sources = append(r.sources, source)
+	r.events = append(r.events, event)
+}
+
 func Test_newHostWrapper(t *testing.T) {
-	hw := NewHostWrapper(componenttest.NewNopHost(), zap.NewNop())
+	hw := NewHostWrapper(componenttest.NewNopHost(), nil, nil, zap.NewNop())
 	hw.ReportFatalError(errors.New("test error"))
+	hw.ReportComponentStatus(component.StatusRecoverableError, errors.New("test error"))
+}
+
+func TestHostWrapperReportStatus(t *testing.T) {
+	rec := &statusRecorder{}
+	source := &component.StatusSource{Kind: component.KindExporter, ID: config.NewComponentID("otlp"), DataType: config.TracesDataType}
+	hw := NewHostWrapper(componenttest.NewNopHost(), source, rec, zap.NewNop())
+
+	err := errors.New("connection refused")
+	hw.ReportComponentStatus(component.StatusRecoverableError, err)
+	hw.ReportComponentStatus(component.StatusOK, nil)
+	hw.ReportFatalError(err)
+
+	require.Len(t, rec.events, 3)
+	for _, s := range rec.sources {
+		assert.Same(t, source, s)
+	}
+	assert.Equal(t, component.StatusRecoverableError, rec.events[0].Status)
+	assert.Equal(t, err, rec.events[0].Err)
+	assert.False(t, rec.events[0].Timestamp.IsZero())
+	assert.Equal(t, component.StatusOK, rec.events[1].Status)
+	assert.NoError(t, rec.events[1].Err)
+	assert.Equal(t, component.StatusFatalError, rec.events[2].Status)
+	assert.Equal(t, err, rec.events[2].Err)
+}
+
+type tapsHost struct {
+	component.Host
+	tapped []config.ComponentID
+}
+
+func (h *tapsHost) TapTraces(pipelineID config.ComponentID, _ component.Kind, _ config.ComponentID, _ consumer.Traces) (func(), error) {
+	h.tapped = append(h.tapped, pipelineID)
+	return func() {}, nil
+}
+
+func (h *tapsHost) TapMetrics(pipelineID config.ComponentID, _ component.Kind, _ config.ComponentID, _ consumer.Metrics) (func(), error) {
+	h.tapped = append(h.tapped, pipelineID)
+	return func() {}, nil
+}
+
+func (h *tapsHost) TapLogs(pipelineID config.ComponentID, _ component.Kind, _ config.ComponentID, _ consumer.Logs) (func(), error) {
+	h.tapped = append(h.tapped, pipelineID)
+	return func() {}, nil
+}
+
+func TestHostWrapperTaps(t *testing.T) {
+	recvID := config.NewComponentID("otlp")
+	hw := NewHostWrapper(componenttest.NewNopHost(), nil, nil, zap.NewNop()).(*hostWrapper)
+	_, err := hw.TapTraces(config.NewComponentID(config.TracesDataType), component.KindReceiver, recvID, consumertest.NewNop())
+	assert.ErrorIs(t, err, errTapNotSupported)
+	_, err = hw.TapMetrics(config.NewComponentID(config.MetricsDataType), component.KindReceiver, recvID, consumertest.NewNop())
+	assert.ErrorIs(t, err, errTapNotSupported)
+	_, err = hw.TapLogs(config.NewComponentID(config.LogsDataType), component.KindReceiver, recvID, consumertest.NewNop())
+	assert.ErrorIs(t, err, errTapNotSupported)
+
+	host := &tapsHost{Host: componenttest.NewNopHost()}
+	hw = NewHostWrapper(host, nil, nil, zap.NewNop()).(*hostWrapper)
+	_, err = hw.TapTraces(config.NewComponentID(config.TracesDataType), component.KindReceiver, recvID, consumertest.NewNop())
+	assert.NoError(t, err)
+	_, err = hw.TapMetrics(config.NewComponentID(config.MetricsDataType), component.KindReceiver, recvID, consumertest.NewNop())
+	assert.NoError(t, err)
+	_, err = hw.TapLogs(config.NewComponentID(config.LogsDataType), component.KindReceiver, recvID, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.Equal(t, []config.ComponentID{
+		config.NewComponentID(config.TracesDataType),
+		config.NewComponentID(config.MetricsDataType),
+		config.NewComponentID(config.LogsDataType),
+	}, host.tapped)
 }
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components // import "go.opentelemetry.io/collector/service/internal/components"
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// InstanceStatus is the last status reported for a component instance.
+type InstanceStatus struct {
+	Source *component.StatusSource
+	Event  *component.StatusEvent
+	// StartTime is the time the instance was last successfully started, zero if it never was.
+	StartTime time.Time
+}
+
+// Statuses is a StatusReporter keeping the last status reported for each component instance.
+type Statuses struct {
+	mu        sync.Mutex
+	instances map[string]*InstanceStatus
+}
+
+var _ StatusReporter = (*Statuses)(nil)
+
+// NewStatuses returns an empty Statuses.
+func NewStatuses() *Statuses {
+	return &Statuses{instances: make(map[string]*InstanceStatus)}
+}
+
+// ReportStatus records the status reported for the instance identified by source.
+func (s *Statuses) ReportStatus(source *component.StatusSource, event *component.StatusEvent) {
+	key := source.InstanceKey()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	is, ok := s.instances[key]
+	if !ok {
+		is = &InstanceStatus{}
+		s.instances[key] = is
+	}
+	if event.Status == component.StatusOK && is.Event != nil && is.Event.Status == component.StatusStarting {
+		is.StartTime = event.Timestamp
+	}
+	is.Source = source
+	is.Event = event
+}
+
+// List returns the last statuses of all the instances, sorted by kind, ID, data type and pipelines.
+func (s *Statuses) List() []InstanceStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]InstanceStatus, 0, len(s.instances))
+	for _, is := range s.instances {
+		list = append(list, *is)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Source.InstanceKey() < list[j].Source.InstanceKey()
+	})
+	return list
+}
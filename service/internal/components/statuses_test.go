@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package components
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+func TestStatuses(t *testing.T) {
+	statuses := NewStatuses()
+	assert.Empty(t, statuses.List())
+
+	exporter := &component.StatusSource{
+		Kind:      component.KindExporter,
+		ID:        config.NewComponentID("otlp"),
+		DataType:  config.TracesDataType,
+		Pipelines: []config.ComponentID{config.NewComponentID(config.TracesDataType)},
+	}
+	processor := &component.StatusSource{
+		Kind:      component.KindProcessor,
+		ID:        config.NewComponentID("batch"),
+		DataType:  config.TracesDataType,
+		Pipelines: []config.ComponentID{config.NewComponentID(config.TracesDataType)},
+	}
+	start := time.Date(2022, 9, 1, 10, 0, 0, 0, time.UTC)
+	report := func(source *component.StatusSource, status component.Status, err error, ts time.Time) {
+		// The sources are recreated on each report.
+		copied := *source
+		statuses.ReportStatus(&copied, &component.StatusEvent{Status: status, Err: err, Timestamp: ts})
+	}
+
+	report(exporter, component.StatusStarting, nil, start)
+	report(exporter, component.StatusOK, nil, start.Add(time.Second))
+	report(processor, component.StatusStarting, nil, start.Add(2*time.Second))
+	report(processor, component.StatusPermanentError, errors.New("failed to start"), start.Add(3*time.Second))
+	transient := errors.New("transient error")
+	report(exporter, component.StatusRecoverableError, transient, start.Add(4*time.Second))
+	report(exporter, component.StatusOK, nil, start.Add(5*time.Second))
+	report(exporter, component.StatusRecoverableError, transient, start.Add(6*time.Second))
+
+	list := statuses.List()
+	require.Len(t, list, 2)
+
+	assert.Equal(t, exporter, list[0].Source)
+	assert.Equal(t, &component.StatusEvent{Status: component.StatusRecoverableError, Err: transient, Timestamp: start.Add(6 * time.Second)}, list[0].Event)
+	assert.Equal(t, start.Add(time.Second), list[0].StartTime)
+
+	assert.Equal(t, processor, list[1].Source)
+	assert.Equal(t, component.StatusPermanentError, list[1].Event.Status)
+	assert.True(t, list[1].StartTime.IsZero())
+}
@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/obsreportconfig/obsmetrics"
+)
+
+const scopeName = "go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+
+// AsyncSettings configures an asynchronous fanout.
+type AsyncSettings struct {
+	component.TelemetrySettings
+	// PipelineID is the ID of the pipeline of the fanout, recorded with the metrics of the branches.
+	PipelineID config.ComponentID
+	// Quorum is the number of branches which must succeed, capped to the number of branches;
+	// the configuration validation rejects a quorum greater than the number of exporters.
+	// If zero, all the branches must succeed, unless Primary is set.
+	Quorum int
+	// Primary are the IDs of the branches which must succeed.
+	Primary []config.ComponentID
+}
+
+// AsyncConsumer is implemented by the asynchronous fanouts, whose branches may still be consuming
+// data after it was acknowledged to the upstream.
+type AsyncConsumer interface {
+	// Wait blocks until all the branches returned.
+	Wait()
+}
+
+// asyncFanout dispatches data to its branches concurrently, and returns once the quorum and the
+// primary branches succeeded, or once they cannot succeed anymore.
+type asyncFanout struct {
+	quorum    int
+	primary   []bool
+	telemetry []*branchTelemetry
+	wg        sync.WaitGroup
+}
+
+func newAsyncFanout(set AsyncSettings, ids []config.ComponentID, registry *featuregate.Registry) *asyncFanout {
+	f := &asyncFanout{
+		quorum:  set.Quorum,
+		primary: make([]bool, len(ids)),
+	}
+	for i, id := range ids {
+		for _, p := range set.Primary {
+			if p == id {
+				f.primary[i] = true
+			}
+		}
+	}
+	if f.quorum == 0 && len(set.Primary) == 0 || f.quorum > len(ids) {
+		f.quorum = len(ids)
+	}
+
+	useOtel := registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID)
+	for _, id := range ids {
+		f.telemetry = append(f.telemetry, newBranchTelemetry(set, id, useOtel))
+	}
+	return f
+}
+
+type branchResult struct {
+	branch int
+	err    error
+}
+
+// dispatch calls consume for each branch in its own goroutine, with a context keeping the values of
+// ctx but not its cancellation, since the branches may outlive the call.
+func (f *asyncFanout) dispatch(ctx context.Context, consume func(ctx context.Context, branch int) error) error {
+	results := make(chan branchResult, len(f.primary))
+	branchCtx := detachedContext{Context: ctx}
+	pendingPrimary := 0
+	for i := range f.primary {
+		if f.primary[i] {
+			pendingPrimary++
+		}
+		f.wg.Add(1)
+		go func(i int) {
+			defer f.wg.Done()
+			err := consume(branchCtx, i)
+			f.telemetry[i].record(err)
+			results <- branchResult{branch: i, err: err}
+		}(i)
+	}
+
+	var errs error
+	succeeded, failed := 0, 0
+	for range f.primary {
+		var res branchResult
+		select {
+		case res = <-results:
+		case <-ctx.Done():
+			return multierr.Append(errs, ctx.Err())
+		}
+
+		if res.err != nil {
+			errs = multierr.Append(errs, res.err)
+			failed++
+			if f.primary[res.branch] || len(f.primary)-failed < f.quorum {
+				return errs
+			}
+			continue
+		}
+		succeeded++
+		if f.primary[res.branch] {
+			pendingPrimary--
+		}
+		if succeeded >= f.quorum && pendingPrimary == 0 {
+			return nil
+		}
+	}
+	return errs
+}
+
+// Wait implements AsyncConsumer.
+func (f *asyncFanout) Wait() {
+	f.wg.Wait()
+}
+
+// detachedContext keeps the values of a context, without its deadline and cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// branchTelemetry records the requests succeeded and failed by a branch of a fanout.
+type branchTelemetry struct {
+	level configtelemetry.Level
+
+	ocCtx context.Context
+
+	useOtelForMetrics bool
+	otelAttrs         []attribute.KeyValue
+	succeeded         syncint64.Counter
+	failed            syncint64.Counter
+}
+
+func newBranchTelemetry(set AsyncSettings, id config.ComponentID, useOtel bool) *branchTelemetry {
+	bt := &branchTelemetry{
+		level:             set.MetricsLevel,
+		useOtelForMetrics: useOtel,
+		otelAttrs: []attribute.KeyValue{
+			attribute.String(obsmetrics.PipelineKey, set.PipelineID.String()),
+			attribute.String(obsmetrics.ExporterKey, id.String()),
+		},
+	}
+	bt.ocCtx, _ = tag.New(context.Background(),
+		tag.Upsert(obsmetrics.TagKeyPipeline, set.PipelineID.String(), tag.WithTTL(tag.TTLNoPropagation)),
+		tag.Upsert(obsmetrics.TagKeyExporter, id.String(), tag.WithTTL(tag.TTLNoPropagation)))
+	if !useOtel || set.MeterProvider == nil {
+		return bt
+	}
+
+	meter := set.MeterProvider.Meter(scopeName)
+	newCounter := func(measure *stats.Int64Measure) syncint64.Counter {
+		counter, err := meter.SyncInt64().Counter(
+			measure.Name(),
+			instrument.WithDescription(measure.Description()),
+			instrument.WithUnit(unit.Unit(measure.Unit())),
+		)
+		if err != nil {
+			set.Logger.Warn("failed to create otel instrument", zap.Error(err), zap.String("metric", measure.Name()))
+		}
+		return counter
+	}
+	bt.succeeded = newCounter(obsmetrics.FanoutBranchSucceededRequests)
+	bt.failed = newCounter(obsmetrics.FanoutBranchFailedRequests)
+	return bt
+}
+
+func (bt *branchTelemetry) record(err error) {
+	if bt.level == configtelemetry.LevelNone {
+		return
+	}
+	measure, counter := obsmetrics.FanoutBranchSucceededRequests, bt.succeeded
+	if err != nil {
+		measure, counter = obsmetrics.FanoutBranchFailedRequests, bt.failed
+	}
+	if !bt.useOtelForMetrics {
+		stats.Record(bt.ocCtx, measure.M(1))
+		return
+	}
+	if counter != nil {
+		// The attributes are sorted in place by the SDK, and the branches of a fanout record concurrently.
+		counter.Add(bt.ocCtx, 1, append([]attribute.KeyValue(nil), bt.otelAttrs...)...)
+	}
+}
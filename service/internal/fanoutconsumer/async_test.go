@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var branchIDs = []config.ComponentID{
+	config.NewComponentIDWithName("exporter", "a"),
+	config.NewComponentIDWithName("exporter", "b"),
+	config.NewComponentIDWithName("exporter", "c"),
+}
+
+func newAsyncSettings(quorum int, primary ...config.ComponentID) AsyncSettings {
+	return AsyncSettings{
+		TelemetrySettings: componenttest.NewNopTelemetrySettings(),
+		PipelineID:        config.NewComponentID(config.TracesDataType),
+		Quorum:            quorum,
+		Primary:           primary,
+	}
+}
+
+// blockingTraces consumes the traces once unblocked, returning err.
+type blockingTraces struct {
+	consumertest.TracesSink
+	unblock chan struct{}
+	err     error
+	ctxErr  error
+}
+
+func newBlockingTraces(err error) *blockingTraces {
+	return &blockingTraces{unblock: make(chan struct{}), err: err}
+}
+
+func (bt *blockingTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	<-bt.unblock
+	bt.ctxErr = ctx.Err()
+	if bt.err != nil {
+		return bt.err
+	}
+	return bt.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestAsyncTracesAllSucceed(t *testing.T) {
+	p1 := new(consumertest.TracesSink)
+	p2 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	p3 := new(consumertest.TracesSink)
+	tfc := NewAsyncTraces(newAsyncSettings(0), branchIDs, []consumer.Traces{p1, p2, p3})
+	assert.False(t, tfc.Capabilities().MutatesData)
+
+	td := testdata.GenerateTraces(1)
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
+	tfc.(AsyncConsumer).Wait()
+
+	// The read-only branches share the data, the mutating ones get a clone.
+	assert.True(t, td == p1.AllTraces()[0])
+	assert.True(t, td == p3.AllTraces()[0])
+	assert.False(t, td == p2.AllTraces()[0])
+	assert.EqualValues(t, td, p2.AllTraces()[0])
+}
+
+func TestAsyncTracesAllMustSucceed(t *testing.T) {
+	tfc := NewAsyncTraces(newAsyncSettings(0), branchIDs[:2], []consumer.Traces{
+		consumertest.NewNop(), consumertest.NewErr(errors.New("my error")),
+	})
+	assert.EqualError(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), "my error")
+}
+
+func TestAsyncTracesQuorum(t *testing.T) {
+	slow := newBlockingTraces(nil)
+	fast := new(consumertest.TracesSink)
+	failing := consumertest.NewErr(errors.New("my error"))
+	tfc := NewAsyncTraces(newAsyncSettings(1), branchIDs, []consumer.Traces{slow, fast, failing})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// The slow branch does not block the acknowledgement, nor does the failing one.
+	require.NoError(t, tfc.ConsumeTraces(ctx, testdata.GenerateTraces(1)))
+	assert.Len(t, fast.AllTraces(), 1)
+	cancel()
+
+	// The slow branch keeps consuming the data, without the cancellation of the upstream.
+	close(slow.unblock)
+	tfc.(AsyncConsumer).Wait()
+	assert.Len(t, slow.AllTraces(), 1)
+	assert.NoError(t, slow.ctxErr)
+}
+
+func TestAsyncTracesQuorumNotReached(t *testing.T) {
+	slow := newBlockingTraces(nil)
+	defer close(slow.unblock)
+	tfc := NewAsyncTraces(newAsyncSettings(2), branchIDs, []consumer.Traces{
+		slow, consumertest.NewErr(errors.New("error 1")), consumertest.NewErr(errors.New("error 2")),
+	})
+
+	// The quorum cannot be reached anymore once two branches failed.
+	err := tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1))
+	assert.ErrorContains(t, err, "error 1")
+	assert.ErrorContains(t, err, "error 2")
+}
+
+func TestAsyncTracesPrimary(t *testing.T) {
+	slow := newBlockingTraces(nil)
+	primary := new(consumertest.TracesSink)
+	tfc := NewAsyncTraces(newAsyncSettings(0, branchIDs[1]), branchIDs[:2], []consumer.Traces{slow, primary})
+	require.NoError(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, primary.AllTraces(), 1)
+	close(slow.unblock)
+	tfc.(AsyncConsumer).Wait()
+
+	slow = newBlockingTraces(nil)
+	defer close(slow.unblock)
+	tfc = NewAsyncTraces(newAsyncSettings(1, branchIDs[1]), branchIDs[:2], []consumer.Traces{slow, consumertest.NewErr(errors.New("my error"))})
+	assert.EqualError(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)), "my error")
+}
+
+func TestAsyncTracesCanceled(t *testing.T) {
+	slow := newBlockingTraces(nil)
+	tfc := NewAsyncTraces(newAsyncSettings(0), branchIDs[:1], []consumer.Traces{slow})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, tfc.ConsumeTraces(ctx, testdata.GenerateTraces(1)), context.Canceled)
+	close(slow.unblock)
+	tfc.(AsyncConsumer).Wait()
+	assert.Len(t, slow.AllTraces(), 1)
+}
+
+func TestAsyncMetrics(t *testing.T) {
+	p1 := new(consumertest.MetricsSink)
+	p2 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	mfc := NewAsyncMetrics(newAsyncSettings(1, branchIDs[0]), branchIDs[:2], []consumer.Metrics{p1, p2})
+	assert.False(t, mfc.Capabilities().MutatesData)
+
+	md := testdata.GenerateMetrics(1)
+	require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
+	mfc.(AsyncConsumer).Wait()
+	assert.True(t, md == p1.AllMetrics()[0])
+	assert.EqualValues(t, md, p2.AllMetrics()[0])
+}
+
+func TestAsyncLogs(t *testing.T) {
+	p1 := new(consumertest.LogsSink)
+	p2 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	lfc := NewAsyncLogs(newAsyncSettings(0), branchIDs[:2], []consumer.Logs{p1, p2})
+	assert.False(t, lfc.Capabilities().MutatesData)
+
+	ld := testdata.GenerateLogs(1)
+	require.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
+	lfc.(AsyncConsumer).Wait()
+	assert.True(t, ld == p1.AllLogs()[0])
+	assert.EqualValues(t, ld, p2.AllLogs()[0])
+}
+
+func TestAsyncOtelMetrics(t *testing.T) {
+	registry := featuregate.NewRegistry()
+	obsreportconfig.RegisterInternalMetricFeatureGate(registry)
+	require.NoError(t, registry.Apply(map[string]bool{obsreportconfig.UseOtelForInternalMetricsfeatureGateID: true}))
+
+	reader := sdkmetric.NewManualReader()
+	set := newAsyncSettings(0)
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.MetricsLevel = configtelemetry.LevelNormal
+	tfc := &asyncTracesConsumer{
+		asyncFanout: newAsyncFanout(set, branchIDs[:2], registry),
+		consumers:   []consumer.Traces{consumertest.NewNop(), consumertest.NewErr(errors.New("my error"))},
+	}
+	for i := 0; i < 3; i++ {
+		require.Error(t, tfc.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+	tfc.Wait()
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, scopeName, rm.ScopeMetrics[0].Scope.Name)
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	succeeded := metrics["fanout/branch_succeeded_requests"].(metricdata.Sum[int64])
+	require.Len(t, succeeded.DataPoints, 1)
+	assert.Equal(t, attribute.NewSet(attribute.String("pipeline", "traces"), attribute.String("exporter", "exporter/a")), succeeded.DataPoints[0].Attributes)
+	assert.Equal(t, int64(3), succeeded.DataPoints[0].Value)
+
+	failed := metrics["fanout/branch_failed_requests"].(metricdata.Sum[int64])
+	require.Len(t, failed.DataPoints, 1)
+	assert.Equal(t, attribute.NewSet(attribute.String("pipeline", "traces"), attribute.String("exporter", "exporter/b")), failed.DataPoints[0].Attributes)
+	assert.Equal(t, int64(3), failed.DataPoints[0].Value)
+}
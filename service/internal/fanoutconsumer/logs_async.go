@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// NewAsyncLogs wraps the consumers of the branches, identified by ids, in a single one implementing
+// AsyncConsumer. It passes the incoming data to all the branches concurrently, cloning it for the
+// branches mutating it, and returns once the quorum and the primary branches succeeded.
+func NewAsyncLogs(set AsyncSettings, ids []config.ComponentID, consumers []consumer.Logs) consumer.Logs {
+	return &asyncLogsConsumer{asyncFanout: newAsyncFanout(set, ids, featuregate.GetRegistry()), consumers: consumers}
+}
+
+type asyncLogsConsumer struct {
+	*asyncFanout
+	consumers []consumer.Logs
+}
+
+func (ac *asyncLogsConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeLogs passes the plog.Logs to all the branches.
+func (ac *asyncLogsConsumer) ConsumeLogs(ctx context.Context, data plog.Logs) error {
+	// Clone before dispatching, the branches sharing the data run concurrently.
+	branchData := make([]plog.Logs, len(ac.consumers))
+	for i, c := range ac.consumers {
+		branchData[i] = data
+		if c.Capabilities().MutatesData {
+			branchData[i] = plog.NewLogs()
+			data.CopyTo(branchData[i])
+		}
+	}
+	return ac.dispatch(ctx, func(ctx context.Context, branch int) error {
+		return ac.consumers[branch].ConsumeLogs(ctx, branchData[branch])
+	})
+}
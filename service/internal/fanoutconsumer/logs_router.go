@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// NewLogsRouter wraps the consumers of the routes, and the default consumer, in a single one.
+// It sends the data of each resource to the consumer of the first route it matches, or to the
+// default consumer if it matches none, splitting the data per route when needed.
+func NewLogsRouter(routes []Route, routeConsumers []consumer.Logs, defaultConsumer consumer.Logs) consumer.Logs {
+	consumers := append(append(make([]consumer.Logs, 0, len(routeConsumers)+1), routeConsumers...), defaultConsumer)
+	caps := make([]consumer.Capabilities, 0, len(consumers))
+	for _, c := range consumers {
+		caps = append(caps, c.Capabilities())
+	}
+	return &logsRouter{router: router{routes: routes}, consumers: consumers, cap: mutatesData(caps)}
+}
+
+type logsRouter struct {
+	router
+	// consumers holds the consumer of each route, followed by the default consumer.
+	consumers []consumer.Logs
+	cap       consumer.Capabilities
+}
+
+func (r *logsRouter) Capabilities() consumer.Capabilities {
+	return r.cap
+}
+
+// ConsumeLogs sends the plog.Logs of each resource to the consumer of its route.
+func (r *logsRouter) ConsumeLogs(ctx context.Context, data plog.Logs) error {
+	rs := data.ResourceLogs()
+	dests := r.routeResources(ctx, rs.Len(), func(i int) pcommon.Resource { return rs.At(i).Resource() })
+	if len(dests) == 0 {
+		return r.consumers[len(r.consumers)-1].ConsumeLogs(ctx, data)
+	}
+	if singleRoute(dests) {
+		return r.consumers[dests[0]].ConsumeLogs(ctx, data)
+	}
+
+	split := make(map[int]plog.Logs)
+	for i, dest := range dests {
+		if _, ok := split[dest]; !ok {
+			split[dest] = plog.NewLogs()
+		}
+		rs.At(i).CopyTo(split[dest].ResourceLogs().AppendEmpty())
+	}
+	var errs error
+	for dest, c := range r.consumers {
+		if part, ok := split[dest]; ok {
+			errs = multierr.Append(errs, c.ConsumeLogs(ctx, part))
+		}
+	}
+	return errs
+}
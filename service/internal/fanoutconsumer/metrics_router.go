@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// NewMetricsRouter wraps the consumers of the routes, and the default consumer, in a single one.
+// It sends the data of each resource to the consumer of the first route it matches, or to the
+// default consumer if it matches none, splitting the data per route when needed.
+func NewMetricsRouter(routes []Route, routeConsumers []consumer.Metrics, defaultConsumer consumer.Metrics) consumer.Metrics {
+	consumers := append(append(make([]consumer.Metrics, 0, len(routeConsumers)+1), routeConsumers...), defaultConsumer)
+	caps := make([]consumer.Capabilities, 0, len(consumers))
+	for _, c := range consumers {
+		caps = append(caps, c.Capabilities())
+	}
+	return &metricsRouter{router: router{routes: routes}, consumers: consumers, cap: mutatesData(caps)}
+}
+
+type metricsRouter struct {
+	router
+	// consumers holds the consumer of each route, followed by the default consumer.
+	consumers []consumer.Metrics
+	cap       consumer.Capabilities
+}
+
+func (r *metricsRouter) Capabilities() consumer.Capabilities {
+	return r.cap
+}
+
+// ConsumeMetrics sends the pmetric.Metrics of each resource to the consumer of its route.
+func (r *metricsRouter) ConsumeMetrics(ctx context.Context, data pmetric.Metrics) error {
+	rs := data.ResourceMetrics()
+	dests := r.routeResources(ctx, rs.Len(), func(i int) pcommon.Resource { return rs.At(i).Resource() })
+	if len(dests) == 0 {
+		return r.consumers[len(r.consumers)-1].ConsumeMetrics(ctx, data)
+	}
+	if singleRoute(dests) {
+		return r.consumers[dests[0]].ConsumeMetrics(ctx, data)
+	}
+
+	split := make(map[int]pmetric.Metrics)
+	for i, dest := range dests {
+		if _, ok := split[dest]; !ok {
+			split[dest] = pmetric.NewMetrics()
+		}
+		rs.At(i).CopyTo(split[dest].ResourceMetrics().AppendEmpty())
+	}
+	var errs error
+	for dest, c := range r.consumers {
+		if part, ok := split[dest]; ok {
+			errs = multierr.Append(errs, c.ConsumeMetrics(ctx, part))
+		}
+	}
+	return errs
+}
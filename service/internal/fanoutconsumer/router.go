@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// Route is the condition for a router to send data to the consumer of the route.
+//
+// A resource matches the route if it has all the ResourceAttributes with their string
+// values, and the client.Info of the request has all the Metadata as one of the values
+// of their key.
+type Route struct {
+	ResourceAttributes map[string]string
+	Metadata           map[string]string
+}
+
+// router assigns the resources of the data to the first route they match.
+type router struct {
+	routes []Route
+}
+
+// routeResources returns, for each of the n resources, the index of the first route
+// it matches, or len(routes) for the default consumer.
+func (r router) routeResources(ctx context.Context, n int, resource func(int) pcommon.Resource) []int {
+	info := client.FromContext(ctx)
+	metadataMatches := make([]bool, len(r.routes))
+	for i, route := range r.routes {
+		metadataMatches[i] = matchMetadata(info.Metadata, route.Metadata)
+	}
+
+	dests := make([]int, n)
+	for i := 0; i < n; i++ {
+		dests[i] = len(r.routes)
+		attrs := resource(i).Attributes()
+		for j, route := range r.routes {
+			if metadataMatches[j] && matchAttributes(attrs, route.ResourceAttributes) {
+				dests[i] = j
+				break
+			}
+		}
+	}
+	return dests
+}
+
+// singleRoute reports whether all the resources are sent to the same route.
+func singleRoute(dests []int) bool {
+	for _, d := range dests {
+		if d != dests[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func matchMetadata(md client.Metadata, expected map[string]string) bool {
+	for k, v := range expected {
+		found := false
+		for _, val := range md.Get(k) {
+			if val == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAttributes(attrs pcommon.Map, expected map[string]string) bool {
+	for k, v := range expected {
+		val, ok := attrs.Get(k)
+		if !ok || val.AsString() != v {
+			return false
+		}
+	}
+	return true
+}
+
+// mutatesData reports whether any of the consumers mutates the data, since a router passes the
+// data unchanged when all the resources are sent to the same route.
+func mutatesData(caps []consumer.Capabilities) consumer.Capabilities {
+	for _, c := range caps {
+		if c.MutatesData {
+			return consumer.Capabilities{MutatesData: true}
+		}
+	}
+	return consumer.Capabilities{MutatesData: false}
+}
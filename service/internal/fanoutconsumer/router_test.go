@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var teamRoutes = []Route{
+	{ResourceAttributes: map[string]string{"team": "a"}},
+	{ResourceAttributes: map[string]string{"team": "b"}},
+}
+
+func generateTracesForTeams(teams ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, team := range teams {
+		rs := td.ResourceSpans().AppendEmpty()
+		testdata.GenerateTraces(1).ResourceSpans().At(0).CopyTo(rs)
+		rs.Resource().Attributes().PutStr("team", team)
+	}
+	return td
+}
+
+func TestTracesRouterSingleRoute(t *testing.T) {
+	a := new(consumertest.TracesSink)
+	b := new(consumertest.TracesSink)
+	def := new(consumertest.TracesSink)
+	rc := NewTracesRouter(teamRoutes, []consumer.Traces{a, b}, def)
+	assert.False(t, rc.Capabilities().MutatesData)
+
+	td := generateTracesForTeams("b", "b")
+	require.NoError(t, rc.ConsumeTraces(context.Background(), td))
+	// The data is passed unchanged when it is not split.
+	require.Len(t, b.AllTraces(), 1)
+	assert.True(t, td == b.AllTraces()[0])
+	assert.Len(t, a.AllTraces(), 0)
+	assert.Len(t, def.AllTraces(), 0)
+
+	// Empty data and resources matching no route go to the default consumer.
+	require.NoError(t, rc.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NoError(t, rc.ConsumeTraces(context.Background(), generateTracesForTeams("c")))
+	assert.Len(t, def.AllTraces(), 2)
+}
+
+func TestTracesRouterSplit(t *testing.T) {
+	a := new(consumertest.TracesSink)
+	b := new(consumertest.TracesSink)
+	def := new(consumertest.TracesSink)
+	rc := NewTracesRouter(teamRoutes, []consumer.Traces{a, b}, def)
+
+	require.NoError(t, rc.ConsumeTraces(context.Background(), generateTracesForTeams("a", "c", "a", "b")))
+	require.Len(t, a.AllTraces(), 1)
+	assert.Equal(t, generateTracesForTeams("a", "a"), a.AllTraces()[0])
+	require.Len(t, b.AllTraces(), 1)
+	assert.Equal(t, generateTracesForTeams("b"), b.AllTraces()[0])
+	require.Len(t, def.AllTraces(), 1)
+	assert.Equal(t, generateTracesForTeams("c"), def.AllTraces()[0])
+}
+
+func TestTracesRouterFirstRouteWins(t *testing.T) {
+	first := new(consumertest.TracesSink)
+	second := new(consumertest.TracesSink)
+	routes := []Route{
+		{ResourceAttributes: map[string]string{"team": "a", "resource-attr": "resource-attr-val-1"}},
+		{ResourceAttributes: map[string]string{"team": "a"}},
+	}
+	rc := NewTracesRouter(routes, []consumer.Traces{first, second}, consumertest.NewNop())
+
+	require.NoError(t, rc.ConsumeTraces(context.Background(), generateTracesForTeams("a")))
+	assert.Len(t, first.AllTraces(), 1)
+	assert.Len(t, second.AllTraces(), 0)
+}
+
+func TestTracesRouterMetadata(t *testing.T) {
+	a := new(consumertest.TracesSink)
+	def := new(consumertest.TracesSink)
+	routes := []Route{{Metadata: map[string]string{"X-Tenant": "a"}}}
+	rc := NewTracesRouter(routes, []consumer.Traces{a}, def)
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"b", "a"}}),
+	})
+	require.NoError(t, rc.ConsumeTraces(ctx, generateTracesForTeams("a", "b")))
+	assert.Len(t, a.AllTraces(), 1)
+	assert.Len(t, def.AllTraces(), 0)
+
+	require.NoError(t, rc.ConsumeTraces(context.Background(), generateTracesForTeams("a")))
+	assert.Len(t, a.AllTraces(), 1)
+	assert.Len(t, def.AllTraces(), 1)
+}
+
+func TestTracesRouterMutatingAndErrors(t *testing.T) {
+	mutating := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	rc := NewTracesRouter(teamRoutes, []consumer.Traces{mutating, consumertest.NewErr(errors.New("my error"))}, consumertest.NewNop())
+	// The data is passed unchanged to the mutating consumer when it is not split.
+	assert.True(t, rc.Capabilities().MutatesData)
+
+	assert.Error(t, rc.ConsumeTraces(context.Background(), generateTracesForTeams("a", "b")))
+	assert.Len(t, mutating.AllTraces(), 1)
+}
+
+func TestMetricsRouterSplit(t *testing.T) {
+	a := new(consumertest.MetricsSink)
+	def := new(consumertest.MetricsSink)
+	rc := NewMetricsRouter(teamRoutes[:1], []consumer.Metrics{a}, def)
+	assert.False(t, rc.Capabilities().MutatesData)
+
+	md := pmetric.NewMetrics()
+	for _, team := range []string{"a", "b"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		testdata.GenerateMetrics(1).ResourceMetrics().At(0).CopyTo(rm)
+		rm.Resource().Attributes().PutStr("team", team)
+	}
+	require.NoError(t, rc.ConsumeMetrics(context.Background(), md))
+	require.Len(t, a.AllMetrics(), 1)
+	assert.Equal(t, 1, a.AllMetrics()[0].ResourceMetrics().Len())
+	assert.Equal(t, md.ResourceMetrics().At(0), a.AllMetrics()[0].ResourceMetrics().At(0))
+	require.Len(t, def.AllMetrics(), 1)
+	assert.Equal(t, md.ResourceMetrics().At(1), def.AllMetrics()[0].ResourceMetrics().At(0))
+}
+
+func TestLogsRouterSplit(t *testing.T) {
+	a := new(consumertest.LogsSink)
+	def := new(consumertest.LogsSink)
+	rc := NewLogsRouter(teamRoutes[:1], []consumer.Logs{a}, def)
+	assert.False(t, rc.Capabilities().MutatesData)
+
+	ld := plog.NewLogs()
+	for _, team := range []string{"a", "b"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		testdata.GenerateLogs(1).ResourceLogs().At(0).CopyTo(rl)
+		rl.Resource().Attributes().PutStr("team", team)
+	}
+	require.NoError(t, rc.ConsumeLogs(context.Background(), ld))
+	require.Len(t, a.AllLogs(), 1)
+	assert.Equal(t, ld.ResourceLogs().At(0), a.AllLogs()[0].ResourceLogs().At(0))
+	require.Len(t, def.AllLogs(), 1)
+	assert.Equal(t, ld.ResourceLogs().At(1), def.AllLogs()[0].ResourceLogs().At(0))
+}
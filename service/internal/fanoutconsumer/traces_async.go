@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fanoutconsumer // import "go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// NewAsyncTraces wraps the consumers of the branches, identified by ids, in a single one implementing
+// AsyncConsumer. It passes the incoming data to all the branches concurrently, cloning it for the
+// branches mutating it, and returns once the quorum and the primary branches succeeded.
+func NewAsyncTraces(set AsyncSettings, ids []config.ComponentID, consumers []consumer.Traces) consumer.Traces {
+	return &asyncTracesConsumer{asyncFanout: newAsyncFanout(set, ids, featuregate.GetRegistry()), consumers: consumers}
+}
+
+type asyncTracesConsumer struct {
+	*asyncFanout
+	consumers []consumer.Traces
+}
+
+func (ac *asyncTracesConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces passes the ptrace.Traces to all the branches.
+func (ac *asyncTracesConsumer) ConsumeTraces(ctx context.Context, data ptrace.Traces) error {
+	// Clone before dispatching, the branches sharing the data run concurrently.
+	branchData := make([]ptrace.Traces, len(ac.consumers))
+	for i, c := range ac.consumers {
+		branchData[i] = data
+		if c.Capabilities().MutatesData {
+			branchData[i] = ptrace.NewTraces()
+			data.CopyTo(branchData[i])
+		}
+	}
+	return ac.dispatch(ctx, func(ctx context.Context, branch int) error {
+		return ac.consumers[branch].ConsumeTraces(ctx, branchData[branch])
+	})
+}
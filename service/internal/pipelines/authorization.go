@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// authorizedReceiver returns a consumer passing the data received by a receiver to next, the fan out to
+// its pipelines, unless the authorization rules of the receiver allow the request in none of the pipelines,
+// see configauth.AuthorizeAnyPipeline. The request then fails before any data is consumed.
+func authorizedReceiver(dt config.DataType, pipelineIDs []config.ComponentID, next baseConsumer) baseConsumer {
+	switch dt {
+	case config.TracesDataType:
+		return authorizedReceiverTraces{Traces: next.(consumer.Traces), pipelineIDs: pipelineIDs}
+	case config.MetricsDataType:
+		return authorizedReceiverMetrics{Metrics: next.(consumer.Metrics), pipelineIDs: pipelineIDs}
+	case config.LogsDataType:
+		return authorizedReceiverLogs{Logs: next.(consumer.Logs), pipelineIDs: pipelineIDs}
+	}
+	return next
+}
+
+type authorizedReceiverTraces struct {
+	consumer.Traces
+	pipelineIDs []config.ComponentID
+}
+
+func (c authorizedReceiverTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if err := configauth.AuthorizeAnyPipeline(ctx, c.pipelineIDs); err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+type authorizedReceiverMetrics struct {
+	consumer.Metrics
+	pipelineIDs []config.ComponentID
+}
+
+func (c authorizedReceiverMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if err := configauth.AuthorizeAnyPipeline(ctx, c.pipelineIDs); err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type authorizedReceiverLogs struct {
+	consumer.Logs
+	pipelineIDs []config.ComponentID
+}
+
+func (c authorizedReceiverLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if err := configauth.AuthorizeAnyPipeline(ctx, c.pipelineIDs); err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+// authorizedConsumer returns a consumer passing the data received by a receiver to next, unless the
+// authorization rules of the receiver don't allow the request in the pipeline, see configauth.AuthorizedPipeline.
+// The data is then not consumed by the pipeline, and a permanent error is returned.
+func authorizedConsumer(pipelineID config.ComponentID, next baseConsumer) baseConsumer {
+	switch pipelineID.Type() {
+	case config.TracesDataType:
+		return authorizedTraces{Traces: next.(consumer.Traces), pipelineID: pipelineID}
+	case config.MetricsDataType:
+		return authorizedMetrics{Metrics: next.(consumer.Metrics), pipelineID: pipelineID}
+	case config.LogsDataType:
+		return authorizedLogs{Logs: next.(consumer.Logs), pipelineID: pipelineID}
+	}
+	return next
+}
+
+type authorizedTraces struct {
+	consumer.Traces
+	pipelineID config.ComponentID
+}
+
+func (c authorizedTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if !configauth.AuthorizedPipeline(ctx, c.pipelineID) {
+		return errPipelineDenied(c.pipelineID)
+	}
+	return c.Traces.ConsumeTraces(ctx, td)
+}
+
+type authorizedMetrics struct {
+	consumer.Metrics
+	pipelineID config.ComponentID
+}
+
+func (c authorizedMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if !configauth.AuthorizedPipeline(ctx, c.pipelineID) {
+		return errPipelineDenied(c.pipelineID)
+	}
+	return c.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type authorizedLogs struct {
+	consumer.Logs
+	pipelineID config.ComponentID
+}
+
+func (c authorizedLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if !configauth.AuthorizedPipeline(ctx, c.pipelineID) {
+		return errPipelineDenied(c.pipelineID)
+	}
+	return c.Logs.ConsumeLogs(ctx, ld)
+}
+
+func errPipelineDenied(pipelineID config.ComponentID) error {
+	return consumererror.NewPermanent(fmt.Errorf("%w in pipeline %q", configauth.ErrPermissionDenied, pipelineID))
+}
@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+// connectorKey identifies a connector instance. A connector has an instance per pair of the data type
+// of the pipelines it is an exporter in, and of the data type of the pipelines it is a receiver in.
+type connectorKey struct {
+	id           config.ComponentID
+	exporterType config.DataType
+	receiverType config.DataType
+}
+
+// connectorStatusSource returns the source of the statuses of the connector instance, with the pipelines
+// it consumes data from and emits data to.
+func (bps *Pipelines) connectorStatusSource(key connectorKey) *component.StatusSource {
+	source := &component.StatusSource{
+		Kind:            component.KindConnector,
+		ID:              key.id,
+		DataType:        key.exporterType,
+		EmittedDataType: key.receiverType,
+	}
+	for pipelineID, bp := range bps.pipelines {
+		if (pipelineID.Type() == key.exporterType && hasComponent(bp.exporters, key.id)) ||
+			(pipelineID.Type() == key.receiverType && hasComponent(bp.receivers, key.id)) {
+			source.Pipelines = append(source.Pipelines, pipelineID)
+		}
+	}
+	sort.Slice(source.Pipelines, func(i, j int) bool {
+		return source.Pipelines[i].String() < source.Pipelines[j].String()
+	})
+	return source
+}
+
+func hasComponent(comps []builtComponent, id config.ComponentID) bool {
+	for _, c := range comps {
+		if c.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func buildConnector(
+	ctx context.Context,
+	settings component.TelemetrySettings,
+	buildInfo component.BuildInfo,
+	cfgs map[config.ComponentID]config.Connector,
+	factories map[config.Type]component.ConnectorFactory,
+	key connectorKey,
+	next baseConsumer,
+) (component.Connector, error) {
+	cfg, existsCfg := cfgs[key.id]
+	if !existsCfg {
+		return nil, fmt.Errorf("connector %q is not configured", key.id)
+	}
+
+	factory, existsFactory := factories[key.id.Type()]
+	if !existsFactory {
+		return nil, fmt.Errorf("connector factory not available for: %q", key.id)
+	}
+
+	set := component.ConnectorCreateSettings{
+		TelemetrySettings: settings,
+		BuildInfo:         buildInfo,
+	}
+	set.TelemetrySettings.Logger = connectorLogger(settings.Logger, key)
+	components.LogStabilityLevel(set.TelemetrySettings.Logger, getConnectorStabilityLevel(factory, key.exporterType, key.receiverType))
+
+	conn, err := createConnector(ctx, set, cfg, key, next, factory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %q connector, from %s to %s: %w", key.id, key.exporterType, key.receiverType, err)
+	}
+	return conn, nil
+}
+
+func createConnector(ctx context.Context, set component.ConnectorCreateSettings, cfg config.Connector, key connectorKey, next baseConsumer, factory component.ConnectorFactory) (component.Connector, error) {
+	switch key.exporterType {
+	case config.TracesDataType:
+		switch key.receiverType {
+		case config.TracesDataType:
+			return factory.CreateTracesToTracesConnector(ctx, set, cfg, next.(consumer.Traces))
+		case config.MetricsDataType:
+			return factory.CreateTracesToMetricsConnector(ctx, set, cfg, next.(consumer.Metrics))
+		case config.LogsDataType:
+			return factory.CreateTracesToLogsConnector(ctx, set, cfg, next.(consumer.Logs))
+		}
+	case config.MetricsDataType:
+		switch key.receiverType {
+		case config.TracesDataType:
+			return factory.CreateMetricsToTracesConnector(ctx, set, cfg, next.(consumer.Traces))
+		case config.MetricsDataType:
+			return factory.CreateMetricsToMetricsConnector(ctx, set, cfg, next.(consumer.Metrics))
+		case config.LogsDataType:
+			return factory.CreateMetricsToLogsConnector(ctx, set, cfg, next.(consumer.Logs))
+		}
+	case config.LogsDataType:
+		switch key.receiverType {
+		case config.TracesDataType:
+			return factory.CreateLogsToTracesConnector(ctx, set, cfg, next.(consumer.Traces))
+		case config.MetricsDataType:
+			return factory.CreateLogsToMetricsConnector(ctx, set, cfg, next.(consumer.Metrics))
+		case config.LogsDataType:
+			return factory.CreateLogsToLogsConnector(ctx, set, cfg, next.(consumer.Logs))
+		}
+	}
+	return nil, fmt.Errorf("error creating connector %q, connecting data type %q to %q is not supported", key.id, key.exporterType, key.receiverType)
+}
+
+func connectorLogger(logger *zap.Logger, key connectorKey) *zap.Logger {
+	return logger.With(
+		zap.String(components.ZapKindKey, components.ZapKindConnector),
+		zap.String(components.ZapNameKey, key.id.String()),
+		zap.String(components.ZapDataTypeKey, string(key.exporterType)),
+		zap.String(components.ZapEmittedDataTypeKey, string(key.receiverType)))
+}
+
+// getConnectorStabilityLevel returns the stability level of the connector consuming the data type from
+// and emitting the data type to, StabilityLevelUndefined if the connector does not support it.
+func getConnectorStabilityLevel(factory component.ConnectorFactory, from, to config.DataType) component.StabilityLevel {
+	switch from {
+	case config.TracesDataType:
+		switch to {
+		case config.TracesDataType:
+			return factory.TracesToTracesConnectorStability()
+		case config.MetricsDataType:
+			return factory.TracesToMetricsConnectorStability()
+		case config.LogsDataType:
+			return factory.TracesToLogsConnectorStability()
+		}
+	case config.MetricsDataType:
+		switch to {
+		case config.TracesDataType:
+			return factory.MetricsToTracesConnectorStability()
+		case config.MetricsDataType:
+			return factory.MetricsToMetricsConnectorStability()
+		case config.LogsDataType:
+			return factory.MetricsToLogsConnectorStability()
+		}
+	case config.LogsDataType:
+		switch to {
+		case config.TracesDataType:
+			return factory.LogsToTracesConnectorStability()
+		case config.MetricsDataType:
+			return factory.LogsToMetricsConnectorStability()
+		case config.LogsDataType:
+			return factory.LogsToLogsConnectorStability()
+		}
+	}
+	return component.StabilityLevelUndefined
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+// connection is an edge of the pipelines graph, from a pipeline where the connector is an exporter
+// to a pipeline where it is a receiver.
+type connection struct {
+	connID config.ComponentID
+	to     config.ComponentID
+}
+
+// pipelinesGraph is the graph of the pipelines joined by the connectors. A pipeline is connected
+// to another one when a connector is an exporter in the first and a receiver in the second, and
+// supports consuming the data type of the first while emitting the data type of the second.
+type pipelinesGraph struct {
+	// pipelineIDs are the IDs of all pipelines, sorted.
+	pipelineIDs []config.ComponentID
+	connections map[config.ComponentID][]connection
+}
+
+// newPipelinesGraph connects the pipelines through the connectors, and checks that every connector can
+// emit the data it consumes in a pipeline to at least one other pipeline, and conversely.
+func newPipelinesGraph(set Settings) (*pipelinesGraph, error) {
+	g := &pipelinesGraph{
+		pipelineIDs: make([]config.ComponentID, 0, len(set.PipelineConfigs)),
+		connections: make(map[config.ComponentID][]connection),
+	}
+	for pipelineID := range set.PipelineConfigs {
+		g.pipelineIDs = append(g.pipelineIDs, pipelineID)
+	}
+	sort.Slice(g.pipelineIDs, func(i, j int) bool {
+		return g.pipelineIDs[i].String() < g.pipelineIDs[j].String()
+	})
+
+	// The pipelines each connector is a receiver in, sorted.
+	receiverPipelines := make(map[config.ComponentID][]config.ComponentID)
+	for _, pipelineID := range g.pipelineIDs {
+		for _, recvID := range set.PipelineConfigs[pipelineID].Receivers {
+			if _, ok := set.ConnectorConfigs[recvID]; ok {
+				receiverPipelines[recvID] = append(receiverPipelines[recvID], pipelineID)
+			}
+		}
+	}
+
+	// The connectors, by pipeline they are a receiver in, consuming the data of at least one pipeline.
+	consumingConnectors := make(map[config.ComponentID]map[config.ComponentID]bool)
+	for _, pipelineID := range g.pipelineIDs {
+		for _, expID := range set.PipelineConfigs[pipelineID].Exporters {
+			if _, ok := set.ConnectorConfigs[expID]; !ok {
+				continue
+			}
+			factory, ok := set.ConnectorFactories[expID.Type()]
+			if !ok {
+				return nil, fmt.Errorf("connector factory not available for: %q", expID)
+			}
+			connected := false
+			for _, toID := range receiverPipelines[expID] {
+				if getConnectorStabilityLevel(factory, pipelineID.Type(), toID.Type()) == component.StabilityLevelUndefined {
+					continue
+				}
+				g.connections[pipelineID] = append(g.connections[pipelineID], connection{connID: expID, to: toID})
+				if consumingConnectors[toID] == nil {
+					consumingConnectors[toID] = make(map[config.ComponentID]bool)
+				}
+				consumingConnectors[toID][expID] = true
+				connected = true
+			}
+			if !connected {
+				return nil, fmt.Errorf("connector %q used as an exporter in pipeline %q cannot emit data to any of the pipelines it is a receiver in", expID, pipelineID)
+			}
+		}
+	}
+
+	for _, pipelineID := range g.pipelineIDs {
+		for _, recvID := range set.PipelineConfigs[pipelineID].Receivers {
+			if _, ok := set.ConnectorConfigs[recvID]; ok && !consumingConnectors[pipelineID][recvID] {
+				return nil, fmt.Errorf("connector %q used as a receiver in pipeline %q cannot consume data from any of the pipelines it is an exporter in", recvID, pipelineID)
+			}
+		}
+	}
+	return g, nil
+}
+
+// buildOrder returns the IDs of the pipelines in the order they must be built, every pipeline after
+// the pipelines it is connected to, or an error if the connections form a cycle.
+func (g *pipelinesGraph) buildOrder() ([]config.ComponentID, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[config.ComponentID]int, len(g.pipelineIDs))
+	order := make([]config.ComponentID, 0, len(g.pipelineIDs))
+	// path holds the pipelines being visited, joined by the connectors, to report cycles.
+	var path []string
+
+	var visit func(pipelineID config.ComponentID) error
+	visit = func(pipelineID config.ComponentID) error {
+		switch state[pipelineID] {
+		case visited:
+			return nil
+		case visiting:
+			path = append(path, fmt.Sprintf("pipeline %q", pipelineID))
+			return fmt.Errorf("cycle detected: %s", strings.Join(path[cycleStart(path, pipelineID):], " -> "))
+		}
+		state[pipelineID] = visiting
+		path = append(path, fmt.Sprintf("pipeline %q", pipelineID))
+		for _, conn := range g.connections[pipelineID] {
+			path = append(path, fmt.Sprintf("connector %q", conn.connID))
+			if err := visit(conn.to); err != nil {
+				return err
+			}
+			path = path[:len(path)-1]
+		}
+		path = path[:len(path)-1]
+		state[pipelineID] = visited
+		order = append(order, pipelineID)
+		return nil
+	}
+
+	for _, pipelineID := range g.pipelineIDs {
+		if err := visit(pipelineID); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// cycleStart returns the index in path where the cycle returning to the pipeline starts.
+func cycleStart(path []string, pipelineID config.ComponentID) int {
+	step := fmt.Sprintf("pipeline %q", pipelineID)
+	for i, s := range path {
+		if s == step {
+			return i
+		}
+	}
+	return 0
+}
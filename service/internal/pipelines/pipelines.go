@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+	"go.opentelemetry.io/collector/service/internal/tapconsumer"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -43,26 +44,50 @@ type baseConsumer interface {
 }
 
 type builtComponent struct {
-	id   config.ComponentID
+	id config.ComponentID
+	// comp is nil for the connectors, which have an instance per pair of connected data types in Pipelines.allConnectors.
 	comp component.Component
 }
 
+// tapKey identifies the position of a pipeline after a receiver, a connector or a processor, where data can be tapped.
+type tapKey struct {
+	pipelineID config.ComponentID
+	kind       component.Kind
+	id         config.ComponentID
+}
+
 type builtPipeline struct {
 	lastConsumer baseConsumer
 
 	receivers  []builtComponent
 	processors []builtComponent
 	exporters  []builtComponent
+
+	// connectors are the connector instances created while building the pipeline, started right before its processors.
+	connectors []connectorKey
+
+	// asyncFanOuts are the asynchronous fan outs to the exporters, waited for before shutting down the exporters.
+	asyncFanOuts []fanoutconsumer.AsyncConsumer
 }
 
 // Pipelines is set of all pipelines created from exporter configs.
 type Pipelines struct {
-	telemetry component.TelemetrySettings
+	telemetry      component.TelemetrySettings
+	statusReporter components.StatusReporter
 
 	allReceivers map[config.DataType]map[config.ComponentID]component.Receiver
 	allExporters map[config.DataType]map[config.ComponentID]component.Exporter
 
+	allConnectors map[connectorKey]component.Connector
+
 	pipelines map[config.ComponentID]*builtPipeline
+	// order is the order the pipelines are built in, every pipeline after the pipelines it emits data to through connectors.
+	order []config.ComponentID
+	// graph is the topology of the component instances.
+	graph *Graph
+
+	// taps are the tapconsumer instances after the receivers and processors of the pipelines.
+	taps map[tapKey]baseConsumer
 }
 
 // StartAll starts all pipelines.
@@ -70,13 +95,16 @@ type Pipelines struct {
 // Start with exporters, processors (in reverse configured order), then receivers.
 // This is important so that components that are earlier in the pipeline and reference components that are
 // later in the pipeline do not start sending data to later components which are not yet started.
+// The connectors are started after the processors of the pipelines they emit data to, and before the
+// processors of the pipelines they consume data from.
 func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
 	bps.telemetry.Logger.Info("Starting exporters...")
 	for dt, expByID := range bps.allExporters {
 		for expID, exp := range expByID {
 			expLogger := exporterLogger(bps.telemetry.Logger, expID, dt)
 			expLogger.Info("Exporter is starting...")
-			if err := exp.Start(ctx, components.NewHostWrapper(host, expLogger)); err != nil {
+			source := bps.statusSource(component.KindExporter, expID, dt)
+			if err := bps.start(ctx, exp, host, source, expLogger); err != nil {
 				return err
 			}
 			expLogger.Info("Exporter started.")
@@ -84,11 +112,21 @@ func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
 	}
 
 	bps.telemetry.Logger.Info("Starting processors...")
-	for pipelineID, bp := range bps.pipelines {
+	for _, pipelineID := range bps.order {
+		bp := bps.pipelines[pipelineID]
+		for _, key := range bp.connectors {
+			connLogger := connectorLogger(bps.telemetry.Logger, key)
+			connLogger.Info("Connector is starting...")
+			if err := bps.start(ctx, bps.allConnectors[key], host, bps.connectorStatusSource(key), connLogger); err != nil {
+				return err
+			}
+			connLogger.Info("Connector started.")
+		}
 		for i := len(bp.processors) - 1; i >= 0; i-- {
 			procLogger := processorLogger(bps.telemetry.Logger, bp.processors[i].id, pipelineID)
 			procLogger.Info("Processor is starting...")
-			if err := bp.processors[i].comp.Start(ctx, components.NewHostWrapper(host, procLogger)); err != nil {
+			source := processorStatusSource(bp.processors[i].id, pipelineID)
+			if err := bps.start(ctx, bp.processors[i].comp, host, source, procLogger); err != nil {
 				return err
 			}
 			procLogger.Info("Processor started.")
@@ -100,7 +138,8 @@ func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
 		for recvID, recv := range recvByID {
 			recvLogger := receiverLogger(bps.telemetry.Logger, recvID, dt)
 			recvLogger.Info("Receiver is starting...")
-			if err := recv.Start(ctx, components.NewHostWrapper(host, recvLogger)); err != nil {
+			source := bps.statusSource(component.KindReceiver, recvID, dt)
+			if err := bps.start(ctx, recv, host, source, recvLogger); err != nil {
 				return err
 			}
 			recvLogger.Info("Receiver started.")
@@ -109,6 +148,62 @@ func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
 	return nil
 }
 
+// start starts the component instance identified by source, and reports the statuses of its start.
+func (bps *Pipelines) start(ctx context.Context, comp component.Component, host component.Host, source *component.StatusSource, logger *zap.Logger) error {
+	components.ReportStatus(bps.statusReporter, source, component.StatusStarting, nil)
+	if err := comp.Start(ctx, components.NewHostWrapper(host, source, bps.statusReporter, logger)); err != nil {
+		components.ReportStatus(bps.statusReporter, source, component.StatusPermanentError, err)
+		return err
+	}
+	components.ReportStatus(bps.statusReporter, source, component.StatusOK, nil)
+	return nil
+}
+
+// shutdown shuts down the component instance identified by source, and reports the statuses of its shutdown.
+func (bps *Pipelines) shutdown(ctx context.Context, comp component.Component, source *component.StatusSource) error {
+	components.ReportStatus(bps.statusReporter, source, component.StatusStopping, nil)
+	if err := comp.Shutdown(ctx); err != nil {
+		components.ReportStatus(bps.statusReporter, source, component.StatusPermanentError, err)
+		return err
+	}
+	components.ReportStatus(bps.statusReporter, source, component.StatusStopped, nil)
+	return nil
+}
+
+// statusSource returns the source of the statuses of the receiver or exporter instance handling
+// the data type dt, with the pipelines it is part of.
+func (bps *Pipelines) statusSource(kind component.Kind, id config.ComponentID, dt config.DataType) *component.StatusSource {
+	source := &component.StatusSource{Kind: kind, ID: id, DataType: dt}
+	for pipelineID, bp := range bps.pipelines {
+		if pipelineID.Type() != dt {
+			continue
+		}
+		comps := bp.receivers
+		if kind == component.KindExporter {
+			comps = bp.exporters
+		}
+		for _, c := range comps {
+			if c.id == id {
+				source.Pipelines = append(source.Pipelines, pipelineID)
+				break
+			}
+		}
+	}
+	sort.Slice(source.Pipelines, func(i, j int) bool {
+		return source.Pipelines[i].String() < source.Pipelines[j].String()
+	})
+	return source
+}
+
+func processorStatusSource(id config.ComponentID, pipelineID config.ComponentID) *component.StatusSource {
+	return &component.StatusSource{
+		Kind:      component.KindProcessor,
+		ID:        id,
+		DataType:  pipelineID.Type(),
+		Pipelines: []config.ComponentID{pipelineID},
+	}
+}
+
 // ShutdownAll stops all pipelines.
 //
 // Shutdown order is the reverse of starting: receivers, processors, then exporters.
@@ -116,29 +211,43 @@ func (bps *Pipelines) StartAll(ctx context.Context, host component.Host) error {
 func (bps *Pipelines) ShutdownAll(ctx context.Context) error {
 	var errs error
 	bps.telemetry.Logger.Info("Stopping receivers...")
-	for _, recvByID := range bps.allReceivers {
-		for _, recv := range recvByID {
-			errs = multierr.Append(errs, recv.Shutdown(ctx))
+	for dt, recvByID := range bps.allReceivers {
+		for recvID, recv := range recvByID {
+			errs = multierr.Append(errs, bps.shutdown(ctx, recv, bps.statusSource(component.KindReceiver, recvID, dt)))
 		}
 	}
 
 	bps.telemetry.Logger.Info("Stopping processors...")
-	for _, bp := range bps.pipelines {
+	for i := len(bps.order) - 1; i >= 0; i-- {
+		pipelineID := bps.order[i]
+		bp := bps.pipelines[pipelineID]
 		for _, p := range bp.processors {
-			errs = multierr.Append(errs, p.comp.Shutdown(ctx))
+			errs = multierr.Append(errs, bps.shutdown(ctx, p.comp, processorStatusSource(p.id, pipelineID)))
+		}
+		// The exporters and connectors may still be consuming data acknowledged by an asynchronous fan out.
+		if err := waitAsyncFanOuts(ctx, bp.asyncFanOuts); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed waiting for the asynchronous fan out of pipeline %q: %w", pipelineID, err))
+		}
+		for _, key := range bp.connectors {
+			errs = multierr.Append(errs, bps.shutdown(ctx, bps.allConnectors[key], bps.connectorStatusSource(key)))
 		}
 	}
 
 	bps.telemetry.Logger.Info("Stopping exporters...")
-	for _, expByID := range bps.allExporters {
-		for _, exp := range expByID {
-			errs = multierr.Append(errs, exp.Shutdown(ctx))
+	for dt, expByID := range bps.allExporters {
+		for expID, exp := range expByID {
+			errs = multierr.Append(errs, bps.shutdown(ctx, exp, bps.statusSource(component.KindExporter, expID, dt)))
 		}
 	}
 
 	return errs
 }
 
+// Graph returns the topology of the component instances of the pipelines.
+func (bps *Pipelines) Graph() *Graph {
+	return bps.graph
+}
+
 func (bps *Pipelines) GetExporters() map[config.DataType]map[config.ComponentID]component.Exporter {
 	exportersMap := make(map[config.DataType]map[config.ComponentID]component.Exporter)
 
@@ -155,16 +264,33 @@ func (bps *Pipelines) GetExporters() map[config.DataType]map[config.ComponentID]
 	return exportersMap
 }
 
+// GetLogsConsumer returns the first consumer of the logs pipeline with the given ID, or nil if it does not exist.
+func (bps *Pipelines) GetLogsConsumer(pipelineID config.ComponentID) consumer.Logs {
+	bp, ok := bps.pipelines[pipelineID]
+	if !ok || pipelineID.Type() != config.LogsDataType {
+		return nil
+	}
+	return bp.lastConsumer.(consumer.Logs)
+}
+
 func (bps *Pipelines) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	qValues := r.URL.Query()
 	pipelineName := qValues.Get(zPipelineName)
 	componentName := qValues.Get(zComponentName)
 	componentKind := qValues.Get(zComponentKind)
 
+	data := bps.getPipelinesSummaryTableData()
+	if pipelineName != "" && componentName != "" && componentKind != "" {
+		data.Component = &zpages.SelectedComponentData{Kind: componentKind, Name: componentName, Pipeline: pipelineName}
+	}
+	if zpages.WantsJSON(r) {
+		zpages.WriteJSON(w, data)
+		return
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipelines"})
-	zpages.WriteHTMLPipelinesSummaryTable(w, bps.getPipelinesSummaryTableData())
-	if pipelineName != "" && componentName != "" && componentKind != "" {
+	zpages.WriteHTMLPipelinesSummaryTable(w, data)
+	if data.Component != nil {
 		fullName := componentName
 		if componentKind == "processor" {
 			fullName = pipelineName + "/" + componentName
@@ -177,6 +303,19 @@ func (bps *Pipelines) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	zpages.WriteHTMLPageFooter(w)
 }
 
+// HandleQueuesZPages serves the page with the state of the sending queues and retries of the exporters.
+func (bps *Pipelines) HandleQueuesZPages(w http.ResponseWriter, r *http.Request) {
+	data := bps.getQueuesTableData()
+	if zpages.WantsJSON(r) {
+		zpages.WriteJSON(w, data)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Exporter Queues"})
+	zpages.WriteHTMLQueuesTable(w, data)
+	zpages.WriteHTMLPageFooter(w)
+}
+
 // Settings holds configuration for building Pipelines.
 type Settings struct {
 	Telemetry component.TelemetrySettings
@@ -200,25 +339,55 @@ type Settings struct {
 	// ExporterConfigs is a map of config.ComponentID to config.Exporter.
 	ExporterConfigs map[config.ComponentID]config.Exporter
 
+	// ConnectorFactories maps connector type names in the config to the respective component.ConnectorFactory.
+	ConnectorFactories map[config.Type]component.ConnectorFactory
+
+	// ConnectorConfigs is a map of config.ComponentID to config.Connector.
+	ConnectorConfigs map[config.ComponentID]config.Connector
+
 	// PipelineConfigs is a map of config.ComponentID to config.Pipeline.
 	PipelineConfigs map[config.ComponentID]*config.Pipeline
+
+	// StatusReporter receives the statuses of the components, it can be nil.
+	StatusReporter components.StatusReporter
 }
 
 // Build builds all pipelines from config.
+//
+// The pipelines joined by connectors form a graph, which must not have cycles. Every pipeline is built
+// after the pipelines it emits data to through connectors, to create the connectors with the first
+// consumers of these pipelines.
 func Build(ctx context.Context, set Settings) (*Pipelines, error) {
+	graph, err := newPipelinesGraph(set)
+	if err != nil {
+		return nil, err
+	}
+	order, err := graph.buildOrder()
+	if err != nil {
+		return nil, err
+	}
+
 	exps := &Pipelines{
-		telemetry:    set.Telemetry,
-		allReceivers: make(map[config.DataType]map[config.ComponentID]component.Receiver),
-		allExporters: make(map[config.DataType]map[config.ComponentID]component.Exporter),
-		pipelines:    make(map[config.ComponentID]*builtPipeline, len(set.PipelineConfigs)),
+		telemetry:      set.Telemetry,
+		statusReporter: set.StatusReporter,
+		allReceivers:   make(map[config.DataType]map[config.ComponentID]component.Receiver),
+		allExporters:   make(map[config.DataType]map[config.ComponentID]component.Exporter),
+		allConnectors:  make(map[connectorKey]component.Connector),
+		pipelines:      make(map[config.ComponentID]*builtPipeline, len(set.PipelineConfigs)),
+		order:          order,
+		graph:          newGraph(set, graph),
+		taps:           make(map[tapKey]baseConsumer),
 	}
 
 	receiversConsumers := make(map[config.DataType]map[config.ComponentID][]baseConsumer)
+	// receiversPipelines holds the pipelines of each receiver, to which its authorization rules apply.
+	receiversPipelines := make(map[config.DataType]map[config.ComponentID][]config.ComponentID)
 
-	// Iterate over all pipelines, and create exporters, then processors.
+	// Iterate over all pipelines, and create exporters and connectors, then processors.
 	// Receivers cannot be created since we need to know all consumers, a.k.a. we need all pipelines build up to the
 	// first processor.
-	for pipelineID, pipeline := range set.PipelineConfigs {
+	for _, pipelineID := range order {
+		pipeline := set.PipelineConfigs[pipelineID]
 		// The data type of the pipeline defines what data type each exporter is expected to receive.
 		if _, ok := exps.allExporters[pipelineID.Type()]; !ok {
 			exps.allExporters[pipelineID.Type()] = make(map[config.ComponentID]component.Exporter)
@@ -232,11 +401,41 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 		}
 		exps.pipelines[pipelineID] = bp
 
+		consumersByExporter := make(map[config.ComponentID][]baseConsumer, len(pipeline.Exporters))
 		// Iterate over all Exporters for this pipeline.
 		for i, expID := range pipeline.Exporters {
+			bp.exporters[i] = builtComponent{id: expID}
+
+			if _, ok := set.ConnectorConfigs[expID]; ok {
+				// The pipelines the connector emits data to are already built.
+				for _, conn := range graph.connections[pipelineID] {
+					if conn.connID != expID {
+						continue
+					}
+					key := connectorKey{id: expID, exporterType: pipelineID.Type(), receiverType: conn.to.Type()}
+					if c, ok := exps.allConnectors[key]; ok {
+						// The connector instance emits data to all the pipelines of the type, only add it once.
+						if !containsConsumer(consumersByExporter[expID], c.(baseConsumer)) {
+							consumersByExporter[expID] = append(consumersByExporter[expID], c.(baseConsumer))
+						}
+						continue
+					}
+					c, err := buildConnector(ctx, set.Telemetry, set.BuildInfo, set.ConnectorConfigs, set.ConnectorFactories, key,
+						buildFanOutConsumer(key.receiverType, receiversConsumers[key.receiverType][expID]))
+					if err != nil {
+						return nil, err
+					}
+					exps.allConnectors[key] = c
+					bp.connectors = append(bp.connectors, key)
+					consumersByExporter[expID] = append(consumersByExporter[expID], c.(baseConsumer))
+				}
+				continue
+			}
+
 			// If already created an exporter for this [DataType, ComponentID] nothing to do, will reuse this instance.
 			if exp, ok := expByID[expID]; ok {
-				bp.exporters[i] = builtComponent{id: expID, comp: exp}
+				bp.exporters[i].comp = exp
+				consumersByExporter[expID] = append(consumersByExporter[expID], exp.(baseConsumer))
 				continue
 			}
 
@@ -245,19 +444,14 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 				return nil, err
 			}
 
-			bp.exporters[i] = builtComponent{id: expID, comp: exp}
+			bp.exporters[i].comp = exp
 			expByID[expID] = exp
+			consumersByExporter[expID] = append(consumersByExporter[expID], exp.(baseConsumer))
 		}
 
-		// Build a fan out consumer to all exporters.
-		switch pipelineID.Type() {
-		case config.TracesDataType:
-			bp.lastConsumer = buildFanOutExportersTracesConsumer(bp.exporters)
-		case config.MetricsDataType:
-			bp.lastConsumer = buildFanOutExportersMetricsConsumer(bp.exporters)
-		case config.LogsDataType:
-			bp.lastConsumer = buildFanOutExportersLogsConsumer(bp.exporters)
-		default:
+		// Build a fan out consumer to all exporters and connectors, or a router to the ones of each route.
+		bp.lastConsumer = exps.buildExportersConsumer(bp, pipelineID, pipeline, consumersByExporter)
+		if bp.lastConsumer == nil {
 			return nil, fmt.Errorf("create fan-out exporter in pipeline %q, data type %q is not supported", pipelineID, pipelineID.Type())
 		}
 
@@ -268,7 +462,8 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 		for i := len(pipeline.Processors) - 1; i >= 0; i-- {
 			procID := pipeline.Processors[i]
 
-			proc, err := buildProcessor(ctx, set.Telemetry, set.BuildInfo, set.ProcessorConfigs, set.ProcessorFactories, procID, pipelineID, bp.lastConsumer)
+			next := exps.newTap(pipelineID, component.KindProcessor, procID, bp.lastConsumer)
+			proc, err := buildProcessor(ctx, set.Telemetry, set.BuildInfo, set.ProcessorConfigs, set.ProcessorFactories, procID, pipelineID, next)
 			if err != nil {
 				return nil, err
 			}
@@ -296,14 +491,25 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 			receiversConsumers[pipelineID.Type()] = make(map[config.ComponentID][]baseConsumer)
 		}
 		recvConsByID := receiversConsumers[pipelineID.Type()]
-		// Iterate over all Receivers for this pipeline and just append the lastConsumer as a consumer for the receiver.
+		if _, ok := receiversPipelines[pipelineID.Type()]; !ok {
+			receiversPipelines[pipelineID.Type()] = make(map[config.ComponentID][]config.ComponentID)
+		}
+		// Iterate over all Receivers and connectors for this pipeline and just append the lastConsumer as a consumer
+		// for them, through a tap to see the data they pass to this pipeline.
+		// The data of receivers is only passed to the pipelines their authorization rules allow.
 		for _, recvID := range pipeline.Receivers {
-			recvConsByID[recvID] = append(recvConsByID[recvID], bp.lastConsumer)
+			if _, ok := set.ConnectorConfigs[recvID]; ok {
+				recvConsByID[recvID] = append(recvConsByID[recvID], exps.newTap(pipelineID, component.KindConnector, recvID, bp.lastConsumer))
+				continue
+			}
+			recvConsByID[recvID] = append(recvConsByID[recvID], authorizedConsumer(pipelineID, exps.newTap(pipelineID, component.KindReceiver, recvID, bp.lastConsumer)))
+			receiversPipelines[pipelineID.Type()][recvID] = append(receiversPipelines[pipelineID.Type()][recvID], pipelineID)
 		}
 	}
 
 	// Now that we built the `receiversConsumers` map, we can build the receivers as well.
-	for pipelineID, pipeline := range set.PipelineConfigs {
+	for _, pipelineID := range order {
+		pipeline := set.PipelineConfigs[pipelineID]
 		// The data type of the pipeline defines what data type each exporter is expected to receive.
 		if _, ok := exps.allReceivers[pipelineID.Type()]; !ok {
 			exps.allReceivers[pipelineID.Type()] = make(map[config.ComponentID]component.Receiver)
@@ -313,13 +519,19 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 
 		// Iterate over all Receivers for this pipeline.
 		for i, recvID := range pipeline.Receivers {
+			// The connectors were created with the exporters.
+			if _, ok := set.ConnectorConfigs[recvID]; ok {
+				bp.receivers[i] = builtComponent{id: recvID}
+				continue
+			}
+
 			// If already created a receiver for this [DataType, ComponentID] nothing to do.
 			if exp, ok := recvByID[recvID]; ok {
 				bp.receivers[i] = builtComponent{id: recvID, comp: exp}
 				continue
 			}
 
-			recv, err := buildReceiver(ctx, set.Telemetry, set.BuildInfo, set.ReceiverConfigs, set.ReceiverFactories, recvID, pipelineID, receiversConsumers[pipelineID.Type()][recvID])
+			recv, err := buildReceiver(ctx, set.Telemetry, set.BuildInfo, set.ReceiverConfigs, set.ReceiverFactories, recvID, pipelineID, receiversConsumers[pipelineID.Type()][recvID], receiversPipelines[pipelineID.Type()][recvID])
 			if err != nil {
 				return nil, err
 			}
@@ -331,6 +543,84 @@ func Build(ctx context.Context, set Settings) (*Pipelines, error) {
 	return exps, nil
 }
 
+func containsConsumer(consumers []baseConsumer, c baseConsumer) bool {
+	for _, cons := range consumers {
+		if cons == c {
+			return true
+		}
+	}
+	return false
+}
+
+// newTap returns a tapconsumer passing the data to next, to tap the data after the receiver, connector or
+// processor identified by kind and id in the pipeline.
+func (bps *Pipelines) newTap(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, next baseConsumer) baseConsumer {
+	var tap baseConsumer
+	switch pipelineID.Type() {
+	case config.TracesDataType:
+		tap = tapconsumer.NewTraces(next.(consumer.Traces))
+	case config.MetricsDataType:
+		tap = tapconsumer.NewMetrics(next.(consumer.Metrics))
+	case config.LogsDataType:
+		tap = tapconsumer.NewLogs(next.(consumer.Logs))
+	default:
+		return next
+	}
+	// With a processor present multiple times in the pipeline, the data is tapped after its first occurrence.
+	bps.taps[tapKey{pipelineID: pipelineID, kind: kind, id: id}] = tap
+	return tap
+}
+
+// TapTraces attaches tc after the receiver, connector or processor identified by kind and id in the traces pipeline
+// pipelineID, and returns the function detaching it. The data is passed synchronously to tc, which must
+// not keep references to it after returning.
+func (bps *Pipelines) TapTraces(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, tc consumer.Traces) (func(), error) {
+	tap, err := bps.getTap(pipelineID, config.TracesDataType, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	return tap.(*tapconsumer.Traces).Attach(tc), nil
+}
+
+// TapMetrics attaches mc after the receiver, connector or processor identified by kind and id in the metrics pipeline
+// pipelineID, and returns the function detaching it. The data is passed synchronously to mc, which must
+// not keep references to it after returning.
+func (bps *Pipelines) TapMetrics(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, mc consumer.Metrics) (func(), error) {
+	tap, err := bps.getTap(pipelineID, config.MetricsDataType, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	return tap.(*tapconsumer.Metrics).Attach(mc), nil
+}
+
+// TapLogs attaches lc after the receiver, connector or processor identified by kind and id in the logs pipeline
+// pipelineID, and returns the function detaching it. The data is passed synchronously to lc, which must
+// not keep references to it after returning.
+func (bps *Pipelines) TapLogs(pipelineID config.ComponentID, kind component.Kind, id config.ComponentID, lc consumer.Logs) (func(), error) {
+	tap, err := bps.getTap(pipelineID, config.LogsDataType, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	return tap.(*tapconsumer.Logs).Attach(lc), nil
+}
+
+func (bps *Pipelines) getTap(pipelineID config.ComponentID, dt config.DataType, kind component.Kind, id config.ComponentID) (baseConsumer, error) {
+	if _, ok := bps.pipelines[pipelineID]; !ok {
+		return nil, fmt.Errorf("pipeline %q does not exist", pipelineID)
+	}
+	if pipelineID.Type() != dt {
+		return nil, fmt.Errorf("pipeline %q is not a %s pipeline", pipelineID, dt)
+	}
+	if kind != component.KindReceiver && kind != component.KindConnector && kind != component.KindProcessor {
+		return nil, fmt.Errorf("data can only be tapped after receivers, connectors and processors, not after %ss", kind)
+	}
+	tap, ok := bps.taps[tapKey{pipelineID: pipelineID, kind: kind, id: id}]
+	if !ok {
+		return nil, fmt.Errorf("%s %q is not in pipeline %q", kind, id, pipelineID)
+	}
+	return tap, nil
+}
+
 func buildExporter(
 	ctx context.Context,
 	settings component.TelemetrySettings,
@@ -379,31 +669,162 @@ func createExporter(ctx context.Context, set component.ExporterCreateSettings, c
 	return nil, fmt.Errorf("error creating exporter %q in pipeline %q, data type %q is not supported", id, pipelineID, pipelineID.Type())
 }
 
-func buildFanOutExportersTracesConsumer(exporters []builtComponent) consumer.Traces {
-	consumers := make([]consumer.Traces, 0, len(exporters))
-	for _, exp := range exporters {
-		consumers = append(consumers, exp.comp.(consumer.Traces))
+// buildExportersConsumer builds the consumer passing the data of the pipeline to its exporters and connectors:
+// to all of them, or to the ones of the route of each resource when the pipeline has routing.
+func (bps *Pipelines) buildExportersConsumer(bp *builtPipeline, pipelineID config.ComponentID, pipeline *config.Pipeline, consumersByExporter map[config.ComponentID][]baseConsumer) baseConsumer {
+	if pipeline.Routing == nil {
+		return bps.buildExportersFanOut(bp, pipelineID, pipeline.Fanout, pipeline.Exporters, consumersByExporter)
+	}
+
+	dt := pipelineID.Type()
+	routes := make([]fanoutconsumer.Route, 0, len(pipeline.Routing.Routes))
+	routesConsumers := make([]baseConsumer, 0, len(pipeline.Routing.Routes))
+	for _, route := range pipeline.Routing.Routes {
+		routes = append(routes, fanoutconsumer.Route{ResourceAttributes: route.ResourceAttributes, Metadata: route.Metadata})
+		routesConsumers = append(routesConsumers, bps.buildExportersFanOut(bp, pipelineID, pipeline.Fanout, route.Exporters, consumersByExporter))
 	}
-	// Create a junction point that fans out to all allExporters.
-	return fanoutconsumer.NewTraces(consumers)
+	defaultConsumer := bps.buildExportersFanOut(bp, pipelineID, pipeline.Fanout, pipeline.Routing.DefaultExporters, consumersByExporter)
+
+	switch dt {
+	case config.TracesDataType:
+		tracesConsumers := make([]consumer.Traces, 0, len(routesConsumers))
+		for _, c := range routesConsumers {
+			tracesConsumers = append(tracesConsumers, c.(consumer.Traces))
+		}
+		return fanoutconsumer.NewTracesRouter(routes, tracesConsumers, defaultConsumer.(consumer.Traces))
+	case config.MetricsDataType:
+		metricsConsumers := make([]consumer.Metrics, 0, len(routesConsumers))
+		for _, c := range routesConsumers {
+			metricsConsumers = append(metricsConsumers, c.(consumer.Metrics))
+		}
+		return fanoutconsumer.NewMetricsRouter(routes, metricsConsumers, defaultConsumer.(consumer.Metrics))
+	case config.LogsDataType:
+		logsConsumers := make([]consumer.Logs, 0, len(routesConsumers))
+		for _, c := range routesConsumers {
+			logsConsumers = append(logsConsumers, c.(consumer.Logs))
+		}
+		return fanoutconsumer.NewLogsRouter(routes, logsConsumers, defaultConsumer.(consumer.Logs))
+	}
+	return nil
 }
 
-func buildFanOutExportersMetricsConsumer(exporters []builtComponent) consumer.Metrics {
-	consumers := make([]consumer.Metrics, 0, len(exporters))
-	for _, exp := range exporters {
-		consumers = append(consumers, exp.comp.(consumer.Metrics))
+// buildExportersFanOut builds the fan out to the given exporters and connectors of the pipeline. With an asynchronous
+// fan out, each exporter is a branch of the fan out, dispatched concurrently to the others.
+func (bps *Pipelines) buildExportersFanOut(bp *builtPipeline, pipelineID config.ComponentID, fanout *config.PipelineFanout, exporters []config.ComponentID, consumersByExporter map[config.ComponentID][]baseConsumer) baseConsumer {
+	dt := pipelineID.Type()
+	if fanout == nil || !fanout.Async {
+		return buildFanOutConsumer(dt, collectConsumers(exporters, consumersByExporter))
 	}
-	// Create a junction point that fans out to all allExporters.
-	return fanoutconsumer.NewMetrics(consumers)
+
+	var ids []config.ComponentID
+	var branches []baseConsumer
+	for _, expID := range exporters {
+		if containsID(ids, expID) {
+			continue
+		}
+		ids = append(ids, expID)
+		branches = append(branches, buildFanOutConsumer(dt, consumersByExporter[expID]))
+	}
+	set := fanoutconsumer.AsyncSettings{
+		TelemetrySettings: bps.telemetry,
+		PipelineID:        pipelineID,
+		Quorum:            fanout.Quorum,
+		Primary:           fanout.Primary,
+	}
+
+	var fanOut baseConsumer
+	switch dt {
+	case config.TracesDataType:
+		tracesConsumers := make([]consumer.Traces, 0, len(branches))
+		for _, c := range branches {
+			tracesConsumers = append(tracesConsumers, c.(consumer.Traces))
+		}
+		fanOut = fanoutconsumer.NewAsyncTraces(set, ids, tracesConsumers)
+	case config.MetricsDataType:
+		metricsConsumers := make([]consumer.Metrics, 0, len(branches))
+		for _, c := range branches {
+			metricsConsumers = append(metricsConsumers, c.(consumer.Metrics))
+		}
+		fanOut = fanoutconsumer.NewAsyncMetrics(set, ids, metricsConsumers)
+	case config.LogsDataType:
+		logsConsumers := make([]consumer.Logs, 0, len(branches))
+		for _, c := range branches {
+			logsConsumers = append(logsConsumers, c.(consumer.Logs))
+		}
+		fanOut = fanoutconsumer.NewAsyncLogs(set, ids, logsConsumers)
+	default:
+		return nil
+	}
+	bp.asyncFanOuts = append(bp.asyncFanOuts, fanOut.(fanoutconsumer.AsyncConsumer))
+	return fanOut
 }
 
-func buildFanOutExportersLogsConsumer(exporters []builtComponent) consumer.Logs {
-	consumers := make([]consumer.Logs, 0, len(exporters))
-	for _, exp := range exporters {
-		consumers = append(consumers, exp.comp.(consumer.Logs))
+func containsID(ids []config.ComponentID, id config.ComponentID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
 	}
-	// Create a junction point that fans out to all allExporters.
-	return fanoutconsumer.NewLogs(consumers)
+	return false
+}
+
+// collectConsumers returns the consumers of the exporters, each consumer once.
+func collectConsumers(exporters []config.ComponentID, consumersByExporter map[config.ComponentID][]baseConsumer) []baseConsumer {
+	var consumers []baseConsumer
+	for _, expID := range exporters {
+		for _, c := range consumersByExporter[expID] {
+			if !containsConsumer(consumers, c) {
+				consumers = append(consumers, c)
+			}
+		}
+	}
+	return consumers
+}
+
+// waitAsyncFanOuts waits for the branches of the fan outs to return, or for ctx to be done.
+func waitAsyncFanOuts(ctx context.Context, fanOuts []fanoutconsumer.AsyncConsumer) error {
+	if len(fanOuts) == 0 {
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		for _, fanOut := range fanOuts {
+			fanOut.Wait()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// buildFanOutConsumer returns a consumer of the data type passing the data to all consumers,
+// or nil if the data type is not supported.
+func buildFanOutConsumer(dt config.DataType, consumers []baseConsumer) baseConsumer {
+	switch dt {
+	case config.TracesDataType:
+		tracesConsumers := make([]consumer.Traces, 0, len(consumers))
+		for _, c := range consumers {
+			tracesConsumers = append(tracesConsumers, c.(consumer.Traces))
+		}
+		return fanoutconsumer.NewTraces(tracesConsumers)
+	case config.MetricsDataType:
+		metricsConsumers := make([]consumer.Metrics, 0, len(consumers))
+		for _, c := range consumers {
+			metricsConsumers = append(metricsConsumers, c.(consumer.Metrics))
+		}
+		return fanoutconsumer.NewMetrics(metricsConsumers)
+	case config.LogsDataType:
+		logsConsumers := make([]consumer.Logs, 0, len(consumers))
+		for _, c := range consumers {
+			logsConsumers = append(logsConsumers, c.(consumer.Logs))
+		}
+		return fanoutconsumer.NewLogs(logsConsumers)
+	}
+	return nil
 }
 
 func exporterLogger(logger *zap.Logger, id config.ComponentID, dt config.DataType) *zap.Logger {
@@ -499,6 +920,7 @@ func buildReceiver(ctx context.Context,
 	id config.ComponentID,
 	pipelineID config.ComponentID,
 	nexts []baseConsumer,
+	recvPipelineIDs []config.ComponentID,
 ) (component.Receiver, error) {
 	cfg, existsCfg := cfgs[id]
 	if !existsCfg {
@@ -517,7 +939,7 @@ func buildReceiver(ctx context.Context,
 	set.TelemetrySettings.Logger = receiverLogger(settings.Logger, id, pipelineID.Type())
 	components.LogStabilityLevel(set.TelemetrySettings.Logger, getReceiverStabilityLevel(factory, pipelineID.Type()))
 
-	recv, err := createReceiver(ctx, set, cfg, id, pipelineID, nexts, factory)
+	recv, err := createReceiver(ctx, set, cfg, id, pipelineID, nexts, recvPipelineIDs, factory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create %q receiver, in pipeline %q: %w", id, pipelineID, err)
 	}
@@ -525,26 +947,15 @@ func buildReceiver(ctx context.Context,
 	return recv, nil
 }
 
-func createReceiver(ctx context.Context, set component.ReceiverCreateSettings, cfg config.Receiver, id config.ComponentID, pipelineID config.ComponentID, nexts []baseConsumer, factory component.ReceiverFactory) (component.Receiver, error) {
+func createReceiver(ctx context.Context, set component.ReceiverCreateSettings, cfg config.Receiver, id config.ComponentID, pipelineID config.ComponentID, nexts []baseConsumer, recvPipelineIDs []config.ComponentID, factory component.ReceiverFactory) (component.Receiver, error) {
+	next := authorizedReceiver(pipelineID.Type(), recvPipelineIDs, buildFanOutConsumer(pipelineID.Type(), nexts))
 	switch pipelineID.Type() {
 	case config.TracesDataType:
-		var consumers []consumer.Traces
-		for _, next := range nexts {
-			consumers = append(consumers, next.(consumer.Traces))
-		}
-		return factory.CreateTracesReceiver(ctx, set, cfg, fanoutconsumer.NewTraces(consumers))
+		return factory.CreateTracesReceiver(ctx, set, cfg, next.(consumer.Traces))
 	case config.MetricsDataType:
-		var consumers []consumer.Metrics
-		for _, next := range nexts {
-			consumers = append(consumers, next.(consumer.Metrics))
-		}
-		return factory.CreateMetricsReceiver(ctx, set, cfg, fanoutconsumer.NewMetrics(consumers))
+		return factory.CreateMetricsReceiver(ctx, set, cfg, next.(consumer.Metrics))
 	case config.LogsDataType:
-		var consumers []consumer.Logs
-		for _, next := range nexts {
-			consumers = append(consumers, next.(consumer.Logs))
-		}
-		return factory.CreateLogsReceiver(ctx, set, cfg, fanoutconsumer.NewLogs(consumers))
+		return factory.CreateLogsReceiver(ctx, set, cfg, next.(consumer.Logs))
 	}
 	return nil, fmt.Errorf("error creating receiver %q in pipeline %q, data type %q is not supported", id, pipelineID, pipelineID.Type())
 }
@@ -601,3 +1012,41 @@ func (bps *Pipelines) getPipelinesSummaryTableData() zpages.SummaryPipelinesTabl
 	})
 	return sumData
 }
+
+func (bps *Pipelines) getQueuesTableData() zpages.QueuesTableData {
+	data := zpages.QueuesTableData{Rows: []zpages.QueuesTableRowData{}}
+	for dt, expByID := range bps.allExporters {
+		for expID, exp := range expByID {
+			qse, ok := exp.(component.QueueStateExporter)
+			if !ok {
+				continue
+			}
+			state := qse.QueueState()
+			row := zpages.QueuesTableRowData{
+				FullName:         expID.String(),
+				DataType:         string(dt),
+				QueueEnabled:     state.QueueEnabled,
+				QueueSize:        state.QueueSize,
+				QueueCapacity:    state.QueueCapacity,
+				RetryEnabled:     state.RetryEnabled,
+				RetryingRequests: state.RetryingRequests,
+			}
+			if state.LastError != nil {
+				row.LastError = state.LastError.Error()
+			}
+			if !state.LastErrorTime.IsZero() {
+				lastErrorTime := state.LastErrorTime
+				row.LastErrorTime = &lastErrorTime
+			}
+			data.Rows = append(data.Rows, row)
+		}
+	}
+
+	sort.Slice(data.Rows, func(i, j int) bool {
+		if data.Rows[i].FullName == data.Rows[j].FullName {
+			return data.Rows[i].DataType < data.Rows[j].DataType
+		}
+		return data.Rows[i].FullName < data.Rows[j].FullName
+	})
+	return data
+}
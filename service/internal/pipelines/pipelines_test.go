@@ -16,23 +16,36 @@ package pipelines
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/service/internal/configunmarshaler"
+	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
 	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
 func TestBuild(t *testing.T) {
@@ -208,6 +221,8 @@ func TestBuildErrors(t *testing.T) {
 	badReceiverFactory := newBadReceiverFactory()
 	badProcessorFactory := newBadProcessorFactory()
 	badExporterFactory := newBadExporterFactory()
+	nopConnectorFactory := componenttest.NewNopConnectorFactory()
+	badConnectorFactory := newBadConnectorFactory()
 
 	tests := []struct {
 		configFile string
@@ -227,6 +242,9 @@ func TestBuildErrors(t *testing.T) {
 		{configFile: "unknown_processor_factory.yaml"},
 		{configFile: "unknown_receiver_config.yaml"},
 		{configFile: "unknown_receiver_factory.yaml"},
+		{configFile: "not_supported_connector.yaml"},
+		{configFile: "unknown_connector_factory.yaml"},
+		{configFile: "connectors_cycle.yaml"},
 	}
 
 	for _, test := range tests {
@@ -247,6 +265,11 @@ func TestBuildErrors(t *testing.T) {
 					"unknown":                 nopExporterFactory,
 					badExporterFactory.Type(): badExporterFactory,
 				},
+				Connectors: map[config.Type]component.ConnectorFactory{
+					nopConnectorFactory.Type(): nopConnectorFactory,
+					"unknown":                  nopConnectorFactory,
+					badConnectorFactory.Type(): badConnectorFactory,
+				},
 			}
 
 			// Need the unknown factories to do unmarshalling.
@@ -256,6 +279,7 @@ func TestBuildErrors(t *testing.T) {
 			delete(factories.Exporters, "unknown")
 			delete(factories.Processors, "unknown")
 			delete(factories.Receivers, "unknown")
+			delete(factories.Connectors, "unknown")
 
 			_, err := Build(context.Background(), toSettings(factories, cfg))
 			assert.Error(t, err)
@@ -263,6 +287,417 @@ func TestBuildErrors(t *testing.T) {
 	}
 }
 
+func TestBuildConnectors(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_connectors.yaml"), factories)
+
+	rec := &statusRecorder{events: map[string][]component.Status{}, pipelines: map[string][]config.ComponentID{}}
+	set := toSettings(factories, cfg)
+	set.StatusReporter = rec
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	// The pipelines the connector emits data to are built first.
+	assert.Equal(t, []config.ComponentID{
+		config.NewComponentIDWithName(config.MetricsDataType, "out"),
+		config.NewComponentIDWithName(config.TracesDataType, "out"),
+		config.NewComponentIDWithName(config.TracesDataType, "in"),
+	}, pipelines.order)
+	// One connector instance per pair of connected data types.
+	require.Len(t, pipelines.allConnectors, 2)
+
+	assert.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	for _, conn := range pipelines.allConnectors {
+		assert.True(t, conn.(*testcomponents.ExampleConnector).Started)
+	}
+
+	afterConn := new(consumertest.TracesSink)
+	detach, err := pipelines.TapTraces(config.NewComponentIDWithName(config.TracesDataType, "out"), component.KindConnector, config.NewComponentID("exampleconnector"), afterConn)
+	require.NoError(t, err)
+	defer detach()
+
+	recv := pipelines.allReceivers[config.TracesDataType][config.NewComponentID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	assert.NoError(t, recv.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+	assert.Len(t, afterConn.AllTraces(), 1)
+	for _, conn := range pipelines.allConnectors {
+		assert.True(t, conn.(*testcomponents.ExampleConnector).Stopped)
+	}
+
+	expID := config.NewComponentID("exampleexporter")
+	tracesExporter := pipelines.GetExporters()[config.TracesDataType][expID].(*testcomponents.ExampleExporter)
+	assert.Equal(t, []ptrace.Traces{testdata.GenerateTraces(1)}, tracesExporter.Traces)
+	metricsExporter := pipelines.GetExporters()[config.MetricsDataType][expID].(*testcomponents.ExampleExporter)
+	assert.Equal(t, []pmetric.Metrics{testdata.GenerateMetrics(1)}, metricsExporter.Metrics)
+
+	lifecycle := []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped}
+	assert.Equal(t, lifecycle, rec.events["connector/traces->traces/exampleconnector"])
+	assert.Equal(t, []config.ComponentID{
+		config.NewComponentIDWithName(config.TracesDataType, "in"),
+		config.NewComponentIDWithName(config.TracesDataType, "out"),
+	}, rec.pipelines["connector/traces->traces/exampleconnector"])
+	assert.Equal(t, lifecycle, rec.events["connector/traces->metrics/exampleconnector"])
+	assert.Equal(t, []config.ComponentID{
+		config.NewComponentIDWithName(config.MetricsDataType, "out"),
+		config.NewComponentIDWithName(config.TracesDataType, "in"),
+	}, rec.pipelines["connector/traces->metrics/exampleconnector"])
+}
+
+func TestBuildRouting(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_routing.yaml"), factories)
+	pipelines, err := Build(context.Background(), toSettings(factories, cfg))
+	require.NoError(t, err)
+	assert.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	// The first resource matches the first route, the second one matches no route.
+	matching := testdata.GenerateTraces(1)
+	other := testdata.GenerateTraces(1)
+	other.ResourceSpans().At(0).Resource().Attributes().PutStr("resource-attr", "other")
+	td := ptrace.NewTraces()
+	matching.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	other.ResourceSpans().At(0).CopyTo(td.ResourceSpans().AppendEmpty())
+
+	recv := pipelines.allReceivers[config.TracesDataType][config.NewComponentID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	assert.NoError(t, recv.ConsumeTraces(context.Background(), td))
+	// The request metadata matches the second route.
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"b"}}),
+	})
+	assert.NoError(t, recv.ConsumeTraces(ctx, other))
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+
+	exps := pipelines.GetExporters()[config.TracesDataType]
+	expA := exps[config.NewComponentIDWithName("exampleexporter", "a")].(*testcomponents.ExampleExporter)
+	expB := exps[config.NewComponentIDWithName("exampleexporter", "b")].(*testcomponents.ExampleExporter)
+	expDefault := exps[config.NewComponentIDWithName("exampleexporter", "default")].(*testcomponents.ExampleExporter)
+	assert.Equal(t, []ptrace.Traces{testdata.GenerateTraces(1), other}, expA.Traces)
+	assert.Equal(t, []ptrace.Traces{other}, expB.Traces)
+	assert.Equal(t, []ptrace.Traces{other}, expDefault.Traces)
+}
+
+func TestBuildAuthorizedPipelines(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_exporter_multi_pipeline.yaml"), factories)
+	pipelines, err := Build(context.Background(), toSettings(factories, cfg))
+	require.NoError(t, err)
+	assert.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+
+	recvID := config.NewComponentID("examplereceiver")
+	allowed := config.NewComponentIDWithName(config.TracesDataType, "1")
+	sinks := map[config.ComponentID]*consumertest.TracesSink{}
+	for _, pipelineID := range []config.ComponentID{config.NewComponentID(config.TracesDataType), allowed} {
+		sink := new(consumertest.TracesSink)
+		detach, terr := pipelines.TapTraces(pipelineID, component.KindReceiver, recvID, sink)
+		require.NoError(t, terr)
+		defer detach()
+		sinks[pipelineID] = sink
+	}
+
+	authorization := &configauth.Authorization{
+		Rules: []configauth.AuthorizationRule{{Principals: []string{"tenant-a"}, Pipelines: []config.ComponentID{allowed}}},
+	}
+	ctx := client.NewContext(context.Background(), client.Info{Auth: tenantAuthData{}})
+	ctx, err = authorization.Authorize(ctx, config.TracesDataType)
+	require.NoError(t, err)
+
+	recv := pipelines.allReceivers[config.TracesDataType][recvID].(*testcomponents.ExampleReceiver)
+	// The data is passed to the allowed pipeline, and refused by the other one.
+	err = recv.ConsumeTraces(ctx, testdata.GenerateTraces(1))
+	assert.ErrorIs(t, err, configauth.ErrPermissionDenied)
+	assert.True(t, consumererror.IsPermanent(err))
+
+	// A request allowed in none of the pipelines of the receiver is refused before reaching them.
+	authorization.Rules[0].Pipelines = []config.ComponentID{config.NewComponentIDWithName(config.TracesDataType, "other")}
+	ctx, err = authorization.Authorize(client.NewContext(context.Background(), client.Info{Auth: tenantAuthData{}}), config.TracesDataType)
+	require.NoError(t, err)
+	err = recv.ConsumeTraces(ctx, testdata.GenerateTraces(1))
+	assert.EqualError(t, err, `Permanent error: permission denied for "tenant-a"`)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+
+	assert.Empty(t, sinks[config.NewComponentID(config.TracesDataType)].AllTraces())
+	assert.Len(t, sinks[allowed].AllTraces(), 1)
+}
+
+// tenantAuthData is the auth data of the "tenant-a" subject.
+type tenantAuthData struct{}
+
+func (tenantAuthData) GetAttribute(name string) interface{} {
+	if name == "subject" {
+		return "tenant-a"
+	}
+	return nil
+}
+
+func (tenantAuthData) GetAttributeNames() []string {
+	return []string{"subject"}
+}
+
+func TestBuildAsyncFanOut(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_fanout.yaml"), factories)
+	pipelines, err := Build(context.Background(), toSettings(factories, cfg))
+	require.NoError(t, err)
+	require.Len(t, pipelines.pipelines[config.NewComponentID(config.TracesDataType)].asyncFanOuts, 1)
+
+	assert.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	recv := pipelines.allReceivers[config.TracesDataType][config.NewComponentID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	assert.NoError(t, recv.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	// The exporters are shut down once all the branches of the fan out returned.
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+
+	exps := pipelines.GetExporters()[config.TracesDataType]
+	for _, name := range []string{"a", "b"} {
+		exp := exps[config.NewComponentIDWithName("exampleexporter", name)].(*testcomponents.ExampleExporter)
+		assert.Equal(t, []ptrace.Traces{testdata.GenerateTraces(1)}, exp.Traces)
+	}
+}
+
+// blockingFanOut is an asynchronous fan out whose branches return once release is closed.
+type blockingFanOut struct {
+	release chan struct{}
+}
+
+func (b blockingFanOut) Wait() {
+	<-b.release
+}
+
+func TestWaitAsyncFanOuts(t *testing.T) {
+	fanOut := blockingFanOut{release: make(chan struct{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, waitAsyncFanOuts(ctx, []fanoutconsumer.AsyncConsumer{fanOut}), context.Canceled)
+
+	close(fanOut.release)
+	assert.NoError(t, waitAsyncFanOuts(context.Background(), []fanoutconsumer.AsyncConsumer{fanOut}))
+}
+
+func TestBuildConnectorsChain(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_connectors_chain.yaml"), factories)
+	pipelines, err := Build(context.Background(), toSettings(factories, cfg))
+	require.NoError(t, err)
+	assert.Equal(t, []config.ComponentID{
+		config.NewComponentID(config.MetricsDataType),
+		config.NewComponentID(config.LogsDataType),
+		config.NewComponentID(config.TracesDataType),
+	}, pipelines.order)
+
+	assert.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	recv := pipelines.allReceivers[config.TracesDataType][config.NewComponentID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	assert.NoError(t, recv.ConsumeTraces(context.Background(), testdata.GenerateTraces(2)))
+	assert.NoError(t, pipelines.ShutdownAll(context.Background()))
+
+	exp := pipelines.GetExporters()[config.MetricsDataType][config.NewComponentID("exampleexporter")].(*testcomponents.ExampleExporter)
+	assert.Equal(t, []pmetric.Metrics{testdata.GenerateMetrics(1)}, exp.Metrics)
+}
+
+func TestBuildConnectorsCycle(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "connectors_cycle.yaml"), factories)
+	_, err = Build(context.Background(), toSettings(factories, cfg))
+	assert.EqualError(t, err, `cycle detected: pipeline "traces/a" -> connector "nop/ab" -> pipeline "traces/b" -> connector "nop/ba" -> pipeline "traces/a"`)
+}
+
+func TestBuildConnectorsNotSupported(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+	factories.Connectors["bf"] = newBadConnectorFactory()
+	cfg := loadConfig(t, filepath.Join("testdata", "not_supported_connector.yaml"), factories)
+	_, err = Build(context.Background(), toSettings(factories, cfg))
+	assert.EqualError(t, err, `connector "bf" used as an exporter in pipeline "traces/in" cannot emit data to any of the pipelines it is a receiver in`)
+}
+
+type statusRecorder struct {
+	mu     sync.Mutex
+	events map[string][]component.Status
+	// pipelines are the pipelines of the sources by key.
+	pipelines map[string][]config.ComponentID
+}
+
+func (r *statusRecorder) ReportStatus(source *component.StatusSource, event *component.StatusEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dataType := string(source.DataType)
+	if source.EmittedDataType != "" {
+		dataType += "->" + string(source.EmittedDataType)
+	}
+	key := source.Kind.String() + "/" + dataType + "/" + source.ID.String()
+	r.events[key] = append(r.events[key], event.Status)
+	r.pipelines[key] = source.Pipelines
+}
+
+func TestStatusReporting(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_exporter_multi_pipeline.yaml"), factories)
+
+	rec := &statusRecorder{events: map[string][]component.Status{}, pipelines: map[string][]config.ComponentID{}}
+	set := toSettings(factories, cfg)
+	set.StatusReporter = rec
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, pipelines.ShutdownAll(context.Background()))
+
+	lifecycle := []component.Status{component.StatusStarting, component.StatusOK, component.StatusStopping, component.StatusStopped}
+	// One receiver and exporter instance per data type, one processor instance per pipeline.
+	assert.Len(t, rec.events, 9)
+	for key, statuses := range rec.events {
+		assert.Equal(t, lifecycle, statuses, key)
+	}
+	assert.Equal(t, []config.ComponentID{config.NewComponentID("traces"), config.NewComponentIDWithName("traces", "1")},
+		rec.pipelines["exporter/traces/exampleexporter"])
+	assert.Equal(t, []config.ComponentID{config.NewComponentID("logs"), config.NewComponentIDWithName("logs", "1")},
+		rec.pipelines["receiver/logs/examplereceiver"])
+	assert.Equal(t, []config.ComponentID{config.NewComponentID("metrics")},
+		rec.pipelines["processor/metrics/exampleprocessor"])
+}
+
+func TestStatusReportingErrors(t *testing.T) {
+	rec := &statusRecorder{events: map[string][]component.Status{}, pipelines: map[string][]config.ComponentID{}}
+	bps := &Pipelines{statusReporter: rec}
+	source := &component.StatusSource{Kind: component.KindReceiver, ID: config.NewComponentID("examplereceiver"), DataType: config.TracesDataType}
+	errStart := errors.New("start failed")
+	errShutdown := errors.New("shutdown failed")
+	comp := struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{
+		StartFunc:    func(context.Context, component.Host) error { return errStart },
+		ShutdownFunc: func(context.Context) error { return errShutdown },
+	}
+
+	assert.ErrorIs(t, bps.start(context.Background(), comp, componenttest.NewNopHost(), source, zap.NewNop()), errStart)
+	assert.ErrorIs(t, bps.shutdown(context.Background(), comp, source), errShutdown)
+	assert.Equal(t, []component.Status{component.StatusStarting, component.StatusPermanentError, component.StatusStopping, component.StatusPermanentError},
+		rec.events["receiver/traces/examplereceiver"])
+}
+
+func TestTaps(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_multi.yaml"), factories)
+	pipelines, err := Build(context.Background(), toSettings(factories, cfg))
+	require.NoError(t, err)
+	require.NoError(t, pipelines.StartAll(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, pipelines.ShutdownAll(context.Background())) }()
+
+	tracesID := config.NewComponentID(config.TracesDataType)
+	recvID := config.NewComponentID("examplereceiver")
+	recv1ID := config.NewComponentIDWithName("examplereceiver", "1")
+	procID := config.NewComponentIDWithName("exampleprocessor", "1")
+
+	afterRecv1 := new(consumertest.TracesSink)
+	detachRecv1, err := pipelines.TapTraces(tracesID, component.KindReceiver, recv1ID, afterRecv1)
+	require.NoError(t, err)
+	afterProc := new(consumertest.TracesSink)
+	detachProc, err := pipelines.TapTraces(tracesID, component.KindProcessor, procID, afterProc)
+	require.NoError(t, err)
+	afterMetricsRecv := new(consumertest.MetricsSink)
+	detachMetrics, err := pipelines.TapMetrics(config.NewComponentID(config.MetricsDataType), component.KindReceiver, recvID, afterMetricsRecv)
+	require.NoError(t, err)
+	defer detachMetrics()
+	afterLogsProc := new(consumertest.LogsSink)
+	detachLogs, err := pipelines.TapLogs(config.NewComponentID(config.LogsDataType), component.KindProcessor, procID, afterLogsProc)
+	require.NoError(t, err)
+	defer detachLogs()
+
+	for _, id := range []config.ComponentID{recvID, recv1ID} {
+		require.NoError(t, pipelines.allReceivers[config.TracesDataType][id].(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+		require.NoError(t, pipelines.allReceivers[config.MetricsDataType][id].(*testcomponents.ExampleReceiver).ConsumeMetrics(context.Background(), testdata.GenerateMetrics(1)))
+		require.NoError(t, pipelines.allReceivers[config.LogsDataType][id].(*testcomponents.ExampleReceiver).ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	}
+	// Only the data of the tapped receiver is seen after it, and the data of all the receivers after the processors.
+	assert.Len(t, afterRecv1.AllTraces(), 1)
+	assert.Len(t, afterProc.AllTraces(), 2)
+	assert.Len(t, afterMetricsRecv.AllMetrics(), 1)
+	assert.Len(t, afterLogsProc.AllLogs(), 2)
+	assert.EqualValues(t, testdata.GenerateTraces(1), afterProc.AllTraces()[0])
+
+	detachRecv1()
+	detachProc()
+	require.NoError(t, pipelines.allReceivers[config.TracesDataType][recv1ID].(*testcomponents.ExampleReceiver).ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Len(t, afterRecv1.AllTraces(), 1)
+	assert.Len(t, afterProc.AllTraces(), 2)
+	// The exporters still get all the data.
+	assert.Len(t, pipelines.GetExporters()[config.TracesDataType][config.NewComponentID("exampleexporter")].(*testcomponents.ExampleExporter).Traces, 3)
+
+	_, err = pipelines.TapTraces(config.NewComponentIDWithName(config.TracesDataType, "unknown"), component.KindReceiver, recvID, afterRecv1)
+	assert.EqualError(t, err, `pipeline "traces/unknown" does not exist`)
+	_, err = pipelines.TapTraces(config.NewComponentID(config.MetricsDataType), component.KindReceiver, recvID, afterRecv1)
+	assert.EqualError(t, err, `pipeline "metrics" is not a traces pipeline`)
+	_, err = pipelines.TapTraces(tracesID, component.KindExporter, config.NewComponentID("exampleexporter"), afterRecv1)
+	assert.EqualError(t, err, "data can only be tapped after receivers, connectors and processors, not after exporters")
+	_, err = pipelines.TapTraces(tracesID, component.KindProcessor, config.NewComponentID("unknown"), afterRecv1)
+	assert.EqualError(t, err, `processor "unknown" is not in pipeline "traces"`)
+}
+
+func TestZPages(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_simple.yaml"), factories)
+
+	queueFactory := newQueueStateExporterFactory()
+	set := toSettings(factories, cfg)
+	set.ExporterFactories[queueFactory.Type()] = queueFactory
+	set.ExporterConfigs[config.NewComponentID(queueFactory.Type())] = queueFactory.CreateDefaultConfig()
+	tracesPipeline := set.PipelineConfigs[config.NewComponentID(config.TracesDataType)]
+	tracesPipeline.Exporters = append(tracesPipeline.Exporters, config.NewComponentID(queueFactory.Type()))
+	pipelines, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	pipelines.HandleZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/pipelinez?format=json", nil))
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var pipelinesData zpages.SummaryPipelinesTableData
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &pipelinesData))
+	require.Len(t, pipelinesData.Rows, 3)
+	assert.Equal(t, zpages.SummaryPipelinesTableRowData{
+		FullName:   "traces",
+		InputType:  "traces",
+		Receivers:  []string{"examplereceiver"},
+		Processors: []string{"exampleprocessor"},
+		Exporters:  []string{"exampleexporter", "queue"},
+	}, pipelinesData.Rows[2])
+	assert.Nil(t, pipelinesData.Component)
+
+	rr = httptest.NewRecorder()
+	pipelines.HandleZPages(rr, httptest.NewRequest(http.MethodGet,
+		"/debug/pipelinez?format=json&zpipelinename=traces&zcomponentname=exampleprocessor&zcomponentkind=processor", nil))
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &pipelinesData))
+	require.Len(t, pipelinesData.Rows, 3)
+	assert.Equal(t, &zpages.SelectedComponentData{Kind: "processor", Name: "exampleprocessor", Pipeline: "traces"}, pipelinesData.Component)
+
+	rr = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/debug/queuez", nil)
+	req.Header.Set("Accept", "application/json")
+	pipelines.HandleQueuesZPages(rr, req)
+	assert.JSONEq(t, `{"exporters": [{
+		"full_name": "queue",
+		"data_type": "traces",
+		"queue_enabled": true,
+		"queue_size": 3,
+		"queue_capacity": 10,
+		"retry_enabled": true,
+		"retrying_requests": 1,
+		"last_error": "transient error",
+		"last_error_time": "2022-09-01T10:00:00Z"
+	}]}`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	pipelines.HandleQueuesZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/queuez", nil))
+	assert.Equal(t, "text/html; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), "transient error")
+}
+
 func TestFailToStartAndShutdown(t *testing.T) {
 	errReceiverFactory := newErrReceiverFactory()
 	errProcessorFactory := newErrProcessorFactory()
@@ -375,6 +810,16 @@ func newBadExporterFactory() component.ExporterFactory {
 	})
 }
 
+func newBadConnectorFactory() component.ConnectorFactory {
+	return component.NewConnectorFactory("bf", func() config.Connector {
+		return &struct {
+			config.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+		}{
+			ConnectorSettings: config.NewConnectorSettings(config.NewComponentID("bf")),
+		}
+	})
+}
+
 func newErrReceiverFactory() component.ReceiverFactory {
 	return component.NewReceiverFactory("err", func() config.Receiver {
 		return &struct {
@@ -445,6 +890,8 @@ func toSettings(factories component.Factories, cfg *configSettings) Settings {
 		ProcessorConfigs:   cfg.Processors.GetProcessors(),
 		ExporterFactories:  factories.Exporters,
 		ExporterConfigs:    cfg.Exporters.GetExporters(),
+		ConnectorFactories: factories.Connectors,
+		ConnectorConfigs:   cfg.Connectors.GetConnectors(),
 		PipelineConfigs:    cfg.Service.Pipelines,
 	}
 }
@@ -470,6 +917,7 @@ type configSettings struct {
 	Receivers  *configunmarshaler.Receivers  `mapstructure:"receivers"`
 	Processors *configunmarshaler.Processors `mapstructure:"processors"`
 	Exporters  *configunmarshaler.Exporters  `mapstructure:"exporters"`
+	Connectors *configunmarshaler.Connectors `mapstructure:"connectors"`
 	Service    *serviceSettings              `mapstructure:"service"`
 }
 
@@ -485,7 +933,38 @@ func loadConfig(t *testing.T, fileName string, factories component.Factories) *c
 		Receivers:  configunmarshaler.NewReceivers(factories.Receivers),
 		Processors: configunmarshaler.NewProcessors(factories.Processors),
 		Exporters:  configunmarshaler.NewExporters(factories.Exporters),
+		Connectors: configunmarshaler.NewConnectors(factories.Connectors),
 	}
 	require.NoError(t, conf.Unmarshal(cfg, confmap.WithErrorUnused()))
 	return cfg
 }
+
+func newQueueStateExporterFactory() component.ExporterFactory {
+	return component.NewExporterFactory("queue", func() config.Exporter {
+		return &struct {
+			config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+		}{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentID("queue")),
+		}
+	},
+		component.WithTracesExporter(func(context.Context, component.ExporterCreateSettings, config.Exporter) (component.TracesExporter, error) {
+			return &queueStateExporter{}, nil
+		}, component.StabilityLevelUndefined),
+	)
+}
+
+type queueStateExporter struct {
+	errComponent
+}
+
+func (queueStateExporter) QueueState() component.ExporterQueueState {
+	return component.ExporterQueueState{
+		QueueEnabled:     true,
+		QueueSize:        3,
+		QueueCapacity:    10,
+		RetryEnabled:     true,
+		RetryingRequests: 1,
+		LastError:        errors.New("transient error"),
+		LastErrorTime:    time.Date(2022, 9, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines // import "go.opentelemetry.io/collector/service/internal/pipelines"
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+// Graph is the topology of the component instances created for the pipelines, and of the data
+// passed between them.
+type Graph struct {
+	// Nodes are sorted by kind, receivers first and exporters last, then by ID.
+	Nodes []GraphNode `json:"nodes"`
+	// Edges are sorted by the IDs of their nodes.
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a component instance. A receiver or an exporter is shared by all the pipelines of a
+// data type using it, a processor belongs to a single pipeline, and a connector is shared by the
+// pipelines it joins with the same pair of data types.
+type GraphNode struct {
+	// ID identifies the instance in the graph.
+	ID        string             `json:"id"`
+	Kind      string             `json:"kind"`
+	Component config.ComponentID `json:"component"`
+	// DataType is the type of the data consumed by the instance.
+	DataType config.DataType `json:"data_type"`
+	// EmittedDataType is the type of the data emitted by a connector.
+	EmittedDataType config.DataType      `json:"emitted_data_type,omitempty"`
+	Pipelines       []config.ComponentID `json:"pipelines"`
+}
+
+// GraphEdge is the data passed from a component instance to another one in a pipeline.
+type GraphEdge struct {
+	From     string             `json:"from"`
+	To       string             `json:"to"`
+	DataType config.DataType    `json:"data_type"`
+	Pipeline config.ComponentID `json:"pipeline"`
+}
+
+// BuildGraph returns the graph of the component instances Build creates for the pipelines, without
+// creating them.
+func BuildGraph(set Settings) (*Graph, error) {
+	pg, err := newPipelinesGraph(set)
+	if err != nil {
+		return nil, err
+	}
+	return newGraph(set, pg), nil
+}
+
+// graphBuilder accumulates the nodes and the edges of a Graph.
+type graphBuilder struct {
+	nodes map[string]*GraphNode
+	edges map[GraphEdge]bool
+}
+
+func (gb *graphBuilder) addNode(kind component.Kind, id config.ComponentID, dt, emitted config.DataType, pipelineID config.ComponentID) string {
+	// The processors are the only instances not shared between pipelines.
+	nodeID := kind.String() + ":" + id.String() + ":" + string(dt)
+	switch kind {
+	case component.KindProcessor:
+		nodeID = kind.String() + ":" + id.String() + ":" + pipelineID.String()
+	case component.KindConnector:
+		nodeID += ":" + string(emitted)
+	}
+
+	node, ok := gb.nodes[nodeID]
+	if !ok {
+		node = &GraphNode{ID: nodeID, Kind: kind.String(), Component: id, DataType: dt, EmittedDataType: emitted}
+		gb.nodes[nodeID] = node
+	}
+	for _, p := range node.Pipelines {
+		if p == pipelineID {
+			return nodeID
+		}
+	}
+	node.Pipelines = append(node.Pipelines, pipelineID)
+	return nodeID
+}
+
+func (gb *graphBuilder) addEdge(from, to string, dt config.DataType, pipelineID config.ComponentID) {
+	gb.edges[GraphEdge{From: from, To: to, DataType: dt, Pipeline: pipelineID}] = true
+}
+
+func newGraph(set Settings, pg *pipelinesGraph) *Graph {
+	gb := &graphBuilder{nodes: make(map[string]*GraphNode), edges: make(map[GraphEdge]bool)}
+	for _, pipelineID := range pg.pipelineIDs {
+		pipeline := set.PipelineConfigs[pipelineID]
+		dt := pipelineID.Type()
+
+		// The exporters and the connector instances the pipeline passes its data to.
+		var lasts []string
+		for _, expID := range pipeline.Exporters {
+			if _, ok := set.ConnectorConfigs[expID]; !ok {
+				lasts = append(lasts, gb.addNode(component.KindExporter, expID, dt, "", pipelineID))
+				continue
+			}
+			for _, conn := range pg.connections[pipelineID] {
+				if conn.connID == expID {
+					lasts = append(lasts, gb.addNode(component.KindConnector, expID, dt, conn.to.Type(), pipelineID))
+				}
+			}
+		}
+
+		heads := lasts
+		for i := len(pipeline.Processors) - 1; i >= 0; i-- {
+			procNode := gb.addNode(component.KindProcessor, pipeline.Processors[i], dt, "", pipelineID)
+			for _, next := range heads {
+				gb.addEdge(procNode, next, dt, pipelineID)
+			}
+			heads = []string{procNode}
+		}
+
+		// The receivers and the connector instances passing data to the pipeline.
+		for _, recvID := range pipeline.Receivers {
+			var firsts []string
+			if _, ok := set.ConnectorConfigs[recvID]; !ok {
+				firsts = append(firsts, gb.addNode(component.KindReceiver, recvID, dt, "", pipelineID))
+			}
+			for _, fromID := range pg.pipelineIDs {
+				for _, conn := range pg.connections[fromID] {
+					if conn.connID == recvID && conn.to == pipelineID {
+						firsts = append(firsts, gb.addNode(component.KindConnector, recvID, fromID.Type(), dt, pipelineID))
+					}
+				}
+			}
+			for _, first := range firsts {
+				for _, next := range heads {
+					gb.addEdge(first, next, dt, pipelineID)
+				}
+			}
+		}
+	}
+
+	g := &Graph{Nodes: make([]GraphNode, 0, len(gb.nodes)), Edges: make([]GraphEdge, 0, len(gb.edges))}
+	for _, node := range gb.nodes {
+		sort.Slice(node.Pipelines, func(i, j int) bool { return node.Pipelines[i].String() < node.Pipelines[j].String() })
+		g.Nodes = append(g.Nodes, *node)
+	}
+	kindOrder := map[string]int{
+		component.KindReceiver.String():  0,
+		component.KindProcessor.String(): 1,
+		component.KindConnector.String(): 2,
+		component.KindExporter.String():  3,
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		if kindOrder[g.Nodes[i].Kind] != kindOrder[g.Nodes[j].Kind] {
+			return kindOrder[g.Nodes[i].Kind] < kindOrder[g.Nodes[j].Kind]
+		}
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	for edge := range gb.edges {
+		g.Edges = append(g.Edges, edge)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		if g.Edges[i].To != g.Edges[j].To {
+			return g.Edges[i].To < g.Edges[j].To
+		}
+		return g.Edges[i].Pipeline.String() < g.Edges[j].Pipeline.String()
+	})
+	return g
+}
+
+// WriteDOT writes the Graphviz DOT representation of the graph.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph pipelines {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for _, node := range g.Nodes {
+		label := node.Component.String() + "\\n" + node.Kind + " (" + string(node.DataType)
+		if node.EmittedDataType != "" {
+			label += " -> " + string(node.EmittedDataType)
+		}
+		label += ")"
+		attrs := ""
+		if node.Kind == component.KindConnector.String() {
+			attrs = ", shape=diamond"
+		}
+		fmt.Fprintf(&b, "\t%s [label=%s%s];\n", dotQuote(node.ID), dotQuote(label), attrs)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(string(edge.DataType)))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote returns s as a DOT quoted string, keeping the escaped line breaks of labels.
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipelines
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+)
+
+func TestBuildGraph(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_connectors.yaml"), factories)
+	graph, err := BuildGraph(toSettings(factories, cfg))
+	require.NoError(t, err)
+
+	tracesIn := config.NewComponentIDWithName(config.TracesDataType, "in")
+	tracesOut := config.NewComponentIDWithName(config.TracesDataType, "out")
+	metricsOut := config.NewComponentIDWithName(config.MetricsDataType, "out")
+	recv := config.NewComponentID("examplereceiver")
+	proc := config.NewComponentID("exampleprocessor")
+	conn := config.NewComponentID("exampleconnector")
+	exp := config.NewComponentID("exampleexporter")
+
+	assert.Equal(t, []GraphNode{
+		{ID: "receiver:examplereceiver:traces", Kind: "receiver", Component: recv, DataType: config.TracesDataType, Pipelines: []config.ComponentID{tracesIn}},
+		{ID: "processor:exampleprocessor:traces/in", Kind: "processor", Component: proc, DataType: config.TracesDataType, Pipelines: []config.ComponentID{tracesIn}},
+		{ID: "processor:exampleprocessor:traces/out", Kind: "processor", Component: proc, DataType: config.TracesDataType, Pipelines: []config.ComponentID{tracesOut}},
+		{ID: "connector:exampleconnector:traces:metrics", Kind: "connector", Component: conn, DataType: config.TracesDataType, EmittedDataType: config.MetricsDataType, Pipelines: []config.ComponentID{metricsOut, tracesIn}},
+		{ID: "connector:exampleconnector:traces:traces", Kind: "connector", Component: conn, DataType: config.TracesDataType, EmittedDataType: config.TracesDataType, Pipelines: []config.ComponentID{tracesIn, tracesOut}},
+		{ID: "exporter:exampleexporter:metrics", Kind: "exporter", Component: exp, DataType: config.MetricsDataType, Pipelines: []config.ComponentID{metricsOut}},
+		{ID: "exporter:exampleexporter:traces", Kind: "exporter", Component: exp, DataType: config.TracesDataType, Pipelines: []config.ComponentID{tracesOut}},
+	}, graph.Nodes)
+
+	assert.Equal(t, []GraphEdge{
+		{From: "connector:exampleconnector:traces:metrics", To: "exporter:exampleexporter:metrics", DataType: config.MetricsDataType, Pipeline: metricsOut},
+		{From: "connector:exampleconnector:traces:traces", To: "processor:exampleprocessor:traces/out", DataType: config.TracesDataType, Pipeline: tracesOut},
+		{From: "processor:exampleprocessor:traces/in", To: "connector:exampleconnector:traces:metrics", DataType: config.TracesDataType, Pipeline: tracesIn},
+		{From: "processor:exampleprocessor:traces/in", To: "connector:exampleconnector:traces:traces", DataType: config.TracesDataType, Pipeline: tracesIn},
+		{From: "processor:exampleprocessor:traces/out", To: "exporter:exampleexporter:traces", DataType: config.TracesDataType, Pipeline: tracesOut},
+		{From: "receiver:examplereceiver:traces", To: "processor:exampleprocessor:traces/in", DataType: config.TracesDataType, Pipeline: tracesIn},
+	}, graph.Edges)
+
+	// The pipelines expose the graph of the instances they built.
+	pipelines, err := Build(context.Background(), toSettings(factories, cfg))
+	require.NoError(t, err)
+	assert.Equal(t, graph, pipelines.Graph())
+}
+
+func TestBuildGraphSharedComponents(t *testing.T) {
+	factories, err := testcomponents.ExampleComponents()
+	require.NoError(t, err)
+	cfg := loadConfig(t, filepath.Join("testdata", "pipelines_simple.yaml"), factories)
+	graph, err := BuildGraph(toSettings(factories, cfg))
+	require.NoError(t, err)
+
+	// One receiver and one exporter per data type, one processor per pipeline.
+	assert.Len(t, graph.Nodes, 9)
+	// Without processors, the receivers are joined directly to the exporters.
+	cfg = loadConfig(t, filepath.Join("testdata", "pipelines_simple_no_proc.yaml"), factories)
+	graph, err = BuildGraph(toSettings(factories, cfg))
+	require.NoError(t, err)
+	for _, edge := range graph.Edges {
+		assert.Contains(t, edge.From, "receiver:")
+		assert.Contains(t, edge.To, "exporter:")
+	}
+}
+
+func TestGraphWriteDOT(t *testing.T) {
+	graph := &Graph{
+		Nodes: []GraphNode{
+			{ID: "receiver:otlp:traces", Kind: "receiver", Component: config.NewComponentID("otlp"), DataType: config.TracesDataType},
+			{ID: "connector:count:traces:metrics", Kind: "connector", Component: config.NewComponentID("count"), DataType: config.TracesDataType, EmittedDataType: config.MetricsDataType},
+		},
+		Edges: []GraphEdge{
+			{From: "receiver:otlp:traces", To: "connector:count:traces:metrics", DataType: config.TracesDataType},
+		},
+	}
+	buf := new(bytes.Buffer)
+	require.NoError(t, graph.WriteDOT(buf))
+	assert.Equal(t, `digraph pipelines {
+	rankdir=LR;
+	node [shape=box];
+	"receiver:otlp:traces" [label="otlp\nreceiver (traces)"];
+	"connector:count:traces:metrics" [label="count\nconnector (traces -> metrics)", shape=diamond];
+	"receiver:otlp:traces" -> "connector:count:traces:metrics" [label="traces"];
+}
+`, buf.String())
+}
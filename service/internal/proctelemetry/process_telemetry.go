@@ -15,6 +15,7 @@
 package proctelemetry // import "go.opentelemetry.io/collector/service/internal/proctelemetry"
 
 import (
+	"context"
 	"os"
 	"runtime"
 	"sync"
@@ -23,6 +24,15 @@ import (
 	"github.com/shirou/gopsutil/v3/process"
 	"go.opencensus.io/metric"
 	"go.opencensus.io/stats"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/asyncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/asyncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+)
+
+const (
+	scopeName = "go.opentelemetry.io/collector/service/process_telemetry"
 )
 
 // processMetrics is a struct that contains views related to process metrics (cpu, mem, etc)
@@ -38,6 +48,16 @@ type processMetrics struct {
 	cpuSeconds    *metric.Float64DerivedCumulative
 	rssMemory     *metric.Int64DerivedGauge
 
+	// otel metrics
+	otelProcessUptime asyncfloat64.Counter
+	otelAllocMem      asyncint64.Gauge
+	otelTotalAllocMem asyncint64.Counter
+	otelSysMem        asyncint64.Gauge
+	otelCPUSeconds    asyncfloat64.Counter
+	otelRSSMemory     asyncint64.Gauge
+
+	meter otelmetric.Meter
+
 	// mu protects everything bellow.
 	mu         sync.Mutex
 	lastMsRead time.Time
@@ -45,8 +65,9 @@ type processMetrics struct {
 }
 
 // RegisterProcessMetrics creates a new set of processMetrics (mem, cpu) that can be used to measure
-// basic information about this process.
-func RegisterProcessMetrics(registry *metric.Registry, ballastSizeBytes uint64) error {
+// basic information about this process. The metrics are registered with the OpenCensus registry,
+// or with the OpenTelemetry MeterProvider when useOtel is set.
+func RegisterProcessMetrics(ocRegistry *metric.Registry, mp otelmetric.MeterProvider, useOtel bool, ballastSizeBytes uint64) error {
 	pm := &processMetrics{
 		startTimeUnixNano: time.Now().UnixNano(),
 		ballastSizeBytes:  ballastSizeBytes,
@@ -58,6 +79,84 @@ func RegisterProcessMetrics(registry *metric.Registry, ballastSizeBytes uint64)
 		return err
 	}
 
+	if useOtel {
+		pm.meter = mp.Meter(scopeName)
+		return pm.recordWithOtel()
+	}
+	return pm.recordWithOC(ocRegistry)
+}
+
+func (pm *processMetrics) recordWithOtel() error {
+	var err error
+
+	pm.otelProcessUptime, err = pm.meter.AsyncFloat64().Counter(
+		"process/uptime",
+		instrument.WithDescription("Uptime of the process"),
+		instrument.WithUnit(unit.Unit(stats.UnitSeconds)))
+	if err != nil {
+		return err
+	}
+
+	pm.otelAllocMem, err = pm.meter.AsyncInt64().Gauge(
+		"process/runtime/heap_alloc_bytes",
+		instrument.WithDescription("Bytes of allocated heap objects (see 'go doc runtime.MemStats.HeapAlloc')"),
+		instrument.WithUnit(unit.Bytes))
+	if err != nil {
+		return err
+	}
+
+	pm.otelTotalAllocMem, err = pm.meter.AsyncInt64().Counter(
+		"process/runtime/total_alloc_bytes",
+		instrument.WithDescription("Cumulative bytes allocated for heap objects (see 'go doc runtime.MemStats.TotalAlloc')"),
+		instrument.WithUnit(unit.Bytes))
+	if err != nil {
+		return err
+	}
+
+	pm.otelSysMem, err = pm.meter.AsyncInt64().Gauge(
+		"process/runtime/total_sys_memory_bytes",
+		instrument.WithDescription("Total bytes of memory obtained from the OS (see 'go doc runtime.MemStats.Sys')"),
+		instrument.WithUnit(unit.Bytes))
+	if err != nil {
+		return err
+	}
+
+	pm.otelCPUSeconds, err = pm.meter.AsyncFloat64().Counter(
+		"process/cpu_seconds",
+		instrument.WithDescription("Total CPU user and system time in seconds"),
+		instrument.WithUnit(unit.Unit(stats.UnitSeconds)))
+	if err != nil {
+		return err
+	}
+
+	pm.otelRSSMemory, err = pm.meter.AsyncInt64().Gauge(
+		"process/memory/rss",
+		instrument.WithDescription("Total physical memory (resident set size)"),
+		instrument.WithUnit(unit.Bytes))
+	if err != nil {
+		return err
+	}
+
+	return pm.meter.RegisterCallback([]instrument.Asynchronous{
+		pm.otelProcessUptime,
+		pm.otelAllocMem,
+		pm.otelTotalAllocMem,
+		pm.otelSysMem,
+		pm.otelCPUSeconds,
+		pm.otelRSSMemory,
+	}, func(ctx context.Context) {
+		pm.otelProcessUptime.Observe(ctx, pm.updateProcessUptime())
+		pm.otelAllocMem.Observe(ctx, pm.updateAllocMem())
+		pm.otelTotalAllocMem.Observe(ctx, pm.updateTotalAllocMem())
+		pm.otelSysMem.Observe(ctx, pm.updateSysMem())
+		pm.otelCPUSeconds.Observe(ctx, pm.updateCPUSeconds())
+		pm.otelRSSMemory.Observe(ctx, pm.updateRSSMemory())
+	})
+}
+
+func (pm *processMetrics) recordWithOC(registry *metric.Registry) error {
+	var err error
+
 	pm.processUptime, err = registry.AddFloat64DerivedCumulative(
 		"process/uptime",
 		metric.WithDescription("Uptime of the process"),
@@ -15,6 +15,7 @@
 package proctelemetry
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -22,6 +23,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/metric"
 	"go.opencensus.io/metric/metricdata"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdkmetricdata "go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 var expectedMetrics = []string{
@@ -38,7 +42,7 @@ var expectedMetrics = []string{
 
 func TestProcessTelemetry(t *testing.T) {
 	registry := metric.NewRegistry()
-	require.NoError(t, RegisterProcessMetrics(registry, 0))
+	require.NoError(t, RegisterProcessMetrics(registry, otelmetric.NewNoopMeterProvider(), false, 0))
 
 	// Check that the metrics are actually filled.
 	<-time.After(200 * time.Millisecond)
@@ -70,6 +74,46 @@ func TestProcessTelemetry(t *testing.T) {
 	}
 }
 
+func TestOtelProcessTelemetry(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { require.NoError(t, mp.Shutdown(context.Background())) })
+	require.NoError(t, RegisterProcessMetrics(nil, mp, true, 0))
+
+	rm, err := reader.Collect(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, scopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	values := map[string]float64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case sdkmetricdata.Sum[float64]:
+			require.Len(t, data.DataPoints, 1)
+			values[m.Name] = data.DataPoints[0].Value
+		case sdkmetricdata.Sum[int64]:
+			require.Len(t, data.DataPoints, 1)
+			values[m.Name] = float64(data.DataPoints[0].Value)
+		case sdkmetricdata.Gauge[int64]:
+			require.Len(t, data.DataPoints, 1)
+			values[m.Name] = float64(data.DataPoints[0].Value)
+		default:
+			t.Fatalf("unexpected data type %T for metric %q", m.Data, m.Name)
+		}
+	}
+
+	for _, metricName := range expectedMetrics {
+		value, ok := values[metricName]
+		require.True(t, ok, metricName)
+		if metricName == "process/uptime" || metricName == "process/cpu_seconds" {
+			// This likely will still be zero when running the test.
+			assert.True(t, value >= 0, metricName)
+			continue
+		}
+		assert.True(t, value > 0, metricName)
+	}
+}
+
 func TestProcessTelemetryFailToRegister(t *testing.T) {
 
 	for _, metricName := range expectedMetrics {
@@ -77,7 +121,7 @@ func TestProcessTelemetryFailToRegister(t *testing.T) {
 			registry := metric.NewRegistry()
 			_, err := registry.AddFloat64Gauge(metricName)
 			require.NoError(t, err)
-			assert.Error(t, RegisterProcessMetrics(registry, 0))
+			assert.Error(t, RegisterProcessMetrics(registry, otelmetric.NewNoopMeterProvider(), false, 0))
 		})
 	}
 }
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+)
+
+// Logs is a consumer.Logs passing the data to the next consumer, and to the consumers attached to it.
+type Logs struct {
+	next consumer.Logs
+
+	mu       sync.Mutex
+	attached []*logsAttachment
+	// current holds the logsChain the data is passed to.
+	current atomic.Value
+}
+
+// logsAttachment gives a distinct identity to each consumer attached.
+type logsAttachment struct {
+	consumer.Logs
+}
+
+// logsChain wraps the consumers the data is passed to, since an atomic.Value must always hold the same type.
+type logsChain struct {
+	consumer.Logs
+}
+
+var _ consumer.Logs = (*Logs)(nil)
+
+// NewLogs returns a Logs passing the data to next, until consumers are attached to it.
+func NewLogs(next consumer.Logs) *Logs {
+	t := &Logs{next: next}
+	t.current.Store(logsChain{Logs: next})
+	return t
+}
+
+// Capabilities returns the capabilities of the next consumer, the attached consumers get copies of the data if needed.
+func (t *Logs) Capabilities() consumer.Capabilities {
+	return t.next.Capabilities()
+}
+
+// ConsumeLogs passes the data to the next consumer, and to the attached consumers.
+func (t *Logs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return t.current.Load().(logsChain).ConsumeLogs(ctx, ld)
+}
+
+// Attach attaches c, which receives the data passed to the next consumer until the returned function is called.
+// The data is passed synchronously to c, which must not keep references to it after returning.
+func (t *Logs) Attach(c consumer.Logs) func() {
+	a := &logsAttachment{Logs: c}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.attached = append(t.attached, a)
+	t.updateLocked()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { t.detach(a) })
+	}
+}
+
+func (t *Logs) detach(a *logsAttachment) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, attached := range t.attached {
+		if attached == a {
+			t.attached = append(t.attached[:i], t.attached[i+1:]...)
+			break
+		}
+	}
+	t.updateLocked()
+}
+
+func (t *Logs) updateLocked() {
+	consumers := make([]consumer.Logs, 0, len(t.attached)+1)
+	consumers = append(consumers, t.next)
+	for _, a := range t.attached {
+		consumers = append(consumers, a)
+	}
+	t.current.Store(logsChain{Logs: fanoutconsumer.NewLogs(consumers)})
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestLogsAttach(t *testing.T) {
+	next := new(consumertest.LogsSink)
+	tc := NewLogs(next)
+	assert.False(t, tc.Capabilities().MutatesData)
+	ld := testdata.GenerateLogs(1)
+
+	require.NoError(t, tc.ConsumeLogs(context.Background(), ld))
+	assert.Len(t, next.AllLogs(), 1)
+
+	tap1 := new(consumertest.LogsSink)
+	detach1 := tc.Attach(tap1)
+	tap2 := new(consumertest.LogsSink)
+	detach2 := tc.Attach(tap2)
+	require.NoError(t, tc.ConsumeLogs(context.Background(), ld))
+	assert.Len(t, next.AllLogs(), 2)
+	assert.Len(t, tap1.AllLogs(), 1)
+	assert.Len(t, tap2.AllLogs(), 1)
+	// None of the consumers mutates the data, they all get the same data.
+	assert.True(t, ld == next.AllLogs()[1])
+	assert.True(t, ld == tap1.AllLogs()[0])
+
+	detach1()
+	// Detaching twice is a no-op.
+	detach1()
+	require.NoError(t, tc.ConsumeLogs(context.Background(), ld))
+	assert.Len(t, next.AllLogs(), 3)
+	assert.Len(t, tap1.AllLogs(), 1)
+	assert.Len(t, tap2.AllLogs(), 2)
+
+	detach2()
+	require.NoError(t, tc.ConsumeLogs(context.Background(), ld))
+	assert.Len(t, next.AllLogs(), 4)
+	assert.Len(t, tap2.AllLogs(), 2)
+}
+
+func TestLogsAttachMutatingNext(t *testing.T) {
+	next := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
+	tc := NewLogs(next)
+	assert.True(t, tc.Capabilities().MutatesData)
+	ld := testdata.GenerateLogs(1)
+
+	tap := new(consumertest.LogsSink)
+	defer tc.Attach(tap)()
+	require.NoError(t, tc.ConsumeLogs(context.Background(), ld))
+	// The next consumer mutating the data gets a copy.
+	assert.False(t, ld == next.AllLogs()[0])
+	assert.EqualValues(t, ld, next.AllLogs()[0])
+	assert.True(t, ld == tap.AllLogs()[0])
+}
+
+type mutatingLogsSink struct {
+	*consumertest.LogsSink
+}
+
+func (mts *mutatingLogsSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+)
+
+// Metrics is a consumer.Metrics passing the data to the next consumer, and to the consumers attached to it.
+type Metrics struct {
+	next consumer.Metrics
+
+	mu       sync.Mutex
+	attached []*metricsAttachment
+	// current holds the metricsChain the data is passed to.
+	current atomic.Value
+}
+
+// metricsAttachment gives a distinct identity to each consumer attached.
+type metricsAttachment struct {
+	consumer.Metrics
+}
+
+// metricsChain wraps the consumers the data is passed to, since an atomic.Value must always hold the same type.
+type metricsChain struct {
+	consumer.Metrics
+}
+
+var _ consumer.Metrics = (*Metrics)(nil)
+
+// NewMetrics returns a Metrics passing the data to next, until consumers are attached to it.
+func NewMetrics(next consumer.Metrics) *Metrics {
+	t := &Metrics{next: next}
+	t.current.Store(metricsChain{Metrics: next})
+	return t
+}
+
+// Capabilities returns the capabilities of the next consumer, the attached consumers get copies of the data if needed.
+func (t *Metrics) Capabilities() consumer.Capabilities {
+	return t.next.Capabilities()
+}
+
+// ConsumeMetrics passes the data to the next consumer, and to the attached consumers.
+func (t *Metrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return t.current.Load().(metricsChain).ConsumeMetrics(ctx, md)
+}
+
+// Attach attaches c, which receives the data passed to the next consumer until the returned function is called.
+// The data is passed synchronously to c, which must not keep references to it after returning.
+func (t *Metrics) Attach(c consumer.Metrics) func() {
+	a := &metricsAttachment{Metrics: c}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.attached = append(t.attached, a)
+	t.updateLocked()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { t.detach(a) })
+	}
+}
+
+func (t *Metrics) detach(a *metricsAttachment) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, attached := range t.attached {
+		if attached == a {
+			t.attached = append(t.attached[:i], t.attached[i+1:]...)
+			break
+		}
+	}
+	t.updateLocked()
+}
+
+func (t *Metrics) updateLocked() {
+	consumers := make([]consumer.Metrics, 0, len(t.attached)+1)
+	consumers = append(consumers, t.next)
+	for _, a := range t.attached {
+		consumers = append(consumers, a)
+	}
+	t.current.Store(metricsChain{Metrics: fanoutconsumer.NewMetrics(consumers)})
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestMetricsAttach(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	tc := NewMetrics(next)
+	assert.False(t, tc.Capabilities().MutatesData)
+	md := testdata.GenerateMetrics(1)
+
+	require.NoError(t, tc.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, next.AllMetrics(), 1)
+
+	tap1 := new(consumertest.MetricsSink)
+	detach1 := tc.Attach(tap1)
+	tap2 := new(consumertest.MetricsSink)
+	detach2 := tc.Attach(tap2)
+	require.NoError(t, tc.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, next.AllMetrics(), 2)
+	assert.Len(t, tap1.AllMetrics(), 1)
+	assert.Len(t, tap2.AllMetrics(), 1)
+	// None of the consumers mutates the data, they all get the same data.
+	assert.True(t, md == next.AllMetrics()[1])
+	assert.True(t, md == tap1.AllMetrics()[0])
+
+	detach1()
+	// Detaching twice is a no-op.
+	detach1()
+	require.NoError(t, tc.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, next.AllMetrics(), 3)
+	assert.Len(t, tap1.AllMetrics(), 1)
+	assert.Len(t, tap2.AllMetrics(), 2)
+
+	detach2()
+	require.NoError(t, tc.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, next.AllMetrics(), 4)
+	assert.Len(t, tap2.AllMetrics(), 2)
+}
+
+func TestMetricsAttachMutatingNext(t *testing.T) {
+	next := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
+	tc := NewMetrics(next)
+	assert.True(t, tc.Capabilities().MutatesData)
+	md := testdata.GenerateMetrics(1)
+
+	tap := new(consumertest.MetricsSink)
+	defer tc.Attach(tap)()
+	require.NoError(t, tc.ConsumeMetrics(context.Background(), md))
+	// The next consumer mutating the data gets a copy.
+	assert.False(t, md == next.AllMetrics()[0])
+	assert.EqualValues(t, md, next.AllMetrics()[0])
+	assert.True(t, md == tap.AllMetrics()[0])
+}
+
+type mutatingMetricsSink struct {
+	*consumertest.MetricsSink
+}
+
+func (mts *mutatingMetricsSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapconsumer // import "go.opentelemetry.io/collector/service/internal/tapconsumer"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/service/internal/fanoutconsumer"
+)
+
+// Traces is a consumer.Traces passing the data to the next consumer, and to the consumers attached to it.
+type Traces struct {
+	next consumer.Traces
+
+	mu       sync.Mutex
+	attached []*tracesAttachment
+	// current holds the tracesChain the data is passed to.
+	current atomic.Value
+}
+
+// tracesAttachment gives a distinct identity to each consumer attached.
+type tracesAttachment struct {
+	consumer.Traces
+}
+
+// tracesChain wraps the consumers the data is passed to, since an atomic.Value must always hold the same type.
+type tracesChain struct {
+	consumer.Traces
+}
+
+var _ consumer.Traces = (*Traces)(nil)
+
+// NewTraces returns a Traces passing the data to next, until consumers are attached to it.
+func NewTraces(next consumer.Traces) *Traces {
+	t := &Traces{next: next}
+	t.current.Store(tracesChain{Traces: next})
+	return t
+}
+
+// Capabilities returns the capabilities of the next consumer, the attached consumers get copies of the data if needed.
+func (t *Traces) Capabilities() consumer.Capabilities {
+	return t.next.Capabilities()
+}
+
+// ConsumeTraces passes the data to the next consumer, and to the attached consumers.
+func (t *Traces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return t.current.Load().(tracesChain).ConsumeTraces(ctx, td)
+}
+
+// Attach attaches c, which receives the data passed to the next consumer until the returned function is called.
+// The data is passed synchronously to c, which must not keep references to it after returning.
+func (t *Traces) Attach(c consumer.Traces) func() {
+	a := &tracesAttachment{Traces: c}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.attached = append(t.attached, a)
+	t.updateLocked()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { t.detach(a) })
+	}
+}
+
+func (t *Traces) detach(a *tracesAttachment) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, attached := range t.attached {
+		if attached == a {
+			t.attached = append(t.attached[:i], t.attached[i+1:]...)
+			break
+		}
+	}
+	t.updateLocked()
+}
+
+func (t *Traces) updateLocked() {
+	consumers := make([]consumer.Traces, 0, len(t.attached)+1)
+	consumers = append(consumers, t.next)
+	for _, a := range t.attached {
+		consumers = append(consumers, a)
+	}
+	t.current.Store(tracesChain{Traces: fanoutconsumer.NewTraces(consumers)})
+}
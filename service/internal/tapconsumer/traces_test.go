@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tapconsumer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+)
+
+func TestTracesAttach(t *testing.T) {
+	next := new(consumertest.TracesSink)
+	tc := NewTraces(next)
+	assert.False(t, tc.Capabilities().MutatesData)
+	td := testdata.GenerateTraces(1)
+
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Len(t, next.AllTraces(), 1)
+
+	tap1 := new(consumertest.TracesSink)
+	detach1 := tc.Attach(tap1)
+	tap2 := new(consumertest.TracesSink)
+	detach2 := tc.Attach(tap2)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Len(t, next.AllTraces(), 2)
+	assert.Len(t, tap1.AllTraces(), 1)
+	assert.Len(t, tap2.AllTraces(), 1)
+	// None of the consumers mutates the data, they all get the same data.
+	assert.True(t, td == next.AllTraces()[1])
+	assert.True(t, td == tap1.AllTraces()[0])
+
+	detach1()
+	// Detaching twice is a no-op.
+	detach1()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Len(t, next.AllTraces(), 3)
+	assert.Len(t, tap1.AllTraces(), 1)
+	assert.Len(t, tap2.AllTraces(), 2)
+
+	detach2()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Len(t, next.AllTraces(), 4)
+	assert.Len(t, tap2.AllTraces(), 2)
+}
+
+func TestTracesAttachMutatingNext(t *testing.T) {
+	next := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
+	tc := NewTraces(next)
+	assert.True(t, tc.Capabilities().MutatesData)
+	td := testdata.GenerateTraces(1)
+
+	tap := new(consumertest.TracesSink)
+	defer tc.Attach(tap)()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	// The next consumer mutating the data gets a copy.
+	assert.False(t, td == next.AllTraces()[0])
+	assert.EqualValues(t, td, next.AllTraces()[0])
+	assert.True(t, td == tap.AllTraces()[0])
+}
+
+type mutatingTracesSink struct {
+	*consumertest.TracesSink
+}
+
+func (mts *mutatingTracesSink) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
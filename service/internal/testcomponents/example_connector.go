@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testcomponents // import "go.opentelemetry.io/collector/service/internal/testcomponents"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const connType = "exampleconnector"
+
+// ExampleConnectorConfig config for ExampleConnector.
+type ExampleConnectorConfig struct {
+	config.ConnectorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+}
+
+// ExampleConnectorFactory is factory for ExampleConnector.
+var ExampleConnectorFactory = component.NewConnectorFactory(
+	connType,
+	createConnectorDefaultConfig,
+	component.WithTracesToTracesConnector(createTracesToTracesConnector, stability),
+	component.WithTracesToMetricsConnector(createTracesToMetricsConnector, stability),
+	component.WithTracesToLogsConnector(createTracesToLogsConnector, stability),
+	component.WithMetricsToTracesConnector(createMetricsToTracesConnector, stability),
+	component.WithMetricsToMetricsConnector(createMetricsToMetricsConnector, stability),
+	component.WithMetricsToLogsConnector(createMetricsToLogsConnector, stability),
+	component.WithLogsToTracesConnector(createLogsToTracesConnector, stability),
+	component.WithLogsToMetricsConnector(createLogsToMetricsConnector, stability),
+	component.WithLogsToLogsConnector(createLogsToLogsConnector, stability),
+)
+
+func createConnectorDefaultConfig() config.Connector {
+	return &ExampleConnectorConfig{
+		ConnectorSettings: config.NewConnectorSettings(config.NewComponentID(connType)),
+	}
+}
+
+func createTracesToTracesConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Traces) (component.TracesConnector, error) {
+	return &ExampleConnector{Traces: next}, nil
+}
+
+func createTracesToMetricsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Metrics) (component.TracesConnector, error) {
+	return &ExampleConnector{Metrics: next}, nil
+}
+
+func createTracesToLogsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Logs) (component.TracesConnector, error) {
+	return &ExampleConnector{Logs: next}, nil
+}
+
+func createMetricsToTracesConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Traces) (component.MetricsConnector, error) {
+	return &ExampleConnector{Traces: next}, nil
+}
+
+func createMetricsToMetricsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Metrics) (component.MetricsConnector, error) {
+	return &ExampleConnector{Metrics: next}, nil
+}
+
+func createMetricsToLogsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Logs) (component.MetricsConnector, error) {
+	return &ExampleConnector{Logs: next}, nil
+}
+
+func createLogsToTracesConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Traces) (component.LogsConnector, error) {
+	return &ExampleConnector{Traces: next}, nil
+}
+
+func createLogsToMetricsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Metrics) (component.LogsConnector, error) {
+	return &ExampleConnector{Metrics: next}, nil
+}
+
+func createLogsToLogsConnector(_ context.Context, _ component.ConnectorCreateSettings, _ config.Connector, next consumer.Logs) (component.LogsConnector, error) {
+	return &ExampleConnector{Logs: next}, nil
+}
+
+// ExampleConnector passes the data it consumes to the next consumer when it emits the same data type,
+// and otherwise emits generated data of the type of the next consumer for each consumed batch.
+type ExampleConnector struct {
+	consumer.Traces
+	consumer.Metrics
+	consumer.Logs
+	Started bool
+	Stopped bool
+}
+
+// Start tells the connector to start.
+func (c *ExampleConnector) Start(_ context.Context, _ component.Host) error {
+	c.Started = true
+	return nil
+}
+
+func (c *ExampleConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces receives ptrace.Traces and emits them, or generated data, to the next consumer.
+func (c *ExampleConnector) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	switch {
+	case c.Traces != nil:
+		return c.Traces.ConsumeTraces(ctx, td)
+	case c.Metrics != nil:
+		return c.Metrics.ConsumeMetrics(ctx, testdata.GenerateMetrics(1))
+	case c.Logs != nil:
+		return c.Logs.ConsumeLogs(ctx, testdata.GenerateLogs(1))
+	}
+	return nil
+}
+
+// ConsumeMetrics receives pmetric.Metrics and emits them, or generated data, to the next consumer.
+func (c *ExampleConnector) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	switch {
+	case c.Traces != nil:
+		return c.Traces.ConsumeTraces(ctx, testdata.GenerateTraces(1))
+	case c.Metrics != nil:
+		return c.Metrics.ConsumeMetrics(ctx, md)
+	case c.Logs != nil:
+		return c.Logs.ConsumeLogs(ctx, testdata.GenerateLogs(1))
+	}
+	return nil
+}
+
+// ConsumeLogs receives plog.Logs and emits them, or generated data, to the next consumer.
+func (c *ExampleConnector) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	switch {
+	case c.Traces != nil:
+		return c.Traces.ConsumeTraces(ctx, testdata.GenerateTraces(1))
+	case c.Metrics != nil:
+		return c.Metrics.ConsumeMetrics(ctx, testdata.GenerateMetrics(1))
+	case c.Logs != nil:
+		return c.Logs.ConsumeLogs(ctx, ld)
+	}
+	return nil
+}
+
+// Shutdown is invoked during shutdown.
+func (c *ExampleConnector) Shutdown(context.Context) error {
+	c.Stopped = true
+	return nil
+}
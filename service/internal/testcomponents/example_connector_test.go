@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testcomponents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/testdata"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestExampleConnector(t *testing.T) {
+	next := new(consumertest.TracesSink)
+	conn, err := ExampleConnectorFactory.CreateTracesToTracesConnector(context.Background(), componenttest.NewNopConnectorCreateSettings(), ExampleConnectorFactory.CreateDefaultConfig(), next)
+	require.NoError(t, err)
+	host := componenttest.NewNopHost()
+	assert.False(t, conn.(*ExampleConnector).Started)
+	assert.NoError(t, conn.Start(context.Background(), host))
+	assert.True(t, conn.(*ExampleConnector).Started)
+
+	assert.NoError(t, conn.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	assert.Equal(t, []ptrace.Traces{testdata.GenerateTraces(1)}, next.AllTraces())
+
+	assert.False(t, conn.(*ExampleConnector).Stopped)
+	assert.NoError(t, conn.Shutdown(context.Background()))
+	assert.True(t, conn.(*ExampleConnector).Stopped)
+}
+
+func TestExampleConnectorEmitsOtherDataType(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	conn, err := ExampleConnectorFactory.CreateLogsToMetricsConnector(context.Background(), componenttest.NewNopConnectorCreateSettings(), ExampleConnectorFactory.CreateDefaultConfig(), next)
+	require.NoError(t, err)
+
+	assert.NoError(t, conn.ConsumeLogs(context.Background(), testdata.GenerateLogs(2)))
+	assert.Equal(t, []pmetric.Metrics{testdata.GenerateMetrics(1)}, next.AllMetrics())
+}
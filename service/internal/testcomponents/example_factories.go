@@ -31,5 +31,8 @@ func ExampleComponents() (component.Factories, error) {
 		Exporters: map[config.Type]component.ExporterFactory{
 			ExampleExporterFactory.Type(): ExampleExporterFactory,
 		},
+		Connectors: map[config.Type]component.ConnectorFactory{
+			ExampleConnectorFactory.Type(): ExampleConnectorFactory,
+		},
 	}, nil
 }
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages // import "go.opentelemetry.io/collector/service/internal/zpages"
+
+import (
+	"encoding/json"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	// FormatQueryKey is the query parameter selecting the format of a page.
+	FormatQueryKey = "format"
+	// FormatJSON is the value of FormatQueryKey selecting the JSON format.
+	FormatJSON = "json"
+	// FormatDOT is the value of FormatQueryKey selecting the Graphviz DOT format, for the pages showing a graph.
+	FormatDOT = "dot"
+
+	jsonMediaType = "application/json"
+	htmlMediaType = "text/html"
+)
+
+// WantsJSON returns true if the request asks for the JSON representation of a page, either with
+// the "format=json" query parameter or with an Accept header preferring "application/json" to "text/html".
+func WantsJSON(r *http.Request) bool {
+	if format := r.URL.Query().Get(FormatQueryKey); format != "" {
+		return format == FormatJSON
+	}
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(mediaRange)
+			if err != nil {
+				continue
+			}
+			switch mediaType {
+			case jsonMediaType:
+				return true
+			case htmlMediaType:
+				return false
+			}
+		}
+	}
+	return false
+}
+
+// WriteJSON writes the JSON representation of v as the response.
+func WriteJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", jsonMediaType)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Printf("zpages: encoding json: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWantsJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		accept []string
+		want   bool
+	}{
+		{name: "default", url: "/debug/pipelinez"},
+		{name: "format json", url: "/debug/pipelinez?format=json", want: true},
+		{name: "format html", url: "/debug/pipelinez?format=html", accept: []string{"application/json"}},
+		{name: "accept json", url: "/debug/pipelinez", accept: []string{"application/json"}, want: true},
+		{name: "accept json with params", url: "/debug/pipelinez", accept: []string{"application/json; charset=utf-8"}, want: true},
+		{name: "accept browser", url: "/debug/pipelinez", accept: []string{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"}},
+		{name: "accept json first", url: "/debug/pipelinez", accept: []string{"application/json, text/html"}, want: true},
+		{name: "accept html first", url: "/debug/pipelinez", accept: []string{"text/html", "application/json"}},
+		{name: "accept any", url: "/debug/pipelinez", accept: []string{"*/*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			for _, accept := range tt.accept {
+				r.Header.Add("Accept", accept)
+			}
+			assert.Equal(t, tt.want, WantsJSON(r))
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	rr := httptest.NewRecorder()
+	WriteJSON(rr, FeatureGateTableData{Rows: []FeatureGateTableRowData{{ID: "test", Enabled: true, Description: "test gate"}}})
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"feature_gates": [{"id": "test", "enabled": true, "description": "test gate"}]}`, rr.Body.String())
+}
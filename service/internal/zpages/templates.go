@@ -19,6 +19,7 @@ import (
 	"html/template"
 	"io"
 	"log"
+	"time"
 )
 
 var (
@@ -55,6 +56,18 @@ var (
 	//go:embed templates/features_table.html
 	featuresTableBytes    []byte
 	featuresTableTemplate = parseTemplate("features_table", featuresTableBytes)
+
+	//go:embed templates/components_table.html
+	componentsTableBytes    []byte
+	componentsTableTemplate = parseTemplate("components_table", componentsTableBytes)
+
+	//go:embed templates/queues_table.html
+	queuesTableBytes    []byte
+	queuesTableTemplate = parseTemplate("queues_table", queuesTableBytes)
+
+	//go:embed templates/graph.html
+	graphBytes    []byte
+	graphTemplate = parseTemplate("graph", graphBytes)
 )
 
 func parseTemplate(name string, bytes []byte) *template.Template {
@@ -75,13 +88,15 @@ func WriteHTMLPageHeader(w io.Writer, hd HeaderData) {
 
 // SummaryExtensionsTableData contains data for extensions summary table template.
 type SummaryExtensionsTableData struct {
-	Rows []SummaryExtensionsTableRowData
+	Rows []SummaryExtensionsTableRowData `json:"extensions"`
+	// Component is the extension selected with the query parameters, if any.
+	Component *SelectedComponentData `json:"component,omitempty"`
 }
 
 // SummaryExtensionsTableRowData contains data for one row in extensions summary table template.
 type SummaryExtensionsTableRowData struct {
-	FullName string
-	Enabled  bool
+	FullName string `json:"full_name"`
+	Enabled  bool   `json:"enabled"`
 }
 
 // WriteHTMLExtensionsSummaryTable writes the summary table for one component type (receivers, processors, exporters).
@@ -94,17 +109,26 @@ func WriteHTMLExtensionsSummaryTable(w io.Writer, spd SummaryExtensionsTableData
 
 // SummaryPipelinesTableData contains data for pipelines summary table template.
 type SummaryPipelinesTableData struct {
-	Rows []SummaryPipelinesTableRowData
+	Rows []SummaryPipelinesTableRowData `json:"pipelines"`
+	// Component is the component selected with the query parameters, if any.
+	Component *SelectedComponentData `json:"component,omitempty"`
+}
+
+// SelectedComponentData identifies the component whose details are requested from a page.
+type SelectedComponentData struct {
+	Kind     string `json:"kind,omitempty"`
+	Name     string `json:"name"`
+	Pipeline string `json:"pipeline,omitempty"`
 }
 
 // SummaryPipelinesTableRowData contains data for one row in pipelines summary table template.
 type SummaryPipelinesTableRowData struct {
-	FullName    string
-	InputType   string
-	MutatesData bool
-	Receivers   []string
-	Processors  []string
-	Exporters   []string
+	FullName    string   `json:"full_name"`
+	InputType   string   `json:"input_type"`
+	MutatesData bool     `json:"mutates_data"`
+	Receivers   []string `json:"receivers"`
+	Processors  []string `json:"processors"`
+	Exporters   []string `json:"exporters"`
 }
 
 // WriteHTMLPipelinesSummaryTable writes the summary table for one component type (receivers, processors, exporters).
@@ -163,14 +187,14 @@ func getValue(row [2]string) string {
 
 // FeatureGateTableData contains data for feature gate table template.
 type FeatureGateTableData struct {
-	Rows []FeatureGateTableRowData
+	Rows []FeatureGateTableRowData `json:"feature_gates"`
 }
 
 // FeatureGateTableRowData contains data for one row in feature gate table template.
 type FeatureGateTableRowData struct {
-	ID          string
-	Enabled     bool
-	Description string
+	ID          string `json:"id"`
+	Enabled     bool   `json:"enabled"`
+	Description string `json:"description"`
 }
 
 // WriteHTMLFeaturesTable writes a table summarizing registered feature gates.
@@ -179,3 +203,65 @@ func WriteHTMLFeaturesTable(w io.Writer, ftd FeatureGateTableData) {
 		log.Printf("zpages: executing template: %v", err)
 	}
 }
+
+// ComponentsTableData contains data for the components table template.
+type ComponentsTableData struct {
+	Rows []ComponentsTableRowData `json:"components"`
+}
+
+// ComponentsTableRowData contains data for one component instance in the components table template.
+type ComponentsTableRowData struct {
+	Kind       string     `json:"kind"`
+	FullName   string     `json:"full_name"`
+	DataType   string     `json:"data_type,omitempty"`
+	Pipelines  []string   `json:"pipelines,omitempty"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	StatusTime time.Time  `json:"status_time"`
+	StartTime  *time.Time `json:"start_time,omitempty"`
+}
+
+// WriteHTMLComponentsTable writes a table summarizing the statuses of the component instances.
+func WriteHTMLComponentsTable(w io.Writer, ctd ComponentsTableData) {
+	if err := componentsTableTemplate.Execute(w, ctd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
+// QueuesTableData contains data for the exporter queues table template.
+type QueuesTableData struct {
+	Rows []QueuesTableRowData `json:"exporters"`
+}
+
+// QueuesTableRowData contains data for one exporter instance in the exporter queues table template.
+type QueuesTableRowData struct {
+	FullName         string     `json:"full_name"`
+	DataType         string     `json:"data_type"`
+	QueueEnabled     bool       `json:"queue_enabled"`
+	QueueSize        int        `json:"queue_size"`
+	QueueCapacity    int        `json:"queue_capacity"`
+	RetryEnabled     bool       `json:"retry_enabled"`
+	RetryingRequests int        `json:"retrying_requests"`
+	LastError        string     `json:"last_error,omitempty"`
+	LastErrorTime    *time.Time `json:"last_error_time,omitempty"`
+}
+
+// WriteHTMLQueuesTable writes a table summarizing the sending queues and retries of the exporters.
+func WriteHTMLQueuesTable(w io.Writer, qtd QueuesTableData) {
+	if err := queuesTableTemplate.Execute(w, qtd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
+// GraphData contains data for the graph template.
+type GraphData struct {
+	// DOT is the Graphviz DOT representation of the graph.
+	DOT string
+}
+
+// WriteHTMLGraph writes the DOT representation of a graph, with links to its DOT and JSON formats.
+func WriteHTMLGraph(w io.Writer, gd GraphData) {
+	if err := graphTemplate.Execute(w, gd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"html/template"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -89,6 +90,35 @@ func TestNoCrash(t *testing.T) {
 			},
 		}})
 	})
+	assert.NotPanics(t, func() {
+		WriteHTMLComponentsTable(buf, ComponentsTableData{Rows: []ComponentsTableRowData{
+			{
+				Kind:       "exporter",
+				FullName:   "otlp",
+				DataType:   "traces",
+				Pipelines:  []string{"traces"},
+				Status:     "RecoverableError",
+				Error:      "test error",
+				StatusTime: time.Now(),
+			},
+		}})
+	})
+	assert.NotPanics(t, func() {
+		WriteHTMLQueuesTable(buf, QueuesTableData{Rows: []QueuesTableRowData{
+			{
+				FullName:         "otlp",
+				DataType:         "traces",
+				QueueEnabled:     true,
+				QueueSize:        10,
+				QueueCapacity:    100,
+				RetryEnabled:     true,
+				RetryingRequests: 1,
+			},
+		}})
+	})
+	assert.NotPanics(t, func() {
+		WriteHTMLGraph(buf, GraphData{DOT: "digraph pipelines {}"})
+	})
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLPageFooter(buf) })
 }
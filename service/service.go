@@ -24,8 +24,11 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/obsreportconfig"
 	"go.opentelemetry.io/collector/service/extensions"
+	"go.opentelemetry.io/collector/service/internal/components"
 	"go.opentelemetry.io/collector/service/internal/pipelines"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -49,13 +52,20 @@ func newService(set *settings) (*service, error) {
 			factories:         set.Factories,
 			buildInfo:         set.BuildInfo,
 			asyncErrorChannel: set.AsyncErrorChannel,
+			collectorState:    set.CollectorState,
+			statuses:          components.NewStatuses(),
 		},
 		telemetryInitializer: set.telemetry,
 	}
 
-	var err error
+	resource, err := srv.telemetryInitializer.resourceAttributes(set.BuildInfo, set.Config.Service.Telemetry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the telemetry resource: %w", err)
+	}
 	srv.telemetry, err = telemetry.New(context.Background(), telemetry.Settings{
-		ZapOptions: set.LoggingOptions}, set.Config.Service.Telemetry)
+		ZapOptions: set.LoggingOptions,
+		Resource:   resource,
+	}, set.Config.Service.Telemetry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get logger: %w", err)
 	}
@@ -99,6 +109,15 @@ func (srv *service) Start(ctx context.Context) error {
 		return fmt.Errorf("cannot start pipelines: %w", err)
 	}
 
+	if exportCfg := srv.config.Service.Telemetry.Logs.Export; exportCfg != nil {
+		set := telemetry.LogsExportSettings{}
+		if exportCfg.OTLP == nil {
+			set.Pipeline = srv.host.pipelines.GetLogsConsumer(exportCfg.Pipeline)
+			set.Loops = logsExportLoops(exportCfg.Pipeline, srv.config.Service.Pipelines[exportCfg.Pipeline])
+		}
+		srv.telemetry.StartLogsExport(set)
+	}
+
 	if err := srv.host.extensions.NotifyPipelineReady(); err != nil {
 		return err
 	}
@@ -118,6 +137,11 @@ func (srv *service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
+	// Stop exporting the logs before the pipelines they could be sent through are shut down.
+	if err := srv.telemetry.StopLogsExport(); err != nil {
+		errs = multierr.Append(errs, fmt.Errorf("failed to stop logs export: %w", err))
+	}
+
 	if err := srv.host.pipelines.ShutdownAll(ctx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
 	}
@@ -132,17 +156,17 @@ func (srv *service) Shutdown(ctx context.Context) error {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown telemetry: %w", err))
 	}
 
-	// TODO: Shutdown MeterProvider.
 	return errs
 }
 
 func (srv *service) initExtensionsAndPipeline(set *settings) error {
 	var err error
 	extensionsSettings := extensions.Settings{
-		Telemetry: srv.telemetrySettings,
-		BuildInfo: srv.buildInfo,
-		Configs:   srv.config.Extensions,
-		Factories: srv.host.factories.Extensions,
+		Telemetry:      srv.telemetrySettings,
+		BuildInfo:      srv.buildInfo,
+		Configs:        srv.config.Extensions,
+		Factories:      srv.host.factories.Extensions,
+		StatusReporter: srv.host,
 	}
 	if srv.host.extensions, err = extensions.New(context.Background(), extensionsSettings, srv.config.Service.Extensions); err != nil {
 		return fmt.Errorf("failed build extensions: %w", err)
@@ -157,18 +181,42 @@ func (srv *service) initExtensionsAndPipeline(set *settings) error {
 		ProcessorConfigs:   srv.config.Processors,
 		ExporterFactories:  srv.host.factories.Exporters,
 		ExporterConfigs:    srv.config.Exporters,
+		ConnectorFactories: srv.host.factories.Connectors,
+		ConnectorConfigs:   srv.config.Connectors,
 		PipelineConfigs:    srv.config.Service.Pipelines,
+		StatusReporter:     srv.host,
 	}
 	if srv.host.pipelines, err = pipelines.Build(context.Background(), pipelinesSettings); err != nil {
 		return fmt.Errorf("cannot build pipelines: %w", err)
 	}
 
-	if set.Config.Service.Telemetry.Metrics.Level != configtelemetry.LevelNone && set.Config.Service.Telemetry.Metrics.Address != "" {
+	metricsCfg := set.Config.Service.Telemetry.Metrics
+	if metricsCfg.Level != configtelemetry.LevelNone && (metricsCfg.Address != "" || len(metricsCfg.Readers) > 0) {
 		// The process telemetry initialization requires the ballast size, which is available after the extensions are initialized.
-		if err = proctelemetry.RegisterProcessMetrics(srv.telemetryInitializer.ocRegistry, getBallastSize(srv.host)); err != nil {
+		useOtel := srv.telemetryInitializer.registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID)
+		if err = proctelemetry.RegisterProcessMetrics(srv.telemetryInitializer.ocRegistry, srv.telemetryInitializer.mp, useOtel, getBallastSize(srv.host)); err != nil {
 			return fmt.Errorf("failed to register process metrics: %w", err)
 		}
 	}
 
 	return nil
 }
+
+// logsExportLoops returns a function reporting whether the logs written by a component can be
+// caused by sending the logs of the collector through the given pipeline.
+func logsExportLoops(pipelineID config.ComponentID, pipeline *config.Pipeline) func(telemetry.LogSource) bool {
+	exporters := make(map[string]struct{}, len(pipeline.Exporters))
+	for _, id := range pipeline.Exporters {
+		exporters[id.String()] = struct{}{}
+	}
+	return func(src telemetry.LogSource) bool {
+		switch src.Kind {
+		case components.ZapKindProcessor:
+			return src.Pipeline == pipelineID.String()
+		case components.ZapKindExporter, components.ZapKindConnector:
+			_, ok := exporters[src.Name]
+			return ok && src.DataType == string(config.LogsDataType)
+		}
+		return false
+	}
+}
@@ -28,6 +28,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/internal/zpages"
+	"go.opentelemetry.io/collector/service/telemetry"
 )
 
 func TestService_GetFactory(t *testing.T) {
@@ -52,6 +54,9 @@ func TestService_GetFactory(t *testing.T) {
 	assert.Nil(t, srv.host.GetFactory(component.KindExtension, "wrongtype"))
 	assert.Equal(t, factories.Extensions["nop"], srv.host.GetFactory(component.KindExtension, "nop"))
 
+	assert.Nil(t, srv.host.GetFactory(component.KindConnector, "wrongtype"))
+	assert.Equal(t, factories.Connectors["nop"], srv.host.GetFactory(component.KindConnector, "nop"))
+
 	// Try retrieve non existing component.Kind.
 	assert.Nil(t, srv.host.GetFactory(42, "nop"))
 }
@@ -204,6 +209,94 @@ func TestServiceTelemetryReusable(t *testing.T) {
 	require.NoError(t, srvTwo.Shutdown(context.Background()))
 }
 
+func TestServiceLogsExport(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	prov, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-logsexport.yaml")}))
+	require.NoError(t, err)
+	cfg, err := prov.Get(context.Background(), factories)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	colTel := newColTelemetry(featuregate.NewRegistry())
+	srv, err := newService(&settings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: factories,
+		Config:    cfg,
+		telemetry: colTel,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, colTel.shutdown())
+	})
+
+	assert.NoError(t, srv.Start(context.Background()))
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestServiceConnectors(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	prov, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-connectors.yaml")}))
+	require.NoError(t, err)
+	cfg, err := prov.Get(context.Background(), factories)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	colTel := newColTelemetry(featuregate.NewRegistry())
+	srv, err := newService(&settings{
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Factories: factories,
+		Config:    cfg,
+		telemetry: colTel,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, colTel.shutdown())
+	})
+
+	assert.NoError(t, srv.Start(context.Background()))
+	var connRows []zpages.ComponentsTableRowData
+	for _, row := range srv.host.getComponentsTableData().Rows {
+		if row.Kind == "connector" {
+			connRows = append(connRows, row)
+		}
+	}
+	require.Len(t, connRows, 1)
+	assert.Equal(t, "nop/conn", connRows[0].FullName)
+	assert.Equal(t, "traces -> metrics", connRows[0].DataType)
+	assert.Equal(t, []string{"metrics", "traces"}, connRows[0].Pipelines)
+	assert.Equal(t, "OK", connRows[0].Status)
+
+	// The graph links the traces pipeline to the metrics pipeline through the connector.
+	graph := srv.host.pipelines.Graph()
+	assert.Contains(t, graph.Edges, GraphEdge{
+		From:     "connector:nop/conn:traces:metrics",
+		To:       "exporter:nop:metrics",
+		DataType: config.MetricsDataType,
+		Pipeline: config.NewComponentID(config.MetricsDataType),
+	})
+	assert.NoError(t, srv.Shutdown(context.Background()))
+}
+
+func TestLogsExportLoops(t *testing.T) {
+	pipelineID := config.NewComponentIDWithName("logs", "self")
+	loops := logsExportLoops(pipelineID, &config.Pipeline{
+		Processors: []config.ComponentID{config.NewComponentID("batch")},
+		Exporters:  []config.ComponentID{config.NewComponentID("otlp"), config.NewComponentID("forward")},
+	})
+
+	assert.True(t, loops(telemetry.LogSource{Kind: "processor", Name: "batch", Pipeline: "logs/self"}))
+	assert.True(t, loops(telemetry.LogSource{Kind: "exporter", Name: "otlp", DataType: "logs"}))
+	assert.True(t, loops(telemetry.LogSource{Kind: "connector", Name: "forward", DataType: "logs"}))
+	assert.False(t, loops(telemetry.LogSource{Kind: "processor", Name: "batch", Pipeline: "logs"}))
+	assert.False(t, loops(telemetry.LogSource{Kind: "exporter", Name: "otlp", DataType: "traces"}))
+	assert.False(t, loops(telemetry.LogSource{Kind: "receiver", Name: "otlp", Pipeline: "logs"}))
+	assert.False(t, loops(telemetry.LogSource{}))
+}
+
 func createExampleService(t *testing.T, factories component.Factories) *service {
 	// Read yaml config from file
 	prov, err := NewConfigProvider(newDefaultConfigProviderSettings([]string{filepath.Join("testdata", "otelcol-nop.yaml")}))
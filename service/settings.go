@@ -37,6 +37,9 @@ type settings struct {
 	// LoggingOptions provides a way to change behavior of zap logging.
 	LoggingOptions []zap.Option
 
+	// CollectorState returns the state of the collector running the service, it can be nil.
+	CollectorState func() State
+
 	// For testing purpose only.
 	telemetry *telemetryInitializer
 }
@@ -37,13 +37,16 @@ import (
 	"go.opentelemetry.io/otel/propagation"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/internal/resourcedetection"
 	"go.opentelemetry.io/collector/processor/batchprocessor"
+	"go.opentelemetry.io/collector/processor/probabilisticsamplerprocessor"
 	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -65,8 +68,16 @@ type telemetryInitializer struct {
 	registry *featuregate.Registry
 	views    []*view.View
 
+	// resource holds the resource attributes of the telemetry of the collector.
+	resource     map[string]string
+	resourceErr  error
+	resourceOnce sync.Once
+
 	ocRegistry *ocmetric.Registry
 	mp         metric.MeterProvider
+	// sdkMP is set when the collector uses OpenTelemetry for internal metrics, to flush the
+	// metrics of the readers on shutdown.
+	sdkMP *sdkmetric.MeterProvider
 
 	server     *http.Server
 	doInitOnce sync.Once
@@ -83,7 +94,7 @@ func (tel *telemetryInitializer) init(buildInfo component.BuildInfo, logger *zap
 	var err error
 	tel.doInitOnce.Do(
 		func() {
-			if cfg.Metrics.Level == configtelemetry.LevelNone || cfg.Metrics.Address == "" {
+			if cfg.Metrics.Level == configtelemetry.LevelNone || (cfg.Metrics.Address == "" && len(cfg.Metrics.Readers) == 0) {
 				logger.Info(
 					"Skipping telemetry setup.",
 					zap.String(zapKeyTelemetryAddress, cfg.Metrics.Address),
@@ -93,7 +104,7 @@ func (tel *telemetryInitializer) init(buildInfo component.BuildInfo, logger *zap
 			}
 
 			err = tel.initOnce(buildInfo, logger, cfg)
-			if err == nil {
+			if err == nil && tel.server != nil {
 				go func() {
 					if serveErr := tel.server.ListenAndServe(); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
 						asyncErrorChannel <- serveErr
@@ -109,8 +120,10 @@ func (tel *telemetryInitializer) init(buildInfo component.BuildInfo, logger *zap
 func (tel *telemetryInitializer) initOnce(buildInfo component.BuildInfo, logger *zap.Logger, cfg telemetry.Config) error {
 	logger.Info("Setting up own telemetry...")
 
-	// Construct telemetry attributes from build info and config's resource attributes.
-	telAttrs := buildTelAttrs(buildInfo, cfg)
+	resource, err := tel.resourceAttributes(buildInfo, cfg)
+	if err != nil {
+		return err
+	}
 
 	if tp, err := textMapPropagatorFromConfig(cfg.Traces.Propagators); err == nil {
 		otel.SetTextMapPropagator(tp)
@@ -118,25 +131,33 @@ func (tel *telemetryInitializer) initOnce(buildInfo component.BuildInfo, logger
 		return err
 	}
 
+	useOtel := tel.registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID)
+	if !useOtel && (len(cfg.Metrics.Readers) > 0 || len(cfg.Metrics.Views) > 0) {
+		return fmt.Errorf("metric readers and views cannot be used when the %q feature gate is disabled", obsreportconfig.UseOtelForInternalMetricsfeatureGateID)
+	}
+
 	var pe http.Handler
-	var err error
 	// This prometheus registry is shared between OpenCensus and OpenTelemetry exporters,
 	// acting as a bridge between OC and Otel.
 	// This is used as a path to migrate the existing OpenCensus instrumentation
 	// to the OpenTelemetry Go SDK without breaking existing metrics.
 	promRegistry := prometheus.NewRegistry()
-	if tel.registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID) {
-		err = tel.initOpenTelemetry(telAttrs, promRegistry)
+	if useOtel {
+		err = tel.initOpenTelemetry(cfg, resource, promRegistry)
 		if err != nil {
 			return err
 		}
 	}
 
-	pe, err = tel.initOpenCensus(cfg, telAttrs, promRegistry)
+	pe, err = tel.initOpenCensus(cfg, resource, promRegistry)
 	if err != nil {
 		return err
 	}
 
+	if cfg.Metrics.Address == "" {
+		return nil
+	}
+
 	logger.Info(
 		"Serving Prometheus metrics",
 		zap.String(zapKeyTelemetryAddress, cfg.Metrics.Address),
@@ -154,7 +175,17 @@ func (tel *telemetryInitializer) initOnce(buildInfo component.BuildInfo, logger
 	return nil
 }
 
-func buildTelAttrs(buildInfo component.BuildInfo, cfg telemetry.Config) map[string]string {
+// resourceAttributes returns the resource attributes of the telemetry of the collector. They are built
+// once, from the first configuration, so that the instance ID remains the same when the configuration is reloaded.
+func (tel *telemetryInitializer) resourceAttributes(buildInfo component.BuildInfo, cfg telemetry.Config) (map[string]string, error) {
+	tel.resourceOnce.Do(func() {
+		// Construct telemetry attributes from build info and config's resource attributes.
+		tel.resource, tel.resourceErr = buildTelAttrs(buildInfo, cfg)
+	})
+	return tel.resource, tel.resourceErr
+}
+
+func buildTelAttrs(buildInfo component.BuildInfo, cfg telemetry.Config) (map[string]string, error) {
 	telAttrs := map[string]string{}
 
 	for k, v := range cfg.Resource {
@@ -182,7 +213,18 @@ func buildTelAttrs(buildInfo component.BuildInfo, cfg telemetry.Config) map[stri
 		telAttrs[semconv.AttributeServiceVersion] = buildInfo.Version
 	}
 
-	return telAttrs
+	detected, err := resourcedetection.Detect(cfg.ResourceDetectors)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range detected {
+		// The attributes specified in the config, even as nil, take precedence.
+		if _, ok := cfg.Resource[k]; !ok {
+			telAttrs[k] = v
+		}
+	}
+
+	return telAttrs, nil
 }
 
 func (tel *telemetryInitializer) initOpenCensus(cfg telemetry.Config, telAttrs map[string]string, promRegistry *prometheus.Registry) (http.Handler, error) {
@@ -190,9 +232,21 @@ func (tel *telemetryInitializer) initOpenCensus(cfg telemetry.Config, telAttrs m
 	metricproducer.GlobalManager().AddProducer(tel.ocRegistry)
 
 	var views []*view.View
-	obsMetrics := obsreportconfig.Configure(cfg.Metrics.Level)
-	views = append(views, batchprocessor.MetricViews()...)
-	views = append(views, obsMetrics.Views...)
+	dims := make([]obsreportconfig.Dimension, 0, len(cfg.Metrics.Dimensions))
+	for _, d := range cfg.Metrics.Dimensions {
+		dims = append(dims, obsreportconfig.Dimension{
+			Name:           d.Name,
+			MetadataKey:    d.MetadataKey,
+			AuthAttribute:  d.AuthAttribute,
+			MaxCardinality: d.MaxCardinality,
+		})
+	}
+	obsMetrics := obsreportconfig.Configure(cfg.Metrics.Level, dims...)
+	if !tel.registry.IsEnabled(obsreportconfig.UseOtelForInternalMetricsfeatureGateID) {
+		views = append(views, batchprocessor.MetricViews()...)
+		views = append(views, probabilisticsamplerprocessor.MetricViews()...)
+		views = append(views, obsMetrics.Views...)
+	}
 
 	tel.views = views
 	if err := view.Register(views...); err != nil {
@@ -220,10 +274,7 @@ func (tel *telemetryInitializer) initOpenCensus(cfg telemetry.Config, telAttrs m
 	return pe, nil
 }
 
-func (tel *telemetryInitializer) initOpenTelemetry(attrs map[string]string, promRegistry prometheus.Registerer) error {
-	// Initialize the ocRegistry, still used by the process metrics.
-	tel.ocRegistry = ocmetric.NewRegistry()
-
+func (tel *telemetryInitializer) initOpenTelemetry(cfg telemetry.Config, attrs map[string]string, promRegistry prometheus.Registerer) error {
 	var resAttrs []attribute.KeyValue
 	for k, v := range attrs {
 		resAttrs = append(resAttrs, attribute.String(k, v))
@@ -234,15 +285,43 @@ func (tel *telemetryInitializer) initOpenTelemetry(attrs map[string]string, prom
 		return fmt.Errorf("error creating otel resources: %w", err)
 	}
 
-	wrappedRegisterer := prometheus.WrapRegistererWithPrefix("otelcol_", promRegistry)
-	exporter, err := otelprom.New(otelprom.WithRegisterer(wrappedRegisterer))
+	views, err := batchprocessor.OtelMetricViews()
 	if err != nil {
-		return fmt.Errorf("error creating otel prometheus exporter: %w", err)
+		return fmt.Errorf("error creating batch processor views: %w", err)
+	}
+	for i, viewCfg := range cfg.Metrics.Views {
+		v, viewErr := newMetricView(viewCfg)
+		if viewErr != nil {
+			return fmt.Errorf("error creating view %d: %w", i, viewErr)
+		}
+		views = append(views, v)
 	}
-	tel.mp = sdkmetric.NewMeterProvider(
-		sdkmetric.WithResource(res),
-		sdkmetric.WithReader(exporter),
-	)
+
+	opts := []sdkmetric.Option{sdkmetric.WithResource(res)}
+	if cfg.Metrics.Address != "" {
+		// The resource attributes are added as labels to all the metrics, rather than exposed with a
+		// target_info metric, so that the metrics keep the labels they have with OpenCensus.
+		constLabels := prometheus.Labels{}
+		for k, v := range attrs {
+			constLabels[sanitizePrometheusKey(k)] = v
+		}
+		wrappedRegisterer := prometheus.WrapRegistererWith(constLabels, prometheus.WrapRegistererWithPrefix("otelcol_", promRegistry))
+		exporter, promErr := otelprom.New(otelprom.WithRegisterer(wrappedRegisterer), otelprom.WithoutTargetInfo(), otelprom.WithoutUnits())
+		if promErr != nil {
+			return fmt.Errorf("error creating otel prometheus exporter: %w", promErr)
+		}
+		opts = append(opts, sdkmetric.WithReader(exporter, views...))
+	}
+	for i, readerCfg := range cfg.Metrics.Readers {
+		reader, readerErr := newMetricReader(context.Background(), readerCfg)
+		if readerErr != nil {
+			return fmt.Errorf("error creating metric reader %d: %w", i, readerErr)
+		}
+		opts = append(opts, sdkmetric.WithReader(reader, views...))
+	}
+
+	tel.sdkMP = sdkmetric.NewMeterProvider(opts...)
+	tel.mp = tel.sdkMP
 
 	return nil
 }
@@ -252,11 +331,17 @@ func (tel *telemetryInitializer) shutdown() error {
 
 	view.Unregister(tel.views...)
 
+	var errs error
+	if tel.sdkMP != nil {
+		// Flushes the metrics of the readers.
+		errs = multierr.Append(errs, tel.sdkMP.Shutdown(context.Background()))
+	}
+
 	if tel.server != nil {
-		return tel.server.Close()
+		errs = multierr.Append(errs, tel.server.Close())
 	}
 
-	return nil
+	return errs
 }
 
 func sanitizePrometheusKey(str string) string {
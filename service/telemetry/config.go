@@ -15,9 +15,17 @@
 package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opencensus.io/tag"
 	"go.uber.org/zap/zapcore"
 
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/internal/resourcedetection"
 )
 
 // Config defines the configurable settings for service telemetry.
@@ -31,6 +39,12 @@ type Config struct {
 	// if they are not specified here. In order to suppress such attributes the
 	// attribute must be specified in this map with null YAML value (nil string pointer).
 	Resource map[string]*string `mapstructure:"resource"`
+
+	// ResourceDetectors are the detectors of the environment of the collector
+	// whose attributes are added to the resource of all emitted telemetry,
+	// among "host", "container" and "k8s". The attributes specified in Resource
+	// take precedence over the detected ones.
+	ResourceDetectors []string `mapstructure:"resource_detectors"`
 }
 
 // LogsConfig defines the configurable settings for service telemetry logs.
@@ -89,6 +103,45 @@ type LogsConfig struct {
 	//
 	// By default, there is no initial field.
 	InitialFields map[string]interface{} `mapstructure:"initial_fields"`
+
+	// Export sends the logs through one of the logs pipelines of the collector or to an OTLP
+	// endpoint, in addition to writing them to the OutputPaths.
+	// By default, logs are not exported.
+	Export *LogsExportConfig `mapstructure:"export"`
+}
+
+// LogsExportConfig defines where the logs of the collector are exported to. The logs written by
+// the components of the logs pipeline and by gRPC are never exported, since they can be caused
+// by the export itself.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type LogsExportConfig struct {
+	// Pipeline is the ID of the logs pipeline the logs are sent through, e.g. "logs/self".
+	// The pipeline does not need any receiver.
+	Pipeline config.ComponentID `mapstructure:"pipeline"`
+
+	// OTLP sends the logs to an OTLP endpoint.
+	OTLP *OTLPExporterConfig `mapstructure:"otlp"`
+
+	// QueueSize is the maximum number of log records waiting to be exported. Records
+	// written while the queue is full are dropped.
+	// (default = 1000)
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+// Validate checks the logs export configuration is valid.
+func (e *LogsExportConfig) Validate() error {
+	if (e.Pipeline == config.ComponentID{}) == (e.OTLP == nil) {
+		return errors.New("exactly one of pipeline or otlp must be specified")
+	}
+	if e.OTLP != nil {
+		if err := e.OTLP.Validate(); err != nil {
+			return fmt.Errorf("otlp: %w", err)
+		}
+	}
+	if e.QueueSize < 0 {
+		return fmt.Errorf("invalid queue_size %d, must not be negative", e.QueueSize)
+	}
+	return nil
 }
 
 // MetricsConfig exposes the common Telemetry configuration for one component.
@@ -103,6 +156,298 @@ type MetricsConfig struct {
 
 	// Address is the [address]:port that metrics exposition should be bound to.
 	Address string `mapstructure:"address"`
+
+	// Dimensions are additional dimensions of the receiver and exporter metrics, whose values
+	// are read from the metadata or the authentication data of the clients sending telemetry.
+	// By default, no dimension is added.
+	Dimensions []DimensionConfig `mapstructure:"dimensions"`
+
+	// Readers are the metric readers pushing the metrics to a backend, in addition to
+	// the Prometheus endpoint exposed at Address. They are rejected when the
+	// "telemetry.useOtelForInternalMetrics" feature gate is disabled to record the metrics with OpenCensus.
+	// By default, no reader is configured.
+	Readers []MetricReaderConfig `mapstructure:"readers"`
+
+	// Views customize the metrics emitted by the collector, e.g. to rename or drop metrics,
+	// or to change the bucket boundaries of histograms. Like the Readers, they are rejected when the
+	// "telemetry.useOtelForInternalMetrics" feature gate is disabled.
+	Views []MetricViewConfig `mapstructure:"views"`
+}
+
+// DimensionConfig defines an additional dimension of the receiver and exporter metrics,
+// for instance to account for the telemetry sent by each tenant.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type DimensionConfig struct {
+	// Name is the name of the metric label the value is recorded with.
+	Name string `mapstructure:"name"`
+
+	// MetadataKey is the key of the client metadata the value is read from. The receivers
+	// must be configured to include the client metadata, e.g. with `include_metadata: true`.
+	MetadataKey string `mapstructure:"metadata_key"`
+
+	// AuthAttribute is the attribute of the client authentication data the value is read from,
+	// e.g. "subject" or "username".
+	AuthAttribute string `mapstructure:"auth_attribute"`
+
+	// MaxCardinality is the maximum number of distinct values recorded for the dimension.
+	// Values seen once the limit is reached are recorded as "other".
+	// (default = 100)
+	MaxCardinality int `mapstructure:"max_cardinality"`
+}
+
+var reservedDimensions = map[string]struct{}{
+	"receiver":  {},
+	"transport": {},
+	"exporter":  {},
+}
+
+// Validate checks the telemetry configuration is valid.
+func (c *Config) Validate() error {
+	if c.Logs.Export != nil {
+		if err := c.Logs.Export.Validate(); err != nil {
+			return fmt.Errorf("logs export: %w", err)
+		}
+	}
+	if err := c.Traces.Validate(); err != nil {
+		return fmt.Errorf("traces: %w", err)
+	}
+	if err := resourcedetection.Validate(c.ResourceDetectors); err != nil {
+		return err
+	}
+	return c.Metrics.Validate()
+}
+
+// Validate checks the metrics configuration is valid.
+func (c *MetricsConfig) Validate() error {
+	names := map[string]struct{}{}
+	for i, d := range c.Dimensions {
+		if err := d.Validate(); err != nil {
+			return fmt.Errorf("dimension %d: %w", i, err)
+		}
+		if _, ok := names[d.Name]; ok {
+			return fmt.Errorf("dimension %d: duplicate name %q", i, d.Name)
+		}
+		names[d.Name] = struct{}{}
+	}
+	for i, r := range c.Readers {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("reader %d: %w", i, err)
+		}
+	}
+	for i, v := range c.Views {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("view %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the dimension configuration is valid.
+func (d *DimensionConfig) Validate() error {
+	if _, err := tag.NewKey(d.Name); err != nil {
+		return fmt.Errorf("invalid name %q: %w", d.Name, err)
+	}
+	if _, ok := reservedDimensions[d.Name]; ok {
+		return fmt.Errorf("name %q is reserved", d.Name)
+	}
+	if (d.MetadataKey == "") == (d.AuthAttribute == "") {
+		return errors.New("exactly one of metadata_key or auth_attribute must be specified")
+	}
+	if d.MaxCardinality < 0 {
+		return fmt.Errorf("invalid max_cardinality %d, must not be negative", d.MaxCardinality)
+	}
+	return nil
+}
+
+const (
+	// ProtocolGRPC is the OTLP/gRPC protocol.
+	ProtocolGRPC = "grpc"
+	// ProtocolHTTPProtobuf is the OTLP/HTTP protocol, with protobuf encoded payloads.
+	ProtocolHTTPProtobuf = "http/protobuf"
+
+	// AggregationDrop drops the measurements of the selected instruments.
+	AggregationDrop = "drop"
+	// AggregationSum aggregates the measurements as a sum.
+	AggregationSum = "sum"
+	// AggregationLastValue aggregates the measurements as a gauge holding the last value.
+	AggregationLastValue = "last_value"
+	// AggregationExplicitBucketHistogram aggregates the measurements as a histogram
+	// with the configured bucket boundaries.
+	AggregationExplicitBucketHistogram = "explicit_bucket_histogram"
+)
+
+// MetricReaderConfig defines a reader collecting the metrics of the collector.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type MetricReaderConfig struct {
+	// Periodic collects the metrics at a fixed interval and pushes them with an exporter.
+	Periodic *PeriodicMetricReaderConfig `mapstructure:"periodic"`
+}
+
+// Validate checks the reader configuration is valid.
+func (r *MetricReaderConfig) Validate() error {
+	if r.Periodic == nil {
+		return errors.New("periodic must be specified")
+	}
+	return r.Periodic.Validate()
+}
+
+// PeriodicMetricReaderConfig defines a reader collecting the metrics at a fixed interval.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type PeriodicMetricReaderConfig struct {
+	// Interval is the time between the starts of two consecutive exports.
+	// (default = 60s)
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Timeout is the maximum duration of an export.
+	// (default = 30s)
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// Exporter is the exporter the metrics are pushed with.
+	Exporter MetricExporterConfig `mapstructure:"exporter"`
+}
+
+// Validate checks the periodic reader configuration is valid.
+func (p *PeriodicMetricReaderConfig) Validate() error {
+	if p.Interval < 0 {
+		return fmt.Errorf("invalid interval %v, must not be negative", p.Interval)
+	}
+	if p.Timeout < 0 {
+		return fmt.Errorf("invalid timeout %v, must not be negative", p.Timeout)
+	}
+	if err := p.Exporter.Validate(); err != nil {
+		return fmt.Errorf("exporter: %w", err)
+	}
+	return nil
+}
+
+// MetricExporterConfig defines the exporter a reader pushes the metrics with.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type MetricExporterConfig struct {
+	// OTLP exports the metrics with the OpenTelemetry protocol.
+	OTLP *OTLPExporterConfig `mapstructure:"otlp"`
+}
+
+// Validate checks the exporter configuration is valid.
+func (e *MetricExporterConfig) Validate() error {
+	if e.OTLP == nil {
+		return errors.New("otlp must be specified")
+	}
+	return e.OTLP.Validate()
+}
+
+// OTLPExporterConfig defines an exporter pushing the telemetry of the collector with the OpenTelemetry protocol.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type OTLPExporterConfig struct {
+	// Protocol is the OTLP transport, "grpc" or "http/protobuf".
+	// (default = "grpc")
+	Protocol string `mapstructure:"protocol"`
+
+	// Endpoint is the host:port of the OTLP server. With the "http/protobuf" protocol,
+	// the metrics, logs and spans are sent to the /v1/metrics, /v1/logs and /v1/traces paths.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Insecure disables TLS.
+	// (default = false)
+	Insecure bool `mapstructure:"insecure"`
+
+	// Headers are additional headers sent with every export.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Compression is the compression of the payloads, "gzip" or "none".
+	// (default = "none")
+	Compression string `mapstructure:"compression"`
+
+	// Timeout is the maximum duration of an export request.
+	// (default = 10s)
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks the OTLP exporter configuration is valid.
+func (o *OTLPExporterConfig) Validate() error {
+	switch o.Protocol {
+	case "", ProtocolGRPC, ProtocolHTTPProtobuf:
+	default:
+		return fmt.Errorf("unsupported protocol %q, must be %q or %q", o.Protocol, ProtocolGRPC, ProtocolHTTPProtobuf)
+	}
+	if o.Endpoint == "" {
+		return errors.New("endpoint must be specified")
+	}
+	switch o.Compression {
+	case "", "none", "gzip":
+	default:
+		return fmt.Errorf("unsupported compression %q, must be \"gzip\" or \"none\"", o.Compression)
+	}
+	if o.Timeout < 0 {
+		return fmt.Errorf("invalid timeout %v, must not be negative", o.Timeout)
+	}
+	return nil
+}
+
+// MetricViewConfig defines a view customizing the metrics of the selected instruments.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type MetricViewConfig struct {
+	// Selector selects the instruments the view applies to.
+	Selector MetricViewSelectorConfig `mapstructure:"selector"`
+
+	// Stream describes the metric emitted for the selected instruments.
+	Stream MetricViewStreamConfig `mapstructure:"stream"`
+}
+
+// Validate checks the view configuration is valid.
+func (v *MetricViewConfig) Validate() error {
+	if v.Selector.InstrumentName == "" && v.Selector.MeterName == "" {
+		return errors.New("selector: at least one of instrument_name or meter_name must be specified")
+	}
+	if v.Stream.Name != "" && (v.Selector.InstrumentName == "" || strings.ContainsAny(v.Selector.InstrumentName, "*?")) {
+		return errors.New("stream: name can only be set when selecting a single instrument_name")
+	}
+	switch v.Stream.Aggregation {
+	case "", AggregationDrop, AggregationSum, AggregationLastValue:
+		if len(v.Stream.Boundaries) != 0 {
+			return fmt.Errorf("stream: boundaries can only be set with the %q aggregation", AggregationExplicitBucketHistogram)
+		}
+	case AggregationExplicitBucketHistogram:
+		for i := 1; i < len(v.Stream.Boundaries); i++ {
+			if v.Stream.Boundaries[i] <= v.Stream.Boundaries[i-1] {
+				return errors.New("stream: boundaries must be in increasing order")
+			}
+		}
+	default:
+		return fmt.Errorf("stream: unsupported aggregation %q", v.Stream.Aggregation)
+	}
+	return nil
+}
+
+// MetricViewSelectorConfig selects the instruments a view applies to.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type MetricViewSelectorConfig struct {
+	// InstrumentName is the name of the instruments, e.g. "processor/batch/batch_send_size".
+	// The "*" and "?" wildcards match zero or more characters and exactly one character.
+	InstrumentName string `mapstructure:"instrument_name"`
+
+	// MeterName is the name of the meter which created the instruments,
+	// e.g. "go.opentelemetry.io/collector/obsreport/receiver".
+	MeterName string `mapstructure:"meter_name"`
+}
+
+// MetricViewStreamConfig describes the metric emitted by a view.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type MetricViewStreamConfig struct {
+	// Name is the new name of the metric. By default, the instrument name is kept.
+	Name string `mapstructure:"name"`
+
+	// Description is the new description of the metric. By default, the instrument description is kept.
+	Description string `mapstructure:"description"`
+
+	// AttributeKeys are the only attributes kept on the metric. By default, all attributes are kept.
+	AttributeKeys []string `mapstructure:"attribute_keys"`
+
+	// Aggregation is the aggregation of the measurements, one of "drop", "sum", "last_value"
+	// or "explicit_bucket_histogram". By default, the aggregation depends on the instrument kind.
+	Aggregation string `mapstructure:"aggregation"`
+
+	// Boundaries are the bucket boundaries of the "explicit_bucket_histogram" aggregation.
+	Boundaries []float64 `mapstructure:"boundaries"`
 }
 
 // TracesConfig exposes the common Telemetry configuration for collector's internal spans.
@@ -112,4 +457,137 @@ type TracesConfig struct {
 	// tracecontext and  b3 are supported. By default, the value is set to empty list and
 	// context propagation is disabled.
 	Propagators []string `mapstructure:"propagators"`
+
+	// Sampler decides which spans of the collector are sampled, and exported by the Processors.
+	// The spans which are not sampled are still recorded for the zpages extension.
+	Sampler TraceSamplerConfig `mapstructure:"sampler"`
+
+	// Processors are the span processors exporting the sampled spans to a backend.
+	// By default, spans are not exported.
+	Processors []SpanProcessorConfig `mapstructure:"processors"`
+}
+
+// Validate checks the traces configuration is valid.
+func (c *TracesConfig) Validate() error {
+	if err := c.Sampler.Validate(); err != nil {
+		return fmt.Errorf("sampler: %w", err)
+	}
+	for i, p := range c.Processors {
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("processor %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+const (
+	// SamplerAlwaysOn samples all the spans.
+	SamplerAlwaysOn = "always_on"
+	// SamplerAlwaysOff samples none of the spans.
+	SamplerAlwaysOff = "always_off"
+	// SamplerTraceIDRatio samples the configured ratio of the traces.
+	SamplerTraceIDRatio = "traceidratio"
+)
+
+// TraceSamplerConfig defines the sampler of the spans of the collector. The sampler only applies
+// to the root spans, the other spans are sampled when their parent is.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type TraceSamplerConfig struct {
+	// Type is the sampler, one of "always_on", "always_off" or "traceidratio".
+	// (default = "always_off")
+	Type string `mapstructure:"type"`
+
+	// Ratio is the ratio of the traces sampled by the "traceidratio" sampler, between 0 and 1.
+	Ratio float64 `mapstructure:"ratio"`
+}
+
+// Validate checks the sampler configuration is valid.
+func (s *TraceSamplerConfig) Validate() error {
+	switch s.Type {
+	case "", SamplerAlwaysOn, SamplerAlwaysOff:
+		if s.Ratio != 0 {
+			return fmt.Errorf("ratio can only be set with the %q sampler", SamplerTraceIDRatio)
+		}
+	case SamplerTraceIDRatio:
+		if s.Ratio < 0 || s.Ratio > 1 {
+			return fmt.Errorf("invalid ratio %v, must be between 0 and 1", s.Ratio)
+		}
+	default:
+		return fmt.Errorf("unsupported type %q", s.Type)
+	}
+	return nil
+}
+
+// SpanProcessorConfig defines a processor exporting the spans of the collector.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type SpanProcessorConfig struct {
+	// Batch exports the spans in batches with an exporter.
+	Batch *BatchSpanProcessorConfig `mapstructure:"batch"`
+}
+
+// Validate checks the span processor configuration is valid.
+func (p *SpanProcessorConfig) Validate() error {
+	if p.Batch == nil {
+		return errors.New("batch must be specified")
+	}
+	return p.Batch.Validate()
+}
+
+// BatchSpanProcessorConfig defines a processor exporting the spans in batches.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type BatchSpanProcessorConfig struct {
+	// ScheduleDelay is the maximum time between two consecutive exports.
+	// (default = 5s)
+	ScheduleDelay time.Duration `mapstructure:"schedule_delay"`
+
+	// ExportTimeout is the maximum duration of an export.
+	// (default = 30s)
+	ExportTimeout time.Duration `mapstructure:"export_timeout"`
+
+	// MaxQueueSize is the maximum number of spans waiting to be exported. The spans
+	// ended while the queue is full are dropped.
+	// (default = 2048)
+	MaxQueueSize int `mapstructure:"max_queue_size"`
+
+	// MaxExportBatchSize is the maximum number of spans exported at once.
+	// (default = 512)
+	MaxExportBatchSize int `mapstructure:"max_export_batch_size"`
+
+	// Exporter is the exporter the spans are pushed with.
+	Exporter SpanExporterConfig `mapstructure:"exporter"`
+}
+
+// Validate checks the batch span processor configuration is valid.
+func (b *BatchSpanProcessorConfig) Validate() error {
+	if b.ScheduleDelay < 0 {
+		return fmt.Errorf("invalid schedule_delay %v, must not be negative", b.ScheduleDelay)
+	}
+	if b.ExportTimeout < 0 {
+		return fmt.Errorf("invalid export_timeout %v, must not be negative", b.ExportTimeout)
+	}
+	if b.MaxQueueSize < 0 {
+		return fmt.Errorf("invalid max_queue_size %d, must not be negative", b.MaxQueueSize)
+	}
+	if b.MaxExportBatchSize < 0 {
+		return fmt.Errorf("invalid max_export_batch_size %d, must not be negative", b.MaxExportBatchSize)
+	}
+	if err := b.Exporter.Validate(); err != nil {
+		return fmt.Errorf("exporter: %w", err)
+	}
+	return nil
+}
+
+// SpanExporterConfig defines the exporter a span processor pushes the spans with.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type SpanExporterConfig struct {
+	// OTLP exports the spans with the OpenTelemetry protocol.
+	OTLP *OTLPExporterConfig `mapstructure:"otlp"`
+}
+
+// Validate checks the exporter configuration is valid.
+func (e *SpanExporterConfig) Validate() error {
+	if e.OTLP == nil {
+		return errors.New("otlp must be specified")
+	}
+	return e.OTLP.Validate()
 }
@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+const (
+	logsExportScopeName        = "go.opentelemetry.io/collector/service/telemetry"
+	defaultLogsExportQueueSize = 1000
+	// logsExportBatchSize is the number of queued records triggering an export before the next tick.
+	logsExportBatchSize = 100
+	logsExportInterval  = time.Second
+
+	// grpcLogKey is the field added to the logs of gRPC by the collector.
+	grpcLogKey = "grpc_log"
+)
+
+// LogSource identifies the component which wrote a log, from the fields of its logger.
+type LogSource struct {
+	Kind     string
+	Name     string
+	DataType string
+	Pipeline string
+}
+
+// LogsExportSettings holds the settings to start exporting the logs of the collector.
+type LogsExportSettings struct {
+	// Pipeline is the first consumer of the logs pipeline the logs are sent through,
+	// when the export is configured with a pipeline.
+	Pipeline consumer.Logs
+
+	// Loops reports whether the logs written by a component can be caused by their export,
+	// in which case they are not exported to avoid an endless loop.
+	Loops func(LogSource) bool
+}
+
+type logRecord struct {
+	entry  zapcore.Entry
+	attrs  map[string]interface{}
+	source LogSource
+}
+
+// logsExporter queues the logs of the collector, and exports them in the background once started.
+type logsExporter struct {
+	// logger only writes to the configured outputs, it is used to report export failures.
+	logger    *zap.Logger
+	resource  map[string]string
+	otlp      *otlpLogsClient
+	queueSize int
+
+	mu      sync.Mutex
+	records []logRecord
+	dropped int
+	stopped bool
+	loops   func(LogSource) bool
+	send    func(context.Context, plog.Logs) error
+
+	flushCh chan struct{}
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newLogsExporter(cfg *LogsExportConfig, resource map[string]string, logger *zap.Logger) (*logsExporter, error) {
+	e := &logsExporter{
+		logger:    logger,
+		resource:  resource,
+		queueSize: cfg.QueueSize,
+		flushCh:   make(chan struct{}, 1),
+	}
+	if e.queueSize == 0 {
+		e.queueSize = defaultLogsExportQueueSize
+	}
+	if cfg.OTLP != nil {
+		var err error
+		if e.otlp, err = newOTLPLogsClient(cfg.OTLP); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func (e *logsExporter) start(set LogsExportSettings) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopped || e.stopCh != nil {
+		return
+	}
+	e.loops = set.Loops
+	if e.otlp != nil {
+		e.send = e.otlp.export
+	} else {
+		e.send = set.Pipeline.ConsumeLogs
+	}
+	e.stopCh = make(chan struct{})
+	e.wg.Add(1)
+	go e.run()
+}
+
+// shutdown exports the queued logs and stops the export. The logs written afterwards are not exported.
+func (e *logsExporter) shutdown() error {
+	e.mu.Lock()
+	if e.stopped {
+		e.mu.Unlock()
+		return nil
+	}
+	e.stopped = true
+	stopCh := e.stopCh
+	e.mu.Unlock()
+
+	if stopCh != nil {
+		close(stopCh)
+		e.wg.Wait()
+	}
+	if e.otlp != nil {
+		return e.otlp.shutdown()
+	}
+	return nil
+}
+
+func (e *logsExporter) enqueue(r logRecord) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stopped {
+		return
+	}
+	if len(e.records) >= e.queueSize {
+		e.dropped++
+		return
+	}
+	e.records = append(e.records, r)
+	if len(e.records) == logsExportBatchSize {
+		select {
+		case e.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *logsExporter) run() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(logsExportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.stopCh:
+			e.flush()
+			return
+		case <-ticker.C:
+			e.flush()
+		case <-e.flushCh:
+			e.flush()
+		}
+	}
+}
+
+func (e *logsExporter) flush() {
+	e.mu.Lock()
+	records, dropped := e.records, e.dropped
+	e.records, e.dropped = nil, 0
+	e.mu.Unlock()
+
+	if dropped > 0 {
+		e.logger.Warn("Dropped logs since the export queue is full", zap.Int("dropped", dropped))
+	}
+	ld := e.toLogs(records)
+	if ld.LogRecordCount() == 0 {
+		return
+	}
+	if err := e.send(context.Background(), ld); err != nil {
+		e.logger.Warn("Failed to export logs", zap.Int("records", ld.LogRecordCount()), zap.Error(err))
+	}
+}
+
+func (e *logsExporter) toLogs(records []logRecord) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	for k, v := range e.resource {
+		rl.Resource().Attributes().PutStr(k, v)
+	}
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(logsExportScopeName)
+	observed := pcommon.NewTimestampFromTime(time.Now())
+	for _, r := range records {
+		if e.loops != nil && e.loops(r.source) {
+			continue
+		}
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(r.entry.Time))
+		lr.SetObservedTimestamp(observed)
+		lr.SetSeverityNumber(severityNumber(r.entry.Level))
+		lr.SetSeverityText(r.entry.Level.CapitalString())
+		lr.Body().SetStr(r.entry.Message)
+		lr.Attributes().FromRaw(r.attrs)
+		if r.entry.LoggerName != "" {
+			lr.Attributes().PutStr("logger", r.entry.LoggerName)
+		}
+		if r.entry.Caller.Defined {
+			lr.Attributes().PutStr("caller", r.entry.Caller.TrimmedPath())
+		}
+		if r.entry.Stack != "" {
+			lr.Attributes().PutStr("stacktrace", r.entry.Stack)
+		}
+	}
+	return ld
+}
+
+func severityNumber(lvl zapcore.Level) plog.SeverityNumber {
+	switch lvl {
+	case zapcore.DebugLevel:
+		return plog.SeverityNumberDebug
+	case zapcore.InfoLevel:
+		return plog.SeverityNumberInfo
+	case zapcore.WarnLevel:
+		return plog.SeverityNumberWarn
+	case zapcore.ErrorLevel, zapcore.DPanicLevel:
+		return plog.SeverityNumberError
+	case zapcore.PanicLevel, zapcore.FatalLevel:
+		return plog.SeverityNumberFatal
+	}
+	return plog.SeverityNumberUnspecified
+}
+
+// rawValue converts the values of the zapcore.MapObjectEncoder to the types supported by pcommon.Map.FromRaw.
+func rawValue(v interface{}) interface{} {
+	switch tv := v.(type) {
+	case nil, string, bool, []byte,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case uintptr:
+		return uint64(tv)
+	case time.Time:
+		return tv.Format(time.RFC3339Nano)
+	case time.Duration:
+		return tv.String()
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(tv))
+		for k, e := range tv {
+			m[k] = rawValue(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(tv))
+		for i, e := range tv {
+			s[i] = rawValue(e)
+		}
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// exportCore is a zapcore.Core queueing the logs for export.
+type exportCore struct {
+	zapcore.LevelEnabler
+	exporter *logsExporter
+	fields   []zapcore.Field
+	source   LogSource
+	grpcLog  bool
+}
+
+var _ zapcore.Core = (*exportCore)(nil)
+
+func (c *exportCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	for _, f := range fields {
+		switch {
+		case f.Key == grpcLogKey && f.Type == zapcore.BoolType:
+			clone.grpcLog = f.Integer == 1
+		case f.Type != zapcore.StringType:
+		case f.Key == components.ZapKindKey:
+			clone.source.Kind = f.String
+		case f.Key == components.ZapNameKey:
+			clone.source.Name = f.String
+		case f.Key == components.ZapDataTypeKey:
+			clone.source.DataType = f.String
+		case f.Key == components.ZapKindPipeline:
+			clone.source.Pipeline = f.String
+		}
+	}
+	return &clone
+}
+
+func (c *exportCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// The logs of gRPC are never exported, they can be caused by an OTLP export.
+	if c.grpcLog || !c.Enabled(entry.Level) {
+		return ce
+	}
+	return ce.AddCore(entry, c)
+}
+
+func (c *exportCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	// Fields are encoded right away, since they could reference values modified after the call.
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	c.exporter.enqueue(logRecord{
+		entry:  entry,
+		attrs:  rawValue(enc.Fields).(map[string]interface{}),
+		source: c.source,
+	})
+	return nil
+}
+
+func (c *exportCore) Sync() error {
+	return nil
+}
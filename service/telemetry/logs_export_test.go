@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/service/internal/components"
+)
+
+func newTestTelemetry(t *testing.T, export *LogsExportConfig) *Telemetry {
+	tel, err := New(context.Background(), Settings{Resource: map[string]string{"service.name": "otelcol"}}, Config{
+		Logs: LogsConfig{
+			Level:             zapcore.InfoLevel,
+			Encoding:          "console",
+			DisableCaller:     true,
+			DisableStacktrace: true,
+			OutputPaths:       []string{},
+			Export:            export,
+		},
+	})
+	require.NoError(t, err)
+	return tel
+}
+
+func TestLogsExportPipeline(t *testing.T) {
+	tel := newTestTelemetry(t, &LogsExportConfig{Pipeline: config.NewComponentIDWithName("logs", "self")})
+	logger := tel.Logger()
+
+	// Logs written before the export starts are queued.
+	logger.Info("Starting", zap.String("version", "1.0.0"))
+	logger.Debug("Not enabled")
+	logger.With(zap.String(components.ZapKindKey, components.ZapKindProcessor), zap.String(components.ZapKindPipeline, "logs/self")).
+		Warn("Failed to process logs")
+
+	sink := new(consumertest.LogsSink)
+	tel.StartLogsExport(LogsExportSettings{
+		Pipeline: sink,
+		Loops: func(src LogSource) bool {
+			return src.Kind == components.ZapKindProcessor && src.Pipeline == "logs/self"
+		},
+	})
+	logger.With(zap.String(components.ZapKindKey, components.ZapKindExporter), zap.String(components.ZapNameKey, "otlp")).
+		Error("Exporting failed", zap.Duration("backoff", time.Second), zap.Int("items", 3))
+	logger.With(zap.Bool(grpcLogKey, true)).Warn("grpc: addrConn.createTransport failed")
+	require.NoError(t, tel.Shutdown(context.Background()))
+
+	// Logs written after the export is stopped are not exported.
+	logger.Info("Stopped")
+
+	var records []plog.LogRecord
+	for _, ld := range sink.AllLogs() {
+		rl := ld.ResourceLogs().At(0)
+		v, ok := rl.Resource().Attributes().Get("service.name")
+		require.True(t, ok)
+		assert.Equal(t, "otelcol", v.Str())
+		sl := rl.ScopeLogs().At(0)
+		assert.Equal(t, logsExportScopeName, sl.Scope().Name())
+		for i := 0; i < sl.LogRecords().Len(); i++ {
+			records = append(records, sl.LogRecords().At(i))
+		}
+	}
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "Starting", records[0].Body().Str())
+	assert.Equal(t, plog.SeverityNumberInfo, records[0].SeverityNumber())
+	assert.Equal(t, "INFO", records[0].SeverityText())
+	assert.Equal(t, map[string]interface{}{"version": "1.0.0"}, records[0].Attributes().AsRaw())
+
+	assert.Equal(t, "Exporting failed", records[1].Body().Str())
+	assert.Equal(t, plog.SeverityNumberError, records[1].SeverityNumber())
+	assert.Equal(t, map[string]interface{}{
+		"kind":    "exporter",
+		"name":    "otlp",
+		"backoff": "1s",
+		"items":   int64(3),
+	}, records[1].Attributes().AsRaw())
+}
+
+func TestLogsExportQueueFull(t *testing.T) {
+	tel := newTestTelemetry(t, &LogsExportConfig{Pipeline: config.NewComponentIDWithName("logs", "self"), QueueSize: 2})
+	for i := 0; i < 3; i++ {
+		tel.Logger().Info("Log")
+	}
+
+	sink := new(consumertest.LogsSink)
+	tel.StartLogsExport(LogsExportSettings{Pipeline: sink})
+	require.NoError(t, tel.StopLogsExport())
+	assert.Equal(t, 2, sink.LogRecordCount())
+}
+
+func TestLogsExportOTLP(t *testing.T) {
+	var mu sync.Mutex
+	var received []plog.Logs
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := plogotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		mu.Lock()
+		received = append(received, req.Logs())
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	tel := newTestTelemetry(t, &LogsExportConfig{OTLP: &OTLPExporterConfig{
+		Protocol: ProtocolHTTPProtobuf,
+		Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+		Insecure: true,
+		Headers:  map[string]string{"X-Token": "secret"},
+	}})
+	tel.StartLogsExport(LogsExportSettings{})
+	tel.Logger().Warn("Something happened")
+	require.NoError(t, tel.Shutdown(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 1)
+	require.Equal(t, 1, received[0].LogRecordCount())
+	lr := received[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "Something happened", lr.Body().Str())
+	assert.Equal(t, plog.SeverityNumberWarn, lr.SeverityNumber())
+}
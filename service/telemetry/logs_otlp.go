@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcgzip "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+)
+
+const defaultOTLPTimeout = 10 * time.Second
+
+// otlpLogsClient sends the logs of the collector to an OTLP endpoint.
+type otlpLogsClient struct {
+	cfg     *OTLPExporterConfig
+	timeout time.Duration
+
+	// Set with the "grpc" protocol.
+	conn       *grpc.ClientConn
+	grpcClient plogotlp.GRPCClient
+
+	// Set with the "http/protobuf" protocol.
+	httpClient *http.Client
+	url        string
+}
+
+func newOTLPLogsClient(cfg *OTLPExporterConfig) (*otlpLogsClient, error) {
+	c := &otlpLogsClient{
+		cfg:     cfg,
+		timeout: cfg.Timeout,
+	}
+	if c.timeout == 0 {
+		c.timeout = defaultOTLPTimeout
+	}
+
+	if cfg.Protocol == ProtocolHTTPProtobuf {
+		scheme := "https"
+		if cfg.Insecure {
+			scheme = "http"
+		}
+		c.url = scheme + "://" + cfg.Endpoint + "/v1/logs"
+		c.httpClient = &http.Client{}
+		return c, nil
+	}
+
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if cfg.Insecure {
+		creds = insecure.NewCredentials()
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if cfg.Compression == "gzip" {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(grpcgzip.Name)))
+	}
+	conn, err := grpc.Dial(cfg.Endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP client: %w", err)
+	}
+	c.conn = conn
+	c.grpcClient = plogotlp.NewGRPCClient(conn)
+	return c, nil
+}
+
+func (c *otlpLogsClient) export(ctx context.Context, ld plog.Logs) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	req := plogotlp.NewExportRequestFromLogs(ld)
+
+	if c.grpcClient != nil {
+		if len(c.cfg.Headers) > 0 {
+			ctx = metadata.NewOutgoingContext(ctx, metadata.New(c.cfg.Headers))
+		}
+		_, err := c.grpcClient.Export(ctx, req)
+		return err
+	}
+
+	body, err := req.MarshalProto()
+	if err != nil {
+		return err
+	}
+	if c.cfg.Compression == "gzip" {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err = zw.Write(body); err != nil {
+			return err
+		}
+		if err = zw.Close(); err != nil {
+			return err
+		}
+		body = buf.Bytes()
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range c.cfg.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	if c.cfg.Compression == "gzip" {
+		httpReq.Header.Set("Content-Encoding", "gzip")
+	}
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("export request to %q failed with status %q", c.url, resp.Status)
+	}
+	return nil
+}
+
+func (c *otlpLogsClient) shutdown() error {
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
@@ -18,22 +18,41 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
-type recordSampler struct{}
+// recordSampler records the spans which are not sampled by the wrapped sampler, since they are
+// needed by the zpages extension.
+type recordSampler struct {
+	sampler sdktrace.Sampler
+}
 
 func (r recordSampler) ShouldSample(parameters sdktrace.SamplingParameters) sdktrace.SamplingResult {
-	return sdktrace.SamplingResult{Decision: sdktrace.RecordOnly}
+	res := r.sampler.ShouldSample(parameters)
+	if res.Decision == sdktrace.Drop {
+		res.Decision = sdktrace.RecordOnly
+	}
+	return res
 }
 
 func (r recordSampler) Description() string {
-	return "Always record sampler"
+	return "Always record sampler{" + r.sampler.Description() + "}"
 }
 
-func alwaysRecord() sdktrace.Sampler {
-	rs := &recordSampler{}
+// newSampler returns the sampler of the spans of the collector. The root spans are sampled as
+// configured, the other spans are sampled when their parent is. All the spans are recorded.
+func newSampler(cfg TraceSamplerConfig) sdktrace.Sampler {
+	var root sdktrace.Sampler
+	switch cfg.Type {
+	case SamplerAlwaysOn:
+		root = sdktrace.AlwaysSample()
+	case SamplerTraceIDRatio:
+		root = recordSampler{sampler: sdktrace.TraceIDRatioBased(cfg.Ratio)}
+	default:
+		root = recordSampler{sampler: sdktrace.NeverSample()}
+	}
+	notSampled := recordSampler{sampler: sdktrace.NeverSample()}
 	return sdktrace.ParentBased(
-		rs,
+		root,
 		sdktrace.WithRemoteParentSampled(sdktrace.AlwaysSample()),
-		sdktrace.WithRemoteParentNotSampled(rs),
+		sdktrace.WithRemoteParentNotSampled(notSampled),
 		sdktrace.WithLocalParentSampled(sdktrace.AlwaysSample()),
-		sdktrace.WithRemoteParentSampled(rs))
+		sdktrace.WithLocalParentNotSampled(notSampled))
 }
@@ -27,6 +27,7 @@ import (
 type Telemetry struct {
 	logger         *zap.Logger
 	tracerProvider *sdktrace.TracerProvider
+	logsExporter   *logsExporter
 }
 
 func (t *Telemetry) TracerProvider() trace.TracerProvider {
@@ -37,9 +38,26 @@ func (t *Telemetry) Logger() *zap.Logger {
 	return t.logger
 }
 
+// StartLogsExport starts exporting the logs as configured with the "export" setting of the logs.
+// The logs written before are queued, up to the queue size, and exported as well.
+func (t *Telemetry) StartLogsExport(set LogsExportSettings) {
+	if t.logsExporter != nil {
+		t.logsExporter.start(set)
+	}
+}
+
+// StopLogsExport exports the queued logs, and stops exporting the logs written afterwards.
+func (t *Telemetry) StopLogsExport() error {
+	if t.logsExporter == nil {
+		return nil
+	}
+	return t.logsExporter.shutdown()
+}
+
 func (t *Telemetry) Shutdown(ctx context.Context) error {
 	// TODO: Sync logger.
 	return multierr.Combine(
+		t.StopLogsExport(),
 		t.tracerProvider.Shutdown(ctx),
 	)
 }
@@ -47,26 +65,34 @@ func (t *Telemetry) Shutdown(ctx context.Context) error {
 // Settings holds configuration for building Telemetry.
 type Settings struct {
 	ZapOptions []zap.Option
+
+	// Resource holds the resource attributes of the exported spans and logs.
+	Resource map[string]string
 }
 
 // New creates a new Telemetry from Config.
-func New(_ context.Context, set Settings, cfg Config) (*Telemetry, error) {
+func New(ctx context.Context, set Settings, cfg Config) (*Telemetry, error) {
 	logger, err := newLogger(cfg.Logs, set.ZapOptions)
 	if err != nil {
 		return nil, err
 	}
-	tp := sdktrace.NewTracerProvider(
-		// needed for supporting the zpages extension
-		sdktrace.WithSampler(alwaysRecord()),
-	)
-	// TODO: Remove when https://github.com/open-telemetry/opentelemetry-go/pull/3268 released.
-	//   For the moment, register and unregister so shutdown does not fail.
-	sp := &nopSpanProcessor{}
-	tp.RegisterSpanProcessor(sp)
-	tp.UnregisterSpanProcessor(sp)
+	var exp *logsExporter
+	if cfg.Logs.Export != nil {
+		if exp, err = newLogsExporter(cfg.Logs.Export, set.Resource, logger); err != nil {
+			return nil, err
+		}
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(core, &exportCore{LevelEnabler: cfg.Logs.Level, exporter: exp})
+		}))
+	}
+	tp, err := newTracerProvider(ctx, cfg.Traces, set.Resource)
+	if err != nil {
+		return nil, err
+	}
 	return &Telemetry{
 		logger:         logger,
 		tracerProvider: tp,
+		logsExporter:   exp,
 	}, nil
 }
 
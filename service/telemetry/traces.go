@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry // import "go.opentelemetry.io/collector/service/telemetry"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newTracerProvider creates the tracer provider of the collector, exporting the sampled spans with
+// the configured span processors.
+func newTracerProvider(ctx context.Context, cfg TracesConfig, res map[string]string) (*sdktrace.TracerProvider, error) {
+	attrs := make([]attribute.KeyValue, 0, len(res))
+	for k, v := range res {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	opts := []sdktrace.TracerProviderOption{
+		// All the spans are recorded, needed for supporting the zpages extension.
+		sdktrace.WithSampler(newSampler(cfg.Sampler)),
+		sdktrace.WithResource(resource.NewSchemaless(attrs...)),
+	}
+	for _, pc := range cfg.Processors {
+		sp, err := newSpanProcessor(ctx, pc)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithSpanProcessor(sp))
+	}
+	tp := sdktrace.NewTracerProvider(opts...)
+	if len(cfg.Processors) == 0 {
+		// TODO: Remove when https://github.com/open-telemetry/opentelemetry-go/pull/3268 released.
+		//   For the moment, register and unregister so shutdown does not fail.
+		sp := &nopSpanProcessor{}
+		tp.RegisterSpanProcessor(sp)
+		tp.UnregisterSpanProcessor(sp)
+	}
+	return tp, nil
+}
+
+func newSpanProcessor(ctx context.Context, cfg SpanProcessorConfig) (sdktrace.SpanProcessor, error) {
+	exporter, err := newOTLPSpanExporter(ctx, cfg.Batch.Exporter.OTLP)
+	if err != nil {
+		return nil, err
+	}
+	var opts []sdktrace.BatchSpanProcessorOption
+	if cfg.Batch.ScheduleDelay > 0 {
+		opts = append(opts, sdktrace.WithBatchTimeout(cfg.Batch.ScheduleDelay))
+	}
+	if cfg.Batch.ExportTimeout > 0 {
+		opts = append(opts, sdktrace.WithExportTimeout(cfg.Batch.ExportTimeout))
+	}
+	if cfg.Batch.MaxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(cfg.Batch.MaxQueueSize))
+	}
+	if cfg.Batch.MaxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(cfg.Batch.MaxExportBatchSize))
+	}
+	return sdktrace.NewBatchSpanProcessor(exporter, opts...), nil
+}
+
+func newOTLPSpanExporter(ctx context.Context, cfg *OTLPExporterConfig) (sdktrace.SpanExporter, error) {
+	if cfg.Protocol == ProtocolHTTPProtobuf {
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+		if cfg.Compression == "gzip" {
+			opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		}
+		if cfg.Timeout > 0 {
+			opts = append(opts, otlptracehttp.WithTimeout(cfg.Timeout))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+	if cfg.Compression == "gzip" {
+		opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlptracegrpc.WithTimeout(cfg.Timeout))
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func TestSampler(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     TraceSamplerConfig
+		sampled bool
+	}{
+		{name: "default", cfg: TraceSamplerConfig{}, sampled: false},
+		{name: "always_on", cfg: TraceSamplerConfig{Type: SamplerAlwaysOn}, sampled: true},
+		{name: "always_off", cfg: TraceSamplerConfig{Type: SamplerAlwaysOff}, sampled: false},
+		{name: "traceidratio_all", cfg: TraceSamplerConfig{Type: SamplerTraceIDRatio, Ratio: 1}, sampled: true},
+		{name: "traceidratio_none", cfg: TraceSamplerConfig{Type: SamplerTraceIDRatio, Ratio: 0}, sampled: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp, err := newTracerProvider(context.Background(), TracesConfig{Sampler: tt.cfg}, nil)
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, tp.Shutdown(context.Background())) }()
+
+			ctx, root := tp.Tracer("test").Start(context.Background(), "root")
+			// Spans are always recorded, even when they are not sampled.
+			assert.True(t, root.IsRecording())
+			assert.Equal(t, tt.sampled, root.SpanContext().IsSampled())
+
+			// Child spans follow the decision of their parent.
+			_, child := tp.Tracer("test").Start(ctx, "child")
+			assert.True(t, child.IsRecording())
+			assert.Equal(t, tt.sampled, child.SpanContext().IsSampled())
+			child.End()
+			root.End()
+		})
+	}
+}
+
+func TestTracesExportOTLP(t *testing.T) {
+	var mu sync.Mutex
+	var received []ptrace.Traces
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := ptraceotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		mu.Lock()
+		received = append(received, req.Traces())
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	tel, err := New(context.Background(), Settings{Resource: map[string]string{"service.name": "otelcol"}}, Config{
+		Logs: LogsConfig{
+			Level:       zapcore.InfoLevel,
+			Encoding:    "console",
+			OutputPaths: []string{},
+		},
+		Traces: TracesConfig{
+			Sampler: TraceSamplerConfig{Type: SamplerAlwaysOn},
+			Processors: []SpanProcessorConfig{{
+				Batch: &BatchSpanProcessorConfig{
+					Exporter: SpanExporterConfig{OTLP: &OTLPExporterConfig{
+						Protocol: ProtocolHTTPProtobuf,
+						Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+						Insecure: true,
+						Headers:  map[string]string{"X-Token": "secret"},
+					}},
+				},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, span := tel.TracerProvider().Tracer("test").Start(context.Background(), "receiver/otlp/TraceDataReceived",
+		trace.WithSpanKind(trace.SpanKindServer))
+	span.End()
+	require.NoError(t, tel.Shutdown(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 1)
+	require.Equal(t, 1, received[0].SpanCount())
+	rs := received[0].ResourceSpans().At(0)
+	v, ok := rs.Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "otelcol", v.Str())
+	assert.Equal(t, "receiver/otlp/TraceDataReceived", rs.ScopeSpans().At(0).Spans().At(0).Name())
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service // import "go.opentelemetry.io/collector/service"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/view"
+
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+// newMetricReader creates the reader pushing the internal metrics as configured.
+func newMetricReader(ctx context.Context, cfg telemetry.MetricReaderConfig) (sdkmetric.Reader, error) {
+	exporter, err := newOTLPMetricExporter(ctx, cfg.Periodic.Exporter.OTLP)
+	if err != nil {
+		return nil, err
+	}
+	var opts []sdkmetric.PeriodicReaderOption
+	if cfg.Periodic.Interval > 0 {
+		opts = append(opts, sdkmetric.WithInterval(cfg.Periodic.Interval))
+	}
+	if cfg.Periodic.Timeout > 0 {
+		opts = append(opts, sdkmetric.WithTimeout(cfg.Periodic.Timeout))
+	}
+	return sdkmetric.NewPeriodicReader(exporter, opts...), nil
+}
+
+func newOTLPMetricExporter(ctx context.Context, cfg *telemetry.OTLPExporterConfig) (sdkmetric.Exporter, error) {
+	if cfg.Protocol == telemetry.ProtocolHTTPProtobuf {
+		opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlpmetrichttp.WithHeaders(cfg.Headers))
+		}
+		if cfg.Compression == "gzip" {
+			opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+		}
+		if cfg.Timeout > 0 {
+			opts = append(opts, otlpmetrichttp.WithTimeout(cfg.Timeout))
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	}
+
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(cfg.Headers))
+	}
+	if cfg.Compression == "gzip" {
+		opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, otlpmetricgrpc.WithTimeout(cfg.Timeout))
+	}
+	return otlpmetricgrpc.New(ctx, opts...)
+}
+
+// newMetricView creates the view customizing the internal metrics as configured.
+func newMetricView(cfg telemetry.MetricViewConfig) (view.View, error) {
+	var opts []view.Option
+	if cfg.Selector.InstrumentName != "" {
+		opts = append(opts, view.MatchInstrumentName(cfg.Selector.InstrumentName))
+	}
+	if cfg.Selector.MeterName != "" {
+		opts = append(opts, view.MatchInstrumentationScope(instrumentation.Scope{Name: cfg.Selector.MeterName}))
+	}
+	if cfg.Stream.Name != "" {
+		opts = append(opts, view.WithRename(cfg.Stream.Name))
+	}
+	if cfg.Stream.Description != "" {
+		opts = append(opts, view.WithSetDescription(cfg.Stream.Description))
+	}
+	if cfg.Stream.AttributeKeys != nil {
+		keys := make([]attribute.Key, 0, len(cfg.Stream.AttributeKeys))
+		for _, k := range cfg.Stream.AttributeKeys {
+			keys = append(keys, attribute.Key(k))
+		}
+		opts = append(opts, view.WithFilterAttributes(keys...))
+	}
+	switch cfg.Stream.Aggregation {
+	case telemetry.AggregationDrop:
+		opts = append(opts, view.WithSetAggregation(aggregation.Drop{}))
+	case telemetry.AggregationSum:
+		opts = append(opts, view.WithSetAggregation(aggregation.Sum{}))
+	case telemetry.AggregationLastValue:
+		opts = append(opts, view.WithSetAggregation(aggregation.LastValue{}))
+	case telemetry.AggregationExplicitBucketHistogram:
+		opts = append(opts, view.WithSetAggregation(aggregation.ExplicitBucketHistogram{Boundaries: cfg.Stream.Boundaries}))
+	case "":
+	default:
+		return view.View{}, fmt.Errorf("unsupported aggregation %q", cfg.Stream.Aggregation)
+	}
+	return view.New(opts...)
+}
@@ -16,9 +16,13 @@ package service
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
@@ -32,6 +36,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/internal/obsreportconfig"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	semconv "go.opentelemetry.io/collector/semconv/v1.5.0"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -48,7 +53,8 @@ func TestBuildTelAttrs(t *testing.T) {
 
 	// Check default config
 	cfg := telemetry.Config{}
-	telAttrs := buildTelAttrs(buildInfo, cfg)
+	telAttrs, err := buildTelAttrs(buildInfo, cfg)
+	require.NoError(t, err)
 
 	assert.Len(t, telAttrs, 3)
 	assert.Equal(t, buildInfo.Command, telAttrs[semconv.AttributeServiceName])
@@ -65,7 +71,8 @@ func TestBuildTelAttrs(t *testing.T) {
 			semconv.AttributeServiceInstanceID: nil,
 		},
 	}
-	telAttrs = buildTelAttrs(buildInfo, cfg)
+	telAttrs, err = buildTelAttrs(buildInfo, cfg)
+	require.NoError(t, err)
 
 	// Attributes should not exist since we nil-ified all.
 	assert.Len(t, telAttrs, 0)
@@ -79,12 +86,33 @@ func TestBuildTelAttrs(t *testing.T) {
 			semconv.AttributeServiceInstanceID: strPtr("c"),
 		},
 	}
-	telAttrs = buildTelAttrs(buildInfo, cfg)
+	telAttrs, err = buildTelAttrs(buildInfo, cfg)
+	require.NoError(t, err)
 
 	assert.Len(t, telAttrs, 3)
 	assert.Equal(t, "a", telAttrs[semconv.AttributeServiceName])
 	assert.Equal(t, "b", telAttrs[semconv.AttributeServiceVersion])
 	assert.Equal(t, "c", telAttrs[semconv.AttributeServiceInstanceID])
+
+	// Check detected attributes, overridden by the config
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	cfg = telemetry.Config{
+		Resource: map[string]*string{
+			semconv.AttributeOSType: strPtr("custom"),
+		},
+		ResourceDetectors: []string{"host"},
+	}
+	telAttrs, err = buildTelAttrs(buildInfo, cfg)
+	require.NoError(t, err)
+
+	assert.Len(t, telAttrs, 5)
+	assert.Equal(t, hostname, telAttrs[semconv.AttributeHostName])
+	assert.Equal(t, "custom", telAttrs[semconv.AttributeOSType])
+
+	cfg = telemetry.Config{ResourceDetectors: []string{"ec2"}}
+	_, err = buildTelAttrs(buildInfo, cfg)
+	assert.EqualError(t, err, `unknown resource detector "ec2", must be "host", "container" or "k8s"`)
 }
 
 func TestTelemetryInit(t *testing.T) {
@@ -125,11 +153,7 @@ func TestTelemetryInit(t *testing.T) {
 					},
 				},
 				metricPrefix + otelPrefix + counterName + "_total": {
-					value:  13,
-					labels: map[string]string{},
-				},
-				metricPrefix + "target_info": {
-					value: 0,
+					value: 13,
 					labels: map[string]string{
 						"service_name":        "otelcol",
 						"service_version":     "latest",
@@ -150,6 +174,9 @@ func TestTelemetryInit(t *testing.T) {
 				Resource: map[string]*string{
 					semconv.AttributeServiceInstanceID: &testInstanceID,
 				},
+				Metrics: telemetry.MetricsConfig{
+					Address: "localhost:8888",
+				},
 			}
 
 			err := tel.initOnce(buildInfo, zap.NewNop(), cfg)
@@ -184,6 +211,91 @@ func TestTelemetryInit(t *testing.T) {
 	}
 }
 
+func TestTelemetryMetricReaders(t *testing.T) {
+	requests := make(chan pmetricotlp.ExportRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("x-api-key"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		req := pmetricotlp.NewExportRequest()
+		assert.NoError(t, req.UnmarshalProto(body))
+		requests <- req
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer srv.Close()
+
+	registry := featuregate.NewRegistry()
+	obsreportconfig.RegisterInternalMetricFeatureGate(registry)
+	require.NoError(t, registry.Apply(map[string]bool{obsreportconfig.UseOtelForInternalMetricsfeatureGateID: true}))
+
+	tel := newColTelemetry(registry)
+	cfg := telemetry.Config{
+		Resource: map[string]*string{
+			semconv.AttributeServiceInstanceID: &testInstanceID,
+		},
+		Metrics: telemetry.MetricsConfig{
+			Readers: []telemetry.MetricReaderConfig{{
+				Periodic: &telemetry.PeriodicMetricReaderConfig{
+					Interval: time.Hour,
+					Exporter: telemetry.MetricExporterConfig{
+						OTLP: &telemetry.OTLPExporterConfig{
+							Protocol: telemetry.ProtocolHTTPProtobuf,
+							Endpoint: strings.TrimPrefix(srv.URL, "http://"),
+							Insecure: true,
+							Headers:  map[string]string{"x-api-key": "secret"},
+						},
+					},
+				},
+			}},
+			Views: []telemetry.MetricViewConfig{{
+				Selector: telemetry.MetricViewSelectorConfig{InstrumentName: otelPrefix + counterName},
+				Stream:   telemetry.MetricViewStreamConfig{Name: "renamed_counter"},
+			}},
+		},
+	}
+	require.NoError(t, tel.initOnce(component.NewDefaultBuildInfo(), zap.NewNop(), cfg))
+	// Only the readers are configured, the Prometheus endpoint isn't served.
+	assert.Nil(t, tel.server)
+
+	counter, err := tel.mp.Meter("collector_test").SyncInt64().Counter(otelPrefix + counterName)
+	require.NoError(t, err)
+	counter.Add(context.Background(), 13)
+
+	// Shutting down flushes the metrics.
+	require.NoError(t, tel.shutdown())
+
+	req := <-requests
+	rms := req.Metrics().ResourceMetrics()
+	require.Equal(t, 1, rms.Len())
+	instanceID, ok := rms.At(0).Resource().Attributes().Get(semconv.AttributeServiceInstanceID)
+	require.True(t, ok)
+	assert.Equal(t, testInstanceID, instanceID.Str())
+	require.Equal(t, 1, rms.At(0).ScopeMetrics().Len())
+	metrics := rms.At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, "renamed_counter", metrics.At(0).Name())
+	assert.Equal(t, int64(13), metrics.At(0).Sum().DataPoints().At(0).IntValue())
+}
+
+func TestTelemetryMetricReadersRequireOtel(t *testing.T) {
+	registry := featuregate.NewRegistry()
+	obsreportconfig.RegisterInternalMetricFeatureGate(registry)
+	require.NoError(t, registry.Apply(map[string]bool{obsreportconfig.UseOtelForInternalMetricsfeatureGateID: false}))
+
+	tel := newColTelemetry(registry)
+	cfg := telemetry.Config{
+		Metrics: telemetry.MetricsConfig{
+			Views: []telemetry.MetricViewConfig{{
+				Selector: telemetry.MetricViewSelectorConfig{MeterName: "collector_test"},
+				Stream:   telemetry.MetricViewStreamConfig{Aggregation: telemetry.AggregationDrop},
+			}},
+		},
+	}
+	assert.EqualError(t, tel.initOnce(component.NewDefaultBuildInfo(), zap.NewNop(), cfg),
+		`metric readers and views cannot be used when the "telemetry.useOtelForInternalMetrics" feature gate is disabled`)
+}
+
 func createTestMetrics(t *testing.T, mp metric.MeterProvider) *view.View {
 	// Creates a OTel Go counter
 	counter, err := mp.Meter("collector_test").SyncInt64().Counter(otelPrefix + counterName)
@@ -44,6 +44,7 @@ func TestUnmarshalEmptyAllSections(t *testing.T) {
 		"processors": nil,
 		"exporters":  nil,
 		"extensions": nil,
+		"connectors": nil,
 		"service":    nil,
 	})
 	cfg, err := unmarshal(conf, factories)
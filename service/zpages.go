@@ -17,6 +17,7 @@ package service // import "go.opentelemetry.io/collector/service"
 import (
 	"net/http"
 	"path"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/featuregate"
@@ -29,6 +30,9 @@ const (
 	pipelinezPath  = "pipelinez"
 	extensionzPath = "extensionz"
 	featurezPath   = "featurez"
+	componentzPath = "componentz"
+	queuezPath     = "queuez"
+	graphzPath     = "graphz"
 )
 
 func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
@@ -36,9 +40,25 @@ func (host *serviceHost) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, pipelinezPath), host.pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, extensionzPath), host.extensions.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, featurezPath), handleFeaturezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, componentzPath), host.handleComponentzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, queuezPath), host.pipelines.HandleQueuesZPages)
+	mux.HandleFunc(path.Join(pathPrefix, graphzPath), host.handleGraphzRequest)
+}
+
+// serviceData is the JSON representation of the servicez page.
+type serviceData struct {
+	BuildInfo   map[string]string `json:"build_info"`
+	RuntimeInfo map[string]string `json:"runtime_info"`
 }
 
 func (host *serviceHost) zPagesRequest(w http.ResponseWriter, r *http.Request) {
+	if zpages.WantsJSON(r) {
+		zpages.WriteJSON(w, serviceData{
+			BuildInfo:   propertiesMap(getBuildInfoProperties(host.buildInfo)),
+			RuntimeInfo: propertiesMap(runtimeinfo.Info()),
+		})
+		return
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Service " + host.buildInfo.Command})
 	zpages.WriteHTMLPropertiesTable(w, zpages.PropertiesTableData{Name: "Build Info", Properties: getBuildInfoProperties(host.buildInfo)})
@@ -58,10 +78,99 @@ func (host *serviceHost) zPagesRequest(w http.ResponseWriter, r *http.Request) {
 		ComponentEndpoint: featurezPath,
 		Link:              true,
 	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Components",
+		ComponentEndpoint: componentzPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Exporter Queues",
+		ComponentEndpoint: queuezPath,
+		Link:              true,
+	})
+	zpages.WriteHTMLComponentHeader(w, zpages.ComponentHeaderData{
+		Name:              "Pipelines Graph",
+		ComponentEndpoint: graphzPath,
+		Link:              true,
+	})
 	zpages.WriteHTMLPageFooter(w)
 }
 
+func (host *serviceHost) handleComponentzRequest(w http.ResponseWriter, r *http.Request) {
+	data := host.getComponentsTableData()
+	if zpages.WantsJSON(r) {
+		zpages.WriteJSON(w, data)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Components"})
+	zpages.WriteHTMLComponentsTable(w, data)
+	zpages.WriteHTMLPageFooter(w)
+}
+
+func (host *serviceHost) handleGraphzRequest(w http.ResponseWriter, r *http.Request) {
+	graph := host.pipelines.Graph()
+	if zpages.WantsJSON(r) {
+		zpages.WriteJSON(w, graph)
+		return
+	}
+	var dot strings.Builder
+	if err := graph.WriteDOT(&dot); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if r.URL.Query().Get(zpages.FormatQueryKey) == zpages.FormatDOT {
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		_, _ = w.Write([]byte(dot.String()))
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Pipelines Graph"})
+	zpages.WriteHTMLGraph(w, zpages.GraphData{DOT: dot.String()})
+	zpages.WriteHTMLPageFooter(w)
+}
+
+func (host *serviceHost) getComponentsTableData() zpages.ComponentsTableData {
+	data := zpages.ComponentsTableData{Rows: []zpages.ComponentsTableRowData{}}
+	if host.statuses == nil {
+		return data
+	}
+	for _, is := range host.statuses.List() {
+		row := zpages.ComponentsTableRowData{
+			Kind:       is.Source.Kind.String(),
+			FullName:   is.Source.ID.String(),
+			DataType:   dataTypeLabel(is.Source),
+			Status:     is.Event.Status.String(),
+			StatusTime: is.Event.Timestamp,
+		}
+		for _, pipelineID := range is.Source.Pipelines {
+			row.Pipelines = append(row.Pipelines, pipelineID.String())
+		}
+		if is.Event.Err != nil {
+			row.Error = is.Event.Err.Error()
+		}
+		if !is.StartTime.IsZero() {
+			startTime := is.StartTime
+			row.StartTime = &startTime
+		}
+		data.Rows = append(data.Rows, row)
+	}
+	return data
+}
+
+// dataTypeLabel returns the data type handled by the component instance, and for connectors the data type it emits.
+func dataTypeLabel(source *component.StatusSource) string {
+	if source.EmittedDataType == "" {
+		return string(source.DataType)
+	}
+	return string(source.DataType) + " -> " + string(source.EmittedDataType)
+}
+
 func handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
+	if zpages.WantsJSON(r) {
+		zpages.WriteJSON(w, getFeaturesTableData())
+		return
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	zpages.WriteHTMLPageHeader(w, zpages.HeaderData{Title: "Feature Gates"})
 	zpages.WriteHTMLFeaturesTable(w, getFeaturesTableData())
@@ -69,7 +178,7 @@ func handleFeaturezRequest(w http.ResponseWriter, r *http.Request) {
 }
 
 func getFeaturesTableData() zpages.FeatureGateTableData {
-	data := zpages.FeatureGateTableData{}
+	data := zpages.FeatureGateTableData{Rows: []zpages.FeatureGateTableRowData{}}
 	for _, g := range featuregate.GetRegistry().List() {
 		data.Rows = append(data.Rows, zpages.FeatureGateTableRowData{
 			ID:          g.ID,
@@ -88,3 +197,11 @@ func getBuildInfoProperties(buildInfo component.BuildInfo) [][2]string {
 		{"Version", buildInfo.Version},
 	}
 }
+
+func propertiesMap(properties [][2]string) map[string]string {
+	m := make(map[string]string, len(properties))
+	for _, p := range properties {
+		m[p[0]] = p[1]
+	}
+	return m
+}